@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/debugger"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+var (
+	BisectAInputFlag = &cli.PathFlag{
+		Name:      "a.input",
+		Usage:     "path of the binary state to start the first execution from.",
+		TakesFile: true,
+		Required:  true,
+	}
+	BisectBInputFlag = &cli.PathFlag{
+		Name:      "b.input",
+		Usage:     "path of the binary state to start the second execution from. Defaults to --a.input.",
+		TakesFile: true,
+	}
+	BisectAServerFlag = &cli.StringFlag{
+		Name:  "a.server",
+		Usage: "pre-image server command line for the first execution, split on whitespace.",
+	}
+	BisectBServerFlag = &cli.StringFlag{
+		Name:  "b.server",
+		Usage: "pre-image server command line for the second execution, split on whitespace.",
+	}
+	BisectMetaFlag = &cli.PathFlag{
+		Name:  "meta",
+		Usage: "path to metadata file used to symbolize the divergent instruction.",
+	}
+	BisectIntervalFlag = &cli.Uint64Flag{
+		Name:  "interval",
+		Usage: "number of steps between state hash comparisons while running forward.",
+		Value: 10_000_000,
+	}
+	BisectMaxStepFlag = &cli.Uint64Flag{
+		Name:  "max-step",
+		Usage: "step to stop searching at if no divergence is found. 0 to run until both executions exit.",
+	}
+	BisectOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write the divergence report to as JSON. Use - to write to Stdout.",
+		TakesFile: true,
+	}
+	BisectSnapshotFmtFlag = &cli.StringFlag{
+		Name:  "snapshot-fmt",
+		Usage: "format for file names of the agreed pre-states of the divergent step, with %s replaced by a or b. Not written if empty.",
+	}
+)
+
+func Bisect(ctx *cli.Context) error {
+	if snapshotFmt := ctx.String(BisectSnapshotFmtFlag.Name); snapshotFmt != "" {
+		if !serialize.IsBinaryFile(fmt.Sprintf(snapshotFmt, "a")) {
+			return fmt.Errorf("invalid --%v file format. Only binary file formats (ending in .bin or bin.gz) are supported", BisectSnapshotFmtFlag.Name)
+		}
+	}
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "bisect")
+
+	meta, err := loadMetadata(l, ctx.Path(BisectMetaFlag.Name))
+	if err != nil {
+		return err
+	}
+	bInput := ctx.Path(BisectBInputFlag.Name)
+	if bInput == "" {
+		bInput = ctx.Path(BisectAInputFlag.Name)
+	}
+	a, closeA, err := newBisectTrace(l, "a", ctx.Path(BisectAInputFlag.Name), ctx.String(BisectAServerFlag.Name), meta)
+	if err != nil {
+		return err
+	}
+	defer closeA()
+	b, closeB, err := newBisectTrace(l, "b", bInput, ctx.String(BisectBServerFlag.Name), meta)
+	if err != nil {
+		return err
+	}
+	defer closeB()
+
+	maxStep := ctx.Uint64(BisectMaxStepFlag.Name)
+	if maxStep == 0 {
+		maxStep = math.MaxUint64
+	}
+	bisector := debugger.NewBisector(l, a, b, meta, ctx.Uint64(BisectIntervalFlag.Name), maxStep)
+	divergence, err := bisector.Bisect(ctx.Context)
+	if err != nil {
+		return err
+	}
+	if divergence == nil {
+		_, _ = fmt.Fprintf(os.Stdout, "no divergence found up to step %d\n", a.State().GetStep())
+		return nil
+	}
+	divergence.Write(os.Stdout)
+
+	if output := ctx.Path(BisectOutputFlag.Name); output != "" {
+		if err := jsonutil.WriteJSON(divergence, ioutil.ToStdOutOrFileOrNoop(output, OutFilePerm)); err != nil {
+			return fmt.Errorf("failed to write divergence report: %w", err)
+		}
+	}
+	if snapshotFmt := ctx.String(BisectSnapshotFmtFlag.Name); snapshotFmt != "" {
+		preA, preB, err := bisector.PreStates()
+		if err != nil {
+			return err
+		}
+		for name, state := range map[string]*versions.VersionedState{"a": preA, "b": preB} {
+			if err := serialize.Write(fmt.Sprintf(snapshotFmt, name), state, OutFilePerm); err != nil {
+				return fmt.Errorf("failed to write pre-state snapshot: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// newBisectTrace loads the input state and starts its pre-image server. The returned function stops the server.
+func newBisectTrace(l log.Logger, name string, input string, server string, meta mipsevm.Metadata) (*debugger.Trace, func(), error) {
+	state, err := versions.LoadStateFromFileWithLargeICache(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load state %v: %w", input, err)
+	}
+	l = l.With("trace", name)
+	l.Info("Loaded input state", "version", state.Version, "step", state.GetStep())
+
+	args := strings.Fields(server)
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	poOut := Logger(os.Stderr, log.LevelInfo).With("module", "host", "trace", name)
+	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host", "trace", name)
+	po, err := NewProcessPreimageOracle(l, args[0], args[1:], poOut, poErr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	closePO := func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}
+
+	// Guest output is discarded as it is repeated each time the execution is restored to a checkpoint
+	newVM := func(state *versions.VersionedState) (mipsevm.FPVM, debugger.StepFn) {
+		vm := state.CreateVM(l, po, io.Discard, io.Discard, meta)
+		stepFn := vm.Step
+		if po.cmd != nil {
+			stepFn = Guard(po.cmd.ProcessState, stepFn)
+		}
+		return vm, debugger.StepFn(stepFn)
+	}
+	trace, err := debugger.NewTrace(name, &state.VersionedState, newVM)
+	if err != nil {
+		closePO()
+		return nil, nil, err
+	}
+	return trace, closePO, nil
+}
+
+func CreateBisectCommand(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:  "bisect",
+		Usage: "Find the first step at which two VM executions diverge.",
+		Description: "Runs two VM executions in parallel, comparing state witness hashes every --interval steps. " +
+			"Once the hashes differ, binary searches from the last agreeing state to find the first divergent step, " +
+			"reporting the instruction, thread, syscall and preimage read by each execution at that step.",
+		Action: action,
+		Flags: []cli.Flag{
+			BisectAInputFlag,
+			BisectBInputFlag,
+			BisectAServerFlag,
+			BisectBServerFlag,
+			BisectMetaFlag,
+			BisectIntervalFlag,
+			BisectMaxStepFlag,
+			BisectOutputFlag,
+			BisectSnapshotFmtFlag,
+		},
+	}
+}
+
+var BisectCommand = CreateBisectCommand(Bisect)
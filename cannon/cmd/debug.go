@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/debugger"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	DebugInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input binary state.",
+		TakesFile: true,
+		Value:     "state.bin.gz",
+		Required:  true,
+	}
+	DebugMetaFlag = &cli.PathFlag{
+		Name:     "meta",
+		Usage:    "path to metadata file for symbol lookup and symbol breakpoints.",
+		Value:    "meta.json",
+		Required: false,
+	}
+)
+
+func Debug(ctx *cli.Context) error {
+	guestLogger := Logger(os.Stderr, log.LevelInfo)
+	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
+
+	// split CLI args after first '--'
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+
+	poOut := Logger(os.Stderr, log.LevelInfo).With("module", "host")
+	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host")
+	po, err := NewProcessPreimageOracle(l, args[0], args[1:], poOut, poErr)
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	meta, err := loadMetadata(l, ctx.Path(DebugMetaFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	state, err := versions.LoadStateFromFileWithLargeICache(ctx.Path(DebugInputFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	l.Info("Loaded input state", "version", state.Version)
+	vm := state.CreateVM(l, po, outLog, errLog, meta)
+
+	stepFn := vm.Step
+	if po.cmd != nil {
+		stepFn = Guard(po.cmd.ProcessState, stepFn)
+	}
+	dbg, err := debugger.NewDebugger(&state.VersionedState, vm, debugger.StepFn(stepFn), meta)
+	if err != nil {
+		return err
+	}
+	return debugger.RunREPL(ctx.Context, dbg, os.Stdin, os.Stdout)
+}
+
+// loadMetadata loads the metadata at metaPath, defaulting to empty metadata if no path is specified.
+func loadMetadata(l log.Logger, metaPath string) (*program.Metadata, error) {
+	if metaPath == "" {
+		l.Info("no metadata file specified, defaulting to empty metadata")
+		return &program.Metadata{Symbols: nil}, nil
+	}
+	meta, err := jsonutil.LoadJSON[program.Metadata](metaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+	return meta, nil
+}
+
+func CreateDebugCommand(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:  "debug",
+		Usage: "Interactively step through VM execution.",
+		Description: "Interactively step through VM execution, with breakpoints on PC, symbol, syscall, preimage key and step, " +
+			"and inspection of registers, threads and memory. Type help at the prompt for a list of commands. " +
+			"The pre-image server command is specified after --, as with the run command.",
+		Action: action,
+		Flags: []cli.Flag{
+			DebugInputFlag,
+			DebugMetaFlag,
+		},
+	}
+}
+
+var DebugCommand = CreateDebugCommand(Debug)
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/debugger"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var (
+	DiffAFlag = &cli.PathFlag{
+		Name:      "a",
+		Usage:     "path of the first binary state.",
+		TakesFile: true,
+		Required:  true,
+	}
+	DiffBFlag = &cli.PathFlag{
+		Name:      "b",
+		Usage:     "path of the second binary state.",
+		TakesFile: true,
+		Required:  true,
+	}
+	DiffMetaFlag = &cli.PathFlag{
+		Name:     "meta",
+		Usage:    "path to metadata file used to symbolize differing PCs.",
+		Required: false,
+	}
+)
+
+func loadMultithreadedState(path string) (*multithreaded.State, error) {
+	state, err := versions.LoadStateFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input state (%v): %w", path, err)
+	}
+	mt, ok := state.FPVMState.(*multithreaded.State)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", debugger.ErrUnsupportedState, state.FPVMState)
+	}
+	return mt, nil
+}
+
+func Diff(ctx *cli.Context) error {
+	l := Logger(os.Stderr, log.LevelInfo)
+	a, err := loadMultithreadedState(ctx.Path(DiffAFlag.Name))
+	if err != nil {
+		return err
+	}
+	b, err := loadMultithreadedState(ctx.Path(DiffBFlag.Name))
+	if err != nil {
+		return err
+	}
+	meta, err := loadMetadata(l, ctx.Path(DiffMetaFlag.Name))
+	if err != nil {
+		return err
+	}
+	debugger.DiffStates(a, b, meta).Write(os.Stdout)
+	return nil
+}
+
+func CreateDiffCommand(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:        "diff",
+		Usage:       "Compare two VM states.",
+		Description: "Compare two VM states, printing the scalar fields, thread registers and memory words that differ.",
+		Action:      action,
+		Flags: []cli.Flag{
+			DiffAFlag,
+			DiffBFlag,
+			DiffMetaFlag,
+		},
+	}
+}
+
+var DiffCommand = CreateDiffCommand(Diff)
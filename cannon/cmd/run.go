@@ -21,7 +21,6 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	mipsexec "github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
@@ -405,16 +404,9 @@ func Run(ctx *cli.Context) error {
 	snapshotAt := ctx.Generic(RunSnapshotAtFlag.Name).(*StepMatcherFlag).Matcher()
 	infoAt := ctx.Generic(RunInfoAtFlag.Name).(*StepMatcherFlag).Matcher()
 
-	var meta *program.Metadata
-	if metaPath := ctx.Path(RunMetaFlag.Name); metaPath == "" {
-		l.Info("no metadata file specified, defaulting to empty metadata")
-		meta = &program.Metadata{Symbols: nil} // provide empty metadata by default
-	} else {
-		if m, err := jsonutil.LoadJSON[program.Metadata](metaPath); err != nil {
-			return fmt.Errorf("failed to load metadata: %w", err)
-		} else {
-			meta = m
-		}
+	meta, err := loadMetadata(l, ctx.Path(RunMetaFlag.Name))
+	if err != nil {
+		return err
 	}
 
 	state, err := versions.LoadStateFromFileWithLargeICache(ctx.Path(RunInputFlag.Name))
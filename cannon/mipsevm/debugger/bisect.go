@@ -0,0 +1,354 @@
+package debugger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var ErrStartStepMismatch = errors.New("traces must start at the same step")
+
+// VMFactory creates a VM executing state. The returned StepFn is used to step the VM.
+type VMFactory func(state *versions.VersionedState) (mipsevm.FPVM, StepFn)
+
+// Trace is a VM execution that can be run forward and restored to a previously saved checkpoint.
+type Trace struct {
+	name    string
+	version versions.StateVersion
+	state   *multithreaded.State
+	vm      mipsevm.FPVM
+	stepFn  StepFn
+	newVM   VMFactory
+}
+
+func NewTrace(name string, state *versions.VersionedState, newVM VMFactory) (*Trace, error) {
+	mt, ok := state.FPVMState.(*multithreaded.State)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedState, state.FPVMState)
+	}
+	t := &Trace{
+		name:    name,
+		version: state.Version,
+		newVM:   newVM,
+	}
+	t.setState(mt)
+	return t, nil
+}
+
+func (t *Trace) setState(state *multithreaded.State) {
+	t.state = state
+	t.vm, t.stepFn = t.newVM(&versions.VersionedState{Version: t.version, FPVMState: state})
+}
+
+func (t *Trace) Name() string {
+	return t.name
+}
+
+func (t *Trace) State() *multithreaded.State {
+	return t.state
+}
+
+// Hash returns the witness hash of the current state.
+func (t *Trace) Hash() common.Hash {
+	_, hash := t.state.EncodeWitness()
+	return hash
+}
+
+// RunTo executes steps until the specified step is reached or the program exits.
+func (t *Trace) RunTo(ctx context.Context, step uint64) error {
+	for !t.state.GetExited() && t.state.GetStep() < step {
+		if t.state.GetStep()%100 == 0 { // don't do the ctx err check (includes lock) too often
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if t.vm.CheckInfiniteLoop() {
+			return fmt.Errorf("%s: %w at step %d", t.name, ErrInfiniteLoop, t.state.GetStep())
+		}
+		if _, err := t.stepFn(false); err != nil {
+			return fmt.Errorf("%s: failed at step %d (PC: %08x): %w", t.name, t.state.GetStep(), t.state.GetPC(), err)
+		}
+	}
+	return nil
+}
+
+// Checkpoint serializes the current state so it can later be restored.
+func (t *Trace) Checkpoint() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.state.Serialize(&buf); err != nil {
+		return nil, fmt.Errorf("%s: failed to checkpoint state: %w", t.name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Restore replaces the current state with a checkpoint and creates a new VM to execute it.
+func (t *Trace) Restore(checkpoint []byte) error {
+	state, err := t.decodeCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	t.setState(state)
+	return nil
+}
+
+// decodeCheckpoint deserializes a checkpoint with the same memory layout as the current state.
+func (t *Trace) decodeCheckpoint(checkpoint []byte) (*multithreaded.State, error) {
+	state := &multithreaded.State{UseLargeICache: t.state.UseLargeICache}
+	if err := state.Deserialize(bytes.NewReader(checkpoint)); err != nil {
+		return nil, fmt.Errorf("%s: failed to restore state: %w", t.name, err)
+	}
+	return state, nil
+}
+
+// StepInfo describes the instruction executed by a trace at the divergent step.
+type StepInfo struct {
+	Trace string `json:"trace"`
+	// PostHash is the state hash after executing the step, or the initial state hash if the initial states differ.
+	PostHash common.Hash `json:"postHash"`
+	Thread   Word        `json:"thread"`
+	PC       Word        `json:"pc"`
+	Symbol   string      `json:"symbol"`
+	Insn     uint32      `json:"insn"`
+	// Syscall is the syscall number if the instruction is a syscall.
+	Syscall *Word `json:"syscall,omitempty"`
+	// PreimageKey and PreimageOffset are set if the instruction read from a preimage.
+	PreimageKey    *common.Hash `json:"preimageKey,omitempty"`
+	PreimageOffset *Word        `json:"preimageOffset,omitempty"`
+	Exited         bool         `json:"exited"`
+}
+
+// Divergence describes the first step at which two traces produce different states.
+type Divergence struct {
+	// Step is the step that, when executed from identical pre-states, produces different post-states.
+	// If the initial states already differ, Step is the starting step and PreHash is empty.
+	Step    uint64      `json:"step"`
+	PreHash common.Hash `json:"preHash"`
+	A       *StepInfo   `json:"a"`
+	B       *StepInfo   `json:"b"`
+	// Diff is the difference between the two post-states.
+	Diff *StateDiff `json:"-"`
+}
+
+func (d *Divergence) Write(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "first divergent step: %d (pre-state %v)\n", d.Step, d.PreHash)
+	for _, info := range []*StepInfo{d.A, d.B} {
+		_, _ = fmt.Fprintf(w, "%s: post-state %v thread %d pc %#x <%s> insn %#08x", info.Trace, info.PostHash, info.Thread, info.PC, info.Symbol, info.Insn)
+		if info.Syscall != nil {
+			_, _ = fmt.Fprintf(w, " syscall %d", *info.Syscall)
+		}
+		if info.PreimageKey != nil {
+			_, _ = fmt.Fprintf(w, " preimage %v@%d", *info.PreimageKey, *info.PreimageOffset)
+		}
+		if info.Exited {
+			_, _ = fmt.Fprint(w, " exited")
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	if d.Diff != nil {
+		d.Diff.Write(w)
+	}
+}
+
+// Bisector locates the first step at which two traces diverge.
+// Both traces are run in parallel, comparing witness hashes every interval steps. Once the hashes differ, the
+// interval is binary searched from the last agreeing checkpoint.
+type Bisector struct {
+	logger   log.Logger
+	a, b     *Trace
+	meta     mipsevm.Metadata
+	interval uint64
+	maxStep  uint64
+
+	// last is the last checkpoint at which both traces agreed
+	last *checkpoint
+}
+
+// NewBisector creates a Bisector that compares hashes every interval steps and stops at maxStep.
+// meta is used to symbolize the divergent instruction and may be nil.
+func NewBisector(logger log.Logger, a *Trace, b *Trace, meta mipsevm.Metadata, interval uint64, maxStep uint64) *Bisector {
+	return &Bisector{
+		logger:   logger,
+		a:        a,
+		b:        b,
+		meta:     meta,
+		interval: max(interval, 1),
+		maxStep:  maxStep,
+	}
+}
+
+type checkpoint struct {
+	step uint64
+	hash common.Hash
+	a, b []byte
+}
+
+// Bisect returns the first divergent step or nil if the traces agree until both exit or maxStep is reached.
+func (s *Bisector) Bisect(ctx context.Context) (*Divergence, error) {
+	start := s.a.state.GetStep()
+	if start != s.b.state.GetStep() {
+		return nil, fmt.Errorf("%w: %d vs %d", ErrStartStepMismatch, start, s.b.state.GetStep())
+	}
+	if s.a.Hash() != s.b.Hash() {
+		s.logger.Warn("Initial states differ", "step", start)
+		diff := DiffStates(s.a.state, s.b.state, s.meta)
+		return &Divergence{Step: start, A: s.describe(s.a, false), B: s.describe(s.b, false), Diff: diff}, nil
+	}
+	lo, err := s.checkpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	// Run forward in intervals until the hashes differ
+	hi := lo.step
+	for {
+		if s.a.state.GetExited() && s.b.state.GetExited() {
+			s.logger.Info("Both traces exited without diverging", "step", lo.step)
+			return nil, nil
+		}
+		if hi >= s.maxStep {
+			s.logger.Info("Reached max step without diverging", "step", lo.step)
+			return nil, nil
+		}
+		hi = min(hi+s.interval, s.maxStep)
+		if err := s.runTo(ctx, hi); err != nil {
+			return nil, err
+		}
+		if s.a.Hash() != s.b.Hash() {
+			s.logger.Info("Traces diverged", "after", lo.step, "before", hi)
+			break
+		}
+		if lo, err = s.checkpoint(); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Traces agree", "step", lo.step)
+	}
+
+	// Binary search between the last agreeing checkpoint and the first disagreeing step
+	for hi-lo.step > 1 {
+		mid := lo.step + (hi-lo.step)/2
+		if err := s.restore(lo); err != nil {
+			return nil, err
+		}
+		if err := s.runTo(ctx, mid); err != nil {
+			return nil, err
+		}
+		if s.a.Hash() == s.b.Hash() {
+			if lo, err = s.checkpoint(); err != nil {
+				return nil, err
+			}
+		} else {
+			hi = mid
+		}
+		s.logger.Debug("Bisecting", "lo", lo.step, "hi", hi)
+	}
+
+	if err := s.restore(lo); err != nil {
+		return nil, err
+	}
+	s.last = lo
+	a, b := s.describe(s.a, true), s.describe(s.b, true)
+	return &Divergence{
+		Step:    lo.step,
+		PreHash: lo.hash,
+		A:       a,
+		B:       b,
+		Diff:    DiffStates(s.a.state, s.b.state, s.meta),
+	}, nil
+}
+
+// PreStates returns the agreed states immediately before the divergent step found by the last call to Bisect.
+func (s *Bisector) PreStates() (*versions.VersionedState, *versions.VersionedState, error) {
+	if s.last == nil {
+		return nil, nil, errors.New("no divergence found")
+	}
+	a, err := s.restoreState(s.a, s.last.a)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := s.restoreState(s.b, s.last.b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+func (s *Bisector) restoreState(t *Trace, data []byte) (*versions.VersionedState, error) {
+	state, err := t.decodeCheckpoint(data)
+	if err != nil {
+		return nil, err
+	}
+	return &versions.VersionedState{Version: t.version, FPVMState: state}, nil
+}
+
+func (s *Bisector) runTo(ctx context.Context, step uint64) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return s.a.RunTo(ctx, step) })
+	g.Go(func() error { return s.b.RunTo(ctx, step) })
+	return g.Wait()
+}
+
+func (s *Bisector) checkpoint() (*checkpoint, error) {
+	a, err := s.a.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.b.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint{step: s.a.state.GetStep(), hash: s.a.Hash(), a: a, b: b}, nil
+}
+
+func (s *Bisector) restore(c *checkpoint) error {
+	if s.a.state.GetStep() == c.step && s.a.Hash() == c.hash && s.b.Hash() == c.hash {
+		return nil
+	}
+	if err := s.a.Restore(c.a); err != nil {
+		return err
+	}
+	return s.b.Restore(c.b)
+}
+
+// describe records the instruction about to be executed by the trace. If execute is true, the instruction is then
+// executed and the resulting post-state and any preimage read are recorded.
+func (s *Bisector) describe(t *Trace, execute bool) *StepInfo {
+	state := t.state
+	pc := state.GetPC()
+	info := &StepInfo{
+		Trace:  t.name,
+		Thread: state.GetCurrentThread().ThreadId,
+		PC:     pc,
+		Insn:   uint32(exec.LoadSubWord(state.GetMemory(), pc, 4, false, new(exec.NoopMemoryTracker))),
+	}
+	if s.meta != nil {
+		info.Symbol = s.meta.LookupSymbol(pc)
+	}
+	if IsSyscall(state) {
+		num := state.GetRegistersRef()[register.RegSyscallNum]
+		info.Syscall = &num
+	}
+	if execute && !state.GetExited() {
+		if _, err := t.stepFn(false); err != nil {
+			s.logger.Warn("Failed to execute divergent step", "trace", t.name, "err", err)
+		} else if key, _, offset := t.vm.LastPreimage(); offset != ^Word(0) {
+			hash := common.Hash(key)
+			info.PreimageKey = &hash
+			info.PreimageOffset = &offset
+		}
+	}
+	info.PostHash = t.Hash()
+	info.Exited = state.GetExited()
+	return info
+}
@@ -0,0 +1,144 @@
+package debugger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// loopProgram increments $t0 forever
+var loopProgram = []uint32{
+	0x25080001, // addiu $t0, $t0, 1
+	0x1000fffe, // beq $zero, $zero, -2
+	0x00000000, // nop
+}
+
+func TestBisect_NoDivergence(t *testing.T) {
+	a := createLoopTrace(t, "a", 0, nil)
+	b := createLoopTrace(t, "b", 0, nil)
+	bisector := NewBisector(testlog.Logger(t, log.LevelInfo), a, b, testMeta, 100, 1000)
+	divergence, err := bisector.Bisect(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, divergence)
+	require.Equal(t, uint64(1000), a.State().GetStep())
+	require.Equal(t, uint64(1000), b.State().GetStep())
+}
+
+func TestBisect_FindsDivergentStep(t *testing.T) {
+	for _, divergentStep := range []uint64{0, 1, 99, 100, 101, 537, 999} {
+		divergentStep := divergentStep
+		a := createLoopTrace(t, "a", 0, nil)
+		b := createLoopTrace(t, "b", 0, &divergentStep)
+		bisector := NewBisector(testlog.Logger(t, log.LevelInfo), a, b, testMeta, 100, 1000)
+		divergence, err := bisector.Bisect(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, divergence)
+		require.Equal(t, divergentStep, divergence.Step)
+		require.Equal(t, "a", divergence.A.Trace)
+		require.Equal(t, "b", divergence.B.Trace)
+		require.NotEqual(t, divergence.A.PostHash, divergence.B.PostHash)
+		require.Equal(t, divergence.A.PC, divergence.B.PC)
+		require.Equal(t, "thread[0].t0", divergence.Diff.Threads[0].Field)
+
+		preA, preB, err := bisector.PreStates()
+		require.NoError(t, err)
+		require.Equal(t, divergentStep, preA.GetStep())
+		_, hashA := preA.EncodeWitness()
+		_, hashB := preB.EncodeWitness()
+		require.Equal(t, divergence.PreHash, hashA)
+		require.Equal(t, hashA, hashB)
+	}
+}
+
+func TestBisect_RestoreStateKeepsLargeICache(t *testing.T) {
+	a := createLoopTrace(t, "a", 0, nil)
+	b := createLoopTrace(t, "b", 0, nil)
+	a.State().UseLargeICache = true
+	checkpoint, err := a.Checkpoint()
+	require.NoError(t, err)
+	bisector := NewBisector(testlog.Logger(t, log.LevelInfo), a, b, testMeta, 100, 1000)
+	restored, err := bisector.restoreState(a, checkpoint)
+	require.NoError(t, err)
+	require.True(t, restored.FPVMState.(*multithreaded.State).UseLargeICache)
+}
+
+func TestBisect_DescribesSyscall(t *testing.T) {
+	// Step 3 of testProgram is the getpid syscall
+	divergentStep := uint64(3)
+	a, err := NewTrace("a", versionedState(t, createTestState()), newTestVMFactory(t, nil))
+	require.NoError(t, err)
+	b, err := NewTrace("b", versionedState(t, createTestState()), newTestVMFactory(t, &divergentStep))
+	require.NoError(t, err)
+
+	divergence, err := NewBisector(testlog.Logger(t, log.LevelInfo), a, b, testMeta, 100, 5).Bisect(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, divergentStep, divergence.Step)
+	require.Equal(t, Word(12), divergence.A.PC)
+	require.Equal(t, "getpid", divergence.A.Symbol)
+	require.Equal(t, uint32(0x0c), divergence.A.Insn)
+	require.NotNil(t, divergence.A.Syscall)
+	require.Equal(t, Word(arch.SysGetpid), *divergence.A.Syscall)
+	require.Nil(t, divergence.A.PreimageKey)
+}
+
+func TestBisect_InitialStatesDiffer(t *testing.T) {
+	a := createLoopTrace(t, "a", 0, nil)
+	b := createLoopTrace(t, "b", 0, nil)
+	b.State().GetRegistersRef()[8] = 5
+
+	divergence, err := NewBisector(testlog.Logger(t, log.LevelInfo), a, b, testMeta, 100, 1000).Bisect(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), divergence.Step)
+	require.Equal(t, []Difference{{Field: "thread[0].t0", A: "0x0", B: "0x5"}}, divergence.Diff.Threads)
+}
+
+func TestBisect_StartStepMismatch(t *testing.T) {
+	a := createLoopTrace(t, "a", 0, nil)
+	b := createLoopTrace(t, "b", 10, nil)
+	_, err := NewBisector(testlog.Logger(t, log.LevelInfo), a, b, testMeta, 100, 1000).Bisect(context.Background())
+	require.ErrorIs(t, err, ErrStartStepMismatch)
+}
+
+// createLoopTrace creates a trace running loopProgram, starting at startStep.
+func createLoopTrace(t *testing.T, name string, startStep uint64, corruptAt *uint64) *Trace {
+	state := multithreaded.CreateInitialState(0, arch.ProgramHeapStart)
+	state.Step = startStep
+	for i, insn := range loopProgram {
+		exec.StoreSubWord(state.GetMemory(), Word(i*4), 4, Word(insn), new(exec.NoopMemoryTracker))
+	}
+	trace, err := NewTrace(name, versionedState(t, state), newTestVMFactory(t, corruptAt))
+	require.NoError(t, err)
+	return trace
+}
+
+// newTestVMFactory creates VMs which, if corruptAt is not nil, corrupt $t0 when executing step corruptAt.
+func newTestVMFactory(t *testing.T, corruptAt *uint64) VMFactory {
+	return func(versioned *versions.VersionedState) (mipsevm.FPVM, StepFn) {
+		state := versioned.FPVMState.(*multithreaded.State)
+		vm := multithreaded.NewInstrumentedState(state, nil, new(bytes.Buffer), new(bytes.Buffer), testlog.Logger(t, log.LevelInfo), testMeta, mipsevm.FeatureToggles{})
+		return vm, func(proof bool) (*mipsevm.StepWitness, error) {
+			step := state.GetStep()
+			wit, err := vm.Step(proof)
+			if corruptAt != nil && step == *corruptAt {
+				state.GetRegistersRef()[8] += 100
+			}
+			return wit, err
+		}
+	}
+}
+
+func versionedState(t *testing.T, state *multithreaded.State) *versions.VersionedState {
+	versioned, err := versions.NewFromState(versions.GetCurrentVersion(), state)
+	require.NoError(t, err)
+	return versioned
+}
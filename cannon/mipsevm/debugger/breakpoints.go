@@ -0,0 +1,124 @@
+package debugger
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+)
+
+type BreakpointKind string
+
+const (
+	BreakPC       BreakpointKind = "pc"
+	BreakSymbol   BreakpointKind = "symbol"
+	BreakSyscall  BreakpointKind = "syscall"
+	BreakPreimage BreakpointKind = "preimage"
+	BreakStep     BreakpointKind = "step"
+)
+
+const (
+	opcodeSpecial = 0x00
+	functSyscall  = 0x0c
+)
+
+// Breakpoint stops execution when its condition is met.
+// Conditions are either checked against the state before the next instruction executes (pc, symbol, syscall, step)
+// or against the preimage read by the instruction that just executed (preimage).
+type Breakpoint struct {
+	ID     int
+	Kind   BreakpointKind
+	Target string
+
+	beforeStep func(state *multithreaded.State) bool
+	afterStep  func(key [32]byte, offset Word) bool
+}
+
+func (b *Breakpoint) String() string {
+	return fmt.Sprintf("#%d %s %s", b.ID, b.Kind, b.Target)
+}
+
+// NewPCBreakpoint stops before the instruction at pc is executed.
+func NewPCBreakpoint(pc Word) *Breakpoint {
+	return &Breakpoint{
+		Kind:   BreakPC,
+		Target: hexWord(pc),
+		beforeStep: func(state *multithreaded.State) bool {
+			return state.GetPC() == pc
+		},
+	}
+}
+
+// NewSymbolBreakpoint stops on entry to the named symbol.
+func NewSymbolBreakpoint(meta *program.Metadata, name string) (*Breakpoint, error) {
+	for _, sym := range meta.Symbols {
+		if sym.Name == name {
+			start := sym.Start
+			return &Breakpoint{
+				Kind:   BreakSymbol,
+				Target: fmt.Sprintf("%s (%#x)", name, start),
+				beforeStep: func(state *multithreaded.State) bool {
+					return state.GetPC() == start
+				},
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown symbol %q", name)
+}
+
+// NewSyscallBreakpoint stops before a syscall instruction is executed.
+// If num is nil, any syscall matches, otherwise only syscalls with the given number.
+func NewSyscallBreakpoint(num *Word) *Breakpoint {
+	target := "any"
+	if num != nil {
+		target = fmt.Sprint(*num)
+	}
+	return &Breakpoint{
+		Kind:   BreakSyscall,
+		Target: target,
+		beforeStep: func(state *multithreaded.State) bool {
+			if !IsSyscall(state) {
+				return false
+			}
+			return num == nil || state.GetRegistersRef()[register.RegSyscallNum] == *num
+		},
+	}
+}
+
+// NewPreimageBreakpoint stops after an instruction reads from a preimage whose key starts with prefix.
+// An empty prefix matches any preimage read.
+func NewPreimageBreakpoint(prefix []byte) *Breakpoint {
+	target := "any"
+	if len(prefix) > 0 {
+		target = hexutil.Encode(prefix)
+	}
+	return &Breakpoint{
+		Kind:   BreakPreimage,
+		Target: target,
+		afterStep: func(key [32]byte, offset Word) bool {
+			return offset != ^Word(0) && bytes.HasPrefix(key[:], prefix)
+		},
+	}
+}
+
+// NewStepBreakpoint stops before the specified step is executed.
+func NewStepBreakpoint(step uint64) *Breakpoint {
+	return &Breakpoint{
+		Kind:   BreakStep,
+		Target: fmt.Sprint(step),
+		beforeStep: func(state *multithreaded.State) bool {
+			return state.GetStep() == step
+		},
+	}
+}
+
+// IsSyscall returns true if the next instruction to execute is a syscall.
+func IsSyscall(state *multithreaded.State) bool {
+	insn := uint32(exec.LoadSubWord(state.GetMemory(), state.GetPC(), 4, false, new(exec.NoopMemoryTracker)))
+	return insn>>26 == opcodeSpecial && insn&0x3f == functSyscall
+}
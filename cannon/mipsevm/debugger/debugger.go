@@ -0,0 +1,209 @@
+package debugger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var (
+	ErrUnsupportedState    = errors.New("debugger only supports multithreaded states")
+	ErrUnknownBreakpoint   = errors.New("unknown breakpoint")
+	ErrInfiniteLoop        = errors.New("detected an infinite loop")
+	ErrUnalignedMemoryRead = errors.New("unaligned memory read")
+	ErrMemoryReadTooLarge  = errors.New("memory read too large")
+)
+
+// MaxMemoryReadWords bounds the number of words read by ReadMemory at once.
+const MaxMemoryReadWords = 1 << 16
+
+type StepFn func(proof bool) (*mipsevm.StepWitness, error)
+
+type StopReason string
+
+const (
+	StopBreakpoint StopReason = "breakpoint"
+	StopExited     StopReason = "exited"
+	StopStepLimit  StopReason = "step limit"
+)
+
+// Stop describes why execution was paused.
+type Stop struct {
+	Reason StopReason
+	// Breakpoint is the breakpoint that was hit, if Reason is StopBreakpoint.
+	Breakpoint *Breakpoint
+	// Steps is the number of steps executed before stopping.
+	Steps uint64
+}
+
+func (s *Stop) String() string {
+	if s.Breakpoint != nil {
+		return fmt.Sprintf("stopped at breakpoint %v after %d steps", s.Breakpoint, s.Steps)
+	}
+	return fmt.Sprintf("stopped (%s) after %d steps", s.Reason, s.Steps)
+}
+
+// Debugger executes a VM step by step, pausing execution when breakpoints are hit.
+type Debugger struct {
+	state  *versions.VersionedState
+	mt     *multithreaded.State
+	vm     mipsevm.FPVM
+	stepFn StepFn
+	meta   *program.Metadata
+
+	breakpoints []*Breakpoint
+	nextID      int
+}
+
+// NewDebugger creates a debugger for the VM executing state. If stepFn is nil the VM's Step method is used.
+func NewDebugger(state *versions.VersionedState, vm mipsevm.FPVM, stepFn StepFn, meta *program.Metadata) (*Debugger, error) {
+	mt, ok := state.FPVMState.(*multithreaded.State)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedState, state.FPVMState)
+	}
+	if stepFn == nil {
+		stepFn = vm.Step
+	}
+	if meta == nil {
+		meta = &program.Metadata{}
+	}
+	return &Debugger{
+		state:  state,
+		mt:     mt,
+		vm:     vm,
+		stepFn: stepFn,
+		meta:   meta,
+		nextID: 1,
+	}, nil
+}
+
+func (d *Debugger) State() *multithreaded.State {
+	return d.mt
+}
+
+func (d *Debugger) VersionedState() *versions.VersionedState {
+	return d.state
+}
+
+func (d *Debugger) Metadata() *program.Metadata {
+	return d.meta
+}
+
+// AddBreakpoint registers the breakpoint and assigns it an ID.
+func (d *Debugger) AddBreakpoint(b *Breakpoint) *Breakpoint {
+	b.ID = d.nextID
+	d.nextID++
+	d.breakpoints = append(d.breakpoints, b)
+	return b
+}
+
+func (d *Debugger) RemoveBreakpoint(id int) error {
+	idx := slices.IndexFunc(d.breakpoints, func(b *Breakpoint) bool { return b.ID == id })
+	if idx < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownBreakpoint, id)
+	}
+	d.breakpoints = slices.Delete(d.breakpoints, idx, idx+1)
+	return nil
+}
+
+func (d *Debugger) Breakpoints() []*Breakpoint {
+	return d.breakpoints
+}
+
+// Step executes up to n steps, stopping early if a breakpoint is hit or the program exits.
+// At least one step is always executed so that a breakpoint at the current position does not prevent progress.
+func (d *Debugger) Step(ctx context.Context, n uint64) (*Stop, error) {
+	var steps uint64
+	for steps < n {
+		if d.mt.GetExited() {
+			return &Stop{Reason: StopExited, Steps: steps}, nil
+		}
+		if steps%100 == 0 { // don't do the ctx err check too often
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if steps > 0 {
+			if b := d.matchBeforeStep(); b != nil {
+				return &Stop{Reason: StopBreakpoint, Breakpoint: b, Steps: steps}, nil
+			}
+		}
+		if d.vm.CheckInfiniteLoop() {
+			return nil, fmt.Errorf("%w at step %d", ErrInfiniteLoop, d.mt.GetStep())
+		}
+		if _, err := d.stepFn(false); err != nil {
+			return nil, fmt.Errorf("failed at step %d (PC: %08x): %w", d.mt.GetStep(), d.mt.GetPC(), err)
+		}
+		steps++
+		if b := d.matchAfterStep(); b != nil {
+			return &Stop{Reason: StopBreakpoint, Breakpoint: b, Steps: steps}, nil
+		}
+	}
+	if d.mt.GetExited() {
+		return &Stop{Reason: StopExited, Steps: steps}, nil
+	}
+	if b := d.matchBeforeStep(); b != nil {
+		return &Stop{Reason: StopBreakpoint, Breakpoint: b, Steps: steps}, nil
+	}
+	return &Stop{Reason: StopStepLimit, Steps: steps}, nil
+}
+
+// Continue executes until a breakpoint is hit or the program exits.
+func (d *Debugger) Continue(ctx context.Context) (*Stop, error) {
+	return d.Step(ctx, math.MaxUint64)
+}
+
+func (d *Debugger) matchBeforeStep() *Breakpoint {
+	for _, b := range d.breakpoints {
+		if b.beforeStep != nil && b.beforeStep(d.mt) {
+			return b
+		}
+	}
+	return nil
+}
+
+func (d *Debugger) matchAfterStep() *Breakpoint {
+	key, _, offset := d.vm.LastPreimage()
+	for _, b := range d.breakpoints {
+		if b.afterStep != nil && b.afterStep(key, offset) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Threads returns all threads, left stack first, in stack order from bottom to top.
+func (d *Debugger) Threads() []*multithreaded.ThreadState {
+	threads := make([]*multithreaded.ThreadState, 0, d.mt.ThreadCount())
+	threads = append(threads, d.mt.LeftThreadStack...)
+	return append(threads, d.mt.RightThreadStack...)
+}
+
+// ReadMemory reads count words starting at the word-aligned address addr.
+// At most MaxMemoryReadWords words can be read at once.
+func (d *Debugger) ReadMemory(addr Word, count int) ([]Word, error) {
+	if addr&arch.ExtMask != 0 {
+		return nil, fmt.Errorf("%w: %#x", ErrUnalignedMemoryRead, addr)
+	}
+	if count > MaxMemoryReadWords {
+		return nil, fmt.Errorf("%w: %d words, at most %d", ErrMemoryReadTooLarge, count, MaxMemoryReadWords)
+	}
+	words := make([]Word, count)
+	for i := range words {
+		words[i] = d.mt.GetMemory().GetWord(addr + Word(i*arch.WordSizeBytes))
+	}
+	return words, nil
+}
+
+// Symbolize formats addr along with the symbol it falls within, if known.
+func (d *Debugger) Symbolize(addr Word) string {
+	return symbolize(addr, d.meta)
+}
@@ -0,0 +1,188 @@
+package debugger
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testProgram sets $t0 and $t1, calls getpid then sets $t2.
+var testProgram = []uint32{
+	0x24080001,                          // addiu $t0, $zero, 1
+	0x24090002,                          // addiu $t1, $zero, 2
+	0x24020000 | uint32(arch.SysGetpid), // addiu $v0, $zero, SysGetpid
+	0x0000000c,                          // syscall
+	0x240a0003,                          // addiu $t2, $zero, 3
+	0x00000000,                          // nop
+}
+
+var testMeta = &program.Metadata{
+	Symbols: []program.Symbol{
+		{Name: "setup", Start: 0, Size: 8},
+		{Name: "getpid", Start: 8, Size: 8},
+		{Name: "finish", Start: 16, Size: 8},
+	},
+}
+
+func TestStep(t *testing.T) {
+	d := createDebugger(t)
+	stop, err := d.Step(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, StopStepLimit, stop.Reason)
+	require.Equal(t, uint64(2), stop.Steps)
+	require.Equal(t, Word(8), d.State().GetPC())
+	require.Equal(t, Word(1), d.State().GetRegistersRef()[8])
+	require.Equal(t, Word(2), d.State().GetRegistersRef()[9])
+}
+
+func TestBreakpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		bp       func(t *testing.T) *Breakpoint
+		expectPC Word
+		steps    uint64
+	}{
+		{"PC", func(t *testing.T) *Breakpoint { return NewPCBreakpoint(16) }, 16, 4},
+		{"Step", func(t *testing.T) *Breakpoint { return NewStepBreakpoint(1) }, 4, 1},
+		{"AnySyscall", func(t *testing.T) *Breakpoint { return NewSyscallBreakpoint(nil) }, 12, 3},
+		{"MatchingSyscall", func(t *testing.T) *Breakpoint {
+			num := Word(arch.SysGetpid)
+			return NewSyscallBreakpoint(&num)
+		}, 12, 3},
+		{"Symbol", func(t *testing.T) *Breakpoint {
+			b, err := NewSymbolBreakpoint(testMeta, "finish")
+			require.NoError(t, err)
+			return b
+		}, 16, 4},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			d := createDebugger(t)
+			b := d.AddBreakpoint(test.bp(t))
+			stop, err := d.Step(context.Background(), uint64(len(testProgram)))
+			require.NoError(t, err)
+			require.Equal(t, StopBreakpoint, stop.Reason)
+			require.Same(t, b, stop.Breakpoint)
+			require.Equal(t, test.steps, stop.Steps)
+			require.Equal(t, test.expectPC, d.State().GetPC())
+		})
+	}
+}
+
+func TestBreakpointAtCurrentPCDoesNotPreventProgress(t *testing.T) {
+	d := createDebugger(t)
+	d.AddBreakpoint(NewPCBreakpoint(0))
+	stop, err := d.Step(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, StopStepLimit, stop.Reason)
+	require.Equal(t, Word(4), d.State().GetPC())
+}
+
+func TestNonMatchingSyscallBreakpoint(t *testing.T) {
+	d := createDebugger(t)
+	num := Word(arch.SysGetTID)
+	d.AddBreakpoint(NewSyscallBreakpoint(&num))
+	stop, err := d.Step(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, StopStepLimit, stop.Reason)
+}
+
+func TestUnknownSymbolBreakpoint(t *testing.T) {
+	_, err := NewSymbolBreakpoint(testMeta, "unknown")
+	require.ErrorContains(t, err, "unknown symbol")
+}
+
+func TestPreimageBreakpoint(t *testing.T) {
+	key := [32]byte{0x02, 0xaa}
+	require.True(t, NewPreimageBreakpoint(nil).afterStep(key, 0))
+	require.True(t, NewPreimageBreakpoint([]byte{0x02}).afterStep(key, 8))
+	require.False(t, NewPreimageBreakpoint([]byte{0x01}).afterStep(key, 0))
+	// No preimage was read
+	require.False(t, NewPreimageBreakpoint(nil).afterStep(key, ^Word(0)))
+}
+
+func TestRemoveBreakpoint(t *testing.T) {
+	d := createDebugger(t)
+	b := d.AddBreakpoint(NewPCBreakpoint(16))
+	require.Equal(t, []*Breakpoint{b}, d.Breakpoints())
+	require.NoError(t, d.RemoveBreakpoint(b.ID))
+	require.Empty(t, d.Breakpoints())
+	require.ErrorIs(t, d.RemoveBreakpoint(b.ID), ErrUnknownBreakpoint)
+
+	stop, err := d.Step(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, StopStepLimit, stop.Reason)
+}
+
+func TestReadMemory(t *testing.T) {
+	d := createDebugger(t)
+	words, err := d.ReadMemory(0, 2)
+	require.NoError(t, err)
+	require.Equal(t, []Word{
+		Word(testProgram[0])<<32 | Word(testProgram[1]),
+		Word(testProgram[2])<<32 | Word(testProgram[3]),
+	}, words)
+
+	_, err = d.ReadMemory(4, 1)
+	require.ErrorIs(t, err, ErrUnalignedMemoryRead)
+
+	_, err = d.ReadMemory(0, MaxMemoryReadWords+1)
+	require.ErrorIs(t, err, ErrMemoryReadTooLarge)
+}
+
+func TestREPL(t *testing.T) {
+	d := createDebugger(t)
+	snapshot := filepath.Join(t.TempDir(), "snapshot.bin.gz")
+	commands := strings.Join([]string{
+		"break sym finish",
+		"snapshot " + snapshot,
+		"continue",
+		"regs",
+		"diff " + snapshot,
+		"bogus",
+		"quit",
+		"step", // Not executed
+	}, "\n")
+	var out bytes.Buffer
+	require.NoError(t, RunREPL(context.Background(), d, strings.NewReader(commands), &out))
+	output := out.String()
+	require.Contains(t, output, "added breakpoint #1 symbol finish (0x10)")
+	require.Contains(t, output, "stopped at breakpoint #1 symbol finish (0x10) after 4 steps")
+	require.Contains(t, output, "step 4 thread 0 pc 0x10 <finish>")
+	require.Contains(t, output, "$t0=0x0000000000000001")
+	require.Contains(t, output, "step: 4 -> 0")
+	require.Contains(t, output, `unknown command "bogus"`)
+	require.Equal(t, uint64(4), d.State().GetStep())
+}
+
+func createDebugger(t *testing.T) *Debugger {
+	state := createTestState()
+	vm := multithreaded.NewInstrumentedState(state, nil, new(bytes.Buffer), new(bytes.Buffer), testlog.Logger(t, log.LevelInfo), testMeta, mipsevm.FeatureToggles{})
+	versioned, err := versions.NewFromState(versions.GetCurrentVersion(), state)
+	require.NoError(t, err)
+	d, err := NewDebugger(versioned, vm, nil, testMeta)
+	require.NoError(t, err)
+	return d
+}
+
+func createTestState() *multithreaded.State {
+	state := multithreaded.CreateInitialState(0, arch.ProgramHeapStart)
+	for i, insn := range testProgram {
+		exec.StoreSubWord(state.GetMemory(), Word(i*4), 4, Word(insn), new(exec.NoopMemoryTracker))
+	}
+	return state
+}
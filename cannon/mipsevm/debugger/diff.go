@@ -0,0 +1,225 @@
+package debugger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+)
+
+type Word = arch.Word
+
+// Difference describes a single field that differs between two states.
+type Difference struct {
+	Field string
+	A     string
+	B     string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, d.A, d.B)
+}
+
+// StateDiff is the set of differences between two states.
+type StateDiff struct {
+	Scalars []Difference
+	Threads []Difference
+	// Pages lists the indexes of memory pages that are only present in one state or have different contents.
+	Pages []Word
+	// Words lists the individual memory words that differ, limited to the first differing words of each page.
+	// Pages that are only allocated in one state are reported as a single entry.
+	Words []Difference
+}
+
+// maxWordsPerPage limits how many differing words are reported for a single page.
+const maxWordsPerPage = 8
+
+func (d *StateDiff) Empty() bool {
+	return len(d.Scalars) == 0 && len(d.Threads) == 0 && len(d.Pages) == 0
+}
+
+// Write prints the diff in a human-readable form.
+func (d *StateDiff) Write(w io.Writer) {
+	if d.Empty() {
+		_, _ = fmt.Fprintln(w, "states are identical")
+		return
+	}
+	for _, diff := range d.Scalars {
+		_, _ = fmt.Fprintln(w, diff)
+	}
+	for _, diff := range d.Threads {
+		_, _ = fmt.Fprintln(w, diff)
+	}
+	if len(d.Pages) > 0 {
+		_, _ = fmt.Fprintf(w, "%d memory pages differ\n", len(d.Pages))
+	}
+	for _, diff := range d.Words {
+		_, _ = fmt.Fprintln(w, diff)
+	}
+}
+
+// DiffStates compares two states field by field. Threads are matched by thread ID and memory is compared page by page.
+// If meta is not nil, differing PCs are annotated with their symbol.
+func DiffStates(a *multithreaded.State, b *multithreaded.State, meta mipsevm.Metadata) *StateDiff {
+	result := &StateDiff{}
+	scalar := func(field string, va any, vb any) {
+		sa, sb := fmt.Sprint(va), fmt.Sprint(vb)
+		if sa != sb {
+			result.Scalars = append(result.Scalars, Difference{Field: field, A: sa, B: sb})
+		}
+	}
+	scalar("step", a.Step, b.Step)
+	scalar("stepsSinceLastContextSwitch", a.StepsSinceLastContextSwitch, b.StepsSinceLastContextSwitch)
+	scalar("exited", a.Exited, b.Exited)
+	scalar("exitCode", a.ExitCode, b.ExitCode)
+	scalar("heap", hexWord(a.Heap), hexWord(b.Heap))
+	scalar("preimageKey", common.Hash(a.PreimageKey), common.Hash(b.PreimageKey))
+	scalar("preimageOffset", a.PreimageOffset, b.PreimageOffset)
+	scalar("llReservationStatus", a.LLReservationStatus, b.LLReservationStatus)
+	scalar("llAddress", hexWord(a.LLAddress), hexWord(b.LLAddress))
+	scalar("llOwnerThread", a.LLOwnerThread, b.LLOwnerThread)
+	scalar("traverseRight", a.TraverseRight, b.TraverseRight)
+	scalar("nextThreadId", a.NextThreadId, b.NextThreadId)
+	scalar("activeThread", a.GetCurrentThread().ThreadId, b.GetCurrentThread().ThreadId)
+	scalar("lastHint", a.LastHint, b.LastHint)
+	scalar("memoryRoot", common.Hash(a.Memory.MerkleRoot()), common.Hash(b.Memory.MerkleRoot()))
+
+	result.Threads = diffThreads(a, b, meta)
+	result.Pages, result.Words = diffMemory(a.Memory, b.Memory)
+	return result
+}
+
+func diffThreads(a *multithreaded.State, b *multithreaded.State, meta mipsevm.Metadata) []Difference {
+	threadsA, threadsB := threadsByID(a), threadsByID(b)
+	ids := make([]Word, 0, len(threadsA)+len(threadsB))
+	for id := range threadsA {
+		ids = append(ids, id)
+	}
+	for id := range threadsB {
+		if _, ok := threadsA[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	var diffs []Difference
+	for _, id := range ids {
+		ta, okA := threadsA[id]
+		tb, okB := threadsB[id]
+		prefix := fmt.Sprintf("thread[%d]", id)
+		if !okA {
+			diffs = append(diffs, Difference{Field: prefix, A: "<missing>", B: "present"})
+			continue
+		}
+		if !okB {
+			diffs = append(diffs, Difference{Field: prefix, A: "present", B: "<missing>"})
+			continue
+		}
+		field := func(name string, va string, vb string) {
+			if va != vb {
+				diffs = append(diffs, Difference{Field: prefix + "." + name, A: va, B: vb})
+			}
+		}
+		field("exited", fmt.Sprint(ta.Exited), fmt.Sprint(tb.Exited))
+		field("exitCode", fmt.Sprint(ta.ExitCode), fmt.Sprint(tb.ExitCode))
+		field("pc", symbolize(ta.Cpu.PC, meta), symbolize(tb.Cpu.PC, meta))
+		field("nextPC", symbolize(ta.Cpu.NextPC, meta), symbolize(tb.Cpu.NextPC, meta))
+		field("lo", hexWord(ta.Cpu.LO), hexWord(tb.Cpu.LO))
+		field("hi", hexWord(ta.Cpu.HI), hexWord(tb.Cpu.HI))
+		for i := range ta.Registers {
+			field(RegisterName(i), hexWord(ta.Registers[i]), hexWord(tb.Registers[i]))
+		}
+	}
+	return diffs
+}
+
+func threadsByID(state *multithreaded.State) map[Word]*multithreaded.ThreadState {
+	threads := make(map[Word]*multithreaded.ThreadState)
+	for _, thread := range state.LeftThreadStack {
+		threads[thread.ThreadId] = thread
+	}
+	for _, thread := range state.RightThreadStack {
+		threads[thread.ThreadId] = thread
+	}
+	return threads
+}
+
+func diffMemory(a *memory.Memory, b *memory.Memory) ([]Word, []Difference) {
+	pagesA, pagesB := pagesByIndex(a), pagesByIndex(b)
+	var pages []Word
+	for index, pageA := range pagesA {
+		if pageB, ok := pagesB[index]; !ok || !bytes.Equal(pageA, pageB) {
+			pages = append(pages, index)
+		}
+	}
+	for index := range pagesB {
+		if _, ok := pagesA[index]; !ok {
+			pages = append(pages, index)
+		}
+	}
+	slices.Sort(pages)
+
+	var words []Difference
+	for _, index := range pages {
+		pageA, pageB := pagesA[index], pagesB[index]
+		pageAddr := index << memory.PageAddrSize
+		if pageA == nil || pageB == nil {
+			words = append(words, Difference{Field: "page[" + hexWord(pageAddr) + "]", A: pageState(pageA), B: pageState(pageB)})
+			continue
+		}
+		reported := 0
+		for offset := 0; offset < memory.PageSize && reported < maxWordsPerPage; offset += arch.WordSizeBytes {
+			wa, wb := pageWord(pageA, offset), pageWord(pageB, offset)
+			if wa == wb {
+				continue
+			}
+			words = append(words, Difference{Field: "mem[" + hexWord(pageAddr|Word(offset)) + "]", A: wa, B: wb})
+			reported++
+		}
+	}
+	return pages, words
+}
+
+func pagesByIndex(mem *memory.Memory) map[Word]memory.Page {
+	pages := make(map[Word]memory.Page, mem.PageCount())
+	_ = mem.ForEachPage(func(pageIndex Word, page memory.Page) error {
+		pages[pageIndex] = page
+		return nil
+	})
+	return pages
+}
+
+func pageState(page memory.Page) string {
+	if page == nil {
+		return "<unallocated>"
+	}
+	return "allocated"
+}
+
+func pageWord(page memory.Page, offset int) string {
+	return fmt.Sprintf("%#x", []byte(page[offset:offset+arch.WordSizeBytes]))
+}
+
+func hexWord(w Word) string {
+	return fmt.Sprintf("%#x", w)
+}
+
+// symbolize formats the address along with the symbol it falls within, if known.
+func symbolize(addr Word, meta mipsevm.Metadata) string {
+	if meta == nil {
+		return hexWord(addr)
+	}
+	// Metadata reports addresses outside of any known symbol with a "!" prefix
+	if sym := meta.LookupSymbol(addr); sym != "" && !strings.HasPrefix(sym, "!") {
+		return fmt.Sprintf("%#x <%s>", addr, sym)
+	}
+	return hexWord(addr)
+}
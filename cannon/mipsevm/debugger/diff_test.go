@@ -0,0 +1,70 @@
+package debugger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+)
+
+func TestDiffStates_Identical(t *testing.T) {
+	diff := DiffStates(createTestState(), createTestState(), testMeta)
+	require.True(t, diff.Empty())
+
+	var out bytes.Buffer
+	diff.Write(&out)
+	require.Equal(t, "states are identical\n", out.String())
+}
+
+func TestDiffStates_Scalars(t *testing.T) {
+	a, b := createTestState(), createTestState()
+	b.Step = 10
+	b.Heap = 0x2000
+	b.Exited = true
+	diff := DiffStates(a, b, testMeta)
+	require.Equal(t, []Difference{
+		{Field: "step", A: "0", B: "10"},
+		{Field: "exited", A: "false", B: "true"},
+		{Field: "heap", A: hexWord(arch.ProgramHeapStart), B: "0x2000"},
+	}, diff.Scalars)
+	require.Empty(t, diff.Threads)
+	require.Empty(t, diff.Pages)
+}
+
+func TestDiffStates_Threads(t *testing.T) {
+	a, b := createTestState(), createTestState()
+	thread := b.GetCurrentThread()
+	thread.Cpu.PC = 16
+	thread.Cpu.NextPC = 20
+	thread.Registers[29] = 0x100
+	b.LeftThreadStack = append(b.LeftThreadStack, &multithreaded.ThreadState{ThreadId: 1})
+	b.NextThreadId = 2
+
+	diff := DiffStates(a, b, testMeta)
+	require.Equal(t, []Difference{
+		{Field: "thread[0].pc", A: "0x0 <setup>", B: "0x10 <finish>"},
+		{Field: "thread[0].nextPC", A: "0x4 <setup>", B: "0x14 <finish>"},
+		{Field: "thread[0].sp", A: "0x0", B: "0x100"},
+		{Field: "thread[1]", A: "<missing>", B: "present"},
+	}, diff.Threads)
+	require.Contains(t, diff.Scalars, Difference{Field: "nextThreadId", A: "1", B: "2"})
+	// The newly pushed thread becomes active
+	require.Contains(t, diff.Scalars, Difference{Field: "activeThread", A: "0", B: "1"})
+}
+
+func TestDiffStates_Memory(t *testing.T) {
+	a, b := createTestState(), createTestState()
+	b.Memory.SetWord(0x08, 0x1234)
+	b.Memory.SetWord(0x2000, 0x5678)
+
+	diff := DiffStates(a, b, testMeta)
+	require.Equal(t, []Word{0, 2}, diff.Pages)
+	require.Equal(t, []Difference{
+		{Field: "mem[0x8]", A: "0x240213ae0000000c", B: "0x0000000000001234"},
+		{Field: "page[0x2000]", A: "<unallocated>", B: "allocated"},
+	}, diff.Words)
+	require.Equal(t, "memoryRoot", diff.Scalars[len(diff.Scalars)-1].Field)
+}
@@ -0,0 +1,34 @@
+package debugger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var registerNames = [32]string{
+	"zero", "at", "v0", "v1", "a0", "a1", "a2", "a3",
+	"t0", "t1", "t2", "t3", "t4", "t5", "t6", "t7",
+	"s0", "s1", "s2", "s3", "s4", "s5", "s6", "s7",
+	"t8", "t9", "k0", "k1", "gp", "sp", "fp", "ra",
+}
+
+// RegisterName returns the conventional name of the MIPS general purpose register.
+func RegisterName(i int) string {
+	return registerNames[i]
+}
+
+// ParseRegister parses a register either by name (e.g. "$sp", "a0") or by number (e.g. "$29", "29").
+func ParseRegister(s string) (int, error) {
+	name := strings.TrimPrefix(s, "$")
+	for i, n := range registerNames {
+		if n == name {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(name)
+	if err != nil || i < 0 || i >= len(registerNames) {
+		return 0, fmt.Errorf("unknown register %q", s)
+	}
+	return i, nil
+}
@@ -0,0 +1,301 @@
+package debugger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+const prompt = "(cannon) "
+
+// defaultMemWords is the number of words printed by the mem command when no count is given.
+const defaultMemWords = 8
+
+const snapshotFilePerm = os.FileMode(0o755)
+
+var errQuit = errors.New("quit")
+
+const helpText = `Commands:
+  step [n]                     execute n steps (default 1), stopping at breakpoints
+  continue                     execute until a breakpoint is hit or the program exits
+  break pc <addr>              stop before the instruction at addr executes
+  break sym <name>             stop on entry to the named symbol
+  break syscall [num]          stop before any syscall, or only syscall num
+  break preimage [prefix]      stop after reading a preimage whose key starts with the hex prefix
+  break step <n>               stop before step n executes
+  delete <id>                  remove a breakpoint
+  breakpoints                  list breakpoints
+  info                         print a summary of the current state
+  regs [thread]                print the registers of the active or specified thread
+  threads                      list threads
+  mem <addr> [count]           print count words (at most 65536) of memory starting at addr
+  sym <addr>                   print the symbol containing addr
+  snapshot <file>              write the current state to file
+  diff <file>                  compare the current state to the state in file
+  quit                         exit the debugger`
+
+// RunREPL reads commands from in, one per line, and writes their output to out until in is exhausted or quit is entered.
+func RunREPL(ctx context.Context, d *Debugger, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	d.printLocation(out)
+	for {
+		_, _ = fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			_, _ = fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		args := strings.Fields(scanner.Text())
+		if len(args) == 0 {
+			continue
+		}
+		err := d.execCommand(ctx, args, out)
+		if errors.Is(err, errQuit) {
+			return nil
+		} else if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		} else if err != nil {
+			_, _ = fmt.Fprintf(out, "error: %v\n", err)
+		}
+	}
+}
+
+func (d *Debugger) execCommand(ctx context.Context, args []string, out io.Writer) error {
+	switch args[0] {
+	case "help", "h":
+		_, _ = fmt.Fprintln(out, helpText)
+	case "step", "s":
+		n := uint64(1)
+		if len(args) > 1 {
+			v, err := strconv.ParseUint(args[1], 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid step count: %w", err)
+			}
+			n = v
+		}
+		return d.resume(ctx, out, func() (*Stop, error) { return d.Step(ctx, n) })
+	case "continue", "c":
+		return d.resume(ctx, out, func() (*Stop, error) { return d.Continue(ctx) })
+	case "break", "b":
+		b, err := d.parseBreakpoint(args[1:])
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "added breakpoint %v\n", d.AddBreakpoint(b))
+	case "delete", "d":
+		if len(args) != 2 {
+			return errors.New("usage: delete <id>")
+		}
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid breakpoint id: %w", err)
+		}
+		return d.RemoveBreakpoint(id)
+	case "breakpoints", "bl":
+		if len(d.breakpoints) == 0 {
+			_, _ = fmt.Fprintln(out, "no breakpoints")
+		}
+		for _, b := range d.breakpoints {
+			_, _ = fmt.Fprintln(out, b)
+		}
+	case "info", "i":
+		d.printInfo(out)
+	case "regs", "r":
+		thread := d.mt.GetCurrentThread()
+		if len(args) > 1 {
+			id, err := strconv.ParseUint(args[1], 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid thread id: %w", err)
+			}
+			if thread = d.thread(Word(id)); thread == nil {
+				return fmt.Errorf("unknown thread %d", id)
+			}
+		}
+		d.printRegisters(out, thread)
+	case "threads", "t":
+		active := d.mt.GetCurrentThread().ThreadId
+		for _, thread := range d.Threads() {
+			marker := " "
+			if thread.ThreadId == active {
+				marker = "*"
+			}
+			_, _ = fmt.Fprintf(out, "%s thread %d pc=%s exited=%v exitCode=%d\n",
+				marker, thread.ThreadId, d.Symbolize(thread.Cpu.PC), thread.Exited, thread.ExitCode)
+		}
+	case "mem", "x":
+		if len(args) < 2 {
+			return errors.New("usage: mem <addr> [count]")
+		}
+		addr, err := parseWord(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid address: %w", err)
+		}
+		count := defaultMemWords
+		if len(args) > 2 {
+			if count, err = strconv.Atoi(args[2]); err != nil || count <= 0 {
+				return fmt.Errorf("invalid word count %q", args[2])
+			}
+		}
+		addr &= arch.AddressMask
+		words, err := d.ReadMemory(addr, count)
+		if err != nil {
+			return err
+		}
+		for i, word := range words {
+			_, _ = fmt.Fprintf(out, "%#016x: %#016x\n", addr+Word(i*arch.WordSizeBytes), word)
+		}
+	case "sym":
+		if len(args) != 2 {
+			return errors.New("usage: sym <addr>")
+		}
+		addr, err := parseWord(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid address: %w", err)
+		}
+		_, _ = fmt.Fprintln(out, d.meta.LookupSymbol(addr))
+	case "snapshot":
+		if len(args) != 2 {
+			return errors.New("usage: snapshot <file>")
+		}
+		if err := serialize.Write(args[1], d.state, snapshotFilePerm); err != nil {
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		_, _ = fmt.Fprintf(out, "wrote state at step %d to %s\n", d.mt.GetStep(), args[1])
+	case "diff":
+		if len(args) != 2 {
+			return errors.New("usage: diff <file>")
+		}
+		other, err := versions.LoadStateFromFile(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to load state: %w", err)
+		}
+		otherMT, ok := other.FPVMState.(*multithreaded.State)
+		if !ok {
+			return fmt.Errorf("%w: %T", ErrUnsupportedState, other.FPVMState)
+		}
+		DiffStates(d.mt, otherMT, d.meta).Write(out)
+	case "quit", "q", "exit":
+		return errQuit
+	default:
+		return fmt.Errorf("unknown command %q, try help", args[0])
+	}
+	return nil
+}
+
+func (d *Debugger) resume(ctx context.Context, out io.Writer, fn func() (*Stop, error)) error {
+	stop, err := fn()
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, stop)
+	if stop.Breakpoint != nil && stop.Breakpoint.Kind == BreakPreimage {
+		key, _, offset := d.vm.LastPreimage()
+		_, _ = fmt.Fprintf(out, "read preimage %v at offset %d\n", common.Hash(key), offset)
+	}
+	d.printLocation(out)
+	return nil
+}
+
+func (d *Debugger) parseBreakpoint(args []string) (*Breakpoint, error) {
+	if len(args) == 0 {
+		return nil, errors.New("usage: break <pc|sym|syscall|preimage|step> [arg]")
+	}
+	kind, args := args[0], args[1:]
+	switch BreakpointKind(kind) {
+	case BreakPC:
+		if len(args) != 1 {
+			return nil, errors.New("usage: break pc <addr>")
+		}
+		pc, err := parseWord(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pc: %w", err)
+		}
+		return NewPCBreakpoint(pc), nil
+	case BreakSymbol, "sym":
+		if len(args) != 1 {
+			return nil, errors.New("usage: break sym <name>")
+		}
+		return NewSymbolBreakpoint(d.meta, args[0])
+	case BreakSyscall:
+		if len(args) == 0 {
+			return NewSyscallBreakpoint(nil), nil
+		}
+		num, err := parseWord(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid syscall number: %w", err)
+		}
+		return NewSyscallBreakpoint(&num), nil
+	case BreakPreimage:
+		if len(args) == 0 {
+			return NewPreimageBreakpoint(nil), nil
+		}
+		return NewPreimageBreakpoint(common.FromHex(args[0])), nil
+	case BreakStep:
+		if len(args) != 1 {
+			return nil, errors.New("usage: break step <n>")
+		}
+		step, err := strconv.ParseUint(args[0], 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid step: %w", err)
+		}
+		return NewStepBreakpoint(step), nil
+	default:
+		return nil, fmt.Errorf("unknown breakpoint type %q", kind)
+	}
+}
+
+func (d *Debugger) thread(id Word) *multithreaded.ThreadState {
+	for _, thread := range d.Threads() {
+		if thread.ThreadId == id {
+			return thread
+		}
+	}
+	return nil
+}
+
+func (d *Debugger) printLocation(out io.Writer) {
+	_, _ = fmt.Fprintf(out, "step %d thread %d pc %s\n", d.mt.GetStep(), d.mt.GetCurrentThread().ThreadId, d.Symbolize(d.mt.GetPC()))
+}
+
+func (d *Debugger) printInfo(out io.Writer) {
+	_, witnessHash := d.mt.EncodeWitness()
+	_, _ = fmt.Fprintf(out, "version:        %v\n", d.state.Version)
+	_, _ = fmt.Fprintf(out, "step:           %d\n", d.mt.GetStep())
+	_, _ = fmt.Fprintf(out, "pc:             %s\n", d.Symbolize(d.mt.GetPC()))
+	_, _ = fmt.Fprintf(out, "exited:         %v (code %d)\n", d.mt.GetExited(), d.mt.GetExitCode())
+	_, _ = fmt.Fprintf(out, "active thread:  %d of %d\n", d.mt.GetCurrentThread().ThreadId, d.mt.ThreadCount())
+	_, _ = fmt.Fprintf(out, "heap:           %#x\n", d.mt.GetHeap())
+	_, _ = fmt.Fprintf(out, "preimage key:   %v\n", d.mt.GetPreimageKey())
+	_, _ = fmt.Fprintf(out, "preimage off:   %d\n", d.mt.GetPreimageOffset())
+	_, _ = fmt.Fprintf(out, "pages:          %d\n", d.mt.GetMemory().PageCount())
+	_, _ = fmt.Fprintf(out, "witness hash:   %v\n", witnessHash)
+}
+
+func (d *Debugger) printRegisters(out io.Writer, thread *multithreaded.ThreadState) {
+	_, _ = fmt.Fprintf(out, "thread %d pc=%s nextPC=%s lo=%#x hi=%#x\n",
+		thread.ThreadId, d.Symbolize(thread.Cpu.PC), d.Symbolize(thread.Cpu.NextPC), thread.Cpu.LO, thread.Cpu.HI)
+	for i, val := range thread.Registers {
+		_, _ = fmt.Fprintf(out, "%5s=%#016x", "$"+RegisterName(i), val)
+		if i%4 == 3 {
+			_, _ = fmt.Fprintln(out)
+		} else {
+			_, _ = fmt.Fprint(out, " ")
+		}
+	}
+}
+
+func parseWord(s string) (Word, error) {
+	v, err := strconv.ParseUint(s, 0, arch.WordSize)
+	return Word(v), err
+}
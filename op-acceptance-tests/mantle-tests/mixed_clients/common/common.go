@@ -0,0 +1,111 @@
+// Package common holds the tests that run against every combination of EL clients
+// of the mixed-client Mantle system. The op-reth binary is run from OP_RETH_EXEC_PATH,
+// which must be set: the tests fail rather than silently running op-geth twice.
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// ClientsAgree checks that both EL clients build and import the same chain.
+func ClientsAgree(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewMantleMixedClients(t)
+
+	dsl.CheckAll(t,
+		sys.L2CLB.AdvancedFn(types.LocalUnsafe, 5, 30),
+		sys.L2CLB.AdvancedFn(types.LocalSafe, 1, 60),
+	)
+	sys.L2CLB.Matched(sys.L2CL, types.LocalSafe, 30)
+
+	safe := sys.L2ELB.BlockRefByLabel(eth.Safe)
+	t.Require().True(sys.L2EL.IsCanonical(safe.ID()), "verifier safe block must be canonical on the sequencer")
+	t.Require().Equal(sys.L2EL.BlockRefByNumber(safe.Number), safe, "both clients must agree on the block")
+}
+
+// EngineDelay checks that the verifier keeps following the chain while its engine API is slow.
+func EngineDelay(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewMantleMixedClients(t)
+
+	sys.Faults.DelayEngine(sys.L2ELB, 500*time.Millisecond)
+	dsl.CheckAll(t,
+		sys.L2CLB.AdvancedFn(types.LocalUnsafe, 3, 60),
+		sys.L2CLB.AdvancedFn(types.LocalSafe, 1, 90),
+	)
+
+	sys.Faults.DelayEngine(sys.L2ELB, 0)
+	sys.L2CLB.MatchedUnsafe(sys.L2CL, 30)
+}
+
+// PartitionedELRPC checks that the verifier stalls while the RPC traffic of its EL is held back,
+// and catches up once it is forwarded again.
+func PartitionedELRPC(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewMantleMixedClients(t)
+
+	sys.L2CLB.AdvancedUnsafe(2, 30)
+	sys.Faults.PartitionELRPC(sys.L2ELB)
+	sys.L2CL.AdvancedUnsafe(5, 30)
+
+	sys.Faults.HealELRPC(sys.L2ELB)
+	sys.L2CLB.MatchedUnsafe(sys.L2CL, 60)
+	sys.L2CLB.Matched(sys.L2CL, types.LocalSafe, 60)
+}
+
+// DroppedCLP2P checks that the verifier only follows the safe chain while its CL is cut off from the sequencer,
+// and catches up with the unsafe chain once the peers are restored.
+func DroppedCLP2P(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewMantleMixedClients(t)
+
+	sys.Faults.DropCLP2P(sys.L2CLB)
+	dsl.CheckAll(t,
+		sys.L2CL.AdvancedFn(types.LocalUnsafe, 10, 30),
+		sys.L2CLB.AdvancedFn(types.LocalSafe, 1, 90),
+	)
+	t.Require().Less(sys.L2CLB.HeadBlockRef(types.LocalUnsafe).Number, sys.L2CL.HeadBlockRef(types.LocalUnsafe).Number,
+		"verifier must lag behind without unsafe block gossip")
+
+	sys.Faults.RestoreCLP2P(sys.L2CLB)
+	sys.L2CLB.MatchedUnsafe(sys.L2CL, 60)
+}
+
+// DroppedELP2P checks that the verifier keeps following the chain through its CL while its EL has no peers.
+func DroppedELP2P(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewMantleMixedClients(t)
+
+	sys.Faults.DropELP2P(sys.L2ELB)
+	sys.L2CLB.AdvancedUnsafe(5, 30)
+	sys.L2CLB.MatchedUnsafe(sys.L2CL, 30)
+
+	sys.Faults.RestoreELP2P(sys.L2ELB)
+	sys.L2CLB.AdvancedUnsafe(2, 30)
+}
+
+// L1Reorg checks that both clients follow the L2 chain derived from the new L1 chain after an L1 reorg.
+func L1Reorg(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewMantleMixedClients(t)
+
+	sys.L2CLB.Advanced(types.LocalSafe, 1, 60)
+	divergence := sys.Faults.ReorgL1(sys.L1Network, 3)
+	sys.L1EL.ReorgTriggered(divergence, 10)
+
+	dsl.CheckAll(t,
+		sys.L2CL.AdvancedFn(types.LocalSafe, 2, 90),
+		sys.L2CLB.AdvancedFn(types.LocalSafe, 2, 90),
+	)
+	sys.L2CLB.Matched(sys.L2CL, types.LocalSafe, 60)
+
+	safe := sys.L2ELB.BlockRefByLabel(eth.Safe)
+	t.Require().True(sys.L1EL.IsCanonical(safe.L1Origin), "L1 origin of the safe block must be canonical after the reorg")
+}
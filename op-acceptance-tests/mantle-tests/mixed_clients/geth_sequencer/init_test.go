@@ -0,0 +1,18 @@
+package geth_sequencer
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/compat"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-devstack/sysgo"
+)
+
+// TestMain runs the tests against an op-geth sequencer and an op-reth verifier.
+func TestMain(m *testing.M) {
+	presets.DoMain(m,
+		presets.WithMantleMixedClients(sysgo.L2ELKindOpGeth, sysgo.L2ELKindOpReth),
+		presets.WithCompatibleTypes(compat.SysGo),
+		presets.WithNoDiscovery(),
+	)
+}
@@ -0,0 +1,31 @@
+package reth_sequencer
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-acceptance-tests/mantle-tests/mixed_clients/common"
+)
+
+func TestClientsAgree(gt *testing.T) {
+	common.ClientsAgree(gt)
+}
+
+func TestEngineDelay(gt *testing.T) {
+	common.EngineDelay(gt)
+}
+
+func TestPartitionedELRPC(gt *testing.T) {
+	common.PartitionedELRPC(gt)
+}
+
+func TestDroppedCLP2P(gt *testing.T) {
+	common.DroppedCLP2P(gt)
+}
+
+func TestDroppedELP2P(gt *testing.T) {
+	common.DroppedELP2P(gt)
+}
+
+func TestL1Reorg(gt *testing.T) {
+	common.L1Reorg(gt)
+}
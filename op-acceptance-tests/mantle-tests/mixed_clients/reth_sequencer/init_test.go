@@ -0,0 +1,18 @@
+package reth_sequencer
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/compat"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-devstack/sysgo"
+)
+
+// TestMain runs the tests against an op-reth sequencer and an op-geth verifier.
+func TestMain(m *testing.M) {
+	presets.DoMain(m,
+		presets.WithMantleMixedClients(sysgo.L2ELKindOpReth, sysgo.L2ELKindOpGeth),
+		presets.WithCompatibleTypes(compat.SysGo),
+		presets.WithNoDiscovery(),
+	)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+)
+
+var (
+	prefix     = "DEPOSIT_TRACE"
+	EndpointL1 = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "L1 execution RPC endpoint",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "L1"),
+		Required: true,
+	}
+	EndpointL2 = &cli.StringFlag{
+		Name:     "l2",
+		Usage:    "L2 execution RPC endpoint",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "L2"),
+		Required: true,
+	}
+	TxFlag = &cli.StringFlag{
+		Name:     "tx",
+		Usage:    "Hash of the L1 transaction that made the deposits",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "TX"),
+		Required: true,
+	}
+	PortalFlag = &cli.StringFlag{
+		Name:    "portal-address",
+		Usage:   "Only trace deposits of this OptimismPortal (optional)",
+		EnvVars: op_service.PrefixEnvVar(prefix, "PORTAL_ADDRESS"),
+	}
+	NoTraceFlag = &cli.BoolFlag{
+		Name:    "no-trace",
+		Usage:   "Do not use debug_traceTransaction to find the error of failed deposits",
+		EnvVars: op_service.PrefixEnvVar(prefix, "NO_TRACE"),
+	}
+	JSONFlag = &cli.BoolFlag{
+		Name:    "json",
+		Usage:   "Print the traces as JSON",
+		EnvVars: op_service.PrefixEnvVar(prefix, "JSON"),
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "deposit-trace"
+	app.Usage = "Trace Mantle deposits from L1 to L2."
+	app.Description = "Decodes the deposits of an L1 transaction, computes their L2 transaction hashes, and explains their result on L2."
+	app.Writer = os.Stdout
+	app.ErrWriter = os.Stderr
+	app.Flags = []cli.Flag{EndpointL1, EndpointL2, TxFlag, PortalFlag, NoTraceFlag, JSONFlag}
+	app.Action = traceAction
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Application failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func traceAction(c *cli.Context) error {
+	ctx := ctxinterrupt.WithCancelOnInterrupt(c.Context)
+
+	var txHash common.Hash
+	if err := txHash.UnmarshalText([]byte(c.String(TxFlag.Name))); err != nil {
+		return fmt.Errorf("invalid tx hash: %w", err)
+	}
+	var portal *common.Address
+	if c.IsSet(PortalFlag.Name) {
+		if !common.IsHexAddress(c.String(PortalFlag.Name)) {
+			return fmt.Errorf("invalid portal address: %q", c.String(PortalFlag.Name))
+		}
+		addr := common.HexToAddress(c.String(PortalFlag.Name))
+		portal = &addr
+	}
+
+	l1, err := ethclient.DialContext(ctx, c.String(EndpointL1.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1.Close()
+	l2RPC, err := rpc.DialContext(ctx, c.String(EndpointL2.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	defer l2RPC.Close()
+	l2 := ethclient.NewClient(l2RPC)
+	var tracer Tracer
+	if !c.Bool(NoTraceFlag.Name) {
+		tracer = l2RPC
+	}
+
+	receipt, err := l1.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 receipt of %s: %w", txHash, err)
+	}
+	traces, err := DecodeDeposits(receipt, portal)
+	if err != nil {
+		return err
+	}
+	for _, trace := range traces {
+		trace.L2, err = LocateDeposit(ctx, l2, tracer, receipt.BlockNumber.Uint64(), trace)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Bool(JSONFlag.Name) {
+		enc := json.NewEncoder(c.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(traces)
+	}
+	for _, trace := range traces {
+		printTrace(c.App.Writer, trace)
+	}
+	return nil
+}
+
+func printTrace(w io.Writer, trace *DepositTrace) {
+	to := "<contract creation>"
+	if trace.To != nil {
+		to = trace.To.String()
+	}
+	_, _ = fmt.Fprintf(w, "Deposit at log index %d (portal %s, version %d)\n", trace.LogIndex, trace.Portal, trace.Version)
+	_, _ = fmt.Fprintf(w, "  from:        %s\n", trace.From)
+	_, _ = fmt.Fprintf(w, "  to:          %s\n", to)
+	_, _ = fmt.Fprintf(w, "  mint MNT:    %s\n", trace.MintMNT)
+	_, _ = fmt.Fprintf(w, "  MNT value:   %s\n", trace.MNTValue)
+	_, _ = fmt.Fprintf(w, "  mint ETH:    %s\n", trace.MintETH)
+	_, _ = fmt.Fprintf(w, "  ETH value:   %s\n", trace.ETHValue)
+	_, _ = fmt.Fprintf(w, "  gas limit:   %d\n", trace.GasLimit)
+	_, _ = fmt.Fprintf(w, "  data:        %s\n", trace.Data)
+	_, _ = fmt.Fprintf(w, "  source hash: %s\n", trace.SourceHash)
+	_, _ = fmt.Fprintf(w, "  L2 tx hash:  %s\n", trace.L2TxHash)
+	if res := trace.L2; res != nil {
+		_, _ = fmt.Fprintf(w, "  L2 status:   %s\n", res.Status)
+		if res.Status != StatusPending {
+			_, _ = fmt.Fprintf(w, "  L2 block:    %d (%s)\n", res.BlockNumber, res.BlockHash)
+			_, _ = fmt.Fprintf(w, "  gas used:    %d\n", res.GasUsed)
+		}
+		if res.ContractAddress != nil {
+			_, _ = fmt.Fprintf(w, "  contract:    %s\n", res.ContractAddress)
+		}
+		_, _ = fmt.Fprintf(w, "  %s\n", res.Explanation)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// Status of a deposit on L2.
+const (
+	StatusIncluded = "included"
+	StatusFailed   = "failed"
+	StatusPending  = "pending"
+)
+
+var l1BlockNumberFunc = w3.MustNewFunc("number()", "uint64")
+
+// DepositTrace describes a deposit emitted by the OptimismPortal on L1, and its execution on L2.
+// Values are in wei.
+type DepositTrace struct {
+	LogIndex uint           `json:"logIndex"`
+	Portal   common.Address `json:"portal"`
+	Version  uint64         `json:"version"`
+	// From is the L2 sender, which is aliased if the deposit was made by an L1 contract.
+	From common.Address  `json:"from"`
+	To   *common.Address `json:"to"`
+	// MintMNT is the MNT minted to From on L2, bridged from L1.
+	MintMNT *big.Int `json:"mintMNT"`
+	// MNTValue is the MNT value transferred from From to To on L2.
+	MNTValue *big.Int `json:"mntValue"`
+	// MintETH is the ETH minted as BVM_ETH on L2, bridged from L1.
+	MintETH *big.Int `json:"mintETH"`
+	// ETHValue is the BVM_ETH value transferred from From to To on L2.
+	ETHValue   *big.Int      `json:"ethValue"`
+	GasLimit   uint64        `json:"gasLimit"`
+	IsCreation bool          `json:"isCreation"`
+	Data       hexutil.Bytes `json:"data"`
+	SourceHash common.Hash   `json:"sourceHash"`
+	L2TxHash   common.Hash   `json:"l2TxHash"`
+
+	L2 *L2Result `json:"l2,omitempty"`
+}
+
+// L2Result is the result of locating a deposit on L2.
+type L2Result struct {
+	Status          string          `json:"status"`
+	BlockNumber     uint64          `json:"blockNumber,omitempty"`
+	BlockHash       common.Hash     `json:"blockHash,omitempty"`
+	GasUsed         uint64          `json:"gasUsed,omitempty"`
+	ContractAddress *common.Address `json:"contractAddress,omitempty"`
+	// Error is the execution error of a failed deposit, if it could be traced.
+	Error       string `json:"error,omitempty"`
+	Explanation string `json:"explanation"`
+}
+
+func orZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
+
+// DecodeDeposits decodes all deposits of the L1 receipt.
+// If portal is not nil, only deposits emitted by the portal are decoded.
+func DecodeDeposits(receipt *types.Receipt, portal *common.Address) ([]*DepositTrace, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("L1 transaction %s failed, no deposit was made", receipt.TxHash)
+	}
+	var traces []*DepositTrace
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Topics[0] != derive.DepositEventABIHash {
+			continue
+		}
+		if portal != nil && log.Address != *portal {
+			continue
+		}
+		dep, err := derive.UnmarshalDepositLogEvent(log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode deposit at log index %d: %w", log.Index, err)
+		}
+		traces = append(traces, &DepositTrace{
+			LogIndex:   log.Index,
+			Portal:     log.Address,
+			Version:    new(big.Int).SetBytes(log.Topics[3].Bytes()).Uint64(),
+			From:       dep.From,
+			To:         dep.To,
+			MintMNT:    orZero(dep.Mint),
+			MNTValue:   orZero(dep.Value),
+			MintETH:    orZero(dep.EthValue),
+			ETHValue:   orZero(dep.EthTxValue),
+			GasLimit:   dep.Gas,
+			IsCreation: dep.To == nil,
+			Data:       dep.Data,
+			SourceHash: dep.SourceHash,
+			L2TxHash:   types.NewTx(dep).Hash(),
+		})
+	}
+	if len(traces) == 0 {
+		return nil, fmt.Errorf("no deposits found in L1 transaction %s", receipt.TxHash)
+	}
+	return traces, nil
+}
+
+// L2Client is the L2 RPC client used to locate deposits.
+type L2Client interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Tracer traces transactions with the debug API of the L2 node.
+type Tracer interface {
+	CallContext(ctx context.Context, result any, method string, args ...any) error
+}
+
+// LocateDeposit looks up the deposit on L2, and explains its result.
+// l1BlockNumber is the number of the L1 block the deposit was made in.
+// tracer is optional, and used to find the execution error of failed deposits.
+func LocateDeposit(ctx context.Context, l2 L2Client, tracer Tracer, l1BlockNumber uint64, trace *DepositTrace) (*L2Result, error) {
+	receipt, err := l2.TransactionReceipt(ctx, trace.L2TxHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return pendingResult(ctx, l2, l1BlockNumber)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get L2 receipt of deposit %s: %w", trace.L2TxHash, err)
+	}
+
+	result := &L2Result{
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		BlockHash:   receipt.BlockHash,
+		GasUsed:     receipt.GasUsed,
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		result.Status = StatusIncluded
+		if trace.IsCreation {
+			result.ContractAddress = &receipt.ContractAddress
+		}
+		result.Explanation = fmt.Sprintf("The deposit was executed successfully in L2 block %d.", result.BlockNumber)
+		return result, nil
+	}
+
+	result.Status = StatusFailed
+	if tracer != nil {
+		result.Error = traceFailure(ctx, tracer, trace.L2TxHash)
+	}
+	result.Explanation = explainFailure(trace, result)
+	return result, nil
+}
+
+func pendingResult(ctx context.Context, l2 L2Client, l1BlockNumber uint64) (*L2Result, error) {
+	input, err := l1BlockNumberFunc.EncodeArgs()
+	if err != nil {
+		return nil, err
+	}
+	out, err := l2.CallContract(ctx, ethereum.CallMsg{To: &predeploys.L1BlockAddr, Data: input}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 origin of L2: %w", err)
+	}
+	var origin uint64
+	if err := l1BlockNumberFunc.DecodeReturns(out, &origin); err != nil {
+		return nil, fmt.Errorf("failed to decode L1 origin of L2: %w", err)
+	}
+	result := &L2Result{Status: StatusPending}
+	if origin < l1BlockNumber {
+		result.Explanation = fmt.Sprintf("The deposit is not on L2 yet. The latest L2 block has L1 origin %d, and the deposit is included "+
+			"once L2 reaches L1 block %d. This normally happens within minutes, and at the latest after the sequencing window.", origin, l1BlockNumber)
+	} else {
+		result.Explanation = fmt.Sprintf("The deposit is not on L2, although L2 has already reached L1 block %d (L1 origin %d). "+
+			"Check that the L2 RPC belongs to the chain of the portal that emitted the deposit, and that the L2 node is not stalled on an old head.", l1BlockNumber, origin)
+	}
+	return result, nil
+}
+
+type callFrame struct {
+	Error        string `json:"error"`
+	RevertReason string `json:"revertReason"`
+}
+
+// traceFailure returns the execution error of the failed deposit, or an empty string if it cannot be traced.
+func traceFailure(ctx context.Context, tracer Tracer, txHash common.Hash) string {
+	var frame callFrame
+	if err := tracer.CallContext(ctx, &frame, "debug_traceTransaction", txHash, map[string]any{"tracer": "callTracer"}); err != nil {
+		return ""
+	}
+	if frame.RevertReason != "" {
+		return fmt.Sprintf("%s: %s", frame.Error, frame.RevertReason)
+	}
+	return frame.Error
+}
+
+func explainFailure(trace *DepositTrace, result *L2Result) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "The deposit failed in L2 block %d", result.BlockNumber)
+	switch {
+	case result.Error != "":
+		fmt.Fprintf(&sb, " with %q.", result.Error)
+	case result.GasUsed >= trace.GasLimit:
+		fmt.Fprintf(&sb, ", using its full gas limit of %d: it most likely ran out of gas.", trace.GasLimit)
+	default:
+		sb.WriteString(".")
+	}
+	if strings.Contains(result.Error, "out of gas") {
+		fmt.Fprintf(&sb, " The gas limit of %d is too low for the call to the target.", trace.GasLimit)
+	}
+	if trace.MintMNT.Sign() > 0 || trace.MintETH.Sign() > 0 {
+		sb.WriteString(" The minted MNT and ETH are kept on L2, even though the deposit failed.")
+	}
+	if trace.MNTValue.Sign() > 0 || trace.ETHValue.Sign() > 0 {
+		sb.WriteString(" The MNT and ETH values were not transferred to the target.")
+	}
+	return sb.String()
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+var testPortal = common.HexToAddress("0xb5ac1e85ac6bd6d3ac0a4e14fa9e1e9e5a2e6e79")
+
+type fakeL2 struct {
+	receipts map[common.Hash]*types.Receipt
+	origin   uint64
+}
+
+func (f *fakeL2) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if r, ok := f.receipts[txHash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeL2) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return common.BigToHash(new(big.Int).SetUint64(f.origin)).Bytes(), nil
+}
+
+type fakeTracer struct {
+	frame callFrame
+}
+
+func (f *fakeTracer) CallContext(_ context.Context, result any, _ string, _ ...any) error {
+	data, err := json.Marshal(f.frame)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func testL1Receipt(t *testing.T) (*types.Receipt, *types.DepositTx) {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	dep := &types.DepositTx{
+		From:     common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		To:       &to,
+		Mint:     big.NewInt(100),
+		Value:    big.NewInt(10),
+		EthValue: big.NewInt(5),
+		Gas:      50_000,
+		Data:     []byte{0x01, 0x02},
+	}
+	log, err := derive.MarshalDepositLogEventV0(testPortal, dep)
+	require.NoError(t, err)
+	log.BlockHash = common.Hash{0xaa}
+	log.Index = 3
+	dep.SourceHash = (&derive.UserDepositSource{L1BlockHash: log.BlockHash, LogIndex: uint64(log.Index)}).SourceHash()
+	receipt := &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(100),
+		Logs: []*types.Log{
+			{Address: testPortal, Topics: []common.Hash{{0x01}}},
+			log,
+		},
+	}
+	return receipt, dep
+}
+
+func TestDecodeDeposits(t *testing.T) {
+	receipt, dep := testL1Receipt(t)
+
+	traces, err := DecodeDeposits(receipt, nil)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	trace := traces[0]
+	require.Equal(t, uint(3), trace.LogIndex)
+	require.Equal(t, testPortal, trace.Portal)
+	require.Equal(t, dep.From, trace.From)
+	require.Equal(t, dep.To, trace.To)
+	require.Equal(t, dep.Mint, trace.MintMNT)
+	require.Equal(t, dep.Value, trace.MNTValue)
+	require.Equal(t, dep.EthValue, trace.MintETH)
+	require.Zero(t, trace.ETHValue.Sign())
+	require.Equal(t, dep.Gas, trace.GasLimit)
+	require.Equal(t, dep.SourceHash, trace.SourceHash)
+	require.Equal(t, types.NewTx(dep).Hash(), trace.L2TxHash)
+
+	_, err = DecodeDeposits(receipt, &common.Address{0x01})
+	require.ErrorContains(t, err, "no deposits found")
+
+	receipt.Status = types.ReceiptStatusFailed
+	_, err = DecodeDeposits(receipt, nil)
+	require.ErrorContains(t, err, "no deposit was made")
+}
+
+func TestLocateDeposit(t *testing.T) {
+	receipt, _ := testL1Receipt(t)
+	traces, err := DecodeDeposits(receipt, nil)
+	require.NoError(t, err)
+	trace := traces[0]
+	l1Block := receipt.BlockNumber.Uint64()
+
+	t.Run("Pending", func(t *testing.T) {
+		l2 := &fakeL2{origin: l1Block - 1}
+		res, err := LocateDeposit(context.Background(), l2, nil, l1Block, trace)
+		require.NoError(t, err)
+		require.Equal(t, StatusPending, res.Status)
+		require.Contains(t, res.Explanation, "not on L2 yet")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		l2 := &fakeL2{origin: l1Block + 1}
+		res, err := LocateDeposit(context.Background(), l2, nil, l1Block, trace)
+		require.NoError(t, err)
+		require.Equal(t, StatusPending, res.Status)
+		require.Contains(t, res.Explanation, "has already reached L1 block")
+	})
+
+	t.Run("Included", func(t *testing.T) {
+		l2 := &fakeL2{receipts: map[common.Hash]*types.Receipt{
+			trace.L2TxHash: {Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(20), GasUsed: 21_000},
+		}}
+		res, err := LocateDeposit(context.Background(), l2, nil, l1Block, trace)
+		require.NoError(t, err)
+		require.Equal(t, StatusIncluded, res.Status)
+		require.Equal(t, uint64(20), res.BlockNumber)
+	})
+
+	t.Run("FailedReverted", func(t *testing.T) {
+		l2 := &fakeL2{receipts: map[common.Hash]*types.Receipt{
+			trace.L2TxHash: {Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(20), GasUsed: 30_000},
+		}}
+		tracer := &fakeTracer{frame: callFrame{Error: "execution reverted", RevertReason: "not allowed"}}
+		res, err := LocateDeposit(context.Background(), l2, tracer, l1Block, trace)
+		require.NoError(t, err)
+		require.Equal(t, StatusFailed, res.Status)
+		require.Equal(t, "execution reverted: not allowed", res.Error)
+		require.Contains(t, res.Explanation, "minted MNT and ETH are kept")
+		require.Contains(t, res.Explanation, "were not transferred")
+	})
+
+	t.Run("FailedOutOfGas", func(t *testing.T) {
+		l2 := &fakeL2{receipts: map[common.Hash]*types.Receipt{
+			trace.L2TxHash: {Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(20), GasUsed: trace.GasLimit},
+		}}
+		res, err := LocateDeposit(context.Background(), l2, nil, l1Block, trace)
+		require.NoError(t, err)
+		require.Equal(t, StatusFailed, res.Status)
+		require.Empty(t, res.Error)
+		require.Contains(t, res.Explanation, "ran out of gas")
+	})
+}
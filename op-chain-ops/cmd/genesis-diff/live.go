@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/script/forking"
+)
+
+// fetchConcurrency is the number of accounts that are fetched in parallel.
+const fetchConcurrency = 16
+
+// FetchAlloc reads the expected accounts, and their expected storage slots, from the given state.
+// The state cannot be enumerated, so accounts and storage slots that are not part of the expected allocs are not detected.
+// Accounts that are empty in the state are left out, unless they are expected to be empty,
+// since an RPC cannot tell an empty account from a non-existent one.
+func FetchAlloc(ctx context.Context, src forking.ForkSource, expected types.GenesisAlloc) (types.GenesisAlloc, error) {
+	var mu sync.Mutex
+	out := make(types.GenesisAlloc, len(expected))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(fetchConcurrency)
+	for addr, exp := range expected {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			acc, err := fetchAccount(src, addr, exp)
+			if err != nil {
+				return fmt.Errorf("failed to fetch account %s: %w", addr, err)
+			}
+			if isEmptyAccount(acc) && !isEmptyAccount(exp) {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			out[addr] = acc
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func fetchAccount(src forking.ForkSource, addr common.Address, exp types.Account) (types.Account, error) {
+	var acc types.Account
+	nonce, err := src.Nonce(addr)
+	if err != nil {
+		return acc, err
+	}
+	balance, err := src.Balance(addr)
+	if err != nil {
+		return acc, err
+	}
+	code, err := src.Code(addr)
+	if err != nil {
+		return acc, err
+	}
+	acc.Nonce = nonce
+	acc.Balance = balance.ToBig()
+	acc.Code = code
+	for slot := range exp.Storage {
+		val, err := src.StorageAt(addr, slot)
+		if err != nil {
+			return acc, err
+		}
+		if val == (common.Hash{}) {
+			continue
+		}
+		if acc.Storage == nil {
+			acc.Storage = make(map[common.Hash]common.Hash)
+		}
+		acc.Storage[slot] = val
+	}
+	return acc, nil
+}
+
+func isEmptyAccount(acc types.Account) bool {
+	for _, val := range acc.Storage {
+		if val != (common.Hash{}) {
+			return false
+		}
+	}
+	return acc.Nonce == 0 && (acc.Balance == nil || acc.Balance.Sign() == 0) && len(acc.Code) == 0
+}
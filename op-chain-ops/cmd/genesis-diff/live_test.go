@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/script/forking"
+)
+
+func TestFetchAlloc(t *testing.T) {
+	expected := types.GenesisAlloc{
+		{0x01}: {Balance: big.NewInt(1)},
+		{0x02}: {Code: []byte{0x60, 0x00}, Storage: map[common.Hash]common.Hash{{0x01}: {0x01}}},
+		{0x03}: {Balance: big.NewInt(1)},
+		{0x04}: {},
+	}
+	src := forking.NewAllocSource(types.GenesisAlloc{
+		{0x01}: {Balance: big.NewInt(1)},
+		{0x02}: {Code: []byte{0x60, 0x00}, Storage: map[common.Hash]common.Hash{{0x01}: {0x02}, {0x02}: {0x02}}},
+		{0x05}: {Balance: big.NewInt(1)},
+	})
+	actual, err := FetchAlloc(context.Background(), src, expected)
+	require.NoError(t, err)
+	require.Len(t, actual, 3)
+
+	diff := genesis.DiffGenesisAllocs(expected, actual)
+	require.Equal(t, []genesis.GenesisAccountDiff{
+		{
+			Address: common.Address{0x02},
+			Kind:    genesis.AccountChanged,
+			// Slots that are not expected are not fetched
+			Storage: map[common.Hash]genesis.ValueDiff[common.Hash]{
+				{0x01}: {Expected: common.Hash{0x01}, Actual: common.Hash{0x02}},
+			},
+		},
+		{Address: common.Address{0x03}, Kind: genesis.AccountMissing},
+	}, diff)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/script/forking"
+	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/pipeline"
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	prefix           = "GENESIS_DIFF"
+	DeployConfigFlag = &cli.PathFlag{
+		Name:     "deploy-config",
+		Usage:    "Path to the deploy config the genesis was built from",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "DEPLOY_CONFIG"),
+		Required: true,
+	}
+	L1DeploymentsFlag = &cli.PathFlag{
+		Name:    "l1-deployments",
+		Usage:   "Path to the L1 deployments JSON file. Required unless --l2-allocs is set",
+		EnvVars: op_service.PrefixEnvVar(prefix, "L1_DEPLOYMENTS"),
+	}
+	L2AllocsFlag = &cli.PathFlag{
+		Name:    "l2-allocs",
+		Usage:   "Path to an L2 genesis state dump to use instead of generating the L2 genesis state",
+		EnvVars: op_service.PrefixEnvVar(prefix, "L2_ALLOCS"),
+	}
+	L1RPCFlag = &cli.StringFlag{
+		Name:     "l1-rpc",
+		Usage:    "L1 execution RPC endpoint, used to fetch the L1 starting block",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "L1_RPC"),
+		Required: true,
+	}
+	GenesisFileFlag = &cli.PathFlag{
+		Name:    "genesis-file",
+		Usage:   "Path to an existing L2 genesis file to compare against",
+		EnvVars: op_service.PrefixEnvVar(prefix, "GENESIS_FILE"),
+	}
+	L2RPCFlag = &cli.StringFlag{
+		Name:    "l2-rpc",
+		Usage:   "L2 execution RPC endpoint of a live chain to compare against. Must serve the state of the genesis block",
+		EnvVars: op_service.PrefixEnvVar(prefix, "L2_RPC"),
+	}
+	JSONFlag = &cli.BoolFlag{
+		Name:    "json",
+		Usage:   "Print the diff as JSON",
+		EnvVars: op_service.PrefixEnvVar(prefix, "JSON"),
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "genesis-diff"
+	app.Usage = "Check that a Mantle L2 genesis can be reproduced from its deploy config."
+	app.Description = "Rebuilds the L2 genesis from a deploy config, and compares it against an existing genesis file " +
+		"or the genesis block of a live chain. Prints the differing header fields, chain config fields and accounts, " +
+		"and explains why the genesis block hashes differ."
+	app.Writer = os.Stdout
+	app.ErrWriter = os.Stderr
+	app.Flags = []cli.Flag{DeployConfigFlag, L1DeploymentsFlag, L2AllocsFlag, L1RPCFlag, GenesisFileFlag, L2RPCFlag, JSONFlag}
+	app.Flags = append(app.Flags, oplog.CLIFlags(prefix)...)
+	app.Action = diffAction
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Application failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func diffAction(c *cli.Context) error {
+	ctx := ctxinterrupt.WithCancelOnInterrupt(c.Context)
+	logger := oplog.NewLogger(c.App.ErrWriter, oplog.ReadCLIConfig(c))
+
+	genesisFile, l2RPC := c.Path(GenesisFileFlag.Name), c.String(L2RPCFlag.Name)
+	if (genesisFile == "") == (l2RPC == "") {
+		return fmt.Errorf("exactly one of --%s and --%s must be set", GenesisFileFlag.Name, L2RPCFlag.Name)
+	}
+
+	expected, err := rebuildGenesis(ctx, logger, c)
+	if err != nil {
+		return err
+	}
+	logger.Info("Rebuilt L2 genesis", "hash", expected.ToBlock().Hash(), "accounts", len(expected.Alloc))
+
+	var diff *genesis.GenesisDiff
+	if genesisFile != "" {
+		actual, err := jsonutil.LoadJSON[core.Genesis](genesisFile)
+		if err != nil {
+			return fmt.Errorf("failed to load genesis file: %w", err)
+		}
+		diff, err = genesis.DiffGenesis(expected, actual)
+		if err != nil {
+			return err
+		}
+	} else {
+		diff, err = diffLiveGenesis(ctx, logger, l2RPC, expected)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := writeDiff(c.App.Writer, diff, c.Bool(JSONFlag.Name)); err != nil {
+		return err
+	}
+	if !diff.Match() {
+		return fmt.Errorf("genesis mismatch")
+	}
+	return nil
+}
+
+// rebuildGenesis builds the L2 genesis from the deploy config, like the op-node genesis l2 command does.
+func rebuildGenesis(ctx context.Context, logger log.Logger, c *cli.Context) (*core.Genesis, error) {
+	config, err := genesis.NewDeployConfig(c.Path(DeployConfigFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var l2Allocs *foundry.ForgeAllocs
+	if allocsPath := c.Path(L2AllocsFlag.Name); allocsPath != "" {
+		l2Allocs, err = foundry.LoadForgeAllocs(allocsPath)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		deploymentsPath := c.Path(L1DeploymentsFlag.Name)
+		if deploymentsPath == "" {
+			return nil, fmt.Errorf("--%s is required to generate the L2 genesis state", L1DeploymentsFlag.Name)
+		}
+		deployments, err := genesis.NewL1Deployments(deploymentsPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read L1 deployments at %s: %w", deploymentsPath, err)
+		}
+		config.SetDeployments(deployments)
+		l2Allocs, err = pipeline.DefaultMantleL2GenesisStates(logger, common.Address{0x01}, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate L2 genesis state: %w", err)
+		}
+	}
+
+	l1RPC := c.String(L1RPCFlag.Name)
+	cl, err := ethclient.DialContext(ctx, l1RPC)
+	if err != nil {
+		return nil, fmt.Errorf("cannot dial %s: %w", l1RPC, err)
+	}
+	defer cl.Close()
+	l2Genesis, _, err := genesis.BuildMantleL2Genesis(ctx, logger, config, l2Allocs, cl)
+	return l2Genesis, err
+}
+
+// diffLiveGenesis compares the expected genesis against the genesis block of a live chain.
+func diffLiveGenesis(ctx context.Context, logger log.Logger, l2RPC string, expected *core.Genesis) (*genesis.GenesisDiff, error) {
+	rpcClient, err := rpc.DialContext(ctx, l2RPC)
+	if err != nil {
+		return nil, fmt.Errorf("cannot dial %s: %w", l2RPC, err)
+	}
+	defer rpcClient.Close()
+	header, err := ethclient.NewClient(rpcClient).HeaderByNumber(ctx, common.Big0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L2 genesis block: %w", err)
+	}
+	src, err := forking.RPCSourceByNumber(l2RPC, rpcClient, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open L2 genesis state: %w", err)
+	}
+	defer src.Close()
+
+	logger.Info("Fetching L2 genesis state", "accounts", len(expected.Alloc))
+	actualAlloc, err := FetchAlloc(ctx, src, expected.Alloc)
+	if err != nil {
+		return nil, err
+	}
+	expectedBlock := expected.ToBlock()
+	return &genesis.GenesisDiff{
+		ExpectedHash: expectedBlock.Hash(),
+		ActualHash:   header.Hash(),
+		Header:       genesis.DiffGenesisHeaders(expectedBlock.Header(), header),
+		Accounts:     genesis.DiffGenesisAllocs(expected.Alloc, actualAlloc),
+	}, nil
+}
+
+func writeDiff(w io.Writer, diff *genesis.GenesisDiff, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	}
+	for _, line := range diff.Explain() {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	for _, acc := range diff.Accounts {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", acc.Address, acc.Kind)
+		if acc.Balance != nil {
+			_, _ = fmt.Fprintf(w, "  balance: expected %s, got %s\n", acc.Balance.Expected, acc.Balance.Actual)
+		}
+		if acc.Nonce != nil {
+			_, _ = fmt.Fprintf(w, "  nonce: expected %d, got %d\n", acc.Nonce.Expected, acc.Nonce.Actual)
+		}
+		if acc.CodeHash != nil {
+			_, _ = fmt.Fprintf(w, "  code hash: expected %s, got %s\n", acc.CodeHash.Expected, acc.CodeHash.Actual)
+		}
+		slots := slices.SortedFunc(maps.Keys(acc.Storage), func(a, b common.Hash) int { return a.Cmp(b) })
+		for _, slot := range slots {
+			v := acc.Storage[slot]
+			_, _ = fmt.Fprintf(w, "  storage %s: expected %s, got %s\n", slot, v.Expected, v.Actual)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,553 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	gstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/script/forking"
+)
+
+// BundleTx is a transaction of a bundle.
+// It is either a signed raw transaction, or a call that impersonates From.
+// Impersonated calls are not charged any fees, and run like eth_call,
+// but their L1 fee is still estimated and reported.
+type BundleTx struct {
+	Raw hexutil.Bytes `json:"raw,omitempty"`
+
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to,omitempty"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Data  hexutil.Bytes   `json:"data,omitempty"`
+	// Gas defaults to the gas that is left in the block.
+	Gas *hexutil.Uint64 `json:"gas,omitempty"`
+}
+
+func readBundle(path string) ([]BundleTx, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	var bundle []BundleTx
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+// FeeReport breaks down the fees of a transaction, in wei of MNT.
+type FeeReport struct {
+	EffectiveGasPrice *big.Int `json:"effectiveGasPrice"`
+	L2Fee             *big.Int `json:"l2Fee"`
+	L1Fee             *big.Int `json:"l1Fee"`
+	OperatorFee       *big.Int `json:"operatorFee"`
+	TokenRatio        *big.Int `json:"tokenRatio"`
+	Total             *big.Int `json:"total"`
+}
+
+// TxResult is the result of a simulated transaction.
+type TxResult struct {
+	Index           int             `json:"index"`
+	TxHash          common.Hash     `json:"txHash"`
+	From            common.Address  `json:"from"`
+	To              *common.Address `json:"to,omitempty"`
+	Deposit         bool            `json:"deposit,omitempty"`
+	Impersonated    bool            `json:"impersonated,omitempty"`
+	Status          uint64          `json:"status"`
+	Error           string          `json:"error,omitempty"`
+	GasUsed         uint64          `json:"gasUsed"`
+	ContractAddress *common.Address `json:"contractAddress,omitempty"`
+	Logs            []*types.Log    `json:"logs"`
+	// Fees is nil for deposits, which do not pay fees on L2.
+	Fees      *FeeReport      `json:"fees,omitempty"`
+	CallTrace json.RawMessage `json:"callTrace,omitempty"`
+}
+
+// BlockResult is the result of a simulated block.
+type BlockResult struct {
+	Number  uint64 `json:"number"`
+	Time    uint64 `json:"time"`
+	GasUsed uint64 `json:"gasUsed"`
+	// ExpectedGasUsed is the gas used by the block on the remote chain, if the block was replayed.
+	ExpectedGasUsed *uint64     `json:"expectedGasUsed,omitempty"`
+	Transactions    []*TxResult `json:"transactions"`
+}
+
+// Change is the value of a state item, before and after simulation.
+type Change[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+// AccountChange describes the changes made to an account. Unchanged items are omitted.
+type AccountChange struct {
+	Balance  *Change[*uint256.Int]               `json:"balance,omitempty"`
+	Nonce    *Change[uint64]                     `json:"nonce,omitempty"`
+	CodeHash *Change[common.Hash]                `json:"codeHash,omitempty"`
+	Storage  map[common.Hash]Change[common.Hash] `json:"storage,omitempty"`
+}
+
+// SimResult is the result of simulating blocks on top of a remote state.
+type SimResult struct {
+	ParentNumber uint64                            `json:"parentNumber"`
+	ParentHash   common.Hash                       `json:"parentHash"`
+	Blocks       []*BlockResult                    `json:"blocks"`
+	StateDiff    map[common.Address]*AccountChange `json:"stateDiff"`
+}
+
+// simTx is a transaction to simulate: either a signed tx, or a call that impersonates its sender.
+type simTx struct {
+	tx   *types.Transaction
+	call *BundleTx
+}
+
+// forkedSim simulates blocks on top of the state of a remote block.
+// State is read lazily from the RPC, so it requires an RPC that serves historical state of the parent block.
+type forkedSim struct {
+	log  log.Logger
+	conf *params.ChainConfig
+
+	cache    *forking.CachedSource
+	closeSrc func()
+	state    *gstate.StateDB
+	chain    *simChainContext
+
+	callTraces bool
+	result     *SimResult
+}
+
+func newForkedSim(ctx context.Context, logger log.Logger, endpoint string, cl *ethclient.Client,
+	conf *params.ChainConfig, parent *types.Header, callTraces bool) (*forkedSim, error) {
+	src, err := forking.RPCSourceByHash(endpoint, cl.Client(), parent.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC state source: %w", err)
+	}
+	sim, err := newForkedSimFromSource(src, newSimChainContext(ctx, logger, cl, conf, beacon.New(ethash.NewFaker()), parent), callTraces)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	sim.closeSrc = src.Close
+	return sim, nil
+}
+
+func newForkedSimFromSource(src forking.ForkSource, chain *simChainContext, callTraces bool) (*forkedSim, error) {
+	cache := forking.Cache(src)
+	state, err := gstate.New(cache.StateRoot(), forking.NewForkDB(cache))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forked state: %w", err)
+	}
+	parent := chain.CurrentHeader()
+	return &forkedSim{
+		log:        chain.log,
+		conf:       chain.cfg,
+		cache:      cache,
+		closeSrc:   func() {},
+		state:      state,
+		chain:      chain,
+		callTraces: callTraces,
+		result: &SimResult{
+			ParentNumber: parent.Number.Uint64(),
+			ParentHash:   parent.Hash(),
+		},
+	}, nil
+}
+
+func (s *forkedSim) Close() {
+	s.closeSrc()
+}
+
+// simulateBlock applies the transactions on top of the current state, in a block with the given header.
+// If strict, invalid transactions fail the simulation, like they would fail block processing.
+// Otherwise they are reported and skipped.
+func (s *forkedSim) simulateBlock(header *types.Header, txs []simTx, strict bool) (*BlockResult, error) {
+	s.chain.SetHead(header)
+	blockCtx := core.NewEVMBlockContext(header, s.chain, nil, s.conf, s.state)
+	// NoBaseFee allows impersonated calls without gas price, like eth_call. Signed txs are still checked.
+	vmConfig := vm.Config{NoBaseFee: true}
+
+	// Apply pre-execution system calls
+	sysEVM := vm.NewEVM(blockCtx, s.state, s.conf, vmConfig)
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, sysEVM)
+	}
+	if s.conf.IsPrague(header.Number, header.Time) {
+		core.ProcessParentBlockHash(header.ParentHash, sysEVM)
+	}
+
+	rules := s.conf.Rules(header.Number, false, header.Time)
+	signer := types.MakeSigner(s.conf, header.Number, header.Time)
+	blockHash := header.Hash()
+	gp := new(core.GasPool).AddGas(header.GasLimit)
+	usedGas := uint64(0)
+	result := &BlockResult{Number: header.Number.Uint64(), Time: header.Time}
+	for i, stx := range txs {
+		var (
+			tx           = stx.tx
+			msg          *core.Message
+			impersonated = stx.call != nil
+		)
+		if impersonated {
+			tx, msg = s.impersonatedTx(stx.call, gp.Gas())
+		} else {
+			var err error
+			msg, err = core.TransactionToMessage(tx, signer, header.BaseFee, &rules)
+			if err != nil {
+				if strict {
+					return nil, fmt.Errorf("could not convert tx %d [%s] to message: %w", i, tx.Hash(), err)
+				}
+				result.Transactions = append(result.Transactions, &TxResult{Index: i, TxHash: tx.Hash(), Error: err.Error()})
+				continue
+			}
+		}
+		tracer, err := tracers.DefaultDirectory.New("callTracer", &tracers.Context{
+			BlockHash:   blockHash,
+			BlockNumber: header.Number,
+			TxIndex:     i,
+			TxHash:      tx.Hash(),
+		}, s.tracerConfig(), s.conf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create call tracer: %w", err)
+		}
+		s.state.SetTxContext(tx.Hash(), i)
+		evm := vm.NewEVM(blockCtx, gstate.NewHookedState(s.state, tracer.Hooks), s.conf, vm.Config{Tracer: tracer.Hooks, NoBaseFee: vmConfig.NoBaseFee})
+		tokenRatio := s.state.GetState(types.GasOracleAddr, types.TokenRatioSlot).Big()
+		receipt, err := core.ApplyTransactionWithEVM(msg, gp, s.state, header.Number, blockHash, header.Time, tx, &usedGas, evm)
+		if err != nil {
+			if strict {
+				return nil, fmt.Errorf("could not apply tx %d [%s]: %w", i, tx.Hash(), err)
+			}
+			result.Transactions = append(result.Transactions, &TxResult{Index: i, TxHash: tx.Hash(), From: msg.From, To: msg.To, Impersonated: impersonated, Error: err.Error()})
+			continue
+		}
+		res := &TxResult{
+			Index:        i,
+			TxHash:       tx.Hash(),
+			From:         msg.From,
+			To:           msg.To,
+			Deposit:      tx.IsDepositTx(),
+			Impersonated: impersonated,
+			Status:       receipt.Status,
+			GasUsed:      receipt.GasUsed,
+			Logs:         receipt.Logs,
+		}
+		if tx.To() == nil {
+			res.ContractAddress = &receipt.ContractAddress
+		}
+		if !tx.IsDepositTx() {
+			res.Fees = feeReport(evm, msg, receipt, header, tokenRatio, impersonated)
+		}
+		if err := s.addTrace(res, tracer); err != nil {
+			return nil, err
+		}
+		result.Transactions = append(result.Transactions, res)
+		s.log.Info("Simulated tx", "block", result.Number, "index", i, "tx", res.TxHash,
+			"status", res.Status, "gasUsed", res.GasUsed, "err", res.Error)
+	}
+	result.GasUsed = usedGas
+	s.result.Blocks = append(s.result.Blocks, result)
+	return result, nil
+}
+
+func (s *forkedSim) tracerConfig() json.RawMessage {
+	if s.callTraces {
+		return json.RawMessage(`{"withLog": true}`)
+	}
+	// Only the top call is needed to report the error of the transaction.
+	return json.RawMessage(`{"onlyTopCall": true}`)
+}
+
+type callFrame struct {
+	Error        string `json:"error"`
+	RevertReason string `json:"revertReason"`
+}
+
+func (s *forkedSim) addTrace(res *TxResult, tracer *tracers.Tracer) error {
+	trace, err := tracer.GetResult()
+	if err != nil {
+		return fmt.Errorf("failed to get call trace of tx %s: %w", res.TxHash, err)
+	}
+	var frame callFrame
+	if err := json.Unmarshal(trace, &frame); err != nil {
+		return fmt.Errorf("failed to decode call trace of tx %s: %w", res.TxHash, err)
+	}
+	res.Error = frame.Error
+	if frame.RevertReason != "" {
+		res.Error = fmt.Sprintf("%s: %s", frame.Error, frame.RevertReason)
+	}
+	if s.callTraces {
+		res.CallTrace = trace
+	}
+	return nil
+}
+
+// feeReport breaks down the fees paid by the tx. The L1 fee and operator fee are taken
+// from the receipt and from the operator fee function of the block, like op-geth charges them.
+// Impersonated calls are not charged, and are reported as if they paid the base fee.
+func feeReport(evm *vm.EVM, msg *core.Message, receipt *types.Receipt, header *types.Header, tokenRatio *big.Int, impersonated bool) *FeeReport {
+	gasPrice := new(big.Int).Set(msg.GasPrice)
+	if impersonated && header.BaseFee != nil {
+		gasPrice.Set(header.BaseFee)
+	}
+	report := &FeeReport{
+		EffectiveGasPrice: gasPrice,
+		L2Fee:             new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed)),
+		L1Fee:             new(big.Int),
+		OperatorFee:       new(big.Int),
+		TokenRatio:        tokenRatio,
+	}
+	if receipt.L1Fee != nil {
+		report.L1Fee.Set(receipt.L1Fee)
+	}
+	if fn := evm.Context.OperatorCostFunc; fn != nil {
+		report.OperatorFee = fn(receipt.GasUsed, header.Time).ToBig()
+	}
+	report.Total = new(big.Int).Add(report.L2Fee, report.L1Fee)
+	report.Total.Add(report.Total, report.OperatorFee)
+	return report
+}
+
+// impersonatedTx converts a bundle call into an unsigned transaction and a message that impersonates the sender.
+func (s *forkedSim) impersonatedTx(btx *BundleTx, gas uint64) (*types.Transaction, *core.Message) {
+	if btx.Gas != nil {
+		gas = uint64(*btx.Gas)
+	}
+	value := new(big.Int)
+	if btx.Value != nil {
+		value = btx.Value.ToInt()
+	}
+	nonce := s.state.GetNonce(btx.From)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   s.conf.ChainID,
+		Nonce:     nonce,
+		GasTipCap: new(big.Int),
+		GasFeeCap: new(big.Int),
+		Gas:       gas,
+		To:        btx.To,
+		Value:     value,
+		Data:      btx.Data,
+	})
+	msg := &core.Message{
+		To:                    btx.To,
+		From:                  btx.From,
+		Nonce:                 nonce,
+		Value:                 value,
+		GasLimit:              gas,
+		GasPrice:              new(big.Int),
+		GasFeeCap:             new(big.Int),
+		GasTipCap:             new(big.Int),
+		Data:                  btx.Data,
+		SkipNonceChecks:       true,
+		SkipTransactionChecks: true,
+		RunMode:               core.EthcallMode,
+	}
+	return tx, msg
+}
+
+// bundleHeader creates the header of the block that a bundle is simulated in, on top of parent.
+// The base fee of the parent is reused.
+func bundleHeader(parent *types.Header, blockTime uint64) *types.Header {
+	return &types.Header{
+		ParentHash:       parent.Hash(),
+		UncleHash:        types.EmptyUncleHash,
+		Coinbase:         parent.Coinbase,
+		Root:             parent.Root,
+		Number:           new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:         parent.GasLimit,
+		Time:             parent.Time + blockTime,
+		Difficulty:       new(big.Int),
+		MixDigest:        parent.MixDigest,
+		BaseFee:          parent.BaseFee,
+		WithdrawalsHash:  parent.WithdrawalsHash,
+		BlobGasUsed:      parent.BlobGasUsed,
+		ExcessBlobGas:    parent.ExcessBlobGas,
+		ParentBeaconRoot: parent.ParentBeaconRoot,
+		RequestsHash:     parent.RequestsHash,
+	}
+}
+
+// stateDiff flushes the simulated state, and computes the changes made to the remote state.
+func (s *forkedSim) stateDiff() (map[common.Address]*AccountChange, error) {
+	s.state.IntermediateRoot(true)
+	tr, ok := s.state.GetTrie().(*forking.ForkedAccountsTrie)
+	if !ok {
+		return nil, fmt.Errorf("simulated state trie is unexpectedly not a ForkedAccountsTrie: %T", s.state.GetTrie())
+	}
+	diff := tr.ExportDiff()
+	out := make(map[common.Address]*AccountChange)
+	for addr, acc := range diff.Account {
+		change, err := s.accountChange(addr, acc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff account %s: %w", addr, err)
+		}
+		if change != nil {
+			out[addr] = change
+		}
+	}
+	return out, nil
+}
+
+func (s *forkedSim) accountChange(addr common.Address, acc *forking.AccountDiff) (*AccountChange, error) {
+	balance, err := s.cache.Balance(addr)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.cache.Nonce(addr)
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.cache.Code(addr)
+	if err != nil {
+		return nil, err
+	}
+	codeHash := crypto.Keccak256Hash(code)
+	if acc == nil {
+		// The account was deleted
+		acc = &forking.AccountDiff{Nonce: new(uint64), Balance: new(uint256.Int), CodeHash: &types.EmptyCodeHash}
+	}
+
+	var change AccountChange
+	if acc.Balance != nil && !acc.Balance.Eq(balance) {
+		change.Balance = &Change[*uint256.Int]{From: balance, To: acc.Balance}
+	}
+	if acc.Nonce != nil && *acc.Nonce != nonce {
+		change.Nonce = &Change[uint64]{From: nonce, To: *acc.Nonce}
+	}
+	if acc.CodeHash != nil && *acc.CodeHash != codeHash {
+		change.CodeHash = &Change[common.Hash]{From: codeHash, To: *acc.CodeHash}
+	}
+	for slot, value := range acc.Storage {
+		prev, err := s.cache.StorageAt(addr, slot)
+		if err != nil {
+			return nil, err
+		}
+		if prev == value {
+			continue
+		}
+		if change.Storage == nil {
+			change.Storage = make(map[common.Hash]Change[common.Hash])
+		}
+		change.Storage[slot] = Change[common.Hash]{From: prev, To: value}
+	}
+	if change.Balance == nil && change.Nonce == nil && change.CodeHash == nil && len(change.Storage) == 0 {
+		return nil, nil
+	}
+	return &change, nil
+}
+
+// Result finalizes the simulation, and returns its result with the state diff.
+func (s *forkedSim) Result() (*SimResult, error) {
+	diff, err := s.stateDiff()
+	if err != nil {
+		return nil, err
+	}
+	s.result.StateDiff = diff
+	return s.result, nil
+}
+
+func writeResult(path string, result *SimResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create result file: %w", err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
+
+// blockSource fetches blocks and headers of the remote chain.
+type blockSource interface {
+	headerSource
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// simulateBlocks replays the remote blocks [from, to] on top of the remote state of block from-1.
+// newSim creates the simulation on top of the state of the given parent block.
+func simulateBlocks(ctx context.Context, logger log.Logger, cl blockSource,
+	newSim func(parent *types.Header) (*forkedSim, error), from, to uint64) (*SimResult, error) {
+	if from == 0 || to < from {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	parent, err := cl.HeaderByNumber(ctx, new(big.Int).SetUint64(from-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent header %d: %w", from-1, err)
+	}
+	sim, err := newSim(parent)
+	if err != nil {
+		return nil, err
+	}
+	defer sim.Close()
+	for n := from; n <= to; n++ {
+		block, err := cl.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %w", n, err)
+		}
+		if block.ParentHash() != parent.Hash() {
+			return nil, fmt.Errorf("block %d does not build on %s, the remote chain reorged", n, parent.Hash())
+		}
+		txs := make([]simTx, 0, len(block.Transactions()))
+		for _, tx := range block.Transactions() {
+			txs = append(txs, simTx{tx: tx})
+		}
+		res, err := sim.simulateBlock(block.Header(), txs, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate block %d: %w", n, err)
+		}
+		expected := block.GasUsed()
+		res.ExpectedGasUsed = &expected
+		if res.GasUsed != expected {
+			logger.Warn("Simulated gas usage differs from remote block", "block", n, "gasUsed", res.GasUsed, "expected", expected)
+		}
+		parent = block.Header()
+	}
+	return sim.Result()
+}
+
+// simulateBundle simulates the bundle in a new block on top of the remote block.
+func simulateBundle(ctx context.Context, logger log.Logger, endpoint string, cl *ethclient.Client,
+	conf *params.ChainConfig, parent *types.Header, bundle []BundleTx, blockTime uint64, callTraces bool) (*SimResult, error) {
+	txs := make([]simTx, 0, len(bundle))
+	for i := range bundle {
+		if len(bundle[i].Raw) == 0 {
+			txs = append(txs, simTx{call: &bundle[i]})
+			continue
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(bundle[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to decode raw tx %d of bundle: %w", i, err)
+		}
+		txs = append(txs, simTx{tx: &tx})
+	}
+	sim, err := newForkedSim(ctx, logger, endpoint, cl, conf, parent, callTraces)
+	if err != nil {
+		return nil, err
+	}
+	defer sim.Close()
+	if _, err := sim.simulateBlock(bundleHeader(parent, blockTime), txs, false); err != nil {
+		return nil, fmt.Errorf("failed to simulate bundle: %w", err)
+	}
+	return sim.Result()
+}
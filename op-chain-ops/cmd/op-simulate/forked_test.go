@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testForkSource struct {
+	balances map[common.Address]*uint256.Int
+	codes    map[common.Address][]byte
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+func (t *testForkSource) URLOrAlias() string     { return "test" }
+func (t *testForkSource) StateRoot() common.Hash { return common.Hash{0x42} }
+func (t *testForkSource) Nonce(addr common.Address) (uint64, error) {
+	return 0, nil
+}
+func (t *testForkSource) Balance(addr common.Address) (*uint256.Int, error) {
+	if b, ok := t.balances[addr]; ok {
+		return b.Clone(), nil
+	}
+	return new(uint256.Int), nil
+}
+func (t *testForkSource) StorageAt(addr common.Address, key common.Hash) (common.Hash, error) {
+	return t.storage[addr][key], nil
+}
+func (t *testForkSource) Code(addr common.Address) ([]byte, error) {
+	return t.codes[addr], nil
+}
+
+func TestSimulateBundle(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	conf := params.OptimismTestConfig
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signerAddr := crypto.PubkeyToAddress(key.PublicKey)
+	admin := common.Address{0xad}
+	recipient := common.Address{0xbb}
+	target := common.Address{0xcc}
+	src := &testForkSource{
+		balances: map[common.Address]*uint256.Int{
+			signerAddr: uint256.NewInt(params.Ether),
+			admin:      uint256.NewInt(params.Ether),
+		},
+		// PUSH1 1, PUSH1 0, SSTORE, STOP
+		codes: map[common.Address][]byte{target: {0x60, 0x01, 0x60, 0x00, 0x55, 0x00}},
+		storage: map[common.Address]map[common.Hash]common.Hash{
+			types.GasOracleAddr: {types.TokenRatioSlot: common.BigToHash(big.NewInt(1))},
+		},
+	}
+	parent := &types.Header{
+		Number:     big.NewInt(10),
+		Time:       100,
+		GasLimit:   30_000_000,
+		BaseFee:    big.NewInt(params.GWei),
+		Difficulty: new(big.Int),
+		Coinbase:   common.Address{0xfe},
+	}
+	chain := newSimChainContext(context.Background(), logger, nil, conf, beacon.New(ethash.NewFaker()), parent)
+	sim, err := newForkedSimFromSource(src, chain, true)
+	require.NoError(t, err)
+	defer sim.Close()
+
+	signedTx, err := types.SignNewTx(key, types.LatestSignerForChainID(conf.ChainID), &types.DynamicFeeTx{
+		ChainID:   conf.ChainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2 * params.GWei),
+		Gas:       21_000,
+		To:        &recipient,
+		Value:     big.NewInt(1000),
+	})
+	require.NoError(t, err)
+	gas := hexutil.Uint64(100_000)
+	txs := []simTx{
+		{call: &BundleTx{From: admin, To: &target, Gas: &gas}},
+		{tx: signedTx},
+		{call: &BundleTx{From: common.Address{0x01}, To: &recipient, Value: (*hexutil.Big)(big.NewInt(1))}},
+	}
+	block, err := sim.simulateBlock(bundleHeader(parent, 2), txs, false)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions, 3)
+	require.Equal(t, uint64(11), block.Number)
+	require.Equal(t, uint64(102), block.Time)
+
+	call := block.Transactions[0]
+	require.True(t, call.Impersonated)
+	require.Equal(t, types.ReceiptStatusSuccessful, call.Status)
+	require.Less(t, call.GasUsed, uint64(gas))
+	require.NotEmpty(t, call.CallTrace)
+
+	transfer := block.Transactions[1]
+	require.Equal(t, signedTx.Hash(), transfer.TxHash)
+	require.Equal(t, types.ReceiptStatusSuccessful, transfer.Status)
+	require.Equal(t, big.NewInt(params.GWei+1), transfer.Fees.EffectiveGasPrice)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(params.GWei+1), big.NewInt(21_000)), transfer.Fees.L2Fee)
+
+	// The sender of the last call cannot pay the value
+	require.Contains(t, block.Transactions[2].Error, "insufficient funds")
+
+	result, err := sim.Result()
+	require.NoError(t, err)
+	require.Equal(t, Change[common.Hash]{From: common.Hash{}, To: common.BigToHash(big.NewInt(1))},
+		result.StateDiff[target].Storage[common.Hash{}])
+	require.Equal(t, &Change[*uint256.Int]{From: new(uint256.Int), To: uint256.NewInt(1000)}, result.StateDiff[recipient].Balance)
+	require.Equal(t, &Change[uint64]{From: 0, To: 1}, result.StateDiff[signerAddr].Nonce)
+	require.Equal(t, &Change[uint64]{From: 0, To: 1}, result.StateDiff[admin].Nonce)
+	require.NotContains(t, result.StateDiff, common.Address{0x01})
+}
+
+func TestSimulateBlocks(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	conf := params.OptimismTestConfig
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signerAddr := crypto.PubkeyToAddress(key.PublicKey)
+	recipient := common.Address{0xbb}
+	target := common.Address{0xcc}
+	src := &testForkSource{
+		balances: map[common.Address]*uint256.Int{signerAddr: uint256.NewInt(params.Ether)},
+		// PUSH1 8, BLOCKHASH, PUSH1 0, SSTORE, STOP
+		codes: map[common.Address][]byte{target: {0x60, 0x08, 0x40, 0x60, 0x00, 0x55, 0x00}},
+		storage: map[common.Address]map[common.Hash]common.Hash{
+			types.GasOracleAddr: {types.TokenRatioSlot: common.BigToHash(big.NewInt(1))},
+		},
+	}
+	signTx := func(nonce uint64, to common.Address, gas uint64) *types.Transaction {
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(conf.ChainID), &types.DynamicFeeTx{
+			ChainID:   conf.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2 * params.GWei),
+			Gas:       gas,
+			To:        &to,
+			Value:     big.NewInt(1000),
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	remote := newFakeChain(11)
+	remote.addBlock([]*types.Transaction{signTx(0, target, 100_000)}, 50_000)
+	remote.addBlock([]*types.Transaction{signTx(1, recipient, 21_000)}, 21_000)
+	newSim := func(parent *types.Header) (*forkedSim, error) {
+		require.Equal(t, remote.blocks[10].Hash(), parent.Hash())
+		chain := newSimChainContext(context.Background(), logger, remote, conf, beacon.New(ethash.NewFaker()), parent)
+		return newForkedSimFromSource(src, chain, false)
+	}
+
+	result, err := simulateBlocks(context.Background(), logger, remote, newSim, 11, 12)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), result.ParentNumber)
+	require.Len(t, result.Blocks, 2)
+	for i, block := range result.Blocks {
+		require.Equal(t, uint64(11+i), block.Number)
+		require.Len(t, block.Transactions, 1)
+		require.Equal(t, types.ReceiptStatusSuccessful, block.Transactions[0].Status)
+		require.Equal(t, remote.blocks[11+i].GasUsed(), *block.ExpectedGasUsed)
+	}
+	require.Equal(t, uint64(21_000), result.Blocks[1].GasUsed)
+
+	// BLOCKHASH of an ancestor of the parent is looked up on the remote chain
+	require.Equal(t, Change[common.Hash]{From: common.Hash{}, To: remote.blocks[8].Hash()},
+		result.StateDiff[target].Storage[common.Hash{}])
+	require.Equal(t, &Change[uint64]{From: 0, To: 2}, result.StateDiff[signerAddr].Nonce)
+
+	_, err = simulateBlocks(context.Background(), logger, remote, newSim, 11, 13)
+	require.ErrorContains(t, err, "failed to get block 13")
+	_, err = simulateBlocks(context.Background(), logger, remote, newSim, 0, 1)
+	require.ErrorContains(t, err, "invalid block range")
+}
+
+func TestParseBlockRange(t *testing.T) {
+	from, to, err := parseBlockRange("100-110")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), from)
+	require.Equal(t, uint64(110), to)
+
+	from, to, err = parseBlockRange("7")
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), from)
+	require.Equal(t, uint64(7), to)
+
+	_, _, err = parseBlockRange("a-b")
+	require.Error(t, err)
+}
@@ -9,6 +9,8 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/superutil"
@@ -51,10 +53,42 @@ var (
 		Required: true,
 	}
 	TxFlag = &cli.StringFlag{
-		Name:     "tx",
-		Usage:    "Transaction hash to trace and simulate",
-		EnvVars:  op_service.PrefixEnvVar(EnvPrefix, "TX"),
-		Required: true,
+		Name:    "tx",
+		Usage:   "Transaction hash to trace and simulate",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "TX"),
+	}
+	BlocksFlag = &cli.StringFlag{
+		Name:    "blocks",
+		Usage:   "Range of remote blocks to replay on top of the state of their parent, e.g. 100-110 (inclusive), or a single block",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "BLOCKS"),
+	}
+	BundleFlag = &cli.StringFlag{
+		Name:    "bundle",
+		Usage:   "Path to a JSON bundle of transactions to simulate in a new block on top of --block",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "BUNDLE"),
+	}
+	BlockFlag = &cli.Uint64Flag{
+		Name:        "block",
+		Usage:       "Block to simulate the bundle on top of",
+		EnvVars:     op_service.PrefixEnvVar(EnvPrefix, "BLOCK"),
+		DefaultText: "latest",
+	}
+	BlockTimeFlag = &cli.Uint64Flag{
+		Name:    "block-time",
+		Usage:   "Time in seconds between --block and the block of the bundle",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "BLOCK_TIME"),
+		Value:   2,
+	}
+	CallTracesFlag = &cli.BoolFlag{
+		Name:    "call-traces",
+		Usage:   "Include call traces of blocks and bundles in the output",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "CALL_TRACES"),
+	}
+	OutFlag = &cli.StringFlag{
+		Name:    "out",
+		Usage:   "Path to write the result of blocks and bundles to, as JSON: receipts, fees, call traces and state diff",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "OUT"),
+		Value:   "simulation.json",
 	}
 	ProfFlag = &cli.BoolFlag{
 		Name:     "profile",
@@ -66,14 +100,15 @@ var (
 
 func main() {
 	flags := []cli.Flag{
-		RPCFlag, TxFlag, ProfFlag,
+		RPCFlag, TxFlag, BlocksFlag, BundleFlag, BlockFlag, BlockTimeFlag, CallTracesFlag, OutFlag, ProfFlag,
 	}
 	flags = append(flags, oplog.CLIFlags(EnvPrefix)...)
 
 	app := cli.NewApp()
 	app.Name = "op-simulate"
-	app.Usage = "Simulate a tx locally."
-	app.Description = "Fetch a tx from an RPC and simulate it locally."
+	app.Usage = "Simulate a tx, blocks or a tx bundle locally."
+	app.Description = "Fetch a tx from an RPC and simulate it locally, " +
+		"or simulate a range of blocks or a bundle of txs on top of the remote state."
 	app.Flags = cliapp.ProtectFlags(flags)
 	app.Action = mainAction
 	app.Writer = os.Stdout
@@ -90,11 +125,24 @@ func mainAction(c *cli.Context) error {
 	logCfg := oplog.ReadCLIConfig(c)
 	logger := oplog.NewLogger(c.App.Writer, logCfg)
 
+	modes := 0
+	for _, name := range []string{TxFlag.Name, BlocksFlag.Name, BundleFlag.Name} {
+		if c.IsSet(name) {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return fmt.Errorf("exactly one of --%s, --%s or --%s is required", TxFlag.Name, BlocksFlag.Name, BundleFlag.Name)
+	}
+
 	endpoint := c.String(RPCFlag.Name)
 	cl, err := rpc.DialContext(ctx, endpoint)
 	if err != nil {
 		return fmt.Errorf("failed to dial RPC %q: %w", endpoint, err)
 	}
+	if !c.IsSet(TxFlag.Name) {
+		return forkedAction(ctx, c, logger, endpoint, cl)
+	}
 	txHashStr := c.String(TxFlag.Name)
 	var txHash common.Hash
 	if err := txHash.UnmarshalText([]byte(txHashStr)); err != nil {
@@ -121,12 +169,75 @@ func mainAction(c *cli.Context) error {
 		return fmt.Errorf("failed to get block header: %w", err)
 	}
 	doProfile := c.Bool(ProfFlag.Name)
-	if err := simulate(ctx, logger, chainConfig, prestateTraceFile(prestatesDir, txHash), tx, header, doProfile); err != nil {
+	if err := simulate(ctx, logger, ethclient.NewClient(cl), chainConfig, prestateTraceFile(prestatesDir, txHash), tx, header, doProfile); err != nil {
 		return fmt.Errorf("failed to simulate tx: %w", err)
 	}
 	return nil
 }
 
+func forkedAction(ctx context.Context, c *cli.Context, logger log.Logger, endpoint string, rpcCl *rpc.Client) error {
+	chainConfig, err := fetchChainConfig(ctx, rpcCl)
+	if err != nil {
+		return fmt.Errorf("failed to get chain config: %w", err)
+	}
+	cl := ethclient.NewClient(rpcCl)
+	callTraces := c.Bool(CallTracesFlag.Name)
+
+	var result *SimResult
+	if c.IsSet(BlocksFlag.Name) {
+		from, to, err := parseBlockRange(c.String(BlocksFlag.Name))
+		if err != nil {
+			return err
+		}
+		newSim := func(parent *types.Header) (*forkedSim, error) {
+			return newForkedSim(ctx, logger, endpoint, cl, chainConfig, parent, callTraces)
+		}
+		result, err = simulateBlocks(ctx, logger, cl, newSim, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to simulate blocks: %w", err)
+		}
+	} else {
+		bundle, err := readBundle(c.String(BundleFlag.Name))
+		if err != nil {
+			return err
+		}
+		var num *big.Int
+		if c.IsSet(BlockFlag.Name) {
+			num = new(big.Int).SetUint64(c.Uint64(BlockFlag.Name))
+		}
+		parent, err := cl.HeaderByNumber(ctx, num)
+		if err != nil {
+			return fmt.Errorf("failed to get block header: %w", err)
+		}
+		result, err = simulateBundle(ctx, logger, endpoint, cl, chainConfig, parent, bundle, c.Uint64(BlockTimeFlag.Name), callTraces)
+		if err != nil {
+			return err
+		}
+	}
+	out := c.String(OutFlag.Name)
+	if err := writeResult(out, result); err != nil {
+		return err
+	}
+	logger.Info("Wrote simulation result", "path", out, "blocks", len(result.Blocks), "changedAccounts", len(result.StateDiff))
+	return nil
+}
+
+func parseBlockRange(s string) (from uint64, to uint64, err error) {
+	fromStr, toStr, isRange := strings.Cut(s, "-")
+	from, err = strconv.ParseUint(fromStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block range %q: %w", s, err)
+	}
+	if !isRange {
+		return from, from, nil
+	}
+	to, err = strconv.ParseUint(toStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block range %q: %w", s, err)
+	}
+	return from, to, nil
+}
+
 // TraceConfig is different than Geth TraceConfig, quicknode sin't flexible
 type TraceConfig struct {
 	*tracelogger.Config
@@ -236,6 +347,13 @@ type simChainContext struct {
 	eng  consensus.Engine
 	head *types.Header
 	cfg  *params.ChainConfig
+
+	ctx context.Context
+	log log.Logger
+	// cl is used to fetch headers that are not known yet. Optional.
+	cl      headerSource
+	headers map[common.Hash]*types.Header
+	numbers map[uint64]common.Hash
 }
 
 func (d *simChainContext) Engine() consensus.Engine {
@@ -243,17 +361,18 @@ func (d *simChainContext) Engine() consensus.Engine {
 }
 
 func (d *simChainContext) GetHeader(h common.Hash, n uint64) *types.Header {
-	if n == d.head.Number.Uint64() {
-		return d.head
+	header := d.GetHeaderByHash(h)
+	if header == nil || header.Number.Uint64() != n {
+		return nil
 	}
-	panic(fmt.Errorf("header retrieval not supported, cannot fetch %s %d", h, n))
+	return header
 }
 
 func (d *simChainContext) Config() *params.ChainConfig {
 	return d.cfg
 }
 
-func simulate(ctx context.Context, logger log.Logger, conf *params.ChainConfig,
+func simulate(ctx context.Context, logger log.Logger, cl *ethclient.Client, conf *params.ChainConfig,
 	prestatePath string, tx *types.Transaction, header *types.Header, doProfile bool) error {
 	memDB := rawdb.NewMemoryDatabase()
 	stateDB := gstate.NewDatabase(triedb.NewDatabase(memDB, nil), nil)
@@ -290,7 +409,7 @@ func simulate(ctx context.Context, logger log.Logger, conf *params.ChainConfig,
 	state.Prepare(rules, sender, header.Coinbase, tx.To(), precompiles, tx.AccessList())
 	state.SetTxContext(tx.Hash(), 0)
 
-	cCtx := &simChainContext{eng: beacon.New(ethash.NewFaker()), head: header, cfg: conf}
+	cCtx := newSimChainContext(ctx, logger, cl, conf, beacon.New(ethash.NewFaker()), header)
 	gp := core.GasPool(tx.Gas())
 	usedGas := uint64(0)
 	vmConfig := vm.Config{}
@@ -1,26 +1,86 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
+// headerSource fetches headers of the remote chain.
+type headerSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// newSimChainContext creates a chain context with the given head.
+// Headers of ancestors are fetched from the RPC on demand, so BLOCKHASH works like on the remote chain.
+func newSimChainContext(ctx context.Context, logger log.Logger, cl headerSource, conf *params.ChainConfig, eng consensus.Engine, head *types.Header) *simChainContext {
+	d := &simChainContext{
+		eng:     eng,
+		cfg:     conf,
+		ctx:     ctx,
+		log:     logger,
+		cl:      cl,
+		headers: make(map[common.Hash]*types.Header),
+		numbers: make(map[uint64]common.Hash),
+	}
+	d.SetHead(head)
+	return d
+}
+
+// SetHead sets the header of the block that is being simulated.
+// The header does not have to be part of the remote chain.
+func (d *simChainContext) SetHead(head *types.Header) {
+	d.head = head
+	d.addHeader(head)
+}
+
+func (d *simChainContext) addHeader(h *types.Header) {
+	hash := h.Hash()
+	d.headers[hash] = h
+	d.numbers[h.Number.Uint64()] = hash
+}
+
 func (d *simChainContext) CurrentHeader() *types.Header {
 	return d.head
 }
 
 func (d *simChainContext) GetHeaderByHash(hash common.Hash) *types.Header {
-	if hash == d.head.Hash() {
-		return d.head
+	if h, ok := d.headers[hash]; ok {
+		return h
+	}
+	if d.cl == nil {
+		return nil
+	}
+	h, err := d.cl.HeaderByHash(d.ctx, hash)
+	if err != nil {
+		d.log.Error("Failed to fetch header", "hash", hash, "err", err)
+		return nil
 	}
-	panic(fmt.Errorf("GetHeaderByHash not supported, cannot fetch %s", hash))
+	d.addHeader(h)
+	return h
 }
 
 func (d *simChainContext) GetHeaderByNumber(n uint64) *types.Header {
-	if n == d.head.Number.Uint64() {
-		return d.head
+	if n > d.head.Number.Uint64() {
+		return nil
+	}
+	if hash, ok := d.numbers[n]; ok {
+		return d.headers[hash]
+	}
+	if d.cl == nil {
+		return nil
+	}
+	h, err := d.cl.HeaderByNumber(d.ctx, new(big.Int).SetUint64(n))
+	if err != nil {
+		d.log.Error("Failed to fetch header", "number", n, "err", err)
+		return nil
 	}
-	panic(fmt.Errorf("GetHeaderByNumber not supported, cannot fetch %d", n))
+	d.addHeader(h)
+	return h
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeChain serves the blocks of a remote chain, and counts the fetched headers.
+type fakeChain struct {
+	blocks  []*types.Block
+	fetched int
+}
+
+func newFakeChain(n int) *fakeChain {
+	c := &fakeChain{}
+	for i := 0; i < n; i++ {
+		c.addBlock(nil, 0)
+	}
+	return c
+}
+
+// addBlock appends a block with the given transactions on top of the chain.
+func (c *fakeChain) addBlock(txs []*types.Transaction, gasUsed uint64) *types.Block {
+	header := &types.Header{
+		Number:     big.NewInt(int64(len(c.blocks))),
+		Time:       uint64(100 + 2*len(c.blocks)),
+		GasLimit:   30_000_000,
+		GasUsed:    gasUsed,
+		BaseFee:    big.NewInt(params.GWei),
+		Difficulty: new(big.Int),
+		Coinbase:   common.Address{0xfe},
+	}
+	if len(c.blocks) > 0 {
+		header.ParentHash = c.blocks[len(c.blocks)-1].Hash()
+	}
+	block := types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil), types.DefaultBlockConfig)
+	c.blocks = append(c.blocks, block)
+	return block
+}
+
+func (c *fakeChain) HeaderByHash(_ context.Context, hash common.Hash) (*types.Header, error) {
+	c.fetched++
+	for _, b := range c.blocks {
+		if b.Hash() == hash {
+			return b.Header(), nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	b, err := c.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	c.fetched++
+	return b.Header(), nil
+}
+
+func (c *fakeChain) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.blocks)) {
+		return nil, errors.New("not found")
+	}
+	return c.blocks[number.Uint64()], nil
+}
+
+func TestSimChainContext(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	remote := newFakeChain(10)
+	head := remote.blocks[9].Header()
+	chain := newSimChainContext(context.Background(), logger, remote, params.OptimismTestConfig, beacon.New(ethash.NewFaker()), head)
+
+	require.Equal(t, head, chain.GetHeaderByNumber(9))
+	require.Zero(t, remote.fetched, "the head is known")
+
+	// Ancestors are fetched on demand, and cached
+	require.Equal(t, remote.blocks[5].Hash(), chain.GetHeaderByNumber(5).Hash())
+	require.Equal(t, remote.blocks[5].Hash(), chain.GetHeader(remote.blocks[5].Hash(), 5).Hash())
+	require.Equal(t, 1, remote.fetched)
+	require.Equal(t, remote.blocks[3].Hash(), chain.GetHeaderByHash(remote.blocks[3].Hash()).Hash())
+	require.Equal(t, remote.blocks[3].Hash(), chain.GetHeaderByNumber(3).Hash())
+	require.Equal(t, 2, remote.fetched)
+
+	require.Nil(t, chain.GetHeaderByNumber(10), "no headers after the head")
+	require.Nil(t, chain.GetHeader(remote.blocks[3].Hash(), 4), "number must match")
+	require.Nil(t, chain.GetHeaderByHash(common.Hash{0x01}), "unknown block")
+
+	// Without a header source only the simulated headers are known
+	local := newSimChainContext(context.Background(), logger, nil, params.OptimismTestConfig, beacon.New(ethash.NewFaker()), head)
+	require.Nil(t, local.GetHeaderByNumber(5))
+	require.Nil(t, local.GetHeaderByHash(remote.blocks[5].Hash()))
+}
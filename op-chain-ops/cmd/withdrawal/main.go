@@ -21,6 +21,7 @@ func main() {
 		InitCommand,
 		ProveCommand,
 		FinalizeCommand,
+		MantleCommand,
 	}
 	app.Action = func(c *cli.Context) error {
 		return cli.ShowAppHelp(c)
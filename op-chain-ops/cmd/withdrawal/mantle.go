@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/bridge"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/lmittmann/w3"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	MantleETHValueFlag = &cli.StringFlag{
+		Name:    "eth-value",
+		Usage:   "ETH value in wei to withdraw, burnt from the BVM_ETH balance of the sender.",
+		EnvVars: op_service.PrefixEnvVar(EnvVarPrefix, "ETH_VALUE"),
+		Value:   "0",
+	}
+	MantleMNTValueFlag = &cli.StringFlag{
+		Name:    "mnt-value",
+		Usage:   "MNT value in wei to withdraw.",
+		EnvVars: op_service.PrefixEnvVar(EnvVarPrefix, "MNT_VALUE"),
+		Value:   "0",
+	}
+	MantleTargetFlag = &cli.StringFlag{
+		Name:    "target",
+		Usage:   "Address that receives the withdrawal on L1. Defaults to the sender.",
+		EnvVars: op_service.PrefixEnvVar(EnvVarPrefix, "TARGET"),
+	}
+	MantleGasLimitFlag = &cli.Uint64Flag{
+		Name:    "gas-limit",
+		Usage:   "Gas limit of the call to the target on L1.",
+		EnvVars: op_service.PrefixEnvVar(EnvVarPrefix, "GAS_LIMIT"),
+		Value:   100_000,
+	}
+	MantleWaitFlag = &cli.DurationFlag{
+		Name:    "wait",
+		Usage:   "How long to wait for an output or dispute game covering the withdrawal to be proposed. Zero fails immediately if there is none.",
+		EnvVars: op_service.PrefixEnvVar(EnvVarPrefix, "WAIT"),
+	}
+)
+
+var mantleInitiateWithdrawalFunc = w3.MustNewFunc("initiateWithdrawal(uint256,address,uint256,bytes)", "")
+
+func parseWei(ctx *cli.Context, flag *cli.StringFlag) (*big.Int, error) {
+	str := ctx.String(flag.Name)
+	value, ok := new(big.Int).SetString(str, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s: %s", flag.Name, str)
+	}
+	return value, nil
+}
+
+func MantleInitWithdrawal(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	txMgr, err := createTxMgr(ctx, logger, L2Flag.Name)
+	if err != nil {
+		return err
+	}
+
+	mntValue, err := parseWei(ctx, MantleMNTValueFlag)
+	if err != nil {
+		return err
+	}
+	ethValue, err := parseWei(ctx, MantleETHValueFlag)
+	if err != nil {
+		return err
+	}
+	if mntValue.Sign() == 0 && ethValue.Sign() == 0 {
+		return errors.New("must specify a MNT or ETH value to withdraw")
+	}
+	target := txMgr.From()
+	if ctx.IsSet(MantleTargetFlag.Name) {
+		if !common.IsHexAddress(ctx.String(MantleTargetFlag.Name)) {
+			return fmt.Errorf("invalid target address: %s", ctx.String(MantleTargetFlag.Name))
+		}
+		target = common.HexToAddress(ctx.String(MantleTargetFlag.Name))
+	}
+
+	txData, err := mantleInitiateWithdrawalFunc.EncodeArgs(ethValue, target, new(big.Int).SetUint64(ctx.Uint64(MantleGasLimitFlag.Name)), []byte{})
+	if err != nil {
+		return fmt.Errorf("failed to pack initiateWithdrawal: %w", err)
+	}
+	rcpt, err := txMgr.Send(ctx.Context, txmgr.TxCandidate{
+		TxData: txData,
+		To:     &predeploys.L2ToL1MessagePasserAddr,
+		Value:  mntValue,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send withdrawal transaction: %w", err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("withdrawal transaction %s failed", rcpt.TxHash)
+	}
+	withdrawal, err := crossdomain.ParseMantleMessagePassedFromReceipt(rcpt, predeploys.L2ToL1MessagePasserAddr)
+	if err != nil {
+		return fmt.Errorf("failed to parse withdrawal: %w", err)
+	}
+	hash, err := withdrawal.Hash()
+	if err != nil {
+		return err
+	}
+	// Force printing full hashes
+	logger.Info("Sent withdrawal", "tx", rcpt.TxHash.Hex(), "blockNumber", rcpt.BlockNumber, "withdrawalHash", hash.Hex(),
+		"mntValue", withdrawal.MNTValue, "ethValue", withdrawal.ETHValue, "target", withdrawal.Target)
+	return nil
+}
+
+// mantleWithdrawalContext is the state shared by the Mantle withdrawal commands.
+type mantleWithdrawalContext struct {
+	client     *bridge.Client
+	withdrawal *bridge.Withdrawal
+}
+
+func newMantleWithdrawalContext(ctx *cli.Context, logger log.Logger) (*mantleWithdrawalContext, error) {
+	txHash := common.HexToHash(ctx.String(TxFlag.Name))
+	if txHash == (common.Hash{}) {
+		return nil, errors.New("must specify tx hash")
+	}
+	if !common.IsHexAddress(ctx.String(PortalAddressFlag.Name)) {
+		return nil, errors.New("must specify portal address")
+	}
+	portalAddr := common.HexToAddress(ctx.String(PortalAddressFlag.Name))
+
+	l1Client, err := createEthClient(ctx, L1Flag.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L1: %w", err)
+	}
+	l2Client, err := createEthClient(ctx, L2Flag.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L2: %w", err)
+	}
+	client, err := bridge.NewClient(ctx.Context, logger, bridge.Config{
+		OptimismPortal: portalAddr,
+		PollInterval:   10 * time.Second,
+	}, l1Client, l2Client)
+	if err != nil {
+		return nil, err
+	}
+	withdrawal, err := client.LoadWithdrawal(ctx.Context, txHash)
+	if err != nil {
+		return nil, err
+	}
+	cfg := client.Config()
+	logger.Info("Found withdrawal", "withdrawalHash", withdrawal.Hash.Hex(), "l2Block", withdrawal.L2BlockNumber,
+		"sender", withdrawal.Transaction.Sender, "target", withdrawal.Transaction.Target,
+		"mntValue", withdrawal.Transaction.MNTValue, "ethValue", withdrawal.Transaction.ETHValue,
+		"disputeGames", client.UsesDisputeGames(), "l2OutputOracle", cfg.L2OutputOracle, "disputeGameFactory", cfg.DisputeGameFactory)
+	return &mantleWithdrawalContext{client: client, withdrawal: withdrawal}, nil
+}
+
+func MantleProveWithdrawal(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	txMgr, err := createTxMgr(ctx, logger, L1Flag.Name)
+	if err != nil {
+		return err
+	}
+	wctx, err := newMantleWithdrawalContext(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	// Fail early if there is no output covering the withdrawal and we should not wait for one.
+	// Otherwise, the wait timeout only bounds waiting for the output, not sending the proof.
+	if wait := ctx.Duration(MantleWaitFlag.Name); wait == 0 {
+		output, err := wctx.client.OutputCovering(ctx.Context, wctx.withdrawal.L2BlockNumber)
+		if err != nil {
+			return err
+		}
+		if output == nil {
+			return fmt.Errorf("no output covering L2 block %d proposed yet", wctx.withdrawal.L2BlockNumber)
+		}
+	} else {
+		waitCtx, cancel := context.WithTimeout(ctx.Context, wait)
+		_, err := wctx.client.WaitForOutput(waitCtx, wctx.withdrawal.L2BlockNumber)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to wait for an output covering L2 block %d: %w", wctx.withdrawal.L2BlockNumber, err)
+		}
+	}
+
+	_, err = wctx.client.ProveWithdrawal(ctx.Context, txMgr, wctx.withdrawal)
+	if errors.Is(err, bridge.ErrAlreadyProven) || errors.Is(err, bridge.ErrAlreadyFinal) {
+		logger.Info("Nothing to prove", "reason", err)
+		return nil
+	}
+	return err
+}
+
+func MantleFinalizeWithdrawal(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	txMgr, err := createTxMgr(ctx, logger, L1Flag.Name)
+	if err != nil {
+		return err
+	}
+	wctx, err := newMantleWithdrawalContext(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	_, err = wctx.client.FinalizeWithdrawal(ctx.Context, txMgr, wctx.withdrawal)
+	if errors.Is(err, bridge.ErrAlreadyFinal) {
+		logger.Info("Withdrawal is already finalized")
+		return nil
+	}
+	return err
+}
+
+func MantleWithdrawalStatus(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	wctx, err := newMantleWithdrawalContext(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	state, err := wctx.client.WithdrawalState(ctx.Context, wctx.withdrawal)
+	if err != nil {
+		return err
+	}
+	args := []any{"status", state.Status}
+	if state.Output != nil {
+		args = append(args, "outputIndex", state.Output.Index, "outputBlock", state.Output.L2BlockNumber, "outputRoot", state.Output.OutputRoot)
+		if state.Output.Game != (common.Address{}) {
+			args = append(args, "game", state.Output.Game)
+		}
+	}
+	if state.ProvenAt != 0 {
+		args = append(args, "submitter", state.Submitter, "provenAt", time.Unix(int64(state.ProvenAt), 0).UTC())
+		if state.FinalizableAt != 0 {
+			args = append(args, "finalizableAt", time.Unix(int64(state.FinalizableAt), 0).UTC())
+		}
+	}
+	logger.Info("Withdrawal status", args...)
+	return nil
+}
+
+func mantleFlags(flags ...cli.Flag) []cli.Flag {
+	flags = append(flags, oplog.CLIFlags(EnvVarPrefix)...)
+	return flags
+}
+
+func mantleTxFlags(flags ...cli.Flag) []cli.Flag {
+	flags = append(flags, txmgr.CLIFlagsWithDefaults(EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
+	return mantleFlags(flags...)
+}
+
+var MantleCommand = &cli.Command{
+	Name:  "mantle",
+	Usage: "Perform withdrawals of MNT and ETH from Mantle chains",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "init",
+			Usage:  "Initiates a withdrawal on the L2",
+			Action: interruptible(MantleInitWithdrawal),
+			Flags:  mantleTxFlags(L2Flag, MantleMNTValueFlag, MantleETHValueFlag, MantleTargetFlag, MantleGasLimitFlag),
+		},
+		{
+			Name:   "prove",
+			Usage:  "Prove a withdrawal on the L1, against an L2OutputOracle output or a dispute game",
+			Action: interruptible(MantleProveWithdrawal),
+			Flags:  mantleTxFlags(L1Flag, L2Flag, TxFlag, PortalAddressFlag, MantleWaitFlag),
+		},
+		{
+			Name:   "finalize",
+			Usage:  "Finalize a proven withdrawal on the L1",
+			Action: interruptible(MantleFinalizeWithdrawal),
+			Flags:  mantleTxFlags(L1Flag, L2Flag, TxFlag, PortalAddressFlag),
+		},
+		{
+			Name:   "status",
+			Usage:  "Report the status of a withdrawal",
+			Action: interruptible(MantleWithdrawalStatus),
+			Flags:  mantleFlags(L1Flag, L2Flag, TxFlag, PortalAddressFlag),
+		},
+	},
+}
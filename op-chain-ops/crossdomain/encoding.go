@@ -15,10 +15,14 @@ var (
 	relayMessage0ABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_target\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_sender\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_message\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"_messageNonce\",\"type\":\"uint256\"}],\"name\":\"relayMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 	// relayMessage1ABI represents the v1 relay message encoding
 	relayMessage1ABI = "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_target\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_minGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_message\",\"type\":\"bytes\"}],\"name\":\"relayMessage\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]"
+	// mantleRelayMessage1ABI represents the Mantle v1 relay message encoding, with separate MNT and ETH values
+	mantleRelayMessage1ABI = "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_target\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_mntValue\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_ethValue\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_minGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_message\",\"type\":\"bytes\"}],\"name\":\"relayMessage\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]"
 	// relayMessage0 represents the ABI of relay message v0
 	relayMessage0 abi.ABI
 	// relayMessage1 represents the ABI of relay message v1
 	relayMessage1 abi.ABI
+	// mantleRelayMessage1 represents the ABI of the Mantle relay message v1
+	mantleRelayMessage1 abi.ABI
 )
 
 // Create the required ABIs
@@ -32,6 +36,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	mantleRelayMessage1, err = abi.JSON(strings.NewReader(mantleRelayMessage1ABI))
+	if err != nil {
+		panic(err)
+	}
 }
 
 // EncodeCrossDomainMessageV0 will encode the calldata for
@@ -58,6 +66,21 @@ func EncodeCrossDomainMessageV1(
 	return relayMessage1.Pack("relayMessage", nonce, sender, target, value, gasLimit, data)
 }
 
+// EncodeMantleCrossDomainMessageV1 will encode the calldata for
+// "relayMessage(uint256,address,address,uint256,uint256,uint256,bytes)",
+// the Mantle v1 message with separate MNT and ETH values.
+func EncodeMantleCrossDomainMessageV1(
+	nonce *big.Int,
+	sender common.Address,
+	target common.Address,
+	mntValue *big.Int,
+	ethValue *big.Int,
+	gasLimit *big.Int,
+	data []byte,
+) ([]byte, error) {
+	return mantleRelayMessage1.Pack("relayMessage", nonce, sender, target, mntValue, ethValue, gasLimit, data)
+}
+
 // DecodeVersionedNonce will decode the version that is encoded in the nonce
 func DecodeVersionedNonce(versioned *big.Int) (*big.Int, *big.Int) {
 	nonce := new(big.Int).And(versioned, NonceMask)
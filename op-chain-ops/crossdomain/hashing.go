@@ -40,3 +40,22 @@ func HashCrossDomainMessageV1(
 	hash := crypto.Keccak256(encoded)
 	return common.BytesToHash(hash), nil
 }
+
+// HashMantleCrossDomainMessageV1 computes the Mantle v1 cross domain
+// messaging hashing scheme, with separate MNT and ETH values.
+func HashMantleCrossDomainMessageV1(
+	nonce *big.Int,
+	sender common.Address,
+	target common.Address,
+	mntValue *big.Int,
+	ethValue *big.Int,
+	gasLimit *big.Int,
+	data []byte,
+) (common.Hash, error) {
+	encoded, err := EncodeMantleCrossDomainMessageV1(nonce, sender, target, mntValue, ethValue, gasLimit, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash := crypto.Keccak256(encoded)
+	return common.BytesToHash(hash), nil
+}
@@ -0,0 +1,72 @@
+package crossdomain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MantleCrossDomainMessage represents a cross domain message used by the
+// Mantle CrossDomainMessenger. Version 1 messages carry separate MNT and ETH
+// values, version 0 messages are the same as upstream and have no value.
+type MantleCrossDomainMessage struct {
+	Nonce    *big.Int       `json:"nonce"`
+	Sender   common.Address `json:"sender"`
+	Target   common.Address `json:"target"`
+	MNTValue *big.Int       `json:"mntValue"`
+	ETHValue *big.Int       `json:"ethValue"`
+	GasLimit *big.Int       `json:"gasLimit"`
+	Data     []byte         `json:"data"`
+}
+
+// NewMantleCrossDomainMessage creates a MantleCrossDomainMessage.
+func NewMantleCrossDomainMessage(
+	nonce *big.Int,
+	sender, target common.Address,
+	mntValue, ethValue, gasLimit *big.Int,
+	data []byte,
+) *MantleCrossDomainMessage {
+	return &MantleCrossDomainMessage{
+		Nonce:    nonce,
+		Sender:   sender,
+		Target:   target,
+		MNTValue: mntValue,
+		ETHValue: ethValue,
+		GasLimit: gasLimit,
+		Data:     data,
+	}
+}
+
+// Version will return the version of the MantleCrossDomainMessage.
+// It does this by looking at the first byte of the nonce.
+func (c *MantleCrossDomainMessage) Version() uint64 {
+	_, version := DecodeVersionedNonce(c.Nonce)
+	return version.Uint64()
+}
+
+// Encode will encode a Mantle cross domain message based on the version.
+func (c *MantleCrossDomainMessage) Encode() ([]byte, error) {
+	version := c.Version()
+	switch version {
+	case 0:
+		return EncodeCrossDomainMessageV0(c.Target, c.Sender, c.Data, c.Nonce)
+	case 1:
+		return EncodeMantleCrossDomainMessageV1(c.Nonce, c.Sender, c.Target, c.MNTValue, c.ETHValue, c.GasLimit, c.Data)
+	default:
+		return nil, fmt.Errorf("unknown version %d", version)
+	}
+}
+
+// Hash will compute the hash of the MantleCrossDomainMessage
+func (c *MantleCrossDomainMessage) Hash() (common.Hash, error) {
+	version := c.Version()
+	switch version {
+	case 0:
+		return HashCrossDomainMessageV0(c.Target, c.Sender, c.Data, c.Nonce)
+	case 1:
+		return HashMantleCrossDomainMessageV1(c.Nonce, c.Sender, c.Target, c.MNTValue, c.ETHValue, c.GasLimit, c.Data)
+	default:
+		return common.Hash{}, fmt.Errorf("unknown version %d", version)
+	}
+}
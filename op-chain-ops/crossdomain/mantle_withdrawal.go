@@ -0,0 +1,224 @@
+package crossdomain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bindingsmantle "github.com/ethereum-optimism/optimism/op-node/bindings/mantle"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	MantleMessagePassedEventABI     = "MessagePassed(uint256,address,address,uint256,uint256,uint256,bytes,bytes32)"
+	MantleMessagePassedEventABIHash = crypto.Keccak256Hash([]byte(MantleMessagePassedEventABI))
+)
+
+var (
+	mantleWithdrawalArgs = abi.Arguments{
+		{Name: "nonce", Type: Uint256Type},
+		{Name: "sender", Type: AddressType},
+		{Name: "target", Type: AddressType},
+		{Name: "mntValue", Type: Uint256Type},
+		{Name: "ethValue", Type: Uint256Type},
+		{Name: "gasLimit", Type: Uint256Type},
+		{Name: "data", Type: BytesType},
+	}
+	// mantleMessagePassedDataArgs are the non-indexed fields of the Mantle MessagePassed event
+	mantleMessagePassedDataArgs = abi.Arguments{
+		{Name: "mntValue", Type: Uint256Type},
+		{Name: "ethValue", Type: Uint256Type},
+		{Name: "gasLimit", Type: Uint256Type},
+		{Name: "data", Type: BytesType},
+		{Name: "withdrawalHash", Type: Bytes32Type},
+	}
+)
+
+var _ WithdrawalMessage = (*MantleWithdrawal)(nil)
+
+// MantleWithdrawal represents a withdrawal transaction on a Mantle L2.
+// Mantle withdrawals carry both an MNT value, the native L2 token, and
+// an ETH value, bridged as the BVM_ETH token on L2.
+type MantleWithdrawal struct {
+	Nonce    *big.Int        `json:"nonce"`
+	Sender   *common.Address `json:"sender"`
+	Target   *common.Address `json:"target"`
+	MNTValue *big.Int        `json:"mntValue"`
+	ETHValue *big.Int        `json:"ethValue"`
+	GasLimit *big.Int        `json:"gasLimit"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// NewMantleWithdrawal will create a MantleWithdrawal
+func NewMantleWithdrawal(
+	nonce *big.Int,
+	sender, target *common.Address,
+	mntValue, ethValue, gasLimit *big.Int,
+	data []byte,
+) *MantleWithdrawal {
+	return &MantleWithdrawal{
+		Nonce:    nonce,
+		Sender:   sender,
+		Target:   target,
+		MNTValue: mntValue,
+		ETHValue: ethValue,
+		GasLimit: gasLimit,
+		Data:     hexutil.Bytes(data),
+	}
+}
+
+// Encode will serialize the MantleWithdrawal so that it is suitable for hashing.
+func (w *MantleWithdrawal) Encode() ([]byte, error) {
+	enc, err := mantleWithdrawalArgs.Pack(w.Nonce, w.Sender, w.Target, w.MNTValue, w.ETHValue, w.GasLimit, []byte(w.Data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode mantle withdrawal: %w", err)
+	}
+	return enc, nil
+}
+
+// Decode will deserialize a MantleWithdrawal
+func (w *MantleWithdrawal) Decode(data []byte) error {
+	decoded, err := mantleWithdrawalArgs.Unpack(data)
+	if err != nil {
+		return err
+	}
+
+	nonce, ok := decoded[0].(*big.Int)
+	if !ok {
+		return errors.New("cannot abi decode nonce")
+	}
+	sender, ok := decoded[1].(common.Address)
+	if !ok {
+		return errors.New("cannot abi decode sender")
+	}
+	target, ok := decoded[2].(common.Address)
+	if !ok {
+		return errors.New("cannot abi decode target")
+	}
+	mntValue, ok := decoded[3].(*big.Int)
+	if !ok {
+		return errors.New("cannot abi decode mntValue")
+	}
+	ethValue, ok := decoded[4].(*big.Int)
+	if !ok {
+		return errors.New("cannot abi decode ethValue")
+	}
+	gasLimit, ok := decoded[5].(*big.Int)
+	if !ok {
+		return errors.New("cannot abi decode gasLimit")
+	}
+	msgData, ok := decoded[6].([]byte)
+	if !ok {
+		return errors.New("cannot abi decode data")
+	}
+
+	w.Nonce = nonce
+	w.Sender = &sender
+	w.Target = &target
+	w.MNTValue = mntValue
+	w.ETHValue = ethValue
+	w.GasLimit = gasLimit
+	w.Data = hexutil.Bytes(msgData)
+	return nil
+}
+
+// Hash will hash the MantleWithdrawal. This is the hash that is computed in
+// the Mantle L2ToL1MessagePasser and Hashing.hashWithdrawal.
+func (w *MantleWithdrawal) Hash() (common.Hash, error) {
+	encoded, err := w.Encode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash := crypto.Keccak256(encoded)
+	return common.BytesToHash(hash), nil
+}
+
+// StorageSlot will compute the storage slot that will be set to
+// true in the L2ToL1MessagePasser. The withdrawal proof sent to
+// L1 will prove that this storage slot is set to "true".
+func (w *MantleWithdrawal) StorageSlot() (common.Hash, error) {
+	hash, err := w.Hash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	preimage := make([]byte, 64)
+	copy(preimage, hash.Bytes())
+
+	slot := crypto.Keccak256(preimage)
+	return common.BytesToHash(slot), nil
+}
+
+// WithdrawalTransaction will convert the MantleWithdrawal to a type
+// suitable for sending a transaction to the Mantle OptimismPortal.
+func (w *MantleWithdrawal) WithdrawalTransaction() bindingsmantle.TypesWithdrawalTransaction {
+	return bindingsmantle.TypesWithdrawalTransaction{
+		Nonce:    w.Nonce,
+		Sender:   *w.Sender,
+		Target:   *w.Target,
+		MntValue: w.MNTValue,
+		EthValue: w.ETHValue,
+		GasLimit: w.GasLimit,
+		Data:     []byte(w.Data),
+	}
+}
+
+// ParseMantleMessagePassed parses a MantleWithdrawal from a MessagePassed log
+// of the Mantle L2ToL1MessagePasser. It returns an error if the withdrawal hash
+// emitted in the log does not match the hash of the parsed withdrawal.
+func ParseMantleMessagePassed(log *types.Log) (*MantleWithdrawal, error) {
+	if len(log.Topics) != 4 || log.Topics[0] != MantleMessagePassedEventABIHash {
+		return nil, errors.New("log is not a mantle MessagePassed event")
+	}
+	decoded, err := mantleMessagePassedDataArgs.Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot abi decode MessagePassed data: %w", err)
+	}
+	mntValue, ok := decoded[0].(*big.Int)
+	if !ok {
+		return nil, errors.New("cannot abi decode mntValue")
+	}
+	ethValue, ok := decoded[1].(*big.Int)
+	if !ok {
+		return nil, errors.New("cannot abi decode ethValue")
+	}
+	gasLimit, ok := decoded[2].(*big.Int)
+	if !ok {
+		return nil, errors.New("cannot abi decode gasLimit")
+	}
+	data, ok := decoded[3].([]byte)
+	if !ok {
+		return nil, errors.New("cannot abi decode data")
+	}
+	withdrawalHash, ok := decoded[4].([32]byte)
+	if !ok {
+		return nil, errors.New("cannot abi decode withdrawalHash")
+	}
+
+	sender := common.BytesToAddress(log.Topics[2].Bytes())
+	target := common.BytesToAddress(log.Topics[3].Bytes())
+	w := NewMantleWithdrawal(new(big.Int).SetBytes(log.Topics[1].Bytes()), &sender, &target, mntValue, ethValue, gasLimit, data)
+	hash, err := w.Hash()
+	if err != nil {
+		return nil, err
+	}
+	if hash != withdrawalHash {
+		return nil, fmt.Errorf("withdrawal hash mismatch: emitted %s, computed %s", common.Hash(withdrawalHash), hash)
+	}
+	return w, nil
+}
+
+// ParseMantleMessagePassedFromReceipt parses the MantleWithdrawal from the first
+// MessagePassed log emitted by the given L2ToL1MessagePasser in the receipt.
+func ParseMantleMessagePassedFromReceipt(receipt *types.Receipt, messagePasser common.Address) (*MantleWithdrawal, error) {
+	for _, log := range receipt.Logs {
+		if log.Address != messagePasser || len(log.Topics) == 0 || log.Topics[0] != MantleMessagePassedEventABIHash {
+			continue
+		}
+		return ParseMantleMessagePassed(log)
+	}
+	return nil, errors.New("unable to find MessagePassed event")
+}
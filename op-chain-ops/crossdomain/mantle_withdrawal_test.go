@@ -0,0 +1,243 @@
+package crossdomain_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	bindingsmantle "github.com/ethereum-optimism/optimism/op-node/bindings/mantle"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzEncodeDecodeMantleWithdrawal will fuzz encoding and decoding of a MantleWithdrawal
+func FuzzEncodeDecodeMantleWithdrawal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, _nonce, _sender, _target, _mntValue, _ethValue, _gasLimit, data []byte) {
+		sender := common.BytesToAddress(_sender)
+		target := common.BytesToAddress(_target)
+		withdrawal := crossdomain.NewMantleWithdrawal(
+			new(big.Int).SetBytes(_nonce),
+			&sender,
+			&target,
+			new(big.Int).SetBytes(_mntValue),
+			new(big.Int).SetBytes(_ethValue),
+			new(big.Int).SetBytes(_gasLimit),
+			data,
+		)
+
+		encoded, err := withdrawal.Encode()
+		require.Nil(t, err)
+
+		var w crossdomain.MantleWithdrawal
+		err = w.Decode(encoded)
+		require.Nil(t, err)
+
+		require.Equal(t, 0, withdrawal.Nonce.Cmp(w.Nonce))
+		require.Equal(t, withdrawal.Sender, w.Sender)
+		require.Equal(t, withdrawal.Target, w.Target)
+		require.Equal(t, 0, withdrawal.MNTValue.Cmp(w.MNTValue))
+		require.Equal(t, 0, withdrawal.ETHValue.Cmp(w.ETHValue))
+		require.Equal(t, 0, withdrawal.GasLimit.Cmp(w.GasLimit))
+		require.Equal(t, []byte(withdrawal.Data), []byte(w.Data))
+	})
+}
+
+func testMantleWithdrawal() *crossdomain.MantleWithdrawal {
+	return crossdomain.NewMantleWithdrawal(
+		crossdomain.EncodeVersionedNonce(big.NewInt(7), big.NewInt(1)),
+		ptr(common.HexToAddress("0x4200000000000000000000000000000000000007")),
+		ptr(common.HexToAddress("0x6900000000000000000000000000000000000002")),
+		big.NewInt(1000),
+		decimalStringToBig("124808255574871339965699013847079823271"),
+		big.NewInt(200_000),
+		hexutil.MustDecode("0xd764ad0b0001"),
+	)
+}
+
+// TestMantleWithdrawalHashing tests the computation of Mantle withdrawal hashes and the storage slot
+// that the withdrawal hash is stored in. Test vectors computed as keccak256(abi.encode(withdrawal)),
+// like Hashing.hashWithdrawal of the Mantle contracts, without the go-ethereum abi encoder.
+func TestMantleWithdrawalHashing(t *testing.T) {
+	type expect struct {
+		Hash common.Hash
+		Slot common.Hash
+	}
+
+	cases := []struct {
+		Withdrawal *crossdomain.MantleWithdrawal
+		Expect     expect
+	}{
+		{
+			Withdrawal: testMantleWithdrawal(),
+			Expect: expect{
+				Hash: common.HexToHash("0x50d00a0c20dab824edef0ab0e7226f1e2368bdd5ba22880da0192c95e1d865e8"),
+				Slot: common.HexToHash("0x8b53d90be7391618067abb141b1d92b5eab80a43c57df2d7cd2ab571c584d76e"),
+			},
+		},
+		{
+			Withdrawal: crossdomain.NewMantleWithdrawal(
+				crossdomain.EncodeVersionedNonce(big.NewInt(0), big.NewInt(1)),
+				ptr(common.HexToAddress("0x00000000000000000000000000000000000011bc")),
+				ptr(common.HexToAddress("0x00000000000000000000000000000000000033eb")),
+				big.NewInt(0),
+				big.NewInt(26),
+				big.NewInt(22338),
+				hexutil.MustDecode("0x0000000000000000000000000000000000000000000000000000000000000004"),
+			),
+			Expect: expect{
+				Hash: common.HexToHash("0x850af6523ed1b76a5c4664af72b0a4c1d8608152a9b5743a1beccfe3541abccd"),
+				Slot: common.HexToHash("0x0f8dcfcdaac6dab0cb24b25eb8f49948d878591a4d83409755cec11d7d636fa0"),
+			},
+		},
+		{
+			Withdrawal: crossdomain.NewMantleWithdrawal(
+				crossdomain.EncodeVersionedNonce(big.NewInt(3), big.NewInt(1)),
+				ptr(common.HexToAddress("0x4b0ca57cb88a41771d2cc24ac9fd50afeaa3eedd")),
+				ptr(common.HexToAddress("0x8a5e8410b2c3e1036c49ff8acae1e659e2508200")),
+				decimalStringToBig("115792089237316195423570985008687907853269984665640564039457584007913129639935"),
+				big.NewInt(3),
+				big.NewInt(100_000),
+				[]byte{},
+			),
+			Expect: expect{
+				Hash: common.HexToHash("0x96ac8888dde5aa16b015fafc927136af2b77842f80d532c08d90f6855fbfd95d"),
+				Slot: common.HexToHash("0xaf55efc80f85f4022bf04eb8faa74c6de5634cb75bd7a75f3cb3d24679357a08"),
+			},
+		},
+	}
+
+	portalABI, err := bindingsmantle.OptimismPortalMetaData.GetAbi()
+	require.NoError(t, err)
+	for i, test := range cases {
+		t.Run(fmt.Sprintf("case%d", i), func(t *testing.T) {
+			hash, err := test.Withdrawal.Hash()
+			require.NoError(t, err)
+			require.Equal(t, test.Expect.Hash, hash)
+
+			slot, err := test.Withdrawal.StorageSlot()
+			require.NoError(t, err)
+			require.Equal(t, test.Expect.Slot, slot)
+
+			// The transaction passed to the portal must hash to the same withdrawal hash.
+			calldata, err := portalABI.Pack("finalizeWithdrawalTransaction", test.Withdrawal.WithdrawalTransaction())
+			require.NoError(t, err)
+			// Skip the selector and the offset of the dynamic tuple
+			require.Equal(t, test.Expect.Hash, crypto.Keccak256Hash(calldata[4+32:]))
+		})
+	}
+}
+
+func mantleMessagePassedLog(t *testing.T, w *crossdomain.MantleWithdrawal, withdrawalHash common.Hash) *types.Log {
+	args := abi.Arguments{
+		{Name: "mntValue", Type: crossdomain.Uint256Type},
+		{Name: "ethValue", Type: crossdomain.Uint256Type},
+		{Name: "gasLimit", Type: crossdomain.Uint256Type},
+		{Name: "data", Type: crossdomain.BytesType},
+		{Name: "withdrawalHash", Type: crossdomain.Bytes32Type},
+	}
+	data, err := args.Pack(w.MNTValue, w.ETHValue, w.GasLimit, []byte(w.Data), withdrawalHash)
+	require.NoError(t, err)
+	return &types.Log{
+		Address: common.HexToAddress("0x4200000000000000000000000000000000000016"),
+		Topics: []common.Hash{
+			crossdomain.MantleMessagePassedEventABIHash,
+			common.BigToHash(w.Nonce),
+			common.BytesToHash(w.Sender.Bytes()),
+			common.BytesToHash(w.Target.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func TestParseMantleMessagePassed(t *testing.T) {
+	w := testMantleWithdrawal()
+	hash, err := w.Hash()
+	require.NoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		parsed, err := crossdomain.ParseMantleMessagePassed(mantleMessagePassedLog(t, w, hash))
+		require.NoError(t, err)
+		require.Equal(t, w, parsed)
+	})
+
+	t.Run("FromReceipt", func(t *testing.T) {
+		log := mantleMessagePassedLog(t, w, hash)
+		receipt := &types.Receipt{Logs: []*types.Log{
+			{Address: log.Address, Topics: []common.Hash{crossdomain.MessagePassedEventABIHash}},
+			log,
+		}}
+		parsed, err := crossdomain.ParseMantleMessagePassedFromReceipt(receipt, log.Address)
+		require.NoError(t, err)
+		require.Equal(t, w, parsed)
+
+		_, err = crossdomain.ParseMantleMessagePassedFromReceipt(receipt, common.Address{0x01})
+		require.ErrorContains(t, err, "unable to find MessagePassed event")
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		_, err := crossdomain.ParseMantleMessagePassed(mantleMessagePassedLog(t, w, common.Hash{0x01}))
+		require.ErrorContains(t, err, "withdrawal hash mismatch")
+	})
+
+	t.Run("UpstreamEvent", func(t *testing.T) {
+		log := mantleMessagePassedLog(t, w, hash)
+		log.Topics[0] = crossdomain.MessagePassedEventABIHash
+		_, err := crossdomain.ParseMantleMessagePassed(log)
+		require.ErrorContains(t, err, "not a mantle MessagePassed event")
+	})
+}
+
+func TestMantleCrossDomainMessage(t *testing.T) {
+	sender := common.HexToAddress("0x4200000000000000000000000000000000000010")
+	target := common.HexToAddress("0x6900000000000000000000000000000000000002")
+	data := hexutil.MustDecode("0x1234")
+
+	t.Run("V1", func(t *testing.T) {
+		nonce := crossdomain.EncodeVersionedNonce(big.NewInt(3), big.NewInt(1))
+		msg := crossdomain.NewMantleCrossDomainMessage(nonce, sender, target, big.NewInt(1), big.NewInt(2), big.NewInt(100_000), data)
+		require.Equal(t, uint64(1), msg.Version())
+
+		encoded, err := msg.Encode()
+		require.NoError(t, err)
+		selector := crypto.Keccak256([]byte("relayMessage(uint256,address,address,uint256,uint256,uint256,bytes)"))[:4]
+		require.Equal(t, selector, encoded[:4])
+		args := abi.Arguments{
+			{Type: crossdomain.Uint256Type},
+			{Type: crossdomain.AddressType},
+			{Type: crossdomain.AddressType},
+			{Type: crossdomain.Uint256Type},
+			{Type: crossdomain.Uint256Type},
+			{Type: crossdomain.Uint256Type},
+			{Type: crossdomain.BytesType},
+		}
+		packed, err := args.Pack(nonce, sender, target, big.NewInt(1), big.NewInt(2), big.NewInt(100_000), data)
+		require.NoError(t, err)
+		require.Equal(t, packed, encoded[4:])
+
+		hash, err := msg.Hash()
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256Hash(encoded), hash)
+	})
+
+	t.Run("V0", func(t *testing.T) {
+		nonce := big.NewInt(3)
+		msg := crossdomain.NewMantleCrossDomainMessage(nonce, sender, target, big.NewInt(1), big.NewInt(2), big.NewInt(100_000), data)
+		encoded, err := msg.Encode()
+		require.NoError(t, err)
+		expected, err := crossdomain.EncodeCrossDomainMessageV0(target, sender, data, nonce)
+		require.NoError(t, err)
+		require.Equal(t, expected, encoded)
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		nonce := crossdomain.EncodeVersionedNonce(big.NewInt(3), big.NewInt(2))
+		msg := crossdomain.NewMantleCrossDomainMessage(nonce, sender, target, big.NewInt(1), big.NewInt(2), big.NewInt(100_000), data)
+		_, err := msg.Encode()
+		require.ErrorContains(t, err, "unknown version 2")
+	})
+}
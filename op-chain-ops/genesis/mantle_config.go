@@ -1,7 +1,9 @@
 package genesis
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"reflect"
 
 	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
@@ -133,6 +135,49 @@ func BuildMantleGenesis(config *DeployConfig, dump *foundry.ForgeAllocs, l1Start
 	return genesis, nil
 }
 
+// L1HeaderSource fetches L1 block headers.
+type L1HeaderSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// BuildMantleL2Genesis fetches the L1 starting block of the deploy config, checks the config, and
+// builds the L2 genesis on top of the given L2 allocs. The L1 starting block is returned as well,
+// as it is needed to build the rollup config.
+func BuildMantleL2Genesis(ctx context.Context, logger log.Logger, config *DeployConfig, l2Allocs *foundry.ForgeAllocs, l1 L1HeaderSource) (*core.Genesis, *eth.BlockRef, error) {
+	// MANTLE_FEATURES
+	// SystemConfig contract has not yet upgraded to have startBlock() method,
+	// so we need to fetch the L1 start block from the L1 starting block tag.
+	if config.L1StartingBlockTag == nil {
+		return nil, nil, fmt.Errorf("L1StartingBlockTag is required but not set in deploy config")
+	}
+	var l1StartHeader *types.Header
+	var err error
+	if config.L1StartingBlockTag.BlockHash != nil {
+		l1StartHeader, err = l1.HeaderByHash(ctx, *config.L1StartingBlockTag.BlockHash)
+	} else if config.L1StartingBlockTag.BlockNumber != nil {
+		l1StartHeader, err = l1.HeaderByNumber(ctx, big.NewInt(config.L1StartingBlockTag.BlockNumber.Int64()))
+	} else {
+		return nil, nil, fmt.Errorf("L1StartingBlockTag must specify either a block hash or block number")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting l1 start block: %w", err)
+	}
+	logger.Info("Fetched L1 start block", "hash", l1StartHeader.Hash(), "number", l1StartHeader.Number)
+
+	// Sanity check the config. Do this after fetching the L1 starting block.
+	if err := config.MantleCheck(logger); err != nil {
+		return nil, nil, err
+	}
+
+	l1StartBlock := eth.BlockRefFromHeader(l1StartHeader)
+	l2Genesis, err := BuildMantleGenesis(config, l2Allocs, l1StartBlock)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating l2 genesis: %w", err)
+	}
+	return l2Genesis, l1StartBlock, nil
+}
+
 // Avoid modifying BuildL2Genesis function directly
 func fillInMantleForksIntoGenesis(config *DeployConfig, genesis *core.Genesis, l1StartBlockTimestamp uint64) {
 	chainConfig := genesis.Config
@@ -0,0 +1,303 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// Kinds of genesis account differences.
+const (
+	AccountMissing    = "missing"
+	AccountUnexpected = "unexpected"
+	AccountChanged    = "changed"
+)
+
+// ValueDiff is a value that differs between the expected and the actual genesis.
+type ValueDiff[T any] struct {
+	Expected T `json:"expected"`
+	Actual   T `json:"actual"`
+}
+
+// GenesisAccountDiff describes how an account of the actual genesis differs from the expected genesis.
+// Missing and unexpected accounts do not list their fields.
+type GenesisAccountDiff struct {
+	Address  common.Address                         `json:"address"`
+	Kind     string                                 `json:"kind"`
+	Balance  *ValueDiff[*big.Int]                   `json:"balance,omitempty"`
+	Nonce    *ValueDiff[uint64]                     `json:"nonce,omitempty"`
+	CodeHash *ValueDiff[common.Hash]                `json:"codeHash,omitempty"`
+	Storage  map[common.Hash]ValueDiff[common.Hash] `json:"storage,omitempty"`
+}
+
+// GenesisFieldDiff is a header or chain config field that differs between the expected and the actual genesis.
+type GenesisFieldDiff struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	// Hint explains which part of the deploy config determines the field, if known.
+	Hint string `json:"hint,omitempty"`
+}
+
+// GenesisDiff is the difference between an expected genesis, rebuilt from a deploy config, and an actual genesis.
+type GenesisDiff struct {
+	ExpectedHash common.Hash          `json:"expectedHash"`
+	ActualHash   common.Hash          `json:"actualHash"`
+	Header       []GenesisFieldDiff   `json:"header,omitempty"`
+	Accounts     []GenesisAccountDiff `json:"accounts,omitempty"`
+	// Config is only diffed if the chain config of the actual genesis is known.
+	Config []GenesisFieldDiff `json:"config,omitempty"`
+}
+
+// Match returns true if the actual genesis matches the expected genesis.
+func (d *GenesisDiff) Match() bool {
+	return d.ExpectedHash == d.ActualHash && len(d.Header) == 0 && len(d.Accounts) == 0 && len(d.Config) == 0
+}
+
+// Explain summarizes why the genesis block hashes differ.
+func (d *GenesisDiff) Explain() []string {
+	if d.Match() {
+		return []string{fmt.Sprintf("genesis block %s matches", d.ExpectedHash)}
+	}
+	var out []string
+	if d.ExpectedHash != d.ActualHash {
+		out = append(out, fmt.Sprintf("genesis block hash mismatch: expected %s, got %s", d.ExpectedHash, d.ActualHash))
+	} else {
+		out = append(out, fmt.Sprintf("genesis block hash %s matches, but the genesis differs", d.ExpectedHash))
+	}
+	for _, f := range d.Header {
+		line := fmt.Sprintf("header field %s differs: expected %s, got %s", f.Field, f.Expected, f.Actual)
+		if f.Hint != "" {
+			line += ": " + f.Hint
+		}
+		out = append(out, line)
+	}
+	if len(d.Accounts) > 0 {
+		counts := make(map[string]int)
+		for _, acc := range d.Accounts {
+			counts[acc.Kind]++
+		}
+		out = append(out, fmt.Sprintf("%d accounts differ: %d missing, %d unexpected, %d changed",
+			len(d.Accounts), counts[AccountMissing], counts[AccountUnexpected], counts[AccountChanged]))
+	}
+	for _, f := range d.Config {
+		line := fmt.Sprintf("chain config field %s differs: expected %s, got %s", f.Field, f.Expected, f.Actual)
+		if f.Hint != "" {
+			line += ": " + f.Hint
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
+
+// DiffGenesisAllocs compares the actual genesis allocs against the expected allocs.
+// Absent storage slots are equal to zero slots. The result is sorted by address.
+func DiffGenesisAllocs(expected, actual types.GenesisAlloc) []GenesisAccountDiff {
+	var out []GenesisAccountDiff
+	for addr, exp := range expected {
+		act, ok := actual[addr]
+		if !ok {
+			out = append(out, GenesisAccountDiff{Address: addr, Kind: AccountMissing})
+			continue
+		}
+		if diff := diffGenesisAccount(addr, exp, act); diff != nil {
+			out = append(out, *diff)
+		}
+	}
+	for addr := range actual {
+		if _, ok := expected[addr]; !ok {
+			out = append(out, GenesisAccountDiff{Address: addr, Kind: AccountUnexpected})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].Address[:], out[j].Address[:]) < 0
+	})
+	return out
+}
+
+func diffGenesisAccount(addr common.Address, exp, act types.Account) *GenesisAccountDiff {
+	diff := GenesisAccountDiff{Address: addr, Kind: AccountChanged}
+	if expBal, actBal := bigOrZero(exp.Balance), bigOrZero(act.Balance); expBal.Cmp(actBal) != 0 {
+		diff.Balance = &ValueDiff[*big.Int]{Expected: expBal, Actual: actBal}
+	}
+	if exp.Nonce != act.Nonce {
+		diff.Nonce = &ValueDiff[uint64]{Expected: exp.Nonce, Actual: act.Nonce}
+	}
+	if !bytes.Equal(exp.Code, act.Code) {
+		diff.CodeHash = &ValueDiff[common.Hash]{Expected: crypto.Keccak256Hash(exp.Code), Actual: crypto.Keccak256Hash(act.Code)}
+	}
+	for slot, expVal := range exp.Storage {
+		if actVal := act.Storage[slot]; actVal != expVal {
+			diff.addStorage(slot, expVal, actVal)
+		}
+	}
+	for slot, actVal := range act.Storage {
+		if _, ok := exp.Storage[slot]; !ok && actVal != (common.Hash{}) {
+			diff.addStorage(slot, common.Hash{}, actVal)
+		}
+	}
+	if diff.Balance == nil && diff.Nonce == nil && diff.CodeHash == nil && len(diff.Storage) == 0 {
+		return nil
+	}
+	return &diff
+}
+
+func (d *GenesisAccountDiff) addStorage(slot, expected, actual common.Hash) {
+	if d.Storage == nil {
+		d.Storage = make(map[common.Hash]ValueDiff[common.Hash])
+	}
+	d.Storage[slot] = ValueDiff[common.Hash]{Expected: expected, Actual: actual}
+}
+
+const skadiHint = "only set if Skadi, which Mantle aligns Shanghai, Cancun and Prague with, is active at genesis: check l2GenesisMantleSkadiTimeOffset"
+
+var genesisHeaderHints = map[string]string{
+	"ParentHash":       "check l2GenesisBlockParentHash",
+	"Coinbase":         "the coinbase is the SequencerFeeVault predeploy",
+	"Root":             "the state differs, see the account diff",
+	"Difficulty":       "check l2GenesisBlockDifficulty",
+	"Number":           "check l2GenesisBlockNumber",
+	"GasLimit":         "check l2GenesisBlockGasLimit",
+	"GasUsed":          "check l2GenesisBlockGasUsed",
+	"Time":             "the genesis time is the time of the L1 starting block: check l1StartingBlockTag",
+	"Extra":            "Arsia at genesis encodes the EIP-1559 parameters in the extra data: check l2GenesisMantleArsiaTimeOffset, eip1559Denominator and eip1559Elasticity",
+	"MixDigest":        "check l2GenesisBlockMixHash",
+	"Nonce":            "check l2GenesisBlockNonce",
+	"BaseFee":          "check l2GenesisBlockBaseFeePerGas",
+	"WithdrawalsHash":  skadiHint,
+	"BlobGasUsed":      skadiHint,
+	"ExcessBlobGas":    skadiHint,
+	"ParentBeaconRoot": skadiHint,
+	"RequestsHash":     skadiHint,
+}
+
+func fmtOptional[T any](v *T) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%v", *v)
+}
+
+// DiffGenesisHeaders compares the header fields of the actual genesis block against the expected genesis block.
+func DiffGenesisHeaders(expected, actual *types.Header) []GenesisFieldDiff {
+	fields := []struct {
+		name     string
+		exp, act string
+	}{
+		{"ParentHash", expected.ParentHash.String(), actual.ParentHash.String()},
+		{"UncleHash", expected.UncleHash.String(), actual.UncleHash.String()},
+		{"Coinbase", expected.Coinbase.String(), actual.Coinbase.String()},
+		{"Root", expected.Root.String(), actual.Root.String()},
+		{"TxHash", expected.TxHash.String(), actual.TxHash.String()},
+		{"ReceiptHash", expected.ReceiptHash.String(), actual.ReceiptHash.String()},
+		{"Bloom", common.Bytes2Hex(expected.Bloom[:]), common.Bytes2Hex(actual.Bloom[:])},
+		{"Difficulty", fmtOptional(expected.Difficulty), fmtOptional(actual.Difficulty)},
+		{"Number", fmtOptional(expected.Number), fmtOptional(actual.Number)},
+		{"GasLimit", fmt.Sprint(expected.GasLimit), fmt.Sprint(actual.GasLimit)},
+		{"GasUsed", fmt.Sprint(expected.GasUsed), fmt.Sprint(actual.GasUsed)},
+		{"Time", fmt.Sprint(expected.Time), fmt.Sprint(actual.Time)},
+		{"Extra", common.Bytes2Hex(expected.Extra), common.Bytes2Hex(actual.Extra)},
+		{"MixDigest", expected.MixDigest.String(), actual.MixDigest.String()},
+		{"Nonce", fmt.Sprint(expected.Nonce.Uint64()), fmt.Sprint(actual.Nonce.Uint64())},
+		{"BaseFee", fmtOptional(expected.BaseFee), fmtOptional(actual.BaseFee)},
+		{"WithdrawalsHash", fmtOptional(expected.WithdrawalsHash), fmtOptional(actual.WithdrawalsHash)},
+		{"BlobGasUsed", fmtOptional(expected.BlobGasUsed), fmtOptional(actual.BlobGasUsed)},
+		{"ExcessBlobGas", fmtOptional(expected.ExcessBlobGas), fmtOptional(actual.ExcessBlobGas)},
+		{"ParentBeaconRoot", fmtOptional(expected.ParentBeaconRoot), fmtOptional(actual.ParentBeaconRoot)},
+		{"RequestsHash", fmtOptional(expected.RequestsHash), fmtOptional(actual.RequestsHash)},
+	}
+	var out []GenesisFieldDiff
+	for _, f := range fields {
+		if f.exp != f.act {
+			out = append(out, GenesisFieldDiff{Field: f.name, Expected: f.exp, Actual: f.act, Hint: genesisHeaderHints[f.name]})
+		}
+	}
+	return out
+}
+
+// DiffChainConfigs compares the JSON fields of the actual chain config against the expected chain config.
+// The chain config is not part of the genesis block hash, but determines the fork schedule of the chain.
+func DiffChainConfigs(expected, actual *params.ChainConfig) ([]GenesisFieldDiff, error) {
+	expFields, err := jsonutil.Fields(expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode expected chain config: %w", err)
+	}
+	actFields, err := jsonutil.Fields(actual)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode actual chain config: %w", err)
+	}
+	var names []string
+	for name := range expFields {
+		names = append(names, name)
+	}
+	for name := range actFields {
+		if _, ok := expFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	var out []GenesisFieldDiff
+	for _, name := range names {
+		exp, act := fieldOrNil(expFields, name), fieldOrNil(actFields, name)
+		if exp != act {
+			out = append(out, GenesisFieldDiff{Field: name, Expected: exp, Actual: act})
+		}
+	}
+	return out, nil
+}
+
+func fieldOrNil(fields map[string]json.RawMessage, name string) string {
+	if v, ok := fields[name]; ok {
+		return string(v)
+	}
+	return "<nil>"
+}
+
+// DiffGenesis compares the actual genesis against the expected genesis.
+// A genesis without a chain config is built with the expected config, and
+// its config is not compared.
+func DiffGenesis(expected, actual *core.Genesis) (*GenesisDiff, error) {
+	if expected.Config == nil {
+		return nil, errors.New("expected genesis has no chain config")
+	}
+	actualBlockGenesis := actual
+	if actual.Config == nil {
+		cpy := *actual
+		cpy.Config = expected.Config
+		actualBlockGenesis = &cpy
+	}
+	expBlock, actBlock := expected.ToBlock(), actualBlockGenesis.ToBlock()
+	diff := &GenesisDiff{
+		ExpectedHash: expBlock.Hash(),
+		ActualHash:   actBlock.Hash(),
+		Header:       DiffGenesisHeaders(expBlock.Header(), actBlock.Header()),
+		Accounts:     DiffGenesisAllocs(expected.Alloc, actual.Alloc),
+	}
+	if actual.Config != nil {
+		cfgDiff, err := DiffChainConfigs(expected.Config, actual.Config)
+		if err != nil {
+			return nil, err
+		}
+		diff.Config = cfgDiff
+	}
+	return diff, nil
+}
@@ -0,0 +1,105 @@
+package genesis
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func testDiffGenesis() *core.Genesis {
+	return &core.Genesis{
+		Config:     params.OptimismTestConfig,
+		Timestamp:  1000,
+		GasLimit:   30_000_000,
+		Difficulty: new(big.Int),
+		BaseFee:    big.NewInt(params.GWei),
+		Alloc: types.GenesisAlloc{
+			{0x01}: {Balance: big.NewInt(1)},
+			{0x02}: {Code: []byte{0x60, 0x00}, Storage: map[common.Hash]common.Hash{{0x01}: {0x01}}},
+		},
+	}
+}
+
+func TestDiffGenesis(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		actual := testDiffGenesis()
+		// Zero balances and zero storage slots are equal to absent ones
+		actual.Alloc[common.Address{0x02}] = types.Account{
+			Balance: new(big.Int),
+			Code:    []byte{0x60, 0x00},
+			Storage: map[common.Hash]common.Hash{{0x01}: {0x01}, {0x02}: {}},
+		}
+		diff, err := DiffGenesis(testDiffGenesis(), actual)
+		require.NoError(t, err)
+		require.True(t, diff.Match())
+		require.Len(t, diff.Explain(), 1)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		actual := testDiffGenesis()
+		actual.Timestamp = 1001
+		delete(actual.Alloc, common.Address{0x01})
+		actual.Alloc[common.Address{0x03}] = types.Account{Balance: big.NewInt(1)}
+		actual.Alloc[common.Address{0x02}] = types.Account{
+			Nonce:   1,
+			Code:    []byte{0x60, 0x01},
+			Storage: map[common.Hash]common.Hash{{0x02}: {0x02}},
+		}
+		cfg := *params.OptimismTestConfig
+		cfg.ChainID = big.NewInt(1234)
+		actual.Config = &cfg
+
+		diff, err := DiffGenesis(testDiffGenesis(), actual)
+		require.NoError(t, err)
+		require.False(t, diff.Match())
+		require.NotEqual(t, diff.ExpectedHash, diff.ActualHash)
+
+		require.Equal(t, []GenesisAccountDiff{
+			{Address: common.Address{0x01}, Kind: AccountMissing},
+			{
+				Address:  common.Address{0x02},
+				Kind:     AccountChanged,
+				Nonce:    &ValueDiff[uint64]{Expected: 0, Actual: 1},
+				CodeHash: &ValueDiff[common.Hash]{Expected: crypto.Keccak256Hash([]byte{0x60, 0x00}), Actual: crypto.Keccak256Hash([]byte{0x60, 0x01})},
+				Storage: map[common.Hash]ValueDiff[common.Hash]{
+					{0x01}: {Expected: common.Hash{0x01}, Actual: common.Hash{}},
+					{0x02}: {Expected: common.Hash{}, Actual: common.Hash{0x02}},
+				},
+			},
+			{Address: common.Address{0x03}, Kind: AccountUnexpected},
+		}, diff.Accounts)
+
+		var fields []string
+		for _, f := range diff.Header {
+			fields = append(fields, f.Field)
+		}
+		require.Equal(t, []string{"Root", "Time"}, fields)
+		require.Contains(t, diff.Header[1].Hint, "l1StartingBlockTag")
+
+		require.Equal(t, []GenesisFieldDiff{{Field: "chainId", Expected: params.OptimismTestConfig.ChainID.String(), Actual: "1234"}}, diff.Config)
+		require.Contains(t, diff.Explain()[0], "genesis block hash mismatch")
+	})
+
+	t.Run("NoConfig", func(t *testing.T) {
+		actual := testDiffGenesis()
+		actual.Config = nil
+		diff, err := DiffGenesis(testDiffGenesis(), actual)
+		require.NoError(t, err)
+		require.True(t, diff.Match())
+		require.Empty(t, diff.Config)
+		require.Nil(t, actual.Config, "must not modify the actual genesis")
+	})
+
+	t.Run("NoExpectedConfig", func(t *testing.T) {
+		expected := testDiffGenesis()
+		expected.Config = nil
+		_, err := DiffGenesis(expected, testDiffGenesis())
+		require.ErrorContains(t, err, "no chain config")
+	})
+}
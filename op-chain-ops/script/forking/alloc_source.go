@@ -0,0 +1,49 @@
+package forking
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AllocSource is an in-memory ForkSource that serves the accounts of a genesis alloc.
+// It is meant for tests of code that reads state through a ForkSource.
+type AllocSource struct {
+	alloc types.GenesisAlloc
+}
+
+var _ ForkSource = (*AllocSource)(nil)
+
+// NewAllocSource creates a ForkSource that serves the given accounts.
+func NewAllocSource(alloc types.GenesisAlloc) *AllocSource {
+	return &AllocSource{alloc: alloc}
+}
+
+func (s *AllocSource) URLOrAlias() string {
+	return "alloc"
+}
+
+// StateRoot returns a fixed placeholder root, as the alloc is not committed to a trie.
+func (s *AllocSource) StateRoot() common.Hash {
+	return common.Hash{0x42}
+}
+
+func (s *AllocSource) Nonce(addr common.Address) (uint64, error) {
+	return s.alloc[addr].Nonce, nil
+}
+
+func (s *AllocSource) Balance(addr common.Address) (*uint256.Int, error) {
+	if b := s.alloc[addr].Balance; b != nil {
+		return uint256.MustFromBig(b), nil
+	}
+	return new(uint256.Int), nil
+}
+
+func (s *AllocSource) StorageAt(addr common.Address, key common.Hash) (common.Hash, error) {
+	return s.alloc[addr].Storage[key], nil
+}
+
+func (s *AllocSource) Code(addr common.Address) ([]byte, error) {
+	return s.alloc[addr].Code, nil
+}
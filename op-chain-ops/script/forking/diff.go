@@ -70,6 +70,10 @@ func (ed *ExportDiff) Copy() *ExportDiff {
 		Code:    make(map[common.Hash][]byte),
 	}
 	for addr, acc := range ed.Account {
+		if acc == nil { // deleted accounts are nil
+			out.Account[addr] = nil
+			continue
+		}
 		out.Account[addr] = acc.Copy()
 	}
 	for addr, code := range ed.Code {
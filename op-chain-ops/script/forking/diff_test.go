@@ -0,0 +1,26 @@
+package forking
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestExportDiffCopy(t *testing.T) {
+	nonce := uint64(3)
+	diff := NewExportDiff()
+	diff.Account[common.Address{0x01}] = &AccountDiff{Nonce: &nonce, Balance: uint256.NewInt(7)}
+	diff.Account[common.Address{0x02}] = nil // deleted
+	diff.Code[common.Hash{0xc0}] = []byte{0x60}
+
+	cpy := diff.Copy()
+	require.Equal(t, diff, cpy)
+	require.Nil(t, cpy.Account[common.Address{0x02}])
+	require.Contains(t, cpy.Account, common.Address{0x02})
+
+	*cpy.Account[common.Address{0x01}].Nonce = 4
+	require.Equal(t, uint64(3), *diff.Account[common.Address{0x01}].Nonce)
+}
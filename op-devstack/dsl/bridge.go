@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
 	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/bridge"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txintent/bindings"
 	"github.com/ethereum-optimism/optimism/op-service/txintent/contractio"
@@ -574,15 +575,13 @@ func (w *Withdrawal) WaitForDisputeGameResolved() {
 
 func (b *StandardBridge) gasCost(rcpt *types.Receipt, client apis.EthClient) eth.ETH {
 	var blockTimestamp *uint64
-	if hasOperatorFee(rcpt) {
+	if bridge.HasOperatorFee(rcpt) {
 		b.require.NotNil(client, "client is required to resolve operator fee timestamp")
 		blockTimestamp = b.receiptTimestamp(rcpt, client)
 	}
-	return gasCost(rcpt, b.rollupCfg, blockTimestamp)
-}
-
-func hasOperatorFee(rcpt *types.Receipt) bool {
-	return rcpt.OperatorFeeConstant != nil && rcpt.OperatorFeeScalar != nil
+	cost, err := bridge.GasCost(rcpt, b.rollupCfg, blockTimestamp)
+	b.require.NoError(err)
+	return cost
 }
 
 func (b *StandardBridge) receiptTimestamp(rcpt *types.Receipt, client apis.EthClient) *uint64 {
@@ -592,28 +591,3 @@ func (b *StandardBridge) receiptTimestamp(rcpt *types.Receipt, client apis.EthCl
 	ts := blockInfo.Time()
 	return &ts
 }
-
-func gasCost(rcpt *types.Receipt, rollupCfg *rollup.Config, blockTimestamp *uint64) eth.ETH {
-	cost := eth.WeiBig(new(big.Int).Mul(new(big.Int).SetUint64(rcpt.GasUsed), rcpt.EffectiveGasPrice))
-	if rcpt.L1Fee != nil {
-		cost = cost.Add(eth.WeiBig(rcpt.L1Fee))
-	}
-	if hasOperatorFee(rcpt) {
-		if rollupCfg == nil {
-			panic("rollup config is required to compute operator fee")
-		}
-		if blockTimestamp == nil {
-			panic("block timestamp is required to compute operator fee")
-		}
-		operatorCost := new(big.Int).SetUint64(rcpt.GasUsed)
-		operatorCost.Mul(operatorCost, new(big.Int).SetUint64(*rcpt.OperatorFeeScalar))
-		if rollupCfg.IsOperatorFeeFix(*blockTimestamp) {
-			operatorCost.Mul(operatorCost, big.NewInt(100))
-		} else {
-			operatorCost.Div(operatorCost, big.NewInt(1_000_000))
-		}
-		operatorCost.Add(operatorCost, new(big.Int).SetUint64(*rcpt.OperatorFeeConstant))
-		cost = cost.Add(eth.WeiBig(operatorCost))
-	}
-	return cost
-}
@@ -0,0 +1,90 @@
+package dsl
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack/match"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FaultInjector injects faults into the nodes of a running system, to test how the system behaves and recovers.
+// Every fault can be undone again, so a test can check both the degraded and the recovered system.
+type FaultInjector struct {
+	commonImpl
+	faults stack.FaultInjectingControlPlane
+}
+
+// NewFaultInjector creates a FaultInjector, and skips the test if the control plane
+// of the system does not support fault injection.
+func NewFaultInjector(t devtest.T, control stack.ControlPlane) *FaultInjector {
+	faults, ok := control.(stack.FaultInjectingControlPlane)
+	if !ok {
+		t.Skip("control plane does not support fault injection")
+	}
+	return &FaultInjector{
+		commonImpl: commonFromT(t),
+		faults:     faults,
+	}
+}
+
+// PartitionELRPC holds back all RPC traffic of the EL node, including the engine API.
+// The node itself keeps running, and stays connected to its P2P peers.
+func (f *FaultInjector) PartitionELRPC(el *L2ELNode) {
+	f.log.Info("Partitioning EL RPC", "id", el.inner.ID())
+	f.faults.L2ELRPCPartition(el.inner.ID(), stack.Stop)
+}
+
+// HealELRPC forwards the RPC traffic held back by PartitionELRPC again.
+func (f *FaultInjector) HealELRPC(el *L2ELNode) {
+	f.log.Info("Healing EL RPC", "id", el.inner.ID())
+	f.faults.L2ELRPCPartition(el.inner.ID(), stack.Start)
+}
+
+// DelayEngine delays every engine API response of the EL node. A zero delay removes the fault.
+func (f *FaultInjector) DelayEngine(el *L2ELNode, delay time.Duration) {
+	f.log.Info("Delaying engine API", "id", el.inner.ID(), "delay", delay)
+	f.faults.L2ELEngineDelay(el.inner.ID(), delay)
+}
+
+// DropELP2P disconnects the EL node from all the EL nodes it is peered with.
+func (f *FaultInjector) DropELP2P(el *L2ELNode) {
+	f.log.Info("Dropping EL p2p", "id", el.inner.ID())
+	f.faults.L2ELP2PState(el.inner.ID(), stack.Stop)
+}
+
+// RestoreELP2P reconnects the EL node to the peers dropped by DropELP2P.
+func (f *FaultInjector) RestoreELP2P(el *L2ELNode) {
+	f.log.Info("Restoring EL p2p", "id", el.inner.ID())
+	f.faults.L2ELP2PState(el.inner.ID(), stack.Start)
+}
+
+// DropCLP2P disconnects the CL node from all its peers, and blocks them from reconnecting.
+func (f *FaultInjector) DropCLP2P(cl *L2CLNode) {
+	f.log.Info("Dropping CL p2p", "id", cl.inner.ID())
+	f.faults.L2CLP2PState(cl.inner.ID(), stack.Stop)
+}
+
+// RestoreCLP2P unblocks and reconnects the peers dropped by DropCLP2P.
+func (f *FaultInjector) RestoreCLP2P(cl *L2CLNode) {
+	f.log.Info("Restoring CL p2p", "id", cl.inner.ID())
+	f.faults.L2CLP2PState(cl.inner.ID(), stack.Start)
+}
+
+// ReorgL1 replaces the latest depth blocks of the L1 chain with blocks of an alternative chain,
+// built by the fakePoS of the first L1 CL node. It returns the first block that was reorged out.
+// Blocks built before the chain is rewound are reorged out as well.
+func (f *FaultInjector) ReorgL1(l1 *L1Network, depth uint64) eth.L1BlockRef {
+	el := l1.Escape().L1ELNode(match.FirstL1EL)
+	cl := l1.Escape().L1CLNode(match.FirstL1CL)
+	head, err := el.EthClient().BlockRefByLabel(f.ctx, eth.Unsafe)
+	f.require.NoError(err)
+	f.require.Greater(head.Number, depth, "L1 chain is too short to reorg")
+	divergence, err := el.EthClient().BlockRefByNumber(f.ctx, head.Number-depth+1)
+	f.require.NoError(err)
+
+	f.log.Info("Reorging L1", "head", head, "depth", depth, "divergence", divergence)
+	f.faults.L1Reorg(el.ID(), cl.ID(), divergence.Number-1)
+	return divergence
+}
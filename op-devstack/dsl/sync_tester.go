@@ -36,6 +36,15 @@ func (s *SyncTester) GetSession(sessionID string) *eth.SyncTesterSession {
 	return session
 }
 
+// NoDivergence checks that the node under test did not diverge from the chain served in the given session.
+func (s *SyncTester) NoDivergence(sessionID string) {
+	session := s.GetSession(sessionID)
+	if div := session.Divergence; div != nil {
+		s.t.Errorf("diverged at block %d (expected %s) in %s: %s, diff: %v", div.BlockNumber, div.ExpectedHash, div.Method, div.Reason, div.Diff)
+		s.t.FailNow()
+	}
+}
+
 func (s *SyncTester) DeleteSession(sessionID string) {
 	err := s.inner.APIWithSession(sessionID).DeleteSession(s.ctx)
 	s.t.Require().NoError(err)
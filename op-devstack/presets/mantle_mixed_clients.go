@@ -0,0 +1,32 @@
+package presets
+
+import (
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-devstack/sysgo"
+)
+
+// MantleMixedClients is a Mantle system of a sequencer and a verifier that can run different EL clients,
+// with a fault injector to disturb the nodes.
+type MantleMixedClients struct {
+	MantleSingleChainMultiNode
+
+	Faults *dsl.FaultInjector
+}
+
+// WithMantleMixedClients runs the sequencer and the verifier on the given EL clients.
+func WithMantleMixedClients(sequencer, verifier sysgo.L2ELKind) stack.CommonOption {
+	return stack.MakeCommon(sysgo.DefaultMantleMixedClientSystem(&sysgo.DefaultMantleSingleChainMultiNodeSystemIDs{}, sysgo.MantleELKinds{
+		Sequencer: sequencer,
+		Verifier:  verifier,
+	}))
+}
+
+func NewMantleMixedClients(t devtest.T) *MantleMixedClients {
+	preset := NewMantleSingleChainMultiNode(t)
+	return &MantleMixedClients{
+		MantleSingleChainMultiNode: *preset,
+		Faults:                     dsl.NewFaultInjector(t, preset.ControlPlane),
+	}
+}
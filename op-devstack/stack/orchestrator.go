@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-devstack/compat"
 	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
 )
@@ -35,6 +37,27 @@ type WipeableControlPlane interface {
 	L2ELNodeWipe(id L2ELNodeID)
 }
 
+// FaultInjectingControlPlane is an optional extension of ControlPlane that supports injecting faults
+// into a running system. Only sysgo implements this, as the faults are injected into in-process
+// proxies and test services.
+type FaultInjectingControlPlane interface {
+	// L2ELRPCPartition cuts off (Stop) or restores (Start) all RPC traffic of the L2 EL node, including the engine API.
+	// The traffic is held back, not dropped, and the node process keeps running with its state and P2P connections,
+	// so this partitions the node from its clients rather than pausing it. Use L2ELNodeState to stop the process.
+	L2ELRPCPartition(id L2ELNodeID, action ControlAction)
+	// L2ELEngineDelay delays every engine API response of the L2 EL node by the given duration.
+	// A zero delay removes the fault.
+	L2ELEngineDelay(id L2ELNodeID, delay time.Duration)
+	// L2ELP2PState drops (Stop) or restores (Start) the P2P connections of the L2 EL node.
+	L2ELP2PState(id L2ELNodeID, action ControlAction)
+	// L2CLP2PState drops (Stop) or restores (Start) the P2P connections of the L2 CL node.
+	// Peers stay blocked while dropped, so they cannot reconnect on their own.
+	L2CLP2PState(id L2CLNodeID, action ControlAction)
+	// L1Reorg rewinds the L1 chain to the given block number, and has fakePoS build an alternative chain on top.
+	// The block must not be older than the finalized L1 block.
+	L1Reorg(elID L1ELNodeID, clID L1CLNodeID, target uint64)
+}
+
 // Orchestrator is the base interface for all system orchestrators.
 // It imposes some common things across all orchestrators, but may also have optional extensions, that not every type of backend might support.
 type Orchestrator interface {
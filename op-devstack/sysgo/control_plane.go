@@ -6,6 +6,8 @@ import (
 
 type ControlPlane struct {
 	o *Orchestrator
+
+	faults faultState
 }
 
 func control(lifecycle stack.Lifecycle, mode stack.ControlAction) {
@@ -0,0 +1,223 @@
+package sysgo
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testutils/tcpproxy"
+)
+
+// proxiedL2ELNode is an L2 EL node that serves its RPCs through TCP proxies,
+// which allows faults to be injected into the RPC traffic of the node.
+type proxiedL2ELNode interface {
+	L2ELNode
+	rpcProxies() (auth, user *tcpproxy.Proxy)
+}
+
+var (
+	_ proxiedL2ELNode = (*OpGeth)(nil)
+	_ proxiedL2ELNode = (*OpReth)(nil)
+)
+
+// faultState tracks the injected faults that need to be undone later.
+type faultState struct {
+	mu sync.Mutex
+	// droppedL2ELPeers are the peers each L2 EL node was connected to before its P2P connections were dropped.
+	droppedL2ELPeers map[stack.L2ELNodeID][]stack.L2ELNodeID
+	// droppedL2CLPeers are the peers each L2 CL node was connected to before its P2P connections were dropped.
+	droppedL2CLPeers map[stack.L2CLNodeID][]*apis.PeerInfo
+}
+
+var _ stack.FaultInjectingControlPlane = (*ControlPlane)(nil)
+
+func (c *ControlPlane) proxiedL2EL(id stack.L2ELNodeID) proxiedL2ELNode {
+	require := c.o.P().Require()
+	s, ok := c.o.l2ELs.Get(id)
+	require.True(ok, "need l2el node to inject fault")
+	n, ok := s.(proxiedL2ELNode)
+	require.Truef(ok, "l2el node %s does not support fault injection", id)
+	return n
+}
+
+func (c *ControlPlane) L2ELRPCPartition(id stack.L2ELNodeID, mode stack.ControlAction) {
+	auth, user := c.proxiedL2EL(id).rpcProxies()
+	for _, proxy := range []*tcpproxy.Proxy{auth, user} {
+		switch mode {
+		case stack.Stop:
+			proxy.Pause()
+		case stack.Start:
+			proxy.Resume()
+		}
+	}
+}
+
+func (c *ControlPlane) L2ELEngineDelay(id stack.L2ELNodeID, delay time.Duration) {
+	auth, _ := c.proxiedL2EL(id).rpcProxies()
+	auth.SetDelay(delay)
+}
+
+func (c *ControlPlane) L2ELP2PState(id stack.L2ELNodeID, mode stack.ControlAction) {
+	require := c.o.P().Require()
+	ctx := c.o.P().Ctx()
+	logger := c.o.P().Logger()
+
+	el, ok := c.o.l2ELs.Get(id)
+	require.True(ok, "need l2el node to change p2p state")
+	rpc1, err := dial.DialRPCClientWithTimeout(ctx, logger, el.UserRPC())
+	require.NoError(err, "failed to connect to el rpc")
+	defer rpc1.Close()
+
+	c.faults.mu.Lock()
+	defer c.faults.mu.Unlock()
+	if c.faults.droppedL2ELPeers == nil {
+		c.faults.droppedL2ELPeers = make(map[stack.L2ELNodeID][]stack.L2ELNodeID)
+	}
+
+	switch mode {
+	case stack.Stop:
+		var peers []peer
+		require.NoError(rpc1.CallContext(ctx, &peers, "admin_peers"), "get peers")
+		var dropped []stack.L2ELNodeID
+		for otherID, other := range c.otherL2ELs(id) {
+			rpc2, err := dial.DialRPCClientWithTimeout(ctx, logger, other.UserRPC())
+			require.NoError(err, "failed to connect to peer el rpc")
+			var otherInfo p2p.NodeInfo
+			require.NoError(rpc2.CallContext(ctx, &otherInfo, "admin_nodeInfo"), "get peer node info")
+			if slices.ContainsFunc(peers, func(p peer) bool { return p.ID == otherInfo.ID }) {
+				// Both sides may dial the other as static peer, so remove the peer on both sides.
+				DisconnectP2P(ctx, require, rpc1, rpc2)
+				DisconnectP2P(ctx, require, rpc2, rpc1)
+				dropped = append(dropped, otherID)
+			}
+			rpc2.Close()
+		}
+		logger.Info("Dropped L2 EL p2p connections", "id", id, "peers", dropped)
+		c.faults.droppedL2ELPeers[id] = append(c.faults.droppedL2ELPeers[id], dropped...)
+	case stack.Start:
+		for _, otherID := range c.faults.droppedL2ELPeers[id] {
+			other, ok := c.o.l2ELs.Get(otherID)
+			require.True(ok, "need peer l2el node to restore p2p connection")
+			rpc2, err := dial.DialRPCClientWithTimeout(ctx, logger, other.UserRPC())
+			require.NoError(err, "failed to connect to peer el rpc")
+			ConnectP2P(ctx, require, rpc1, rpc2)
+			rpc2.Close()
+		}
+		logger.Info("Restored L2 EL p2p connections", "id", id, "peers", c.faults.droppedL2ELPeers[id])
+		delete(c.faults.droppedL2ELPeers, id)
+	}
+}
+
+// otherL2ELs returns the L2 EL nodes of the same chain as the given node, excluding the node itself.
+func (c *ControlPlane) otherL2ELs(id stack.L2ELNodeID) map[stack.L2ELNodeID]L2ELNode {
+	out := make(map[stack.L2ELNodeID]L2ELNode)
+	c.o.l2ELs.Range(func(otherID stack.L2ELNodeID, other L2ELNode) bool {
+		if otherID != id && otherID.ChainID() == id.ChainID() {
+			out[otherID] = other
+		}
+		return true
+	})
+	return out
+}
+
+func (c *ControlPlane) L2CLP2PState(id stack.L2CLNodeID, mode stack.ControlAction) {
+	require := c.o.P().Require()
+	ctx := c.o.P().Ctx()
+	logger := c.o.P().Logger()
+
+	cl, ok := c.o.l2CLs.Get(id)
+	require.True(ok, "need l2cl node to change p2p state")
+	p2pClient, err := GetP2PClient(ctx, logger, cl)
+	require.NoError(err)
+
+	c.faults.mu.Lock()
+	defer c.faults.mu.Unlock()
+	if c.faults.droppedL2CLPeers == nil {
+		c.faults.droppedL2CLPeers = make(map[stack.L2CLNodeID][]*apis.PeerInfo)
+	}
+
+	switch mode {
+	case stack.Stop:
+		peerDump, err := GetPeers(ctx, p2pClient)
+		require.NoError(err)
+		var dropped []*apis.PeerInfo
+		for _, peerInfo := range peerDump.Peers {
+			// The connection gater also rejects inbound connections of blocked peers,
+			// so the peer cannot reconnect until it is unblocked again.
+			require.NoError(p2pClient.BlockPeer(ctx, peerInfo.PeerID), "failed to block peer")
+			require.NoError(p2pClient.DisconnectPeer(ctx, peerInfo.PeerID), "failed to disconnect peer")
+			dropped = append(dropped, peerInfo)
+		}
+		err = wait.For(ctx, time.Second, func() (bool, error) {
+			peerDump, err := GetPeers(ctx, p2pClient)
+			if err != nil {
+				return false, err
+			}
+			return peerDump.TotalConnected == 0, nil
+		})
+		require.NoError(err, "peers were not disconnected")
+		logger.Info("Dropped L2 CL p2p connections", "id", id, "peers", len(dropped))
+		c.faults.droppedL2CLPeers[id] = append(c.faults.droppedL2CLPeers[id], dropped...)
+	case stack.Start:
+		for _, peerInfo := range c.faults.droppedL2CLPeers[id] {
+			require.NoError(p2pClient.UnblockPeer(ctx, peerInfo.PeerID), "failed to unblock peer")
+			require.NotEmpty(peerInfo.Addresses, "peer has no address to reconnect to")
+			err := retry.Do0(ctx, 6, retry.Exponential(), func() error {
+				return p2pClient.ConnectPeer(ctx, peerInfo.Addresses[0])
+			})
+			require.NoError(err, "failed to reconnect L2CL peer")
+		}
+		logger.Info("Restored L2 CL p2p connections", "id", id, "peers", len(c.faults.droppedL2CLPeers[id]))
+		delete(c.faults.droppedL2CLPeers, id)
+	}
+}
+
+func (c *ControlPlane) L1Reorg(elID stack.L1ELNodeID, clID stack.L1CLNodeID, target uint64) {
+	require := c.o.P().Require()
+	ctx := c.o.P().Ctx()
+	logger := c.o.P().Logger()
+
+	el, ok := c.o.l1ELs.Get(elID)
+	require.True(ok, "need l1el node to reorg")
+	cl, ok := c.o.l1CLs.Get(clID)
+	require.True(ok, "need l1cl node to reorg")
+	rpcCl, err := dial.DialRPCClientWithTimeout(ctx, logger, el.UserRPC())
+	require.NoError(err, "failed to connect to l1 el rpc")
+	defer rpcCl.Close()
+
+	// Stop building blocks while the chain is rewound
+	control(cl.fakepos, stack.Stop)
+	defer control(cl.fakepos, stack.Start)
+
+	ethCl := ethclient.NewClient(rpcCl)
+	head, err := ethCl.HeaderByNumber(ctx, nil)
+	require.NoError(err, "failed to get L1 head")
+	finalized, err := ethCl.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	require.NoError(err, "failed to get finalized L1 block")
+	headNum := head.Number.Uint64()
+	require.Lessf(target, headNum, "cannot rewind L1 chain to block %d, head is at %d", target, headNum)
+	require.GreaterOrEqualf(target, finalized.Number.Uint64(), "cannot reorg below the finalized block %d", finalized.Number)
+
+	logger.Info("Rewinding L1 chain", "head", headNum, "target", target)
+	require.NoError(rpcCl.CallContext(ctx, nil, "debug_setHead", hexutil.Uint64(target)))
+	err = wait.For(ctx, 100*time.Millisecond, func() (bool, error) {
+		newHead, err := ethCl.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return false, err
+		}
+		return newHead.Number.Uint64() == target, nil
+	})
+	require.NoError(err, "L1 chain was not rewound")
+	// fakePoS builds different blocks on top of the rewound head, as it draws fresh withdrawals for every block.
+}
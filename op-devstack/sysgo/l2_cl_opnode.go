@@ -74,6 +74,19 @@ func devstackL2ELKind() enginekind.Kind {
 	return enginekind.Geth
 }
 
+// l2ELEngineKind returns the engine kind of the given L2 EL node, so the op-node sync config
+// stays consistent with the EL it drives, also when a system mixes EL clients.
+func l2ELEngineKind(el L2ELNode) enginekind.Kind {
+	switch el.(type) {
+	case *OpReth:
+		return enginekind.Reth
+	case *OpGeth:
+		return enginekind.Geth
+	default:
+		return devstackL2ELKind()
+	}
+}
+
 func (n *OpNode) hydrate(system stack.ExtensibleSystem) {
 	require := system.T().Require()
 	rpcCl, err := client.NewRPC(system.T().Ctx(), system.Logger(), n.userRPC, client.WithLazyDial())
@@ -330,7 +343,7 @@ func WithOpNode(l2CLID stack.L2CLNodeID, l1CLID stack.L1CLNodeID, l1ELID stack.L
 				SyncMode:                       syncMode,
 				SyncModeReqResp:                cfg.UseReqRespSync,
 				SkipSyncStartCheck:             false,
-				SupportsPostFinalizationELSync: devstackL2ELKind().SupportsPostFinalizationELSync(),
+				SupportsPostFinalizationELSync: l2ELEngineKind(l2EL).SupportsPostFinalizationELSync(),
 				UnsafeOnly:                     unsafeOnly,
 				L2FollowSourceEndpoint:         "",
 				NeedInitialResetEngine:         cfg.IsSequencer && unsafeOnly,
@@ -83,12 +83,30 @@ func (l L2ELOptionBundle) Apply(p devtest.P, id stack.L2ELNodeID, cfg *L2ELConfi
 	}
 }
 
-// WithL2ELNode adds the default type of L2 CL node.
+// L2ELKind identifies an L2 EL client implementation.
+type L2ELKind string
+
+const (
+	// L2ELKindDefault selects the kind configured with DEVSTACK_L2EL_KIND, and op-geth otherwise.
+	L2ELKindDefault L2ELKind = ""
+	L2ELKindOpGeth  L2ELKind = "op-geth"
+	L2ELKindOpReth  L2ELKind = "op-reth"
+)
+
+// WithL2ELNode adds the default type of L2 EL node.
 // The default can be configured with DEVSTACK_L2EL_KIND.
-// Tests that depend on specific types can use options like WithKonaNode and WithOpNode directly.
+// Tests that depend on specific types can use WithL2ELNodeKind, or options like WithOpGeth and WithOpReth directly.
 func WithL2ELNode(id stack.L2ELNodeID, opts ...L2ELOption) stack.Option[*Orchestrator] {
-	switch os.Getenv("DEVSTACK_L2EL_KIND") {
-	case "op-reth":
+	return WithL2ELNodeKind(id, L2ELKindDefault, opts...)
+}
+
+// WithL2ELNodeKind adds an L2 EL node of the given kind.
+func WithL2ELNodeKind(id stack.L2ELNodeID, kind L2ELKind, opts ...L2ELOption) stack.Option[*Orchestrator] {
+	if kind == L2ELKindDefault {
+		kind = L2ELKind(os.Getenv(devstackL2ELKindEnv))
+	}
+	switch kind {
+	case L2ELKindOpReth:
 		return WithOpReth(id, opts...)
 	default:
 		return WithOpGeth(id, opts...)
@@ -58,6 +58,12 @@ func (n *OpGeth) JWTPath() string {
 	return n.jwtPath
 }
 
+func (n *OpGeth) rpcProxies() (auth, user *tcpproxy.Proxy) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.authProxy, n.userProxy
+}
+
 func (n *OpGeth) hydrate(system stack.ExtensibleSystem) {
 	require := system.T().Require()
 	rpcCl, err := client.NewRPC(system.T().Ctx(), system.Logger(), n.userRPC, client.WithLazyDial())
@@ -187,6 +187,12 @@ func (n *OpReth) Wipe() {
 	n.p.Require().NoError(os.MkdirAll(n.dataDirPath, 0o755), "failed to recreate data dir")
 }
 
+func (n *OpReth) rpcProxies() (auth, user *tcpproxy.Proxy) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.authProxy, n.userProxy
+}
+
 func (n *OpReth) UserRPC() string {
 	return n.userRPC
 }
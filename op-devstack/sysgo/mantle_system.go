@@ -10,10 +10,10 @@ import (
 
 func DefaultMantleMinimalSystem(dest *DefaultMinimalSystemIDs) stack.Option[*Orchestrator] {
 	ids := NewDefaultMinimalSystemIDs(DefaultL1ID, DefaultL2AID)
-	return defaultMantleMinimalSystemOpts(&ids, dest)
+	return defaultMantleMinimalSystemOpts(&ids, dest, L2ELKindDefault)
 }
 
-func defaultMantleMinimalSystemOpts(ids *DefaultMinimalSystemIDs, dest *DefaultMinimalSystemIDs) stack.CombinedOption[*Orchestrator] {
+func defaultMantleMinimalSystemOpts(ids *DefaultMinimalSystemIDs, dest *DefaultMinimalSystemIDs, elKind L2ELKind) stack.CombinedOption[*Orchestrator] {
 	opt := stack.Combine[*Orchestrator]()
 	opt.Add(stack.BeforeDeploy(func(o *Orchestrator) {
 		o.P().Logger().Info("Setting up")
@@ -36,7 +36,7 @@ func defaultMantleMinimalSystemOpts(ids *DefaultMinimalSystemIDs, dest *DefaultM
 
 	opt.Add(WithL1Nodes(ids.L1EL, ids.L1CL))
 
-	opt.Add(WithL2ELNode(ids.L2EL))
+	opt.Add(WithL2ELNodeKind(ids.L2EL, elKind))
 	opt.Add(WithL2CLNode(ids.L2CL, ids.L1CL, ids.L1EL, ids.L2EL, L2CLSequencer()))
 
 	opt.Add(WithBatcher(ids.L2Batcher, ids.L1EL, ids.L2CL, ids.L2EL))
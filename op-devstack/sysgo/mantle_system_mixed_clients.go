@@ -0,0 +1,34 @@
+package sysgo
+
+import (
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+)
+
+// MantleELKinds selects the EL client of each L2 EL node of a multi-node Mantle system.
+// Kinds left at L2ELKindDefault fall back to DEVSTACK_L2EL_KIND.
+type MantleELKinds struct {
+	Sequencer L2ELKind
+	Verifier  L2ELKind
+}
+
+// DefaultMantleMixedClientSystem is a Mantle system with a sequencer and a P2P-connected verifier,
+// like DefaultMantleSingleChainMultiNodeSystem, but with the EL client of each node chosen explicitly,
+// to check that the Mantle forks behave the same across EL clients.
+func DefaultMantleMixedClientSystem(dest *DefaultMantleSingleChainMultiNodeSystemIDs, kinds MantleELKinds) stack.Option[*Orchestrator] {
+	ids := NewDefaultMantleSingleChainMultiNodeSystemIDs(DefaultL1ID, DefaultL2AID)
+
+	opt := stack.Combine[*Orchestrator]()
+	opt.Add(defaultMantleMinimalSystemOpts(&ids.DefaultMinimalSystemIDs, &dest.DefaultMinimalSystemIDs, kinds.Sequencer))
+
+	opt.Add(WithL2ELNodeKind(ids.L2ELB, kinds.Verifier))
+	opt.Add(WithL2CLNode(ids.L2CLB, ids.L1CL, ids.L1EL, ids.L2ELB))
+
+	// P2P connect L2CL nodes
+	opt.Add(WithL2CLP2PConnection(ids.L2CL, ids.L2CLB))
+	opt.Add(WithL2ELP2PConnection(ids.L2EL, ids.L2ELB))
+
+	opt.Add(stack.Finally(func(orch *Orchestrator) {
+		*dest = ids
+	}))
+	return opt
+}
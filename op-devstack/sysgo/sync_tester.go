@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
 	"github.com/ethereum-optimism/optimism/op-devstack/shim"
 	"github.com/ethereum-optimism/optimism/op-devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -67,26 +68,7 @@ func WithSyncTester(syncTesterID stack.SyncTesterID, l2ELs []stack.L2ELNodeID) s
 			}
 		}
 
-		cfg := &config.Config{
-			RPC: oprpc.CLIConfig{
-				ListenAddr: "127.0.0.1",
-			},
-			SyncTesters: &stconf.Config{
-				SyncTesters: syncTesters,
-			},
-		}
-		logger := p.Logger()
-		srv, err := synctester.FromConfig(p.Ctx(), cfg, logger)
-		require.NoError(err, "must setup sync tester service")
-		require.NoError(srv.Start(p.Ctx()))
-		p.Cleanup(func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			cancel() // force-quit
-			logger.Info("Closing sync tester")
-			_ = srv.Stop(ctx)
-			logger.Info("Closed sync tester")
-		})
-		orch.syncTester = &SyncTesterService{id: syncTesterID, service: srv}
+		startSyncTester(orch, p, syncTesterID, syncTesters)
 	})
 }
 
@@ -107,25 +89,52 @@ func WithSyncTesterWithExternalEndpoint(syncTesterID stack.SyncTesterID, endpoin
 			ChainID: chainID,
 		}
 
-		cfg := &config.Config{
-			RPC: oprpc.CLIConfig{
-				ListenAddr: "127.0.0.1",
-			},
-			SyncTesters: &stconf.Config{
-				SyncTesters: syncTesters,
-			},
+		startSyncTester(orch, p, syncTesterID, syncTesters)
+	})
+}
+
+// WithSyncTesterWithRecording sets up a sync tester that replays a block range recorded with op-sync-tester record,
+// instead of reading from a live EL.
+func WithSyncTesterWithRecording(syncTesterID stack.SyncTesterID, recordingPath string, chainID eth.ChainID) stack.Option[*Orchestrator] {
+	return stack.AfterDeploy(func(orch *Orchestrator) {
+		p := orch.P().WithCtx(stack.ContextWithID(orch.P().Ctx(), syncTesterID))
+
+		require := p.Require()
+
+		require.Nil(orch.syncTester, "can only support a single sync-tester-service in sysgo")
+
+		syncTesters := make(map[sttypes.SyncTesterID]*stconf.SyncTesterEntry)
+
+		id := sttypes.SyncTesterID(fmt.Sprintf("dev-sync-tester-%s", chainID))
+		syncTesters[id] = &stconf.SyncTesterEntry{
+			Recording: recordingPath,
+			ChainID:   chainID,
 		}
-		logger := p.Logger()
-		srv, err := synctester.FromConfig(p.Ctx(), cfg, logger)
-		require.NoError(err, "must setup sync tester service")
-		require.NoError(srv.Start(p.Ctx()))
-		p.Cleanup(func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			cancel() // force-quit
-			logger.Info("Closing sync tester")
-			_ = srv.Stop(ctx)
-			logger.Info("Closed sync tester")
-		})
-		orch.syncTester = &SyncTesterService{id: syncTesterID, service: srv}
+
+		startSyncTester(orch, p, syncTesterID, syncTesters)
+	})
+}
+
+func startSyncTester(orch *Orchestrator, p devtest.P, syncTesterID stack.SyncTesterID, syncTesters map[sttypes.SyncTesterID]*stconf.SyncTesterEntry) {
+	require := p.Require()
+	cfg := &config.Config{
+		RPC: oprpc.CLIConfig{
+			ListenAddr: "127.0.0.1",
+		},
+		SyncTesters: &stconf.Config{
+			SyncTesters: syncTesters,
+		},
+	}
+	logger := p.Logger()
+	srv, err := synctester.FromConfig(p.Ctx(), cfg, logger)
+	require.NoError(err, "must setup sync tester service")
+	require.NoError(srv.Start(p.Ctx()))
+	p.Cleanup(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel() // force-quit
+		logger.Info("Closing sync tester")
+		_ = srv.Stop(ctx)
+		logger.Info("Closed sync tester")
 	})
+	orch.syncTester = &SyncTesterService{id: syncTesterID, service: srv}
 }
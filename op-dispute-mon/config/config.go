@@ -7,6 +7,7 @@ import (
 
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -16,6 +17,7 @@ var (
 	ErrMissingGameFactoryAddress     = errors.New("missing game factory address")
 	ErrMissingRollupAndSupervisorRpc = errors.New("must specify rollup rpc or supervisor rpc")
 	ErrMissingMaxConcurrency         = errors.New("missing max concurrency")
+	ErrInvalidNotifyDedupWindow      = errors.New("notify dedup window must not be negative")
 )
 
 const (
@@ -30,6 +32,14 @@ const (
 
 	//DefaultMaxConcurrency is the default number of threads to use when fetching game data
 	DefaultMaxConcurrency = uint(5)
+
+	// DefaultNotifyDedupWindow is the default period during which repeated notifications
+	// for the same condition are suppressed.
+	DefaultNotifyDedupWindow = time.Hour
+	// DefaultNotifyPagerDutyURL is the PagerDuty Events API v2 endpoint.
+	DefaultNotifyPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	// DefaultNotifyMaxPerMinute is the default maximum number of notifications sent per minute.
+	DefaultNotifyMaxPerMinute = uint(30)
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -46,8 +56,18 @@ type Config struct {
 	IgnoredGames    []common.Address // Games to exclude from monitoring
 	MaxConcurrency  uint             // Maximum number of threads to use when fetching game data
 
+	NotifyWebhookURLs         []string      // Webhook URLs to post JSON notification events to
+	NotifyFile                string        // Path to a local journal file to append notification events to
+	NotifyPagerDutyURL        string        // PagerDuty Events API v2 compatible endpoint
+	NotifyPagerDutyRoutingKey string        // PagerDuty routing key. PagerDuty notifications are disabled if empty
+	NotifyDedupWindow         time.Duration // Period during which duplicate notifications are suppressed
+	NotifyMaxPerMinute        uint          // Maximum notifications sent per minute, 0 for no limit
+
+	HistoryDBPath string // Path to the game history database. History is not recorded if empty.
+
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	RPCConfig     oprpc.CLIConfig // Serves the game history. Ignored, and no RPC server started, if HistoryDBPath is empty.
 }
 
 func NewInteropConfig(gameFactoryAddress common.Address, l1EthRpc string, supervisorRpcs []string) Config {
@@ -69,8 +89,13 @@ func NewCombinedConfig(gameFactoryAddress common.Address, l1EthRpc string, rollu
 		GameWindow:      DefaultGameWindow,
 		MaxConcurrency:  DefaultMaxConcurrency,
 
+		NotifyPagerDutyURL: DefaultNotifyPagerDutyURL,
+		NotifyDedupWindow:  DefaultNotifyDedupWindow,
+		NotifyMaxPerMinute: DefaultNotifyMaxPerMinute,
+
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
+		RPCConfig:     oprpc.DefaultCLIConfig(),
 	}
 }
 
@@ -87,11 +112,17 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMissingMaxConcurrency
 	}
+	if c.NotifyDedupWindow < 0 {
+		return ErrInvalidNotifyDedupWindow
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return fmt.Errorf("metrics config: %w", err)
 	}
 	if err := c.PprofConfig.Check(); err != nil {
 		return fmt.Errorf("pprof config: %w", err)
 	}
+	if err := c.RPCConfig.Check(); err != nil {
+		return fmt.Errorf("rpc config: %w", err)
+	}
 	return nil
 }
@@ -95,3 +95,9 @@ func TestCombinedConfig(t *testing.T) {
 	require.Equal(t, supervisorRpcs, config.SupervisorRpcs)
 	require.NoError(t, config.Check())
 }
+
+func TestNotifyDedupWindowMustNotBeNegative(t *testing.T) {
+	config := validConfig()
+	config.NotifyDedupWindow = -1
+	require.ErrorIs(t, config.Check(), ErrInvalidNotifyDedupWindow)
+}
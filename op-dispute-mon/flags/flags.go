@@ -14,6 +14,7 @@ import (
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -82,6 +83,46 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   config.DefaultMaxConcurrency,
 	}
+	NotifyWebhookFlag = &cli.StringSliceFlag{
+		Name:    "notify.webhook",
+		Usage:   "Webhook URLs to post JSON notification events to. Multiple URLs can be specified.",
+		EnvVars: prefixEnvVars("NOTIFY_WEBHOOK"),
+	}
+	NotifyFileFlag = &cli.StringFlag{
+		Name:    "notify.file",
+		Usage:   "Path to a local journal file that notification events are appended to as JSON lines.",
+		EnvVars: prefixEnvVars("NOTIFY_FILE"),
+	}
+	NotifyPagerDutyURLFlag = &cli.StringFlag{
+		Name:    "notify.pagerduty-url",
+		Usage:   "Endpoint accepting PagerDuty Events API v2 payloads.",
+		EnvVars: prefixEnvVars("NOTIFY_PAGERDUTY_URL"),
+		Value:   config.DefaultNotifyPagerDutyURL,
+	}
+	NotifyPagerDutyRoutingKeyFlag = &cli.StringFlag{
+		Name:    "notify.pagerduty-routing-key",
+		Usage:   "PagerDuty routing key. PagerDuty notifications are only sent when set.",
+		EnvVars: prefixEnvVars("NOTIFY_PAGERDUTY_ROUTING_KEY"),
+	}
+	NotifyDedupWindowFlag = &cli.DurationFlag{
+		Name:    "notify.dedup-window",
+		Usage:   "Period during which repeated notifications for the same condition are suppressed. Notifications for resolved games are only sent once.",
+		EnvVars: prefixEnvVars("NOTIFY_DEDUP_WINDOW"),
+		Value:   config.DefaultNotifyDedupWindow,
+	}
+	NotifyMaxPerMinuteFlag = &cli.UintFlag{
+		Name:    "notify.max-per-minute",
+		Usage:   "Maximum number of notifications sent per minute. Set to 0 to disable rate limiting.",
+		EnvVars: prefixEnvVars("NOTIFY_MAX_PER_MINUTE"),
+		Value:   config.DefaultNotifyMaxPerMinute,
+	}
+	HistoryDBFlag = &cli.StringFlag{
+		Name: "history-db",
+		Usage: "Path to the database used to record the history of monitored games. " +
+			"When set, history is served via the disputemon RPC namespace on --rpc.addr and --rpc.port. " +
+			"History is not recorded, and the RPC flags are ignored, if unset.",
+		EnvVars: prefixEnvVars("HISTORY_DB"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -100,12 +141,20 @@ var optionalFlags = []cli.Flag{
 	GameWindowFlag,
 	IgnoredGamesFlag,
 	MaxConcurrencyFlag,
+	NotifyWebhookFlag,
+	NotifyFileFlag,
+	NotifyPagerDutyURLFlag,
+	NotifyPagerDutyRoutingKeyFlag,
+	NotifyDedupWindowFlag,
+	NotifyMaxPerMinuteFlag,
+	HistoryDBFlag,
 }
 
 func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -164,6 +213,7 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
+	rpcConfig := oprpc.ReadCLIConfig(ctx)
 
 	return &config.Config{
 		L1EthRpc:           ctx.String(L1EthRpcFlag.Name),
@@ -177,7 +227,17 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		IgnoredGames:    ignoredGames,
 		MaxConcurrency:  maxConcurrency,
 
+		NotifyWebhookURLs:         ctx.StringSlice(NotifyWebhookFlag.Name),
+		NotifyFile:                ctx.String(NotifyFileFlag.Name),
+		NotifyPagerDutyURL:        ctx.String(NotifyPagerDutyURLFlag.Name),
+		NotifyPagerDutyRoutingKey: ctx.String(NotifyPagerDutyRoutingKeyFlag.Name),
+		NotifyDedupWindow:         ctx.Duration(NotifyDedupWindowFlag.Name),
+		NotifyMaxPerMinute:        ctx.Uint(NotifyMaxPerMinuteFlag.Name),
+
+		HistoryDBPath: ctx.String(HistoryDBFlag.Name),
+
 		MetricsConfig: metricsConfig,
 		PprofConfig:   pprofConfig,
+		RPCConfig:     rpcConfig,
 	}, nil
 }
@@ -197,6 +197,10 @@ type Metricer interface {
 
 	RecordOldestGameUpdateTime(t time.Time)
 
+	RecordNotification(sink string, success bool)
+
+	RecordNotificationSuppressed(reason string)
+
 	caching.Metrics
 	contractMetrics.ContractMetricer
 	opmetrics.RPCMetricer
@@ -249,6 +253,9 @@ type Metrics struct {
 	mixedAvailabilityGames     prometheus.Gauge
 	mixedSafetyGames           prometheus.Gauge
 	differentOutputRootGames   prometheus.Gauge
+
+	notifications           prometheus.CounterVec
+	notificationsSuppressed prometheus.CounterVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -446,6 +453,21 @@ func NewMetrics() *Metrics {
 			Name:      "different_output_root_games",
 			Help:      "Number of games where rollup nodes returned different output roots for the same L2 block in the last update cycle",
 		}),
+		notifications: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "notifications_total",
+			Help:      "Number of notifications delivered to each sink, broken down by whether delivery succeeded",
+		}, []string{
+			"sink",
+			"result",
+		}),
+		notificationsSuppressed: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "notifications_suppressed_total",
+			Help:      "Number of notifications not delivered because they were duplicates or exceeded the rate limit",
+		}, []string{
+			"reason",
+		}),
 	}
 }
 
@@ -622,6 +644,18 @@ func (m *Metrics) RecordBondCollateral(addr common.Address, required, available
 	m.availableCollateral.WithLabelValues(addr.Hex(), zeroBalanceLabel).Set(0)
 }
 
+func (m *Metrics) RecordNotification(sink string, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	m.notifications.WithLabelValues(sink, result).Inc()
+}
+
+func (m *Metrics) RecordNotificationSuppressed(reason string) {
+	m.notificationsSuppressed.WithLabelValues(reason).Inc()
+}
+
 func (m *Metrics) RecordL2Challenges(agreement bool, count int) {
 	agree := "disagree"
 	if agreement {
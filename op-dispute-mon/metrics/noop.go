@@ -65,3 +65,7 @@ func (*NoopMetricsImpl) RecordMixedAvailabilityGames(_ int) {}
 func (*NoopMetricsImpl) RecordMixedSafetyGames(_ int) {}
 
 func (*NoopMetricsImpl) RecordDifferentOutputRootGames(_ int) {}
+
+func (*NoopMetricsImpl) RecordNotification(_ string, _ bool) {}
+
+func (*NoopMetricsImpl) RecordNotificationSuppressed(_ string) {}
@@ -0,0 +1,140 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/notify"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/transform"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, events []notify.Event)
+}
+
+// AlertMonitor converts the state of the monitored games into notification events.
+type AlertMonitor struct {
+	ctx          context.Context
+	clock        RClock
+	honestActors types.HonestActors
+	notifier     Notifier
+}
+
+func NewAlertMonitor(ctx context.Context, clock RClock, honestActors types.HonestActors, notifier Notifier) *AlertMonitor {
+	return &AlertMonitor{
+		ctx:          ctx,
+		clock:        clock,
+		honestActors: honestActors,
+		notifier:     notifier,
+	}
+}
+
+func (a *AlertMonitor) CheckAlerts(games []*types.EnrichedGameData) {
+	now := a.clock.Now()
+	var events []notify.Event
+	for _, game := range games {
+		events = append(events, a.honestActorEvents(game)...)
+		if event, ok := a.forecastEvent(game); ok {
+			events = append(events, event)
+		}
+		if game.RollupEndpointOutOfSyncCount > 0 {
+			events = append(events, notify.Event{
+				Type:     notify.EventNodeOutOfSync,
+				Severity: notify.SeverityWarning,
+				Subject:  game.Proxy,
+				Summary:  fmt.Sprintf("%v rollup node endpoints out of sync checking game %v", game.RollupEndpointOutOfSyncCount, game.Proxy),
+				Details: map[string]string{
+					"outOfSyncCount":   strconv.Itoa(game.RollupEndpointOutOfSyncCount),
+					"totalCount":       strconv.Itoa(game.RollupEndpointTotalCount),
+					"l1HeadNum":        strconv.FormatUint(game.L1HeadNum, 10),
+					"l2SequenceNumber": strconv.FormatUint(game.L2SequenceNumber, 10),
+				},
+			})
+		}
+	}
+	for addr, collateral := range bonds.CalculateRequiredCollateral(games) {
+		if collateral.Actual == nil || collateral.Required.Cmp(collateral.Actual) <= 0 {
+			continue
+		}
+		events = append(events, notify.Event{
+			Type:     notify.EventBondAtRisk,
+			Severity: notify.SeverityCritical,
+			Subject:  addr,
+			Summary:  fmt.Sprintf("Insufficient collateral in DelayedWETH %v", addr),
+			Details: map[string]string{
+				"required": collateral.Required.String(),
+				"actual":   collateral.Actual.String(),
+			},
+		})
+	}
+	for i := range events {
+		events[i].Timestamp = now
+	}
+	a.notifier.Notify(a.ctx, events)
+}
+
+func (a *AlertMonitor) honestActorEvents(game *types.EnrichedGameData) []notify.Event {
+	var events []notify.Event
+	for _, claim := range game.Claims {
+		if !claim.Resolved || !a.honestActors.Contains(claim.Claimant) || claim.CounteredBy == (common.Address{}) {
+			continue
+		}
+		events = append(events, notify.Event{
+			Type:     notify.EventResolvedAgainstHonestActor,
+			Severity: notify.SeverityCritical,
+			Subject:  game.Proxy,
+			Key:      strconv.Itoa(claim.ContractIndex),
+			// Resolved claims can not be resolved again
+			Final:   true,
+			Summary: fmt.Sprintf("Claim %v in game %v resolved against honest actor %v", claim.ContractIndex, game.Proxy, claim.Claimant),
+			Details: map[string]string{
+				"honestActor":        claim.Claimant.Hex(),
+				"counteredBy":        claim.CounteredBy.Hex(),
+				"claimContractIndex": strconv.Itoa(claim.ContractIndex),
+				"bond":               claim.Bond.String(),
+			},
+		})
+	}
+	return events
+}
+
+func (a *AlertMonitor) forecastEvent(game *types.EnrichedGameData) (notify.Event, bool) {
+	expected := gameTypes.GameStatusDefenderWon
+	if !game.AgreeWithClaim {
+		expected = gameTypes.GameStatusChallengerWon
+	}
+	actual := game.Status
+	severity := notify.SeverityCritical
+	if actual == gameTypes.GameStatusInProgress {
+		severity = notify.SeverityError
+		if game.BlockNumberChallenged {
+			actual = gameTypes.GameStatusChallengerWon
+		} else {
+			actual = Resolve(transform.CreateBidirectionalTree(game.Claims))
+		}
+	}
+	if actual == expected {
+		return notify.Event{}, false
+	}
+	return notify.Event{
+		Type:     notify.EventForecastDisagrees,
+		Severity: severity,
+		Subject:  game.Proxy,
+		Key:      game.Status.String(),
+		Final:    game.Status != gameTypes.GameStatusInProgress,
+		Summary:  fmt.Sprintf("Game %v expected %v but forecast %v", game.Proxy, expected, actual),
+		Details: map[string]string{
+			"status":            game.Status.String(),
+			"expected":          expected.String(),
+			"forecast":          actual.String(),
+			"rootClaim":         game.RootClaim.Hex(),
+			"expectedRootClaim": game.ExpectedRootClaim.Hex(),
+			"l2SequenceNumber":  strconv.FormatUint(game.L2SequenceNumber, 10),
+		},
+	}, true
+}
@@ -0,0 +1,167 @@
+package mon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/notify"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alertHonestActor = common.Address{0xaa}
+	alertGame        = common.Address{0x11}
+)
+
+func TestCheckAlerts_NoEvents(t *testing.T) {
+	monitor, notifier := setupAlertMonitorTest(t)
+	monitor.CheckAlerts([]*types.EnrichedGameData{
+		{
+			GameMetadata:   gameTypes.GameMetadata{Proxy: alertGame},
+			Status:         gameTypes.GameStatusDefenderWon,
+			AgreeWithClaim: true,
+		},
+	})
+	require.Len(t, notifier.events, 0)
+}
+
+func TestCheckAlerts_ResolvedAgainstHonestActor(t *testing.T) {
+	monitor, notifier := setupAlertMonitorTest(t)
+	claims := createBondedClaimList()
+	claims[1].Claimant = alertHonestActor
+	claims[1].Resolved = true
+	claims[1].Bond = big.NewInt(100)
+	claims[1].CounteredBy = common.Address{0xbb}
+	monitor.CheckAlerts([]*types.EnrichedGameData{
+		{
+			GameMetadata:   gameTypes.GameMetadata{Proxy: alertGame},
+			Status:         gameTypes.GameStatusInProgress,
+			AgreeWithClaim: true,
+			Claims:         claims,
+		},
+	})
+	event := notifier.requireSingleEvent(t, notify.EventResolvedAgainstHonestActor)
+	require.Equal(t, alertGame, event.Subject)
+	require.Equal(t, "1", event.Key)
+	require.Equal(t, alertHonestActor.Hex(), event.Details["honestActor"])
+	require.Equal(t, "100", event.Details["bond"])
+	require.True(t, event.Final)
+}
+
+func TestCheckAlerts_BondAtRisk(t *testing.T) {
+	monitor, notifier := setupAlertMonitorTest(t)
+	weth := common.Address{0xee}
+	monitor.CheckAlerts([]*types.EnrichedGameData{
+		{
+			GameMetadata:   gameTypes.GameMetadata{Proxy: alertGame},
+			Status:         gameTypes.GameStatusDefenderWon,
+			AgreeWithClaim: true,
+			WETHContract:   weth,
+			ETHCollateral:  big.NewInt(5),
+			Credits:        map[common.Address]*big.Int{{0x01}: big.NewInt(10)},
+		},
+	})
+	event := notifier.requireSingleEvent(t, notify.EventBondAtRisk)
+	require.Equal(t, weth, event.Subject)
+	require.Equal(t, "10", event.Details["required"])
+	require.Equal(t, "5", event.Details["actual"])
+}
+
+func TestCheckAlerts_NodeOutOfSync(t *testing.T) {
+	monitor, notifier := setupAlertMonitorTest(t)
+	monitor.CheckAlerts([]*types.EnrichedGameData{
+		{
+			GameMetadata:                 gameTypes.GameMetadata{Proxy: alertGame},
+			Status:                       gameTypes.GameStatusDefenderWon,
+			AgreeWithClaim:               true,
+			RollupEndpointOutOfSyncCount: 2,
+			RollupEndpointTotalCount:     3,
+		},
+	})
+	event := notifier.requireSingleEvent(t, notify.EventNodeOutOfSync)
+	require.Equal(t, "2", event.Details["outOfSyncCount"])
+	require.Equal(t, "3", event.Details["totalCount"])
+}
+
+func TestCheckAlerts_ForecastDisagrees(t *testing.T) {
+	t.Run("InProgress", func(t *testing.T) {
+		monitor, notifier := setupAlertMonitorTest(t)
+		monitor.CheckAlerts([]*types.EnrichedGameData{
+			{
+				GameMetadata:   gameTypes.GameMetadata{Proxy: alertGame},
+				Status:         gameTypes.GameStatusInProgress,
+				AgreeWithClaim: false,
+				Claims:         createBondedClaimList(),
+			},
+		})
+		event := notifier.requireSingleEvent(t, notify.EventForecastDisagrees)
+		require.Equal(t, notify.SeverityError, event.Severity)
+		require.Equal(t, gameTypes.GameStatusDefenderWon.String(), event.Details["forecast"])
+	})
+
+	t.Run("InProgressBlockNumberChallenged", func(t *testing.T) {
+		monitor, notifier := setupAlertMonitorTest(t)
+		monitor.CheckAlerts([]*types.EnrichedGameData{
+			{
+				GameMetadata:          gameTypes.GameMetadata{Proxy: alertGame},
+				Status:                gameTypes.GameStatusInProgress,
+				AgreeWithClaim:        true,
+				BlockNumberChallenged: true,
+				Claims:                createBondedClaimList(),
+			},
+		})
+		event := notifier.requireSingleEvent(t, notify.EventForecastDisagrees)
+		require.Equal(t, gameTypes.GameStatusChallengerWon.String(), event.Details["forecast"])
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		monitor, notifier := setupAlertMonitorTest(t)
+		monitor.CheckAlerts([]*types.EnrichedGameData{
+			{
+				GameMetadata:   gameTypes.GameMetadata{Proxy: alertGame},
+				Status:         gameTypes.GameStatusChallengerWon,
+				AgreeWithClaim: true,
+			},
+		})
+		event := notifier.requireSingleEvent(t, notify.EventForecastDisagrees)
+		require.Equal(t, notify.SeverityCritical, event.Severity)
+		require.Equal(t, gameTypes.GameStatusDefenderWon.String(), event.Details["expected"])
+		require.True(t, event.Final, "resolved games can not change outcome")
+	})
+}
+
+func setupAlertMonitorTest(t *testing.T) (*AlertMonitor, *stubNotifier) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	notifier := &stubNotifier{}
+	monitor := NewAlertMonitor(context.Background(), cl, types.NewHonestActors([]common.Address{alertHonestActor}), notifier)
+	return monitor, notifier
+}
+
+func createBondedClaimList() []types.EnrichedClaim {
+	claims := createDeepClaimList()
+	for i := range claims {
+		claims[i].Bond = big.NewInt(0)
+	}
+	return claims
+}
+
+type stubNotifier struct {
+	events []notify.Event
+}
+
+func (s *stubNotifier) Notify(_ context.Context, events []notify.Event) {
+	s.events = append(s.events, events...)
+}
+
+func (s *stubNotifier) requireSingleEvent(t *testing.T, eventType notify.EventType) notify.Event {
+	require.Len(t, s.events, 1)
+	require.Equal(t, eventType, s.events[0].Type)
+	require.Equal(t, time.Unix(1000, 0), s.events[0].Timestamp)
+	return s.events[0]
+}
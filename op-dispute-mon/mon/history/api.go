@@ -0,0 +1,76 @@
+package history
+
+import (
+	"context"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// MaxListGames is the maximum number of games returned from a single ListGames request.
+const MaxListGames = 1000
+
+type QueryStore interface {
+	Game(game common.Address) (*GameSnapshot, error)
+	Timeline(ctx context.Context, game common.Address) ([]TimelineEvent, error)
+	GamesByParticipant(ctx context.Context, participant common.Address) ([]*GameSnapshot, error)
+	GamesInRange(ctx context.Context, from uint64, to uint64, after *common.Address, limit int) ([]*GameSnapshot, error)
+}
+
+// API serves historical game data over JSON-RPC.
+type API struct {
+	store QueryStore
+}
+
+func NewAPI(store QueryStore) *API {
+	return &API{store: store}
+}
+
+func GetAPI(api *API) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "disputemon",
+		Service:   api,
+	}
+}
+
+// ListGames returns games created with a timestamp in the range [from, to]. If to is 0, no upper bound is applied.
+// Results are limited to MaxListGames games. To request the next page, pass the timestamp and address of the
+// last game returned as from and after: the cursor is exclusive, so the game is not returned again.
+func (a *API) ListGames(ctx context.Context, from hexutil.Uint64, to hexutil.Uint64, after *common.Address) ([]*GameSnapshot, error) {
+	upper := uint64(to)
+	if upper == 0 {
+		upper = math.MaxUint64
+	}
+	return a.store.GamesInRange(ctx, uint64(from), upper, after, MaxListGames)
+}
+
+func (a *API) GetGame(_ context.Context, game common.Address) (*GameSnapshot, error) {
+	return a.store.Game(game)
+}
+
+func (a *API) GetGameTimeline(ctx context.Context, game common.Address) ([]TimelineEvent, error) {
+	if _, err := a.store.Game(game); err != nil {
+		return nil, err
+	}
+	return a.store.Timeline(ctx, game)
+}
+
+// GetGamesByAddress returns every recorded game the address posted, countered or is owed a bond in.
+func (a *API) GetGamesByAddress(ctx context.Context, addr common.Address) ([]*GameSnapshot, error) {
+	return a.store.GamesByParticipant(ctx, addr)
+}
+
+// GetBondFlows returns the bonds posted, refunded, won and lost by the address across all recorded games.
+func (a *API) GetBondFlows(ctx context.Context, addr common.Address) ([]BondFlow, error) {
+	games, err := a.store.GamesByParticipant(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	flows := make([]BondFlow, 0)
+	for _, game := range games {
+		flows = append(flows, game.BondFlows(addr)...)
+	}
+	return flows, nil
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ListGames(t *testing.T) {
+	recorder, db, _ := setupRecorderTest(t)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{
+		createGame(common.Address{0x03}, 300),
+		createGame(common.Address{0x01}, 100),
+		createGame(common.Address{0x02}, 200),
+	})
+	api := NewAPI(db)
+
+	games, err := api.ListGames(context.Background(), 0, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{0x01}, {0x02}, {0x03}}, proxies(games))
+
+	games, err = api.ListGames(context.Background(), 150, 300, nil)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{0x02}, {0x03}}, proxies(games))
+
+	games, err = api.ListGames(context.Background(), 150, 299, nil)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{0x02}}, proxies(games))
+}
+
+func TestAPI_ListGamesPagination(t *testing.T) {
+	recorder, db, _ := setupRecorderTest(t)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{
+		createGame(common.Address{0x01}, 100),
+		createGame(common.Address{0x02}, 200),
+		createGame(common.Address{0x03}, 200),
+		createGame(common.Address{0x04}, 300),
+	})
+	api := NewAPI(db)
+
+	// The cursor is exclusive, and games with the same timestamp as the cursor are not skipped
+	last := common.Address{0x02}
+	games, err := api.ListGames(context.Background(), 200, 0, &last)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{0x03}, {0x04}}, proxies(games))
+
+	last = common.Address{0x04}
+	games, err = api.ListGames(context.Background(), 300, 0, &last)
+	require.NoError(t, err)
+	require.Empty(t, games)
+}
+
+func TestAPI_GetGame(t *testing.T) {
+	recorder, db, _ := setupRecorderTest(t)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{createGame(gameAddr, 100)})
+	api := NewAPI(db)
+
+	game, err := api.GetGame(context.Background(), gameAddr)
+	require.NoError(t, err)
+	require.Equal(t, gameAddr, game.Proxy)
+
+	_, err = api.GetGame(context.Background(), common.Address{0xff})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	timeline, err := api.GetGameTimeline(context.Background(), gameAddr)
+	require.NoError(t, err)
+	require.Len(t, timeline, 2)
+
+	_, err = api.GetGameTimeline(context.Background(), common.Address{0xff})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAPI_BondFlows(t *testing.T) {
+	recorder, db, _ := setupRecorderTest(t)
+	// Honest actor's root claim is refunded in the first game
+	refunded := createGame(common.Address{0x01}, 100)
+	refunded.Claims[0].Resolved = true
+	refunded.Status = gameTypes.GameStatusDefenderWon
+
+	// Honest actor counters a dishonest root claim in the second game
+	won := createGame(common.Address{0x02}, 200)
+	won.Claims[0].Claimant = dishonest
+	won.Claims[0].CounteredBy = honestActor
+	won.Claims[0].Resolved = true
+	won.Claims = append(won.Claims, monTypes.EnrichedClaim{
+		Claim: faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{
+				Bond:     big.NewInt(20),
+				Position: faultTypes.NewPosition(1, big.NewInt(0)),
+			},
+			Claimant:            honestActor,
+			ContractIndex:       1,
+			ParentContractIndex: 0,
+		},
+		Resolved: true,
+	})
+	recorder.RecordGames([]*monTypes.EnrichedGameData{refunded, won, createGame(common.Address{0x03}, 300)})
+	api := NewAPI(db)
+
+	flows, err := api.GetBondFlows(context.Background(), dishonest)
+	require.NoError(t, err)
+	require.Equal(t, []BondFlow{
+		{Game: common.Address{0x02}, ClaimIndex: 0, Kind: BondPosted, Amount: (*hexutil.Big)(big.NewInt(10))},
+		{Game: common.Address{0x02}, ClaimIndex: 0, Kind: BondLost, Amount: (*hexutil.Big)(big.NewInt(10))},
+	}, flows)
+
+	flows, err = api.GetBondFlows(context.Background(), honestActor)
+	require.NoError(t, err)
+	require.Equal(t, []BondFlow{
+		{Game: common.Address{0x01}, ClaimIndex: 0, Kind: BondPosted, Amount: (*hexutil.Big)(big.NewInt(10))},
+		{Game: common.Address{0x01}, ClaimIndex: 0, Kind: BondRefunded, Amount: (*hexutil.Big)(big.NewInt(10))},
+		{Game: common.Address{0x02}, ClaimIndex: 0, Kind: BondWon, Amount: (*hexutil.Big)(big.NewInt(10))},
+		{Game: common.Address{0x02}, ClaimIndex: 1, Kind: BondPosted, Amount: (*hexutil.Big)(big.NewInt(20))},
+		{Game: common.Address{0x02}, ClaimIndex: 1, Kind: BondRefunded, Amount: (*hexutil.Big)(big.NewInt(20))},
+		// Third game is in progress so only the posted bond is reported
+		{Game: common.Address{0x03}, ClaimIndex: 0, Kind: BondPosted, Amount: (*hexutil.Big)(big.NewInt(10))},
+	}, flows)
+
+	games, err := api.GetGamesByAddress(context.Background(), dishonest)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{0x02}}, proxies(games))
+
+	flows, err = api.GetBondFlows(context.Background(), common.Address{0xff})
+	require.NoError(t, err)
+	require.Empty(t, flows)
+}
+
+func proxies(games []*GameSnapshot) []common.Address {
+	result := make([]common.Address, len(games))
+	for i, game := range games {
+		result[i] = game.Proxy
+	}
+	return result
+}
@@ -0,0 +1,272 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+	ErrClosed       = errors.New("db closed")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixGame byte = iota
+	keyPrefixTimeline
+	keyPrefixParticipant
+	keyPrefixGameByTimestamp
+)
+
+// gameKey: prefix | game address
+func gameKey(game common.Address) []byte {
+	key := make([]byte, 0, 1+common.AddressLength)
+	key = append(key, keyPrefixGame)
+	return append(key, game.Bytes()...)
+}
+
+// timelineKey: prefix | game address | sequence number
+func timelineKey(game common.Address, seq uint64) []byte {
+	key := make([]byte, 0, 1+common.AddressLength+8)
+	key = append(key, keyPrefixTimeline)
+	key = append(key, game.Bytes()...)
+	return binary.BigEndian.AppendUint64(key, seq)
+}
+
+// participantKey: prefix | participant address | game address
+func participantKey(participant common.Address, game common.Address) []byte {
+	key := make([]byte, 0, 1+2*common.AddressLength)
+	key = append(key, keyPrefixParticipant)
+	key = append(key, participant.Bytes()...)
+	return append(key, game.Bytes()...)
+}
+
+// gameByTimestampKey: prefix | game creation timestamp | game address
+func gameByTimestampKey(timestamp uint64, game common.Address) []byte {
+	key := make([]byte, 0, 1+8+common.AddressLength)
+	key = append(key, keyPrefixGameByTimestamp)
+	key = binary.BigEndian.AppendUint64(key, timestamp)
+	return append(key, game.Bytes()...)
+}
+
+// prefixUpperBound returns the smallest key greater than all keys starting with prefix.
+func prefixUpperBound(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// GameDB is a pebble backed store of game snapshots, their timelines and an index of participants.
+type GameDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+func NewGameDB(logger log.Logger, path string) (*GameDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &GameDB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+// StoreGame writes the snapshot, appends the timeline events and indexes the game's participants in a single batch.
+// The snapshot's TimelineLength must already include the new events.
+func (d *GameDB) StoreGame(snapshot *GameSnapshot, events []TimelineEvent) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode game snapshot: %w", err)
+	}
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(gameKey(snapshot.Proxy), data, d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record game snapshot: %w", err)
+	}
+	if err := batch.Set(gameByTimestampKey(snapshot.Timestamp, snapshot.Proxy), nil, d.writeOpts); err != nil {
+		return fmt.Errorf("failed to index game timestamp: %w", err)
+	}
+	for _, participant := range snapshot.Participants() {
+		if err := batch.Set(participantKey(participant, snapshot.Proxy), nil, d.writeOpts); err != nil {
+			return fmt.Errorf("failed to index participant %v: %w", participant, err)
+		}
+	}
+	firstSeq := snapshot.TimelineLength - uint64(len(events))
+	for i, event := range events {
+		eventData, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode timeline event: %w", err)
+		}
+		if err := batch.Set(timelineKey(snapshot.Proxy, firstSeq+uint64(i)), eventData, d.writeOpts); err != nil {
+			return fmt.Errorf("failed to record timeline event: %w", err)
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit game snapshot: %w", err)
+	}
+	return nil
+}
+
+// Game returns the latest snapshot of the specified game or ErrNotFound.
+func (d *GameDB) Game(game common.Address) (*GameSnapshot, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	return d.game(game)
+}
+
+func (d *GameDB) game(game common.Address) (*GameSnapshot, error) {
+	data, closer, err := d.db.Get(gameKey(game))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read game %v: %w", game, err)
+	}
+	defer closer.Close()
+	var snapshot GameSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	return &snapshot, nil
+}
+
+// Timeline returns the timeline events recorded for the specified game in the order they were recorded.
+func (d *GameDB) Timeline(ctx context.Context, game common.Address) ([]TimelineEvent, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	prefix := timelineKey(game, 0)[:1+common.AddressLength]
+	var events []TimelineEvent
+	err := d.iterate(ctx, prefix, prefixUpperBound(prefix), func(_ []byte, val []byte) (bool, error) {
+		var event TimelineEvent
+		if err := json.Unmarshal(val, &event); err != nil {
+			return false, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+		}
+		events = append(events, event)
+		return true, nil
+	})
+	return events, err
+}
+
+// GamesByParticipant returns snapshots of all games the address participated in.
+func (d *GameDB) GamesByParticipant(ctx context.Context, participant common.Address) ([]*GameSnapshot, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	prefix := participantKey(participant, common.Address{})[:1+common.AddressLength]
+	return d.gamesFromIndex(ctx, prefix, prefixUpperBound(prefix), math.MaxInt)
+}
+
+// GamesInRange returns snapshots of games created with a timestamp in [from, to], ordered by creation time
+// and game address. If after is not nil, only the games ordered after the game created at from with that address
+// are returned, so the last game of a page can be used as exclusive cursor for the next page.
+// At most limit games are returned.
+func (d *GameDB) GamesInRange(ctx context.Context, from uint64, to uint64, after *common.Address, limit int) ([]*GameSnapshot, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	var upper []byte
+	if to == math.MaxUint64 {
+		upper = prefixUpperBound([]byte{keyPrefixGameByTimestamp})
+	} else {
+		upper = gameByTimestampKey(to+1, common.Address{})
+	}
+	lower := gameByTimestampKey(from, common.Address{})
+	if after != nil {
+		// The smallest key greater than the key of the cursor game
+		lower = append(gameByTimestampKey(from, *after), 0)
+	}
+	return d.gamesFromIndex(ctx, lower, upper, limit)
+}
+
+// gamesFromIndex loads the snapshots for index keys in [lower, upper) where the game address is the key suffix.
+func (d *GameDB) gamesFromIndex(ctx context.Context, lower []byte, upper []byte, limit int) ([]*GameSnapshot, error) {
+	var games []*GameSnapshot
+	err := d.iterate(ctx, lower, upper, func(key []byte, _ []byte) (bool, error) {
+		if len(key) < common.AddressLength {
+			return false, ErrInvalidEntry
+		}
+		game, err := d.game(common.BytesToAddress(key[len(key)-common.AddressLength:]))
+		if err != nil {
+			return false, err
+		}
+		games = append(games, game)
+		return len(games) < limit, nil
+	})
+	return games, err
+}
+
+func (d *GameDB) iterate(ctx context.Context, lower []byte, upper []byte, fn func(key []byte, val []byte) (bool, error)) error {
+	iter, err := d.db.NewIterWithContext(ctx, &pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read entry: %w", err)
+		}
+		cont, err := fn(iter.Key(), val)
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
+
+func (d *GameDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
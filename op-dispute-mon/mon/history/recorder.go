@@ -0,0 +1,146 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type RClock interface {
+	Now() time.Time
+}
+
+type Store interface {
+	Game(game common.Address) (*GameSnapshot, error)
+	StoreGame(snapshot *GameSnapshot, events []TimelineEvent) error
+}
+
+// Recorder persists a snapshot of each monitored game, recording a timeline of the changes between cycles.
+type Recorder struct {
+	logger       log.Logger
+	clock        RClock
+	honestActors monTypes.HonestActors
+	store        Store
+}
+
+func NewRecorder(logger log.Logger, clock RClock, honestActors monTypes.HonestActors, store Store) *Recorder {
+	return &Recorder{
+		logger:       logger,
+		clock:        clock,
+		honestActors: honestActors,
+		store:        store,
+	}
+}
+
+func (r *Recorder) RecordGames(games []*monTypes.EnrichedGameData) {
+	now := r.clock.Now().UTC()
+	updated := 0
+	for _, game := range games {
+		changed, err := r.recordGame(game, now)
+		if err != nil {
+			r.logger.Error("Failed to record game history", "game", game.Proxy, "err", err)
+			continue
+		}
+		if changed {
+			updated++
+		}
+	}
+	r.logger.Debug("Recorded game history", "games", len(games), "updated", updated)
+}
+
+func (r *Recorder) recordGame(game *monTypes.EnrichedGameData, now time.Time) (bool, error) {
+	snapshot := newSnapshot(game, r.honestActors)
+	prev, err := r.store.Game(game.Proxy)
+	if errors.Is(err, ErrNotFound) {
+		prev = nil
+	} else if err != nil {
+		return false, err
+	}
+	events := diff(prev, snapshot, now)
+	if prev != nil {
+		snapshot.FirstSeen = prev.FirstSeen
+		snapshot.TimelineLength = prev.TimelineLength
+		snapshot.LastChanged = prev.LastChanged
+		if len(events) == 0 {
+			unchanged, err := equalSnapshots(prev, snapshot)
+			if err != nil || unchanged {
+				return false, err
+			}
+		}
+	} else {
+		snapshot.FirstSeen = now
+	}
+	snapshot.LastChanged = now
+	snapshot.TimelineLength += uint64(len(events))
+	return true, r.store.StoreGame(snapshot, events)
+}
+
+// diff determines the timeline events that describe the transition from prev to next.
+// Claim additions are timestamped with the claim's clock, other changes with the time they were first observed.
+func diff(prev *GameSnapshot, next *GameSnapshot, now time.Time) []TimelineEvent {
+	var events []TimelineEvent
+	var prevClaims []ClaimSnapshot
+	if prev == nil {
+		events = append(events, TimelineEvent{
+			Kind: EventGameCreated,
+			Time: time.Unix(int64(next.Timestamp), 0).UTC(),
+		})
+	} else {
+		prevClaims = prev.Claims
+	}
+	for i, claim := range next.Claims {
+		claimIdx := claim.ContractIndex
+		var prevClaim ClaimSnapshot
+		if i < len(prevClaims) {
+			prevClaim = prevClaims[i]
+		} else {
+			events = append(events, TimelineEvent{
+				Kind:       EventClaimAdded,
+				Time:       claim.ClockTimestamp,
+				ClaimIndex: &claimIdx,
+				Address:    claim.Claimant,
+			})
+		}
+		if claim.CounteredBy != prevClaim.CounteredBy && claim.CounteredBy != (common.Address{}) {
+			events = append(events, TimelineEvent{
+				Kind:       EventClaimCountered,
+				Time:       now,
+				ClaimIndex: &claimIdx,
+				Address:    claim.CounteredBy,
+			})
+		}
+		if claim.Resolved && !prevClaim.Resolved {
+			events = append(events, TimelineEvent{
+				Kind:       EventClaimResolved,
+				Time:       now,
+				ClaimIndex: &claimIdx,
+			})
+		}
+	}
+	if prev != nil && prev.Status != next.Status {
+		events = append(events, TimelineEvent{
+			Kind:   EventStatusChanged,
+			Time:   now,
+			Status: next.Status,
+		})
+	}
+	return events
+}
+
+func equalSnapshots(a *GameSnapshot, b *GameSnapshot) (bool, error) {
+	aData, err := json.Marshal(a)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode game snapshot: %w", err)
+	}
+	bData, err := json.Marshal(b)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode game snapshot: %w", err)
+	}
+	return bytes.Equal(aData, bData), nil
+}
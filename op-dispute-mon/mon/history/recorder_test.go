@@ -0,0 +1,153 @@
+package history
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	honestActor = common.Address{0xaa}
+	dishonest   = common.Address{0xbb}
+	gameAddr    = common.Address{0x11}
+)
+
+func TestRecorder_RecordsNewGame(t *testing.T) {
+	recorder, db, cl := setupRecorderTest(t)
+	game := createGame(gameAddr, 100)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{game})
+
+	snapshot, err := db.Game(gameAddr)
+	require.NoError(t, err)
+	require.Equal(t, gameAddr, snapshot.Proxy)
+	require.Equal(t, gameTypes.GameStatusInProgress.String(), snapshot.Status)
+	require.Len(t, snapshot.Claims, 1)
+	require.Equal(t, []common.Address{honestActor}, snapshot.HonestActors)
+	require.Equal(t, cl.Now().UTC(), snapshot.FirstSeen)
+	require.Equal(t, uint64(2), snapshot.TimelineLength)
+
+	timeline, err := db.Timeline(context.Background(), gameAddr)
+	require.NoError(t, err)
+	require.Len(t, timeline, 2)
+	require.Equal(t, EventGameCreated, timeline[0].Kind)
+	require.Equal(t, time.Unix(100, 0).UTC(), timeline[0].Time)
+	require.Equal(t, EventClaimAdded, timeline[1].Kind)
+	require.Equal(t, 0, *timeline[1].ClaimIndex)
+	require.Equal(t, honestActor, timeline[1].Address)
+}
+
+func TestRecorder_SkipsUnchangedGame(t *testing.T) {
+	recorder, db, cl := setupRecorderTest(t)
+	game := createGame(gameAddr, 100)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{game})
+	firstSeen := cl.Now().UTC()
+
+	cl.AdvanceTime(time.Minute)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{game})
+	snapshot, err := db.Game(gameAddr)
+	require.NoError(t, err)
+	require.Equal(t, firstSeen, snapshot.LastChanged)
+	require.Equal(t, uint64(2), snapshot.TimelineLength)
+}
+
+func TestRecorder_RecordsChanges(t *testing.T) {
+	recorder, db, cl := setupRecorderTest(t)
+	game := createGame(gameAddr, 100)
+	recorder.RecordGames([]*monTypes.EnrichedGameData{game})
+
+	cl.AdvanceTime(time.Minute)
+	game = createGame(gameAddr, 100)
+	game.Claims[0].CounteredBy = dishonest
+	game.Claims = append(game.Claims, monTypes.EnrichedClaim{
+		Claim: faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{
+				Bond:     big.NewInt(20),
+				Position: faultTypes.NewPosition(1, big.NewInt(0)),
+			},
+			Claimant:            dishonest,
+			Clock:               faultTypes.Clock{Timestamp: time.Unix(200, 0)},
+			ContractIndex:       1,
+			ParentContractIndex: 0,
+		},
+	})
+	recorder.RecordGames([]*monTypes.EnrichedGameData{game})
+
+	cl.AdvanceTime(time.Minute)
+	game.Claims[0].Resolved = true
+	game.Claims[1].Resolved = true
+	game.Status = gameTypes.GameStatusChallengerWon
+	recorder.RecordGames([]*monTypes.EnrichedGameData{game})
+
+	snapshot, err := db.Game(gameAddr)
+	require.NoError(t, err)
+	require.Equal(t, gameTypes.GameStatusChallengerWon.String(), snapshot.Status)
+	require.Equal(t, cl.Now().UTC(), snapshot.LastChanged)
+
+	timeline, err := db.Timeline(context.Background(), gameAddr)
+	require.NoError(t, err)
+	kinds := make([]TimelineEventKind, len(timeline))
+	for i, event := range timeline {
+		kinds[i] = event.Kind
+	}
+	require.Equal(t, []TimelineEventKind{
+		EventGameCreated,
+		EventClaimAdded,
+		EventClaimCountered,
+		EventClaimAdded,
+		EventClaimResolved,
+		EventClaimResolved,
+		EventStatusChanged,
+	}, kinds)
+	require.Equal(t, uint64(len(timeline)), snapshot.TimelineLength)
+	require.Equal(t, dishonest, timeline[2].Address)
+	require.Equal(t, time.Unix(200, 0).UTC(), timeline[3].Time)
+	require.Equal(t, gameTypes.GameStatusChallengerWon.String(), timeline[6].Status)
+}
+
+func setupRecorderTest(t *testing.T) (*Recorder, *GameDB, *clock.DeterministicClock) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewGameDB(logger, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	return NewRecorder(logger, cl, monTypes.NewHonestActors([]common.Address{honestActor}), db), db, cl
+}
+
+func createGame(proxy common.Address, timestamp uint64) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{
+			Proxy:     proxy,
+			Timestamp: timestamp,
+		},
+		Status:    gameTypes.GameStatusInProgress,
+		RootClaim: common.Hash{0x01},
+		Claims: []monTypes.EnrichedClaim{
+			{
+				Claim: faultTypes.Claim{
+					ClaimData: faultTypes.ClaimData{
+						Value:    common.Hash{0x01},
+						Bond:     big.NewInt(10),
+						Position: faultTypes.NewPosition(0, big.NewInt(0)),
+					},
+					Claimant:            honestActor,
+					Clock:               faultTypes.Clock{Timestamp: time.Unix(int64(timestamp), 0)},
+					ContractIndex:       0,
+					ParentContractIndex: math.MaxInt64,
+				},
+			},
+		},
+	}
+}
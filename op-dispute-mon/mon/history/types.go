@@ -0,0 +1,209 @@
+package history
+
+import (
+	"math/big"
+	"time"
+
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type ClaimSnapshot struct {
+	ContractIndex       int            `json:"contractIndex"`
+	ParentContractIndex int            `json:"parentContractIndex"`
+	Depth               uint64         `json:"depth"`
+	IndexAtDepth        *hexutil.Big   `json:"indexAtDepth"`
+	Value               common.Hash    `json:"value"`
+	Bond                *hexutil.Big   `json:"bond"`
+	Claimant            common.Address `json:"claimant"`
+	CounteredBy         common.Address `json:"counteredBy"`
+	ClockTimestamp      time.Time      `json:"clockTimestamp"`
+	ClockDuration       time.Duration  `json:"clockDuration"`
+	Resolved            bool           `json:"resolved"`
+}
+
+func (c ClaimSnapshot) IsRoot() bool {
+	return c.Depth == 0
+}
+
+// GameSnapshot is the persisted state of a game as of the last monitoring cycle it changed in.
+type GameSnapshot struct {
+	Proxy                 common.Address                  `json:"proxy"`
+	Index                 uint64                          `json:"index"`
+	GameType              uint32                          `json:"gameType"`
+	Timestamp             uint64                          `json:"timestamp"`
+	L1Head                common.Hash                     `json:"l1Head"`
+	L1HeadNum             uint64                          `json:"l1HeadNum"`
+	L2SequenceNumber      uint64                          `json:"l2SequenceNumber"`
+	RootClaim             common.Hash                     `json:"rootClaim"`
+	Status                string                          `json:"status"`
+	MaxClockDuration      uint64                          `json:"maxClockDuration"`
+	BlockNumberChallenged bool                            `json:"blockNumberChallenged"`
+	BlockNumberChallenger common.Address                  `json:"blockNumberChallenger"`
+	AgreeWithClaim        bool                            `json:"agreeWithClaim"`
+	ExpectedRootClaim     common.Hash                     `json:"expectedRootClaim"`
+	Claims                []ClaimSnapshot                 `json:"claims"`
+	Credits               map[common.Address]*hexutil.Big `json:"credits,omitempty"`
+	WETHContract          common.Address                  `json:"wethContract"`
+
+	// HonestActors lists the honest actors that posted or countered a claim in the game.
+	HonestActors []common.Address `json:"honestActors,omitempty"`
+
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastChanged time.Time `json:"lastChanged"`
+	// TimelineLength is the number of timeline events recorded for the game.
+	TimelineLength uint64 `json:"timelineLength"`
+}
+
+// Participants returns every address that posted, countered or is owed a bond in the game.
+func (g *GameSnapshot) Participants() []common.Address {
+	seen := make(map[common.Address]bool)
+	var result []common.Address
+	add := func(addr common.Address) {
+		if addr == (common.Address{}) || seen[addr] {
+			return
+		}
+		seen[addr] = true
+		result = append(result, addr)
+	}
+	for _, claim := range g.Claims {
+		add(claim.Claimant)
+		add(claim.CounteredBy)
+	}
+	add(g.BlockNumberChallenger)
+	for addr := range g.Credits {
+		add(addr)
+	}
+	return result
+}
+
+type TimelineEventKind string
+
+const (
+	EventGameCreated    TimelineEventKind = "game_created"
+	EventClaimAdded     TimelineEventKind = "claim_added"
+	EventClaimCountered TimelineEventKind = "claim_countered"
+	EventClaimResolved  TimelineEventKind = "claim_resolved"
+	EventStatusChanged  TimelineEventKind = "status_changed"
+)
+
+type TimelineEvent struct {
+	Kind TimelineEventKind `json:"kind"`
+	Time time.Time         `json:"time"`
+	// ClaimIndex is the contract index of the claim the event relates to, if any.
+	ClaimIndex *int           `json:"claimIndex,omitempty"`
+	Address    common.Address `json:"address,omitempty"`
+	Status     string         `json:"status,omitempty"`
+}
+
+type BondFlowKind string
+
+const (
+	// BondPosted is a bond paid by the address when posting a claim.
+	BondPosted BondFlowKind = "posted"
+	// BondRefunded is a bond returned to the address because its uncountered claim resolved.
+	BondRefunded BondFlowKind = "refunded"
+	// BondWon is a bond paid to the address for countering another claim.
+	BondWon BondFlowKind = "won"
+	// BondLost is a bond forfeited by the address because its claim was countered.
+	BondLost BondFlowKind = "lost"
+)
+
+type BondFlow struct {
+	Game       common.Address `json:"game"`
+	ClaimIndex int            `json:"claimIndex"`
+	Kind       BondFlowKind   `json:"kind"`
+	Amount     *hexutil.Big   `json:"amount"`
+}
+
+func newSnapshot(game *monTypes.EnrichedGameData, honestActors monTypes.HonestActors) *GameSnapshot {
+	snapshot := &GameSnapshot{
+		Proxy:                 game.Proxy,
+		Index:                 game.Index,
+		GameType:              game.GameType,
+		Timestamp:             game.Timestamp,
+		L1Head:                game.L1Head,
+		L1HeadNum:             game.L1HeadNum,
+		L2SequenceNumber:      game.L2SequenceNumber,
+		RootClaim:             game.RootClaim,
+		Status:                game.Status.String(),
+		MaxClockDuration:      game.MaxClockDuration,
+		BlockNumberChallenged: game.BlockNumberChallenged,
+		BlockNumberChallenger: game.BlockNumberChallenger,
+		AgreeWithClaim:        game.AgreeWithClaim,
+		ExpectedRootClaim:     game.ExpectedRootClaim,
+		WETHContract:          game.WETHContract,
+	}
+	for _, claim := range game.Claims {
+		snapshot.Claims = append(snapshot.Claims, ClaimSnapshot{
+			ContractIndex:       claim.ContractIndex,
+			ParentContractIndex: claim.ParentContractIndex,
+			Depth:               uint64(claim.Position.Depth()),
+			IndexAtDepth:        toHexBig(claim.Position.IndexAtDepth()),
+			Value:               claim.Value,
+			Bond:                toHexBig(claim.Bond),
+			Claimant:            claim.Claimant,
+			CounteredBy:         claim.CounteredBy,
+			ClockTimestamp:      claim.Clock.Timestamp.UTC(),
+			ClockDuration:       claim.Clock.Duration,
+			Resolved:            claim.Resolved,
+		})
+	}
+	if len(game.Credits) > 0 {
+		snapshot.Credits = make(map[common.Address]*hexutil.Big, len(game.Credits))
+		for addr, credit := range game.Credits {
+			snapshot.Credits[addr] = toHexBig(credit)
+		}
+	}
+	for _, addr := range snapshot.Participants() {
+		if honestActors.Contains(addr) {
+			snapshot.HonestActors = append(snapshot.HonestActors, addr)
+		}
+	}
+	return snapshot
+}
+
+// BondFlows derives the movement of bonds for addr in the game.
+func (g *GameSnapshot) BondFlows(addr common.Address) []BondFlow {
+	var flows []BondFlow
+	add := func(claim ClaimSnapshot, kind BondFlowKind) {
+		flows = append(flows, BondFlow{
+			Game:       g.Proxy,
+			ClaimIndex: claim.ContractIndex,
+			Kind:       kind,
+			Amount:     claim.Bond,
+		})
+	}
+	for _, claim := range g.Claims {
+		if claim.Claimant == addr {
+			add(claim, BondPosted)
+		}
+		if !claim.Resolved {
+			continue
+		}
+		// The recipient of a resolved claim is the claimant unless it's been countered.
+		recipient := claim.Claimant
+		if claim.IsRoot() && g.BlockNumberChallenged {
+			recipient = g.BlockNumberChallenger
+		} else if claim.CounteredBy != (common.Address{}) {
+			recipient = claim.CounteredBy
+		}
+		switch {
+		case recipient == addr && claim.Claimant == addr:
+			add(claim, BondRefunded)
+		case recipient == addr:
+			add(claim, BondWon)
+		case claim.Claimant == addr:
+			add(claim, BondLost)
+		}
+	}
+	return flows
+}
+
+func toHexBig(v *big.Int) *hexutil.Big {
+	if v == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Set(v))
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type EventType string
+
+const (
+	// EventResolvedAgainstHonestActor is emitted when a claim posted by an honest actor is resolved as countered.
+	EventResolvedAgainstHonestActor EventType = "resolved_against_honest_actor"
+	// EventBondAtRisk is emitted when a DelayedWETH contract holds less collateral than required to pay out bonds.
+	EventBondAtRisk EventType = "bond_at_risk"
+	// EventNodeOutOfSync is emitted when rollup node endpoints were out of sync while checking a game.
+	EventNodeOutOfSync EventType = "node_out_of_sync"
+	// EventForecastDisagrees is emitted when a game is forecast to, or did, resolve contrary to the reference node.
+	EventForecastDisagrees EventType = "forecast_disagrees"
+)
+
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityError    Severity = "error"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// Event is a structured notification emitted by the dispute monitor.
+type Event struct {
+	Type     EventType `json:"type"`
+	Severity Severity  `json:"severity"`
+	// Subject is the address the event relates to, typically the game proxy or DelayedWETH contract.
+	Subject common.Address `json:"subject"`
+	Summary string         `json:"summary"`
+	// Key distinguishes multiple events of the same type for the same subject, e.g. a claim index.
+	Key       string            `json:"key,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	// Final is set when the condition can no longer change, e.g. because the game is resolved.
+	// Final events are delivered once, rather than once per dedup window.
+	Final bool `json:"final,omitempty"`
+}
+
+// DedupKey identifies events that describe the same underlying condition.
+// Repeated events with the same key are suppressed by the Notifier within the dedup window,
+// or for as long as they are reported if the event is final.
+func (e Event) DedupKey() string {
+	return fmt.Sprintf("%s/%s/%s", e.Type, e.Subject.Hex(), e.Key)
+}
@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/time/rate"
+)
+
+const sendTimeout = 30 * time.Second
+
+type SuppressedReason string
+
+const (
+	SuppressedDuplicate   SuppressedReason = "duplicate"
+	SuppressedRateLimited SuppressedReason = "rate_limited"
+)
+
+type RClock interface {
+	Now() time.Time
+}
+
+type NotifierMetrics interface {
+	RecordNotification(sink string, success bool)
+	RecordNotificationSuppressed(reason string)
+}
+
+// Notifier fans events out to a set of sinks, suppressing duplicates within the dedup window
+// and dropping events once the rate limit is exceeded.
+// Final events are only delivered once, until they have not been reported for the final retention,
+// e.g. when the game left the monitoring window.
+// An event is only recorded as sent to a sink once the sink accepted it, so failed sends are retried.
+type Notifier struct {
+	logger  log.Logger
+	clock   RClock
+	metrics NotifierMetrics
+	sinks   []Sink
+
+	dedupWindow    time.Duration
+	finalRetention time.Duration
+	limiter        *rate.Limiter
+
+	mu       sync.Mutex
+	lastSent map[sentKey]time.Time
+	// finalSent holds the final events that were delivered, with the time they were last reported
+	finalSent map[sentKey]time.Time
+}
+
+// sentKey identifies an event delivered to a sink. Sinks are identified by index, as names are not unique.
+type sentKey struct {
+	sink int
+	key  string
+}
+
+// NewNotifier creates a new Notifier. maxPerMinute limits the total number of events delivered across all sinks,
+// with a burst of the same size. A maxPerMinute of 0 disables rate limiting.
+// Final events are remembered until they have not been reported for finalRetention.
+func NewNotifier(logger log.Logger, clock RClock, metrics NotifierMetrics, dedupWindow time.Duration, finalRetention time.Duration, maxPerMinute uint, sinks ...Sink) *Notifier {
+	limit := rate.Inf
+	if maxPerMinute > 0 {
+		limit = rate.Limit(float64(maxPerMinute) / 60)
+	}
+	return &Notifier{
+		logger:         logger,
+		clock:          clock,
+		metrics:        metrics,
+		sinks:          sinks,
+		dedupWindow:    dedupWindow,
+		finalRetention: finalRetention,
+		limiter:        rate.NewLimiter(limit, int(maxPerMinute)),
+		lastSent:       make(map[sentKey]time.Time),
+		finalSent:      make(map[sentKey]time.Time),
+	}
+}
+
+// Enabled returns true if at least one sink is configured.
+func (n *Notifier) Enabled() bool {
+	return len(n.sinks) > 0
+}
+
+// Notify delivers each event to every sink, subject to dedup and rate limiting.
+func (n *Notifier) Notify(ctx context.Context, events []Event) {
+	if !n.Enabled() {
+		return
+	}
+	now := n.clock.Now()
+	n.prune(now)
+	for _, event := range events {
+		if event.Timestamp.IsZero() {
+			event.Timestamp = now
+		}
+		pending := n.pendingSinks(event.DedupKey(), event.Final, now)
+		if len(pending) == 0 {
+			n.logger.Debug("Suppressing duplicate notification", "type", event.Type, "subject", event.Subject, "key", event.Key)
+			n.metrics.RecordNotificationSuppressed(string(SuppressedDuplicate))
+			continue
+		}
+		if !n.limiter.AllowN(now, 1) {
+			n.logger.Warn("Notification rate limit exceeded, dropping event", "type", event.Type, "subject", event.Subject, "summary", event.Summary)
+			n.metrics.RecordNotificationSuppressed(string(SuppressedRateLimited))
+			continue
+		}
+		n.send(ctx, event, pending, now)
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, event Event, sinks []int, now time.Time) {
+	for _, i := range sinks {
+		sink := n.sinks[i]
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err := sink.Send(sendCtx, event)
+		cancel()
+		if err != nil {
+			n.logger.Error("Failed to send notification", "sink", sink.Name(), "type", event.Type, "subject", event.Subject, "err", err)
+		} else {
+			n.markSent(sentKey{sink: i, key: event.DedupKey()}, event.Final, now)
+		}
+		n.metrics.RecordNotification(sink.Name(), err == nil)
+	}
+}
+
+// pendingSinks returns the indices of the sinks the event was not sent to within the dedup window,
+// or at all if the event is final. Final events that were sent are recorded as reported at the given time.
+func (n *Notifier) pendingSinks(key string, final bool, now time.Time) []int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var pending []int
+	for i := range n.sinks {
+		k := sentKey{sink: i, key: key}
+		if final {
+			if _, ok := n.finalSent[k]; ok {
+				n.finalSent[k] = now
+				continue
+			}
+		} else if last, ok := n.lastSent[k]; ok && now.Sub(last) < n.dedupWindow {
+			continue
+		}
+		pending = append(pending, i)
+	}
+	return pending
+}
+
+// markSent records the event as sent to a sink.
+func (n *Notifier) markSent(key sentKey, final bool, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if final {
+		n.finalSent[key] = now
+	} else {
+		n.lastSent[key] = now
+	}
+}
+
+// prune forgets the events sent before the dedup window, and the final events that have not been reported
+// for the final retention, so the sets do not grow without bound.
+// Final events are kept across cycles they are not reported in, e.g. after a transient error, so they are not repeated.
+func (n *Notifier) prune(now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for key, last := range n.lastSent {
+		if now.Sub(last) >= n.dedupWindow {
+			delete(n.lastSent, key)
+		}
+	}
+	for key, reported := range n.finalSent {
+		if now.Sub(reported) >= n.finalRetention {
+			delete(n.finalSent, key)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_SendsToAllSinks(t *testing.T) {
+	notifier, _, metrics, sinks := setupNotifierTest(t, time.Hour, 0, 2)
+	notifier.Notify(context.Background(), []Event{testEvent("a"), testEvent("b")})
+	for _, sink := range sinks {
+		require.Len(t, sink.events, 2)
+	}
+	require.Equal(t, 4, metrics.sent["stub"])
+}
+
+func TestNotifier_SetsMissingTimestamp(t *testing.T) {
+	notifier, cl, _, sinks := setupNotifierTest(t, time.Hour, 0, 1)
+	notifier.Notify(context.Background(), []Event{testEvent("a")})
+	require.Equal(t, cl.Now(), sinks[0].events[0].Timestamp)
+}
+
+func TestNotifier_Dedup(t *testing.T) {
+	notifier, cl, metrics, sinks := setupNotifierTest(t, time.Hour, 0, 1)
+	notifier.Notify(context.Background(), []Event{testEvent("a")})
+	notifier.Notify(context.Background(), []Event{testEvent("a"), testEvent("b")})
+	require.Len(t, sinks[0].events, 2)
+	require.Equal(t, 1, metrics.suppressed[string(SuppressedDuplicate)])
+
+	cl.AdvanceTime(time.Hour)
+	notifier.Notify(context.Background(), []Event{testEvent("a")})
+	require.Len(t, sinks[0].events, 3)
+}
+
+func TestNotifier_FinalEventsSentOnce(t *testing.T) {
+	notifier, cl, metrics, sinks := setupNotifierTest(t, time.Hour, 0, 1)
+	final := testEvent("a")
+	final.Final = true
+	notifier.Notify(context.Background(), []Event{final})
+
+	cl.AdvanceTime(2 * time.Hour)
+	notifier.Notify(context.Background(), []Event{final})
+	require.Len(t, sinks[0].events, 1, "final events are not repeated after the dedup window")
+	require.Equal(t, 1, metrics.suppressed[string(SuppressedDuplicate)])
+
+	// Not reporting the event for a cycle, e.g. after a transient error, does not repeat it
+	notifier.Notify(context.Background(), nil)
+	notifier.Notify(context.Background(), []Event{final})
+	require.Len(t, sinks[0].events, 1)
+
+	// Once no longer reported for the retention, e.g. the game left the monitoring window, the event is forgotten
+	cl.AdvanceTime(testFinalRetention)
+	notifier.Notify(context.Background(), nil)
+	require.Empty(t, notifier.finalSent)
+	notifier.Notify(context.Background(), []Event{final})
+	require.Len(t, sinks[0].events, 2)
+}
+
+func TestNotifier_RateLimit(t *testing.T) {
+	notifier, cl, metrics, sinks := setupNotifierTest(t, time.Hour, 2, 1)
+	notifier.Notify(context.Background(), []Event{testEvent("a"), testEvent("b"), testEvent("c")})
+	require.Len(t, sinks[0].events, 2)
+	require.Equal(t, 1, metrics.suppressed[string(SuppressedRateLimited)])
+
+	// Rate limited events are not treated as sent so they are retried once capacity is available.
+	cl.AdvanceTime(30 * time.Second)
+	notifier.Notify(context.Background(), []Event{testEvent("a"), testEvent("c")})
+	require.Len(t, sinks[0].events, 3)
+	require.Equal(t, "c", sinks[0].events[2].Key)
+}
+
+func TestNotifier_SinkErrorsDoNotBlockOtherSinks(t *testing.T) {
+	notifier, _, metrics, sinks := setupNotifierTest(t, time.Hour, 0, 2)
+	sinks[0].err = errors.New("boom")
+	notifier.Notify(context.Background(), []Event{testEvent("a")})
+	require.Len(t, sinks[1].events, 1)
+	require.Equal(t, 1, metrics.failed["stub"])
+	require.Equal(t, 1, metrics.sent["stub"])
+}
+
+func TestNotifier_RetriesFailedSends(t *testing.T) {
+	notifier, _, metrics, sinks := setupNotifierTest(t, time.Hour, 0, 2)
+	sinks[0].err = errors.New("boom")
+	final := testEvent("b")
+	final.Final = true
+	notifier.Notify(context.Background(), []Event{testEvent("a"), final})
+	require.Empty(t, sinks[0].events)
+	require.Len(t, sinks[1].events, 2)
+
+	// The failed sink is retried on the next cycle, without repeating the events to the other sink
+	sinks[0].err = nil
+	notifier.Notify(context.Background(), []Event{testEvent("a"), final})
+	require.Len(t, sinks[0].events, 2)
+	require.Len(t, sinks[1].events, 2)
+	require.Equal(t, 2, metrics.failed["stub"])
+
+	notifier.Notify(context.Background(), []Event{testEvent("a"), final})
+	require.Len(t, sinks[0].events, 2)
+	require.Equal(t, 2, metrics.suppressed[string(SuppressedDuplicate)])
+}
+
+func TestNotifier_Disabled(t *testing.T) {
+	notifier, _, metrics, _ := setupNotifierTest(t, time.Hour, 0, 0)
+	require.False(t, notifier.Enabled())
+	notifier.Notify(context.Background(), []Event{testEvent("a")})
+	require.Empty(t, metrics.suppressed)
+}
+
+func testEvent(key string) Event {
+	return Event{
+		Type:     EventNodeOutOfSync,
+		Severity: SeverityWarning,
+		Subject:  common.Address{0x11},
+		Key:      key,
+		Summary:  "test event",
+	}
+}
+
+const testFinalRetention = 24 * time.Hour
+
+func setupNotifierTest(t *testing.T, dedupWindow time.Duration, maxPerMinute uint, sinkCount int) (*Notifier, *clock.DeterministicClock, *stubNotifierMetrics, []*stubSink) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	metrics := &stubNotifierMetrics{
+		sent:       make(map[string]int),
+		failed:     make(map[string]int),
+		suppressed: make(map[string]int),
+	}
+	stubs := make([]*stubSink, sinkCount)
+	sinks := make([]Sink, sinkCount)
+	for i := range stubs {
+		stubs[i] = &stubSink{}
+		sinks[i] = stubs[i]
+	}
+	return NewNotifier(logger, cl, metrics, dedupWindow, testFinalRetention, maxPerMinute, sinks...), cl, metrics, stubs
+}
+
+type stubSink struct {
+	events []Event
+	err    error
+}
+
+func (s *stubSink) Name() string {
+	return "stub"
+}
+
+func (s *stubSink) Send(_ context.Context, event Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+type stubNotifierMetrics struct {
+	sent       map[string]int
+	failed     map[string]int
+	suppressed map[string]int
+}
+
+func (s *stubNotifierMetrics) RecordNotification(sink string, success bool) {
+	if success {
+		s.sent[sink]++
+	} else {
+		s.failed[sink]++
+	}
+}
+
+func (s *stubNotifierMetrics) RecordNotificationSuppressed(reason string) {
+	s.suppressed[reason]++
+}
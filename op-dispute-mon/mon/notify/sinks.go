@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHTTPTimeout = 10 * time.Second
+
+	pagerDutySource = "op-dispute-mon"
+)
+
+// Sink delivers events to an external destination.
+type Sink interface {
+	// Name returns a short identifier for the sink used in logs and metrics.
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// WebhookSink posts each event as a JSON document to a HTTP endpoint.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: DefaultHTTPTimeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.client, s.url, event)
+}
+
+// PagerDutySink posts events using the PagerDuty Events API v2 payload format.
+type PagerDutySink struct {
+	url        string
+	routingKey string
+	client     *http.Client
+}
+
+func NewPagerDutySink(url string, routingKey string) *PagerDutySink {
+	return &PagerDutySink{
+		url:        url,
+		routingKey: routingKey,
+		client:     &http.Client{Timeout: DefaultHTTPTimeout},
+	}
+}
+
+func (s *PagerDutySink) Name() string {
+	return "pagerduty"
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      Severity          `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	Component     string            `json:"component"`
+	Class         string            `json:"class"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+func (s *PagerDutySink) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.client, s.url, pagerDutyEvent{
+		RoutingKey:  s.routingKey,
+		EventAction: "trigger",
+		DedupKey:    event.DedupKey(),
+		Payload: pagerDutyPayload{
+			Summary:       event.Summary,
+			Source:        pagerDutySource,
+			Severity:      event.Severity,
+			Timestamp:     event.Timestamp.UTC().Format(time.RFC3339),
+			Component:     event.Subject.Hex(),
+			Class:         string(event.Type),
+			CustomDetails: event.Details,
+		},
+	})
+}
+
+// FileSink appends each event as a line of JSON to a local journal file.
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Send(_ context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal %v: %w", s.path, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal %v: %w", s.path, err)
+	}
+	return nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %v", resp.Status)
+	}
+	return nil
+}
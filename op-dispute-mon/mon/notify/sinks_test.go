@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var event Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received = append(received, event)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event := sinkTestEvent()
+	sink := NewWebhookSink(server.URL)
+	require.NoError(t, sink.Send(context.Background(), event))
+	require.Equal(t, []Event{event}, received)
+}
+
+func TestWebhookSink_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	require.ErrorContains(t, sink.Send(context.Background(), sinkTestEvent()), "unexpected response status")
+}
+
+func TestPagerDutySink(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	event := sinkTestEvent()
+	sink := NewPagerDutySink(server.URL, "routing-key")
+	require.NoError(t, sink.Send(context.Background(), event))
+
+	require.Equal(t, "routing-key", received["routing_key"])
+	require.Equal(t, "trigger", received["event_action"])
+	require.Equal(t, event.DedupKey(), received["dedup_key"])
+	payload := received["payload"].(map[string]any)
+	require.Equal(t, event.Summary, payload["summary"])
+	require.Equal(t, "op-dispute-mon", payload["source"])
+	require.Equal(t, "critical", payload["severity"])
+	require.Equal(t, "2024-01-02T03:04:05Z", payload["timestamp"])
+	require.Equal(t, string(EventBondAtRisk), payload["class"])
+	require.Equal(t, map[string]any{"required": "10"}, payload["custom_details"])
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := NewFileSink(path)
+	first := sinkTestEvent()
+	second := sinkTestEvent()
+	second.Key = "other"
+	require.NoError(t, sink.Send(context.Background(), first))
+	require.NoError(t, sink.Send(context.Background(), second))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []Event{first, second}, events)
+}
+
+func sinkTestEvent() Event {
+	return Event{
+		Type:      EventBondAtRisk,
+		Severity:  SeverityCritical,
+		Subject:   common.Address{0xee},
+		Summary:   "Insufficient collateral",
+		Details:   map[string]string{"required": "10"},
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/history"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/notify"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -24,6 +26,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 )
@@ -33,6 +36,8 @@ type Service struct {
 	metrics      metrics.Metricer
 	monitor      *gameMonitor
 	honestActors types.HonestActors
+	notifier     *notify.Notifier
+	historyDB    *history.GameDB
 
 	factoryContract *contracts.DisputeGameFactoryContract
 
@@ -48,6 +53,7 @@ type Service struct {
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	rpcServer    *oprpc.Server
 
 	stopped atomic.Bool
 }
@@ -89,6 +95,10 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	}
 
 	s.initGameCallerCreator() // Must be called before initForecast
+	s.initNotifier(cfg)       // Must be called before initMonitor
+	if err := s.initHistory(cfg); err != nil {
+		return fmt.Errorf("failed to init game history: %w", err)
+	}
 
 	s.initMonitor(ctx, cfg) // Monitor must be initialized last
 
@@ -98,6 +108,48 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	return nil
 }
 
+func (s *Service) initNotifier(cfg *config.Config) {
+	var sinks []notify.Sink
+	for _, url := range cfg.NotifyWebhookURLs {
+		sinks = append(sinks, notify.NewWebhookSink(url))
+	}
+	if cfg.NotifyFile != "" {
+		sinks = append(sinks, notify.NewFileSink(cfg.NotifyFile))
+	}
+	if cfg.NotifyPagerDutyRoutingKey != "" {
+		sinks = append(sinks, notify.NewPagerDutySink(cfg.NotifyPagerDutyURL, cfg.NotifyPagerDutyRoutingKey))
+	}
+	// Resolved games are alerted once, until they leave the monitoring window.
+	s.notifier = notify.NewNotifier(s.logger, s.cl, s.metrics, cfg.NotifyDedupWindow, cfg.GameWindow, cfg.NotifyMaxPerMinute, sinks...)
+	if s.notifier.Enabled() {
+		s.logger.Info("Notifications enabled", "sinks", len(sinks))
+	}
+}
+
+func (s *Service) initHistory(cfg *config.Config) error {
+	if cfg.HistoryDBPath == "" {
+		return nil
+	}
+	db, err := history.NewGameDB(s.logger, cfg.HistoryDBPath)
+	if err != nil {
+		return fmt.Errorf("failed to open history db: %w", err)
+	}
+	s.historyDB = db
+	server := oprpc.NewServer(
+		cfg.RPCConfig.ListenAddr,
+		cfg.RPCConfig.ListenPort,
+		version.SimpleWithMeta,
+		oprpc.WithLogger(s.logger),
+	)
+	server.AddAPI(history.GetAPI(history.NewAPI(db)))
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("unable to start RPC server: %w", err)
+	}
+	s.logger.Info("Started history RPC server", "endpoint", server.Endpoint())
+	s.rpcServer = server
+	return nil
+}
+
 func (s *Service) initGameCallerCreator() {
 	s.game = extract.NewGameCallerCreator(s.metrics, s.l1Caller)
 }
@@ -242,9 +294,7 @@ func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
 	mixedAvailabilityMonitor := NewMixedAvailability(s.logger, s.metrics)
 	mixedSafetyMonitor := NewMixedSafetyMonitor(s.logger, s.metrics)
 	differentOutputRootMonitor := NewDifferentOutputRootMonitor(s.logger, s.metrics)
-	s.monitor = newGameMonitor(ctx, s.logger, s.cl, s.metrics, cfg.MonitorInterval, cfg.GameWindow, headBlockFetcher,
-		extractor.Extract,
-		forecast.Forecast,
+	monitors := []Monitor{
 		bonds.CheckBonds,
 		resolutions.CheckResolutions,
 		claims.CheckClaims,
@@ -256,7 +306,20 @@ func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
 		nodeEndpointOutOfSyncMonitor.CheckNodeEndpointOutOfSync,
 		mixedAvailabilityMonitor.CheckMixedAvailability,
 		mixedSafetyMonitor.CheckMixedSafety,
-		differentOutputRootMonitor.CheckDifferentOutputRoots)
+		differentOutputRootMonitor.CheckDifferentOutputRoots,
+	}
+	if s.historyDB != nil {
+		recorder := history.NewRecorder(s.logger, s.cl, s.honestActors, s.historyDB)
+		monitors = append(monitors, recorder.RecordGames)
+	}
+	if s.notifier.Enabled() {
+		alertMonitor := NewAlertMonitor(ctx, s.cl, s.honestActors, s.notifier)
+		monitors = append(monitors, alertMonitor.CheckAlerts)
+	}
+	s.monitor = newGameMonitor(ctx, s.logger, s.cl, s.metrics, cfg.MonitorInterval, cfg.GameWindow, headBlockFetcher,
+		extractor.Extract,
+		forecast.Forecast,
+		monitors...)
 }
 
 func (s *Service) Start(ctx context.Context) error {
@@ -285,6 +348,16 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	if s.rpcServer != nil {
+		if err := s.rpcServer.Stop(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close RPC server: %w", err))
+		}
+	}
+	if s.historyDB != nil {
+		if err := s.historyDB.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close history db: %w", err))
+		}
+	}
 	s.stopped.Store(true)
 	s.logger.Info("stopped dispute mon service", "err", result)
 	return result
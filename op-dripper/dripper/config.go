@@ -17,6 +17,8 @@ import (
 type CLIConfig struct {
 	L1EthRpc       string
 	DrippieAddress string
+	L2EthRpc       string
+	TopUpConfig    string
 	PollInterval   time.Duration
 	TxMgrConfig    txmgr.CLIConfig
 	RPCConfig      oprpc.CLIConfig
@@ -36,8 +38,20 @@ func (c *CLIConfig) Check() error {
 		return err
 	}
 
-	if c.DrippieAddress == "" {
-		return errors.New("drippie address is required")
+	if c.DrippieAddress == "" && c.TopUpConfig == "" {
+		return errors.New("drippie address or top-up config is required")
+	}
+	if c.TopUpConfig != "" {
+		topUps, err := LoadTopUpConfig(c.TopUpConfig)
+		if err != nil {
+			return err
+		}
+		if topUps.UsesChain(ChainL2) && c.L2EthRpc == "" {
+			return errors.New("l2 eth rpc is required to top up L2 accounts")
+		}
+		if topUps.UsesDrippie() && c.DrippieAddress == "" {
+			return errors.New("drippie address is required to fund accounts via drippie")
+		}
 	}
 
 	return nil
@@ -46,15 +60,17 @@ func (c *CLIConfig) Check() error {
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
 		// Required Flags
-		L1EthRpc:       ctx.String(flags.L1EthRpcFlag.Name),
-		DrippieAddress: ctx.String(flags.DrippieAddressFlag.Name),
-		PollInterval:   ctx.Duration(flags.PollIntervalFlag.Name),
-		TxMgrConfig:    txmgr.ReadCLIConfig(ctx),
+		L1EthRpc:    ctx.String(flags.L1EthRpcFlag.Name),
+		TxMgrConfig: txmgr.ReadCLIConfig(ctx),
 
 		// Optional Flags
-		RPCConfig:     oprpc.ReadCLIConfig(ctx),
-		LogConfig:     oplog.ReadCLIConfig(ctx),
-		MetricsConfig: opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:   oppprof.ReadCLIConfig(ctx),
+		DrippieAddress: ctx.String(flags.DrippieAddressFlag.Name),
+		L2EthRpc:       ctx.String(flags.L2EthRpcFlag.Name),
+		TopUpConfig:    ctx.Path(flags.TopUpConfigFlag.Name),
+		PollInterval:   ctx.Duration(flags.PollIntervalFlag.Name),
+		RPCConfig:      oprpc.ReadCLIConfig(ctx),
+		LogConfig:      oplog.ReadCLIConfig(ctx),
+		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:    oppprof.ReadCLIConfig(ctx),
 	}
 }
@@ -36,6 +36,9 @@ type DriverSetup struct {
 	Cfg    DripExecutorConfig
 	Txmgr  txmgr.TxManager
 	Client Client
+
+	// TopUps keeps the configured accounts funded. May be nil.
+	TopUps *TopUps
 }
 
 type DripExecutor struct {
@@ -52,6 +55,9 @@ type DripExecutor struct {
 
 	drippieContract DrippieContract
 	drippieABI      *abi.ABI
+	// accountDrips are the drips that fund top-up accounts. They are only executed
+	// when the account is below its min balance.
+	accountDrips map[string]struct{}
 }
 
 func NewDripExecutor(setup DriverSetup) (_ *DripExecutor, err error) {
@@ -64,10 +70,21 @@ func NewDripExecutor(setup DriverSetup) (_ *DripExecutor, err error) {
 		}
 	}()
 
-	if setup.Cfg.DrippieAddr == nil {
-		return nil, errors.New("drippie address is required")
+	if setup.Cfg.DrippieAddr == nil && setup.TopUps == nil {
+		return nil, errors.New("drippie address or top-up accounts are required")
+	}
+	if setup.Cfg.DrippieAddr == nil && setup.TopUps.cfg.UsesDrippie() {
+		return nil, errors.New("drippie address is required to fund accounts via drippie")
 	}
 
+	if setup.Cfg.DrippieAddr == nil {
+		return &DripExecutor{
+			DriverSetup: setup,
+			done:        make(chan struct{}),
+			ctx:         ctx,
+			cancel:      cancel,
+		}, nil
+	}
 	return newDripExecutor(ctx, cancel, setup)
 }
 
@@ -79,6 +96,10 @@ func newDripExecutor(ctx context.Context, cancel context.CancelFunc, setup Drive
 	}
 
 	log.Info("connected to drippie", "address", setup.Cfg.DrippieAddr)
+	var accountDrips map[string]struct{}
+	if setup.TopUps != nil {
+		accountDrips = setup.TopUps.cfg.drips()
+	}
 	parsed, err := bindings.DrippieMetaData.GetAbi()
 	if err != nil {
 		cancel()
@@ -93,6 +114,7 @@ func newDripExecutor(ctx context.Context, cancel context.CancelFunc, setup Drive
 
 		drippieContract: drippieContract,
 		drippieABI:      parsed,
+		accountDrips:    accountDrips,
 	}, nil
 }
 
@@ -152,19 +174,48 @@ func (d *DripExecutor) loop() {
 			default:
 			}
 
-			drips, err := d.fetchExecutableDrips(ctx)
-			if err != nil {
-				d.Log.Warn("failed to fetch executable drips", "error", err)
-				continue
-			}
+			d.runOnce(ctx)
+		}
+	}
+}
 
-			for _, drip := range drips {
-				d.executeDrip(ctx, drip)
-			}
+func (d *DripExecutor) runOnce(ctx context.Context) {
+	if d.TopUps != nil {
+		var drip DripFunc
+		if d.drippieContract != nil {
+			drip = d.executeDripIfExecutable
 		}
+		d.TopUps.Run(ctx, drip)
+	}
+	if d.drippieContract == nil {
+		return
+	}
+
+	drips, err := d.fetchExecutableDrips(ctx)
+	if err != nil {
+		d.Log.Warn("failed to fetch executable drips", "error", err)
+		return
+	}
+
+	for _, drip := range drips {
+		if _, ok := d.accountDrips[drip]; ok {
+			// only executed when the funded account runs low
+			continue
+		}
+		d.executeDrip(ctx, drip)
 	}
 }
 
+// executeDripIfExecutable executes the named drip if it is executable, and returns whether it was executed.
+func (d *DripExecutor) executeDripIfExecutable(ctx context.Context, name string) bool {
+	executable, err := d.drippieContract.Executable(&bind.CallOpts{Context: ctx}, name)
+	if err != nil || !executable {
+		d.Log.Info("drip is not executable", "name", name, "error", err)
+		return false
+	}
+	return d.executeDrip(ctx, name)
+}
+
 func (d *DripExecutor) fetchExecutableDrips(ctx context.Context) ([]string, error) {
 	// Get total number of drips
 	d.Log.Info("getting drip count")
@@ -205,23 +256,26 @@ func (d *DripExecutor) fetchExecutableDrips(ctx context.Context) ([]string, erro
 	return executableDrips, nil
 }
 
-func (d *DripExecutor) executeDrip(ctx context.Context, name string) {
+// executeDrip executes the named drip, and returns whether the execution succeeded.
+func (d *DripExecutor) executeDrip(ctx context.Context, name string) bool {
 	cCtx, cCancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cCancel()
 
-	if err := d.sendTransaction(cCtx, name); err != nil {
+	ok, err := d.sendTransaction(cCtx, name)
+	if err != nil {
 		d.Log.Error("failed to send drip execution transaction", "name", name, "error", err)
-		return
+		return false
 	}
 	d.Metr.RecordDripExecuted(name)
+	return ok
 }
 
-func (d *DripExecutor) sendTransaction(ctx context.Context, name string) error {
+func (d *DripExecutor) sendTransaction(ctx context.Context, name string) (bool, error) {
 	d.Log.Info("executing drip", "name", name)
 
 	data, err := d.executeDripTxData(name)
 	if err != nil {
-		return err
+		return false, err
 	}
 	receipt, err := d.Txmgr.Send(ctx, txmgr.TxCandidate{
 		TxData:   data,
@@ -229,15 +283,15 @@ func (d *DripExecutor) sendTransaction(ctx context.Context, name string) error {
 		GasLimit: 0,
 	})
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if receipt.Status == types.ReceiptStatusFailed {
 		d.Log.Error("drip execution failed", "name", name, "tx_hash", receipt.TxHash)
-	} else {
-		d.Log.Info("drip executed", "name", name, "tx_hash", receipt.TxHash)
+		return false, nil
 	}
-	return nil
+	d.Log.Info("drip executed", "name", name, "tx_hash", receipt.TxHash)
+	return true, nil
 }
 
 func (d *DripExecutor) executeDripTxData(name string) ([]byte, error) {
@@ -46,6 +46,12 @@ type DripExecutorService struct {
 	TxManager txmgr.TxManager
 	Client    *ethclient.Client
 
+	// L2TxManager and L2Client fund L2 top-up accounts, if any are configured.
+	L2TxManager txmgr.TxManager
+	L2Client    *ethclient.Client
+
+	topUps *TopUps
+
 	driver *DripExecutor
 
 	Version string
@@ -84,6 +90,9 @@ func (ds *DripExecutorService) initFromCLIConfig(ctx context.Context, version st
 	if err := ds.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init tx manager: %w", err)
 	}
+	if err := ds.initTopUps(cfg); err != nil {
+		return fmt.Errorf("failed to init top ups: %w", err)
+	}
 	if err := ds.initMetricsServer(cfg); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -110,6 +119,14 @@ func (ds *DripExecutorService) initRPCClients(ctx context.Context, cfg *CLIConfi
 		return fmt.Errorf("failed to dial rpc: %w", err)
 	}
 	ds.Client = client
+
+	if cfg.L2EthRpc != "" {
+		l2Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ds.Log, cfg.L2EthRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial l2 rpc: %w", err)
+		}
+		ds.L2Client = l2Client
+	}
 	return nil
 }
 
@@ -137,6 +154,37 @@ func (ds *DripExecutorService) initTxManager(cfg *CLIConfig) error {
 	return nil
 }
 
+func (ds *DripExecutorService) initTopUps(cfg *CLIConfig) error {
+	if cfg.TopUpConfig == "" {
+		return nil
+	}
+	topUpCfg, err := LoadTopUpConfig(cfg.TopUpConfig)
+	if err != nil {
+		return err
+	}
+	chains := map[Chain]TopUpChain{
+		ChainL1: {Client: ds.Client, Txmgr: ds.TxManager},
+	}
+	if topUpCfg.UsesChain(ChainL2) {
+		// L2 top ups are sent from the same account, with the L2 rpc.
+		l2TxMgrConfig := cfg.TxMgrConfig
+		l2TxMgrConfig.L1RPCURL = cfg.L2EthRpc
+		l2TxManager, err := txmgr.NewSimpleTxManager("dripper_l2", ds.Log, ds.Metrics.L2TxMetrics(), l2TxMgrConfig)
+		if err != nil {
+			return fmt.Errorf("failed to init l2 tx manager: %w", err)
+		}
+		ds.L2TxManager = l2TxManager
+		chains[ChainL2] = TopUpChain{Client: ds.L2Client, Txmgr: l2TxManager}
+	}
+	topUps, err := NewTopUps(ds.Log, ds.Metrics, *topUpCfg, chains)
+	if err != nil {
+		return err
+	}
+	ds.Log.Info("keeping accounts funded", "accounts", len(topUpCfg.Accounts))
+	ds.topUps = topUps
+	return nil
+}
+
 func (ds *DripExecutorService) initPProf(cfg *CLIConfig) error {
 	ds.pprofService = oppprof.New(
 		cfg.PprofConfig.ListenEnabled,
@@ -188,6 +236,7 @@ func (ds *DripExecutorService) initDriver() error {
 		Cfg:    ds.DripExecutorConfig,
 		Txmgr:  ds.TxManager,
 		Client: ds.Client,
+		TopUps: ds.topUps,
 	})
 	if err != nil {
 		return err
@@ -265,6 +314,10 @@ func (ds *DripExecutorService) Stop(ctx context.Context) error {
 		ds.TxManager.Close()
 	}
 
+	if ds.L2TxManager != nil {
+		ds.L2TxManager.Close()
+	}
+
 	if ds.metricsSrv != nil {
 		if err := ds.metricsSrv.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to stop metrics server: %w", err))
@@ -275,6 +328,10 @@ func (ds *DripExecutorService) Stop(ctx context.Context) error {
 		ds.Client.Close()
 	}
 
+	if ds.L2Client != nil {
+		ds.L2Client.Close()
+	}
+
 	if result == nil {
 		ds.stopped.Store(true)
 		ds.Log.Info("stopped executor")
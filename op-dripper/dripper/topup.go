@@ -0,0 +1,356 @@
+package dripper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dripper/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Chain identifies the chain a top-up account is funded on.
+type Chain string
+
+const (
+	ChainL1 Chain = "l1"
+	ChainL2 Chain = "l2"
+)
+
+// FundingMethod is how a top-up account is funded.
+type FundingMethod string
+
+const (
+	// FundDirect funds the account with a value transfer from the dripper account.
+	FundDirect FundingMethod = "direct"
+	// FundDrippie funds the account by executing a Drippie drip.
+	FundDrippie FundingMethod = "drippie"
+)
+
+// TopUpAccount is an account that is kept funded.
+// Balances are in wei.
+type TopUpAccount struct {
+	Name    string         `json:"name"`
+	Chain   Chain          `json:"chain"`
+	Address common.Address `json:"address"`
+	// MinBalance is the balance below which the account is topped up.
+	MinBalance *big.Int `json:"minBalance"`
+	// TargetBalance is the balance the account is topped up to, when funded directly.
+	TargetBalance *big.Int `json:"targetBalance,omitempty"`
+	// Method defaults to FundDirect.
+	Method FundingMethod `json:"method,omitempty"`
+	// Drip is the name of the Drippie drip that funds the account, when funded via Drippie.
+	Drip string `json:"drip,omitempty"`
+}
+
+func (a *TopUpAccount) method() FundingMethod {
+	if a.Method == "" {
+		return FundDirect
+	}
+	return a.Method
+}
+
+func (a *TopUpAccount) Check() error {
+	if a.Name == "" {
+		return errors.New("account name is required")
+	}
+	if a.Chain != ChainL1 && a.Chain != ChainL2 {
+		return fmt.Errorf("account %q: unknown chain %q", a.Name, a.Chain)
+	}
+	if a.Address == (common.Address{}) {
+		return fmt.Errorf("account %q: address is required", a.Name)
+	}
+	if a.MinBalance == nil || a.MinBalance.Sign() <= 0 {
+		return fmt.Errorf("account %q: min balance must be positive", a.Name)
+	}
+	switch a.method() {
+	case FundDirect:
+		if a.TargetBalance == nil || a.TargetBalance.Cmp(a.MinBalance) <= 0 {
+			return fmt.Errorf("account %q: target balance must be greater than the min balance", a.Name)
+		}
+	case FundDrippie:
+		if a.Chain != ChainL1 {
+			return fmt.Errorf("account %q: drippie funding is only supported on L1", a.Name)
+		}
+		if a.Drip == "" {
+			return fmt.Errorf("account %q: drip name is required for drippie funding", a.Name)
+		}
+	default:
+		return fmt.Errorf("account %q: unknown funding method %q", a.Name, a.Method)
+	}
+	return nil
+}
+
+// TopUpConfig declares the accounts to keep funded, and the daily budget in wei per chain
+// for funding accounts directly. Drippie funding is bounded by the Drippie configuration instead.
+type TopUpConfig struct {
+	Accounts    []TopUpAccount     `json:"accounts"`
+	DailyBudget map[Chain]*big.Int `json:"dailyBudget"`
+}
+
+func (c *TopUpConfig) Check() error {
+	names := make(map[string]struct{})
+	for i := range c.Accounts {
+		account := &c.Accounts[i]
+		if err := account.Check(); err != nil {
+			return err
+		}
+		if _, ok := names[account.Name]; ok {
+			return fmt.Errorf("duplicate account name %q", account.Name)
+		}
+		names[account.Name] = struct{}{}
+		if account.method() == FundDirect {
+			if budget := c.DailyBudget[account.Chain]; budget == nil || budget.Sign() <= 0 {
+				return fmt.Errorf("account %q: a daily budget is required for %s", account.Name, account.Chain)
+			}
+		}
+	}
+	return nil
+}
+
+// UsesChain returns whether any account is funded on the given chain.
+func (c *TopUpConfig) UsesChain(chain Chain) bool {
+	for _, account := range c.Accounts {
+		if account.Chain == chain {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesDrippie returns whether any account is funded via Drippie.
+func (c *TopUpConfig) UsesDrippie() bool {
+	for _, account := range c.Accounts {
+		if account.method() == FundDrippie {
+			return true
+		}
+	}
+	return false
+}
+
+// drips returns the names of the drips that fund accounts.
+func (c *TopUpConfig) drips() map[string]struct{} {
+	drips := make(map[string]struct{})
+	for _, account := range c.Accounts {
+		if account.method() == FundDrippie {
+			drips[account.Drip] = struct{}{}
+		}
+	}
+	return drips
+}
+
+// LoadTopUpConfig reads a TopUpConfig from a JSON file.
+func LoadTopUpConfig(path string) (*TopUpConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read top-up config: %w", err)
+	}
+	var cfg TopUpConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode top-up config: %w", err)
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid top-up config: %w", err)
+	}
+	return &cfg, nil
+}
+
+type BalanceClient interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// TopUpChain is the client and tx manager used to fund accounts on one chain.
+type TopUpChain struct {
+	Client BalanceClient
+	Txmgr  txmgr.TxManager
+}
+
+// dailyBudget tracks the amount spent on direct top-ups in the current UTC day.
+type dailyBudget struct {
+	limit *big.Int
+	day   time.Time
+	spent *big.Int
+}
+
+// reset starts a new day, if the day changed, and returns whether it did.
+func (b *dailyBudget) reset(now time.Time) bool {
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(b.day) {
+		b.day = day
+		b.spent = new(big.Int)
+		return true
+	}
+	return false
+}
+
+func (b *dailyBudget) remaining(now time.Time) *big.Int {
+	b.reset(now)
+	remaining := new(big.Int).Sub(b.limit, b.spent)
+	if remaining.Sign() < 0 {
+		return new(big.Int)
+	}
+	return remaining
+}
+
+// reserve caps the amount to the remaining budget, and spends it.
+// The returned amount is zero if the budget is exhausted.
+func (b *dailyBudget) reserve(now time.Time, amount *big.Int) *big.Int {
+	remaining := b.remaining(now)
+	if amount.Cmp(remaining) > 0 {
+		amount = remaining
+	}
+	b.spent.Add(b.spent, amount)
+	return new(big.Int).Set(amount)
+}
+
+// release returns a reserved amount that was not spent to the budget of the day it was reserved on.
+func (b *dailyBudget) release(day time.Time, amount *big.Int) {
+	if !day.Equal(b.day) {
+		return
+	}
+	b.spent.Sub(b.spent, amount)
+}
+
+// DripFunc executes the named Drippie drip if it is executable, and returns whether it was executed.
+type DripFunc func(ctx context.Context, name string) bool
+
+// TopUps keeps the configured accounts funded above their min balance.
+// Budgets are tracked in memory, and reset at the start of each UTC day or on restart.
+type TopUps struct {
+	log    log.Logger
+	metr   metrics.Metricer
+	cfg    TopUpConfig
+	chains map[Chain]TopUpChain
+	now    func() time.Time
+
+	mu      sync.Mutex
+	budgets map[Chain]*dailyBudget
+}
+
+func NewTopUps(log log.Logger, metr metrics.Metricer, cfg TopUpConfig, chains map[Chain]TopUpChain) (*TopUps, error) {
+	for _, account := range cfg.Accounts {
+		if _, ok := chains[account.Chain]; !ok {
+			return nil, fmt.Errorf("account %q: no client for %s", account.Name, account.Chain)
+		}
+	}
+	budgets := make(map[Chain]*dailyBudget)
+	for chain, limit := range cfg.DailyBudget {
+		budgets[chain] = &dailyBudget{limit: limit}
+	}
+	return &TopUps{
+		log:     log,
+		metr:    metr,
+		cfg:     cfg,
+		chains:  chains,
+		now:     time.Now,
+		budgets: budgets,
+	}, nil
+}
+
+// Run checks the balance of every account, and funds those below their min balance.
+// drip executes drips for accounts funded via Drippie, and may be nil if there are none.
+func (t *TopUps) Run(ctx context.Context, drip DripFunc) {
+	t.resetBudgets()
+	for _, account := range t.cfg.Accounts {
+		if err := t.topUp(ctx, account, drip); err != nil {
+			t.log.Error("failed to top up account", "name", account.Name, "chain", account.Chain, "address", account.Address, "error", err)
+		}
+	}
+}
+
+// resetBudgets starts a new day for the budgets if the day changed, so the spent metric is reset
+// even if no account is topped up that day.
+func (t *TopUps) resetBudgets() {
+	now := t.now()
+	t.mu.Lock()
+	var reset []Chain
+	for chain, budget := range t.budgets {
+		if budget.reset(now) {
+			reset = append(reset, chain)
+		}
+	}
+	t.mu.Unlock()
+	for _, chain := range reset {
+		t.metr.RecordDailyBudgetSpent(string(chain), new(big.Int))
+	}
+}
+
+func (t *TopUps) topUp(ctx context.Context, account TopUpAccount, drip DripFunc) error {
+	chain := t.chains[account.Chain]
+	balance, err := chain.Client.BalanceAt(ctx, account.Address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+	t.metr.RecordAccountBalance(account.Name, string(account.Chain), balance)
+	if balance.Cmp(account.MinBalance) >= 0 {
+		return nil
+	}
+	t.log.Info("account below min balance", "name", account.Name, "chain", account.Chain, "balance", balance, "min", account.MinBalance)
+
+	if account.method() == FundDrippie {
+		if drip == nil || !drip(ctx, account.Drip) {
+			t.metr.RecordTopUpSkipped(account.Name, string(account.Chain))
+			return nil
+		}
+		t.metr.RecordTopUp(account.Name, string(account.Chain), nil)
+		return nil
+	}
+
+	// The amount is reserved before sending, and only released if the transaction was definitely not executed.
+	// A send that fails after broadcasting may still fund the account, and must not free the budget for a retry.
+	wanted := new(big.Int).Sub(account.TargetBalance, balance)
+	t.mu.Lock()
+	budget := t.budgets[account.Chain]
+	amount := budget.reserve(t.now(), wanted)
+	day, spent := budget.day, new(big.Int).Set(budget.spent)
+	t.mu.Unlock()
+	if amount.Sign() == 0 {
+		t.log.Warn("daily budget exhausted, skipping top up", "name", account.Name, "chain", account.Chain, "budget", budget.limit)
+		t.metr.RecordTopUpSkipped(account.Name, string(account.Chain))
+		return nil
+	}
+	if amount.Cmp(wanted) < 0 {
+		t.log.Warn("top up limited by remaining daily budget", "name", account.Name, "chain", account.Chain, "amount", wanted, "remaining", amount)
+	}
+	t.metr.RecordDailyBudgetSpent(string(account.Chain), spent)
+
+	cCtx, cCancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cCancel()
+	t.log.Info("topping up account", "name", account.Name, "chain", account.Chain, "address", account.Address, "amount", amount)
+	receipt, err := chain.Txmgr.Send(cCtx, txmgr.TxCandidate{
+		To:    &account.Address,
+		Value: amount,
+	})
+	if errors.Is(err, txmgr.ErrClosed) {
+		t.releaseBudget(account.Chain, day, amount)
+		return fmt.Errorf("failed to send top up transaction: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send top up transaction: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		t.releaseBudget(account.Chain, day, amount)
+		return fmt.Errorf("top up transaction %s failed", receipt.TxHash)
+	}
+
+	t.log.Info("topped up account", "name", account.Name, "chain", account.Chain, "amount", amount, "tx_hash", receipt.TxHash)
+	t.metr.RecordTopUp(account.Name, string(account.Chain), amount)
+	return nil
+}
+
+// releaseBudget returns a reserved amount to the budget of the chain, for a top up that was not executed.
+func (t *TopUps) releaseBudget(chain Chain, day time.Time, amount *big.Int) {
+	t.mu.Lock()
+	budget := t.budgets[chain]
+	budget.release(day, amount)
+	spent := new(big.Int).Set(budget.spent)
+	t.mu.Unlock()
+	t.metr.RecordDailyBudgetSpent(string(chain), spent)
+}
@@ -0,0 +1,192 @@
+package dripper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-dripper/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+type stubBalanceClient struct {
+	balances map[common.Address]*big.Int
+}
+
+func (s *stubBalanceClient) BalanceAt(_ context.Context, account common.Address, _ *big.Int) (*big.Int, error) {
+	return s.balances[account], nil
+}
+
+func TestTopUpConfig_Check(t *testing.T) {
+	valid := func() TopUpConfig {
+		return TopUpConfig{
+			Accounts: []TopUpAccount{
+				{Name: "batcher", Chain: ChainL1, Address: common.Address{0x01}, MinBalance: big.NewInt(10), TargetBalance: big.NewInt(100)},
+				{Name: "proposer", Chain: ChainL1, Address: common.Address{0x02}, MinBalance: big.NewInt(10), Method: FundDrippie, Drip: "proposer"},
+				{Name: "gas-oracle", Chain: ChainL2, Address: common.Address{0x03}, MinBalance: big.NewInt(10), TargetBalance: big.NewInt(100)},
+			},
+			DailyBudget: map[Chain]*big.Int{ChainL1: big.NewInt(1000), ChainL2: big.NewInt(1000)},
+		}
+	}
+	cfg := valid()
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.UsesChain(ChainL2))
+	require.True(t, cfg.UsesDrippie())
+
+	tests := []struct {
+		name   string
+		modify func(cfg *TopUpConfig)
+		err    string
+	}{
+		{"DuplicateName", func(cfg *TopUpConfig) { cfg.Accounts[1].Name = "batcher" }, "duplicate account name"},
+		{"UnknownChain", func(cfg *TopUpConfig) { cfg.Accounts[0].Chain = "l3" }, "unknown chain"},
+		{"TargetBelowMin", func(cfg *TopUpConfig) { cfg.Accounts[0].TargetBalance = big.NewInt(10) }, "target balance"},
+		{"DrippieOnL2", func(cfg *TopUpConfig) { cfg.Accounts[1].Chain = ChainL2 }, "only supported on L1"},
+		{"MissingDrip", func(cfg *TopUpConfig) { cfg.Accounts[1].Drip = "" }, "drip name is required"},
+		{"MissingBudget", func(cfg *TopUpConfig) { delete(cfg.DailyBudget, ChainL2) }, "daily budget is required"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid()
+			test.modify(&cfg)
+			require.ErrorContains(t, cfg.Check(), test.err)
+		})
+	}
+}
+
+func TestTopUps_Run(t *testing.T) {
+	low, funded := common.Address{0x01}, common.Address{0x02}
+	cfg := TopUpConfig{
+		Accounts: []TopUpAccount{
+			{Name: "low", Chain: ChainL1, Address: low, MinBalance: big.NewInt(50), TargetBalance: big.NewInt(100)},
+			{Name: "funded", Chain: ChainL1, Address: funded, MinBalance: big.NewInt(50), TargetBalance: big.NewInt(100)},
+		},
+		DailyBudget: map[Chain]*big.Int{ChainL1: big.NewInt(100)},
+	}
+	client := &stubBalanceClient{balances: map[common.Address]*big.Int{low: big.NewInt(30), funded: big.NewInt(60)}}
+	txMgr := txmgrmocks.NewTxManager(t)
+	topUps, err := NewTopUps(testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, cfg, map[Chain]TopUpChain{
+		ChainL1: {Client: client, Txmgr: txMgr},
+	})
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	topUps.now = func() time.Time { return now }
+
+	expectTopUp := func(to common.Address, amount int64) {
+		txMgr.On("Send", mock.Anything, txmgr.TxCandidate{To: &to, Value: big.NewInt(amount)}).
+			Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+	}
+
+	// Only the low account is topped up to its target balance
+	expectTopUp(low, 70)
+	topUps.Run(context.Background(), nil)
+	txMgr.AssertExpectations(t)
+
+	// The next top up is limited by the remaining daily budget
+	client.balances[low] = big.NewInt(0)
+	expectTopUp(low, 30)
+	topUps.Run(context.Background(), nil)
+	txMgr.AssertExpectations(t)
+
+	// The budget is exhausted, so no more top ups are sent
+	topUps.Run(context.Background(), nil)
+	txMgr.AssertExpectations(t)
+
+	// The budget resets the next day
+	now = now.Add(24 * time.Hour)
+	expectTopUp(low, 100)
+	topUps.Run(context.Background(), nil)
+	txMgr.AssertExpectations(t)
+}
+
+// budgetMetrics records the daily budget spent per chain
+type budgetMetrics struct {
+	metrics.Metricer
+	spent map[string]int64
+}
+
+func (m *budgetMetrics) RecordDailyBudgetSpent(chain string, spent *big.Int) {
+	m.spent[chain] = spent.Int64()
+}
+
+func TestTopUps_BudgetReservation(t *testing.T) {
+	low := common.Address{0x01}
+	cfg := TopUpConfig{
+		Accounts: []TopUpAccount{
+			{Name: "low", Chain: ChainL1, Address: low, MinBalance: big.NewInt(50), TargetBalance: big.NewInt(100)},
+		},
+		DailyBudget: map[Chain]*big.Int{ChainL1: big.NewInt(100)},
+	}
+	client := &stubBalanceClient{balances: map[common.Address]*big.Int{low: big.NewInt(40)}}
+	txMgr := txmgrmocks.NewTxManager(t)
+	metr := &budgetMetrics{Metricer: metrics.NoopMetrics, spent: make(map[string]int64)}
+	topUps, err := NewTopUps(testlog.Logger(t, log.LevelInfo), metr, cfg, map[Chain]TopUpChain{
+		ChainL1: {Client: client, Txmgr: txMgr},
+	})
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	topUps.now = func() time.Time { return now }
+	candidate := func(amount int64) txmgr.TxCandidate {
+		return txmgr.TxCandidate{To: &low, Value: big.NewInt(amount)}
+	}
+
+	// A transaction that was not sent releases the reserved budget
+	txMgr.On("Send", mock.Anything, candidate(60)).Return(nil, txmgr.ErrClosed).Once()
+	topUps.Run(context.Background(), nil)
+	require.Zero(t, metr.spent[string(ChainL1)])
+
+	// So does a reverted transaction
+	txMgr.On("Send", mock.Anything, candidate(60)).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil).Once()
+	topUps.Run(context.Background(), nil)
+	require.Zero(t, metr.spent[string(ChainL1)])
+
+	// A transaction that may have been broadcast keeps the budget reserved, so a retry cannot exceed it
+	txMgr.On("Send", mock.Anything, candidate(60)).Return(nil, context.DeadlineExceeded).Once()
+	topUps.Run(context.Background(), nil)
+	require.Equal(t, int64(60), metr.spent[string(ChainL1)])
+	txMgr.On("Send", mock.Anything, candidate(40)).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+	topUps.Run(context.Background(), nil)
+	require.Equal(t, int64(100), metr.spent[string(ChainL1)])
+	txMgr.AssertExpectations(t)
+
+	// The spent metric is reset the next day, even without a top up
+	client.balances[low] = big.NewInt(100)
+	now = now.Add(24 * time.Hour)
+	topUps.Run(context.Background(), nil)
+	require.Zero(t, metr.spent[string(ChainL1)])
+}
+
+func TestTopUps_Drippie(t *testing.T) {
+	account := common.Address{0x01}
+	cfg := TopUpConfig{
+		Accounts: []TopUpAccount{
+			{Name: "proposer", Chain: ChainL1, Address: account, MinBalance: big.NewInt(50), Method: FundDrippie, Drip: "proposer-drip"},
+		},
+	}
+	client := &stubBalanceClient{balances: map[common.Address]*big.Int{account: big.NewInt(60)}}
+	topUps, err := NewTopUps(testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, cfg, map[Chain]TopUpChain{
+		ChainL1: {Client: client, Txmgr: txmgrmocks.NewTxManager(t)},
+	})
+	require.NoError(t, err)
+
+	var dripped []string
+	drip := func(_ context.Context, name string) bool {
+		dripped = append(dripped, name)
+		return true
+	}
+	topUps.Run(context.Background(), drip)
+	require.Empty(t, dripped, "should not drip while above the min balance")
+
+	client.balances[account] = big.NewInt(40)
+	topUps.Run(context.Background(), drip)
+	require.Equal(t, []string{"proposer-drip"}, dripped)
+}
@@ -28,14 +28,25 @@ var (
 		EnvVars:  prefixEnvVars("L1_ETH_RPC"),
 		Required: true,
 	}
-	DrippieAddressFlag = &cli.StringFlag{
-		Name:     "drippie-address",
-		Usage:    "The address of the drippie contract",
-		EnvVars:  prefixEnvVars("DRIPPIE_ADDRESS"),
-		Required: true,
-	}
 
 	// Optional Flags
+	DrippieAddressFlag = &cli.StringFlag{
+		Name:    "drippie-address",
+		Usage:   "The address of the drippie contract. Required unless top-up accounts are configured.",
+		EnvVars: prefixEnvVars("DRIPPIE_ADDRESS"),
+	}
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:    "l2-eth-rpc",
+		Usage:   "The RPC URL for the L2 chain. Required to top up L2 accounts.",
+		EnvVars: prefixEnvVars("L2_ETH_RPC"),
+	}
+	TopUpConfigFlag = &cli.PathFlag{
+		Name: "topup-config",
+		Usage: "Path to a JSON file declaring the L1 and L2 accounts to keep funded, with their min and target balances, " +
+			"funding method and the daily budget per chain.",
+		EnvVars:   prefixEnvVars("TOPUP_CONFIG"),
+		TakesFile: true,
+	}
 	PollIntervalFlag = &cli.DurationFlag{
 		Name:    "poll-interval",
 		Usage:   "How frequently to poll L2 for new blocks (legacy L2OO)",
@@ -46,11 +57,13 @@ var (
 
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
-	DrippieAddressFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	DrippieAddressFlag,
 	PollIntervalFlag,
+	L2EthRpcFlag,
+	TopUpConfigFlag,
 }
 
 func init() {
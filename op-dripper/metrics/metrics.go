@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -25,9 +27,18 @@ type Metricer interface {
 	opmetrics.RPCMetricer
 	txmetrics.TxMetricer
 
+	// L2TxMetrics returns the metrics of the L2 tx manager, used for L2 top ups.
+	// They are kept separate from the L1 tx manager metrics, which are recorded by the Metricer itself.
+	L2TxMetrics() txmetrics.TxMetricer
+
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
 	RecordDripExecuted(name string)
+
+	RecordAccountBalance(name string, chain string, balance *big.Int)
+	RecordTopUp(name string, chain string, amount *big.Int)
+	RecordTopUpSkipped(name string, chain string)
+	RecordDailyBudgetSpent(chain string, spent *big.Int)
 }
 
 type Metrics struct {
@@ -39,9 +50,17 @@ type Metrics struct {
 	opmetrics.RPCMetrics
 	txmetrics.TxMetrics
 
+	l2TxMetrics txmetrics.TxMetrics
+
 	info  prometheus.GaugeVec
 	drips prometheus.GaugeVec
 	up    prometheus.Gauge
+
+	accountBalance   prometheus.GaugeVec
+	topUps           prometheus.CounterVec
+	topUpAmount      prometheus.CounterVec
+	topUpsSkipped    prometheus.CounterVec
+	dailyBudgetSpent prometheus.GaugeVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -64,6 +83,8 @@ func NewMetrics(procName string) *Metrics {
 		RPCMetrics: opmetrics.MakeRPCMetrics(ns, factory),
 		TxMetrics:  txmetrics.MakeTxMetrics(ns, factory),
 
+		l2TxMetrics: txmetrics.MakeTxMetrics(ns+"_l2", factory),
+
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "info",
@@ -83,6 +104,45 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-dripper has finished starting up",
 		}),
+		accountBalance: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "account_balance",
+			Help:      "Balance in ETH of the accounts kept funded",
+		}, []string{
+			"name",
+			"chain",
+		}),
+		topUps: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "top_ups_total",
+			Help:      "Number of top ups of the accounts kept funded",
+		}, []string{
+			"name",
+			"chain",
+		}),
+		topUpAmount: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "top_up_amount_total",
+			Help:      "Amount in ETH sent directly to the accounts kept funded",
+		}, []string{
+			"name",
+			"chain",
+		}),
+		topUpsSkipped: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "top_ups_skipped_total",
+			Help:      "Number of top ups skipped, because the daily budget was exhausted or the drip was not executable",
+		}, []string{
+			"name",
+			"chain",
+		}),
+		dailyBudgetSpent: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "daily_budget_spent",
+			Help:      "Amount in ETH of the daily budget spent on direct top ups",
+		}, []string{
+			"chain",
+		}),
 	}
 }
 
@@ -90,6 +150,10 @@ func (m *Metrics) Registry() *prometheus.Registry {
 	return m.registry
 }
 
+func (m *Metrics) L2TxMetrics() txmetrics.TxMetricer {
+	return &m.l2TxMetrics
+}
+
 func (m *Metrics) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
 	return opmetrics.LaunchBalanceMetrics(l, m.registry, m.ns, client, account)
 }
@@ -106,6 +170,27 @@ func (m *Metrics) RecordDripExecuted(name string) {
 	m.drips.WithLabelValues(name).Inc()
 }
 
+func (m *Metrics) RecordAccountBalance(name string, chain string, balance *big.Int) {
+	m.accountBalance.WithLabelValues(name, chain).Set(eth.WeiToEther(balance))
+}
+
+// RecordTopUp records a top up of the named account. The amount is nil if it is not known,
+// e.g. when the account is funded by a drip.
+func (m *Metrics) RecordTopUp(name string, chain string, amount *big.Int) {
+	m.topUps.WithLabelValues(name, chain).Inc()
+	if amount != nil {
+		m.topUpAmount.WithLabelValues(name, chain).Add(eth.WeiToEther(amount))
+	}
+}
+
+func (m *Metrics) RecordTopUpSkipped(name string, chain string) {
+	m.topUpsSkipped.WithLabelValues(name, chain).Inc()
+}
+
+func (m *Metrics) RecordDailyBudgetSpent(chain string, spent *big.Int) {
+	m.dailyBudgetSpent.WithLabelValues(chain).Set(eth.WeiToEther(spent))
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func TestMetrics_SeparateL2TxMetrics(t *testing.T) {
+	procName := "test"
+	prefix := Namespace + "_" + procName + "_"
+
+	m := NewMetrics(procName)
+	m.RecordNonce(1)
+	m.L2TxMetrics().RecordNonce(7)
+
+	checker := opmetrics.NewMetricChecker(t, m.Registry())
+	require.Equal(t, 1.0, checker.FindByName(prefix+"txmgr_current_nonce").FindByLabels(nil).Gauge.GetValue())
+	require.Equal(t, 7.0, checker.FindByName(prefix+"l2_txmgr_current_nonce").FindByLabels(nil).Gauge.GetValue())
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -24,6 +25,15 @@ func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordDripExecuted(name string) {}
 
+func (*noopMetrics) RecordAccountBalance(string, string, *big.Int) {}
+func (*noopMetrics) RecordTopUp(string, string, *big.Int)          {}
+func (*noopMetrics) RecordTopUpSkipped(string, string)             {}
+func (*noopMetrics) RecordDailyBudgetSpent(string, *big.Int)       {}
+
+func (*noopMetrics) L2TxMetrics() txmetrics.TxMetricer {
+	return new(txmetrics.NoopTxMetrics)
+}
+
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
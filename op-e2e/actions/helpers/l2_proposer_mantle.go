@@ -18,7 +18,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
-	"github.com/ethereum-optimism/optimism/op-e2e/mantlebindings"
+	bindingsmantle "github.com/ethereum-optimism/optimism/op-node/bindings/mantle"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/source"
@@ -33,7 +33,7 @@ type MantleL2Proposer struct {
 	l1                     *ethclient.Client
 	driver                 *proposer.L2OutputSubmitter
 	disputeGameFactory     *bindings.DisputeGameFactoryCaller
-	l2OutputOracle         *mantlebindings.L2OutputOracleCaller
+	l2OutputOracle         *bindingsmantle.L2OutputOracleCaller
 	l2OutputOracleAddr     *common.Address
 	disputeGameFactoryAddr *common.Address
 	address                common.Address
@@ -81,10 +81,10 @@ func NewMantleL2Proposer(t Testing, log log.Logger, cfg *MantleProposerCfg, l1 *
 
 	address := crypto.PubkeyToAddress(cfg.ProposerKey.PublicKey)
 
-	var l2OutputOracle *mantlebindings.L2OutputOracleCaller
+	var l2OutputOracle *bindingsmantle.L2OutputOracleCaller
 	var disputeGameFactory *bindings.DisputeGameFactoryCaller
 
-	l2OutputOracle, err = mantlebindings.NewL2OutputOracleCaller(*cfg.OutputOracleAddr, l1)
+	l2OutputOracle, err = bindingsmantle.NewL2OutputOracleCaller(*cfg.OutputOracleAddr, l1)
 	require.NoError(t, err)
 	proposer, err := l2OutputOracle.PROPOSER(&bind.CallOpts{})
 	require.NoError(t, err)
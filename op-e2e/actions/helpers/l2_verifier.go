@@ -294,6 +294,22 @@ func (s *l2VerifierBackend) StopSequencer(ctx context.Context) (common.Hash, err
 	return common.Hash{}, errors.New("stopping the L2Verifier sequencer is not supported")
 }
 
+func (s *l2VerifierBackend) ScheduleSequencerStop(ctx context.Context, blockNum uint64) error {
+	return errors.New("scheduling a L2Verifier sequencer stop is not supported")
+}
+
+func (s *l2VerifierBackend) ScheduleSequencerStopAtTime(ctx context.Context, timestamp uint64) (uint64, error) {
+	return 0, errors.New("scheduling a L2Verifier sequencer stop is not supported")
+}
+
+func (s *l2VerifierBackend) ScheduleSequencerStart(ctx context.Context, blockNum uint64, blockHash common.Hash) error {
+	return errors.New("scheduling a L2Verifier sequencer start is not supported")
+}
+
+func (s *l2VerifierBackend) CancelSequencerHandover(ctx context.Context) error {
+	return nil
+}
+
 func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
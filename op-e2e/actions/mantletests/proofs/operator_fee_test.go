@@ -10,6 +10,7 @@ import (
 	actionsHelpers "github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/mantletests/proofs/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/mantlebindings"
+	bindingsmantle "github.com/ethereum-optimism/optimism/op-node/bindings/mantle"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -267,7 +268,7 @@ func Test_ProgramAction_OperatorFeeConsistency(gt *testing.T) {
 			t.Logf("OptimismPortal address: %s", portalAddr.Hex())
 
 			// Create Mantle OptimismPortal binding
-			mantlePortal, err := mantlebindings.NewOptimismPortal(portalAddr, env.Miner.EthClient())
+			mantlePortal, err := bindingsmantle.NewOptimismPortal(portalAddr, env.Miner.EthClient())
 			require.NoError(t, err, "failed to create Mantle OptimismPortal binding")
 
 			// Check if portal is paused
@@ -10,7 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	actionsHelpers "github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/mantletests/proofs/helpers"
-	"github.com/ethereum-optimism/optimism/op-e2e/mantlebindings"
+	bindingsmantle "github.com/ethereum-optimism/optimism/op-node/bindings/mantle"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,7 +31,7 @@ func runSequenceWindowExpireTest(gt *testing.T, testCfg *helpers.TestCfg[any]) {
 
 	// Create Mantle OptimismPortal binding for 7-parameter deposit transaction
 	portalAddr := env.Dp.DeployConfig.OptimismPortalProxy
-	mantlePortal, err := mantlebindings.NewOptimismPortal(portalAddr, env.Miner.EthClient())
+	mantlePortal, err := bindingsmantle.NewOptimismPortal(portalAddr, env.Miner.EthClient())
 	require.NoError(t, err, "failed to create Mantle OptimismPortal binding")
 
 	// Expire the sequence window by building `SequenceWindow + 1` empty blocks on L1.
@@ -127,7 +127,7 @@ func runSequenceWindowExpire_ChannelCloseAfterWindowExpiry_Test(gt *testing.T, t
 
 	// Create Mantle OptimismPortal binding for 7-parameter deposit transaction
 	portalAddr := env.Dp.DeployConfig.OptimismPortalProxy
-	mantlePortal, err := mantlebindings.NewOptimismPortal(portalAddr, env.Miner.EthClient())
+	mantlePortal, err := bindingsmantle.NewOptimismPortal(portalAddr, env.Miner.EthClient())
 	require.NoError(t, err, "failed to create Mantle OptimismPortal binding")
 
 	// Expire the sequence window by building `SequenceWindow + 1` empty blocks on L1.
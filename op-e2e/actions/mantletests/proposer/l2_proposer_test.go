@@ -15,7 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
-	"github.com/ethereum-optimism/optimism/op-e2e/mantlebindings"
+	bindingsmantle "github.com/ethereum-optimism/optimism/op-node/bindings/mantle"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
@@ -102,7 +102,7 @@ func runProposerTest(gt *testing.T, isSpanBatch bool, allocType config.AllocType
 
 	// check that L1 stored the expected output root
 
-	outputOracleContract, err := mantlebindings.NewL2OutputOracle(sd.DeploymentsL1.L2OutputOracleProxy, miner.EthClient())
+	outputOracleContract, err := bindingsmantle.NewL2OutputOracle(sd.DeploymentsL1.L2OutputOracleProxy, miner.EthClient())
 	require.NoError(t, err)
 	blockNumber, err := outputOracleContract.LatestBlockNumber(&bind.CallOpts{})
 	require.NoError(t, err)
@@ -18,8 +18,13 @@ if [[ $# -ge 1 ]]; then
   CONTRACTS=("$@")
 fi
 
-OUTPUT_DIR="op-e2e/mantlebindings"
-mkdir -p "${OUTPUT_DIR}"
+# The portal and output oracle bindings are used outside of op-e2e, and live in op-node/bindings.
+output_location() {
+  case "$1" in
+    L2OutputOracle | OptimismPortal) echo "op-node/bindings/mantle bindingsmantle" ;;
+    *) echo "op-e2e/mantlebindings mantlebindings" ;;
+  esac
+}
 
 TMPDIR="$(mktemp -d)"
 trap 'rm -rf "${TMPDIR}"' EXIT
@@ -33,6 +38,8 @@ for CONTRACT in "${CONTRACTS[@]}"; do
     exit 1
   fi
 
+  read -r OUTPUT_DIR OUTPUT_PKG <<< "$(output_location "${CONTRACT}")"
+  mkdir -p "${OUTPUT_DIR}"
   OUTPUT_BASENAME="$(echo "${CONTRACT}" | tr '[:upper:]' '[:lower:]')"
   OUTPUT_PATH="${OUTPUT_DIR}/${OUTPUT_BASENAME}.go"
 
@@ -43,7 +50,7 @@ for CONTRACT in "${CONTRACTS[@]}"; do
   jq -r '.bytecode.object' "${ARTIFACT_PATH}" > "${BIN_PATH}"
 
   echo "generating ${OUTPUT_PATH} ..."
-  abigen --pkg mantlebindings --type "${CONTRACT}" --abi "${ABI_PATH}" --bin "${BIN_PATH}" --out "${OUTPUT_PATH}"
+  abigen --pkg "${OUTPUT_PKG}" --type "${CONTRACT}" --abi "${ABI_PATH}" --bin "${BIN_PATH}" --out "${OUTPUT_PATH}"
   gofmt -w "${OUTPUT_PATH}"
 done
 
@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/config"
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
+)
+
+var ErrChallengeFailed = errors.New("challenge failed")
+
+// Challenger guards the requests of a public faucet, e.g. with a proof-of-work or a captcha.
+// Other kinds of challenges can be hooked into a faucet with Faucet.SetChallenger.
+type Challenger interface {
+	// Challenge returns a new challenge for the target to solve.
+	Challenge(ctx context.Context, target common.Address) (*ftypes.Challenge, error)
+	// Verify checks the solution of the target. A solution may only be used once.
+	Verify(ctx context.Context, target common.Address, solution *ftypes.ChallengeSolution) error
+}
+
+func challengerFromConfig(cfg *config.ChallengeConfig) Challenger {
+	if cfg.Captcha != nil {
+		return NewSiteVerifyCaptcha(cfg.Captcha.VerifyURL, cfg.Captcha.Secret)
+	}
+	return NewProofOfWork(cfg.ProofOfWork)
+}
+
+const proofOfWorkTTL = 10 * time.Minute
+
+// ProofOfWork is a Challenger that issues single-use proof-of-work challenges, see ftypes.ProofOfWorkHash.
+//
+// Challenges are stateless, so issuing them costs no memory: the seed holds the expiry of the challenge,
+// and a MAC that binds the seed to the target and the difficulty. Only the seeds of solved challenges
+// are kept, until they expire, so every kept seed cost its client a proof-of-work.
+// The MAC key is generated on startup, so challenges issued before a restart are invalid after it.
+type ProofOfWork struct {
+	mu sync.Mutex
+
+	difficulty uint8
+	key        [32]byte
+	// spent holds the expiry of every solved seed that has not expired yet
+	spent     map[common.Hash]time.Time
+	lastPrune time.Time
+
+	now func() time.Time
+}
+
+var _ Challenger = (*ProofOfWork)(nil)
+
+func NewProofOfWork(difficulty uint8) *ProofOfWork {
+	p := &ProofOfWork{
+		difficulty: difficulty,
+		spent:      make(map[common.Hash]time.Time),
+		now:        time.Now,
+	}
+	if _, err := rand.Read(p.key[:]); err != nil {
+		panic(fmt.Errorf("failed to generate proof-of-work key: %w", err))
+	}
+	return p
+}
+
+// mac authenticates the first 16 bytes of the seed, the expiry and a random nonce, for the target and difficulty.
+func (p *ProofOfWork) mac(seed common.Hash, target common.Address) []byte {
+	h := hmac.New(sha256.New, p.key[:])
+	h.Write(seed[:16])
+	h.Write(target[:])
+	h.Write([]byte{p.difficulty})
+	return h.Sum(nil)[:16]
+}
+
+func (p *ProofOfWork) Challenge(ctx context.Context, target common.Address) (*ftypes.Challenge, error) {
+	// The seed is the expiry, a random nonce, and the MAC of both
+	var seed common.Hash
+	binary.BigEndian.PutUint64(seed[:8], uint64(p.now().Add(proofOfWorkTTL).Unix()))
+	if _, err := rand.Read(seed[8:16]); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge seed: %w", err)
+	}
+	copy(seed[16:], p.mac(seed, target))
+	return &ftypes.Challenge{Kind: ftypes.ProofOfWorkChallenge, Seed: seed, Difficulty: p.difficulty}, nil
+}
+
+func (p *ProofOfWork) Verify(ctx context.Context, target common.Address, solution *ftypes.ChallengeSolution) error {
+	if solution == nil {
+		return fmt.Errorf("%w: proof-of-work solution required", ErrChallengeFailed)
+	}
+	seed := solution.Seed
+	if !hmac.Equal(seed[16:], p.mac(seed, target)) {
+		return fmt.Errorf("%w: unknown challenge", ErrChallengeFailed)
+	}
+	now := p.now()
+	expiry := time.Unix(int64(binary.BigEndian.Uint64(seed[:8])), 0)
+	if now.After(expiry) {
+		return fmt.Errorf("%w: expired challenge", ErrChallengeFailed)
+	}
+	h := ftypes.ProofOfWorkHash(seed, target, uint64(solution.Nonce))
+	if ftypes.LeadingZeroBits(h) < int(p.difficulty) {
+		return fmt.Errorf("%w: insufficient proof-of-work", ErrChallengeFailed)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maybePrune(now)
+	if _, ok := p.spent[seed]; ok {
+		return fmt.Errorf("%w: challenge already used", ErrChallengeFailed)
+	}
+	p.spent[seed] = expiry
+	return nil
+}
+
+// maybePrune drops the expired seeds, at most once per TTL, to bound memory use.
+func (p *ProofOfWork) maybePrune(now time.Time) {
+	if now.Sub(p.lastPrune) < proofOfWorkTTL {
+		return
+	}
+	p.lastPrune = now
+	for seed, expiry := range p.spent {
+		if now.After(expiry) {
+			delete(p.spent, seed)
+		}
+	}
+}
+
+// SiteVerifyCaptcha is a Challenger that verifies captcha responses with a siteverify API,
+// as offered by hCaptcha, reCAPTCHA and Cloudflare Turnstile.
+// The captcha itself is served by the faucet website, the faucet only verifies the response token.
+type SiteVerifyCaptcha struct {
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+var _ Challenger = (*SiteVerifyCaptcha)(nil)
+
+func NewSiteVerifyCaptcha(verifyURL string, secret string) *SiteVerifyCaptcha {
+	return &SiteVerifyCaptcha{
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *SiteVerifyCaptcha) Challenge(ctx context.Context, target common.Address) (*ftypes.Challenge, error) {
+	return &ftypes.Challenge{Kind: ftypes.CaptchaChallenge}, nil
+}
+
+func (c *SiteVerifyCaptcha) Verify(ctx context.Context, target common.Address, solution *ftypes.ChallengeSolution) error {
+	if solution == nil || solution.Captcha == "" {
+		return fmt.Errorf("%w: captcha response required", ErrChallengeFailed)
+	}
+	form := url.Values{"secret": {c.secret}, "response": {solution.Captcha}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create captcha verification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to verify captcha: status %d", resp.StatusCode)
+	}
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode captcha verification: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: captcha rejected %v", ErrChallengeFailed, result.ErrorCodes)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
+)
+
+func TestProofOfWork(t *testing.T) {
+	ctx := context.Background()
+	p := NewProofOfWork(8)
+	now := time.Unix(1_000_000, 0)
+	p.now = func() time.Time { return now }
+	alice := common.Address{0xa1}
+	bob := common.Address{0xb0}
+
+	challenge, err := p.Challenge(ctx, alice)
+	require.NoError(t, err)
+	require.Equal(t, ftypes.ProofOfWorkChallenge, challenge.Kind)
+	require.Equal(t, uint8(8), challenge.Difficulty)
+
+	solution := ftypes.SolveProofOfWork(challenge, alice)
+	require.ErrorIs(t, p.Verify(ctx, bob, solution), ErrChallengeFailed, "solution is bound to the target")
+	require.ErrorIs(t, p.Verify(ctx, alice, nil), ErrChallengeFailed)
+
+	invalid := *solution
+	for ftypes.LeadingZeroBits(ftypes.ProofOfWorkHash(invalid.Seed, alice, uint64(invalid.Nonce))) >= 8 {
+		invalid.Nonce++
+	}
+	require.ErrorIs(t, p.Verify(ctx, alice, &invalid), ErrChallengeFailed)
+
+	require.NoError(t, p.Verify(ctx, alice, solution))
+	require.ErrorIs(t, p.Verify(ctx, alice, solution), ErrChallengeFailed, "solutions are single-use")
+	require.Len(t, p.spent, 1, "only solved challenges are kept")
+
+	t.Run("stateless", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			_, err := p.Challenge(ctx, alice)
+			require.NoError(t, err)
+		}
+		require.Len(t, p.spent, 1, "issued challenges are not kept")
+	})
+
+	t.Run("forged", func(t *testing.T) {
+		forged := &ftypes.Challenge{Kind: ftypes.ProofOfWorkChallenge, Difficulty: 8}
+		require.ErrorIs(t, p.Verify(ctx, alice, ftypes.SolveProofOfWork(forged, alice)), ErrChallengeFailed)
+
+		// the expiry of a seed cannot be extended
+		challenge, err := p.Challenge(ctx, alice)
+		require.NoError(t, err)
+		challenge.Seed[0]++
+		require.ErrorIs(t, p.Verify(ctx, alice, ftypes.SolveProofOfWork(challenge, alice)), ErrChallengeFailed)
+
+		// challenges of another faucet are not accepted
+		other := NewProofOfWork(8)
+		other.now = p.now
+		challenge, err = other.Challenge(ctx, alice)
+		require.NoError(t, err)
+		require.ErrorIs(t, p.Verify(ctx, alice, ftypes.SolveProofOfWork(challenge, alice)), ErrChallengeFailed)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		challenge, err := p.Challenge(ctx, alice)
+		require.NoError(t, err)
+		solution := ftypes.SolveProofOfWork(challenge, alice)
+		now = now.Add(proofOfWorkTTL + time.Second)
+		require.ErrorIs(t, p.Verify(ctx, alice, solution), ErrChallengeFailed)
+
+		challenge, err = p.Challenge(ctx, bob)
+		require.NoError(t, err)
+		require.NoError(t, p.Verify(ctx, bob, ftypes.SolveProofOfWork(challenge, bob)))
+		require.Len(t, p.spent, 1, "expired seeds are dropped")
+	})
+}
+
+func TestSiteVerifyCaptcha(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("response") == "good" {
+			_, _ = w.Write([]byte(`{"success":true}`))
+		} else {
+			_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewSiteVerifyCaptcha(srv.URL, "secret")
+	alice := common.Address{0xa1}
+
+	challenge, err := c.Challenge(ctx, alice)
+	require.NoError(t, err)
+	require.Equal(t, ftypes.CaptchaChallenge, challenge.Kind)
+
+	require.NoError(t, c.Verify(ctx, alice, &ftypes.ChallengeSolution{Captcha: "good"}))
+	err = c.Verify(ctx, alice, &ftypes.ChallengeSolution{Captcha: "bad"})
+	require.ErrorIs(t, err, ErrChallengeFailed)
+	require.ErrorContains(t, err, "invalid-input-response")
+	require.ErrorIs(t, c.Verify(ctx, alice, nil), ErrChallengeFailed)
+}
@@ -2,9 +2,11 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
@@ -44,7 +46,95 @@ type FaucetEntry struct {
 
 	TxCfg TxManagerConfig `yaml:"tx_cfg"`
 
-	// We may add allow-lists, rate-limits, etc. in the future here
+	// Tokens are the ERC-20 tokens the faucet funds with, in addition to the native currency.
+	Tokens map[ftypes.TokenID]*TokenEntry `yaml:"tokens,omitempty"`
+
+	// Public enables the public mode, for faucets on public testnets.
+	// Without it, the faucet serves every request, as is fine for devnets.
+	Public *PublicConfig `yaml:"public,omitempty"`
+}
+
+// TokenEntry configures an ERC-20 token of a faucet, e.g. MNT on L1.
+type TokenEntry struct {
+	Address common.Address `yaml:"address"`
+
+	// Amount is the default amount to fund per request, in the smallest unit of the token.
+	// In public mode, it is also the maximum amount per request.
+	Amount eth.ETH `yaml:"amount,omitempty"`
+}
+
+// PublicConfig configures the abuse controls of a public faucet.
+// Limits apply per funded asset: the native currency and every token are limited separately.
+type PublicConfig struct {
+	// Amount is the native amount to fund per request, in wei.
+	// It is also the maximum amount a request may ask for.
+	Amount eth.ETH `yaml:"amount"`
+
+	// Cooldown is the window in which the address and IP limits apply.
+	Cooldown time.Duration `yaml:"cooldown"`
+
+	// AddressLimit is the number of requests per target address per cooldown. 0 is unlimited.
+	AddressLimit uint64 `yaml:"address_limit,omitempty"`
+
+	// IPLimit is the number of requests per client IP per cooldown. 0 is unlimited.
+	IPLimit uint64 `yaml:"ip_limit,omitempty"`
+
+	// TrustForwardedFor identifies clients by the X-Forwarded-For header, instead of the connection address.
+	// Only enable this behind a trusted proxy, as clients can set the header freely.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for,omitempty"`
+
+	// Challenge requires clients to solve a challenge before they are funded.
+	Challenge *ChallengeConfig `yaml:"challenge,omitempty"`
+
+	// RequestLog is the path of a file to append every funded request to, as JSON lines.
+	// The log is replayed on startup, so the limits persist across restarts.
+	// Only the requests within the cooldown are replayed. The log is never truncated,
+	// so it keeps the full history of funded requests, and can be rotated externally while stopped.
+	RequestLog string `yaml:"request_log,omitempty"`
+}
+
+func (c *PublicConfig) Check() error {
+	if c.Amount.IsZero() {
+		return errors.New("public faucets require an amount")
+	}
+	if c.AddressLimit == 0 && c.IPLimit == 0 && c.Challenge == nil {
+		return errors.New("public faucets require a request limit or a challenge")
+	}
+	if c.Cooldown <= 0 && (c.AddressLimit != 0 || c.IPLimit != 0) {
+		return errors.New("request limits require a cooldown")
+	}
+	if c.Challenge != nil {
+		if err := c.Challenge.Check(); err != nil {
+			return fmt.Errorf("invalid challenge config: %w", err)
+		}
+	}
+	return nil
+}
+
+// ChallengeConfig configures the challenge of a public faucet.
+// Exactly one kind of challenge must be configured.
+type ChallengeConfig struct {
+	// ProofOfWork is the number of leading zero bits a proof-of-work solution must have.
+	ProofOfWork uint8 `yaml:"proof_of_work,omitempty"`
+
+	// Captcha verifies captcha responses with a siteverify API,
+	// as offered by hCaptcha, reCAPTCHA and Cloudflare Turnstile.
+	Captcha *CaptchaConfig `yaml:"captcha,omitempty"`
+}
+
+func (c *ChallengeConfig) Check() error {
+	if (c.ProofOfWork == 0) == (c.Captcha == nil) {
+		return errors.New("expected either proof_of_work or captcha")
+	}
+	if c.Captcha != nil && (c.Captcha.VerifyURL == "" || c.Captcha.Secret == "") {
+		return errors.New("captcha requires verify_url and secret")
+	}
+	return nil
+}
+
+type CaptchaConfig struct {
+	VerifyURL string `yaml:"verify_url"`
+	Secret    string `yaml:"secret"`
 }
 
 func (f *FaucetEntry) TxManagerConfig(logger log.Logger) (*txmgr.Config, error) {
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -78,3 +79,55 @@ func TestStaticConfigLoad(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, cfg, result)
 }
+
+func TestPublicConfig_Check(t *testing.T) {
+	valid := func() *PublicConfig {
+		return &PublicConfig{
+			Amount:       eth.OneEther,
+			Cooldown:     time.Hour,
+			AddressLimit: 1,
+			IPLimit:      2,
+			Challenge:    &ChallengeConfig{ProofOfWork: 16},
+		}
+	}
+	require.NoError(t, valid().Check())
+
+	t.Run("no amount", func(t *testing.T) {
+		cfg := valid()
+		cfg.Amount = eth.ETH{}
+		require.ErrorContains(t, cfg.Check(), "require an amount")
+	})
+	t.Run("limits without cooldown", func(t *testing.T) {
+		cfg := valid()
+		cfg.Cooldown = 0
+		require.ErrorContains(t, cfg.Check(), "require a cooldown")
+		cfg.AddressLimit = 0
+		cfg.IPLimit = 0
+		require.NoError(t, cfg.Check())
+	})
+	t.Run("no limits and no challenge", func(t *testing.T) {
+		cfg := valid()
+		cfg.AddressLimit = 0
+		cfg.IPLimit = 0
+		require.NoError(t, cfg.Check(), "the challenge alone guards the faucet")
+		cfg.Challenge = nil
+		require.ErrorContains(t, cfg.Check(), "require a request limit or a challenge")
+		cfg.IPLimit = 1
+		require.NoError(t, cfg.Check(), "the limit alone guards the faucet")
+	})
+	t.Run("no challenge kind", func(t *testing.T) {
+		cfg := valid()
+		cfg.Challenge = &ChallengeConfig{}
+		require.ErrorContains(t, cfg.Check(), "expected either")
+	})
+	t.Run("both challenge kinds", func(t *testing.T) {
+		cfg := valid()
+		cfg.Challenge.Captcha = &CaptchaConfig{VerifyURL: "http://localhost/siteverify", Secret: "secret"}
+		require.ErrorContains(t, cfg.Check(), "expected either")
+	})
+	t.Run("captcha without secret", func(t *testing.T) {
+		cfg := valid()
+		cfg.Challenge = &ChallengeConfig{Captcha: &CaptchaConfig{VerifyURL: "http://localhost/siteverify"}}
+		require.ErrorContains(t, cfg.Check(), "requires verify_url and secret")
+	})
+}
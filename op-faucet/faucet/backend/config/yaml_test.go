@@ -5,8 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestYamlLoader_Load(t *testing.T) {
@@ -14,6 +17,24 @@ func TestYamlLoader_Load(t *testing.T) {
 	result, err := x.Load(context.Background())
 	require.NoError(t, err)
 	require.NotEmpty(t, result.Faucets)
+
+	public := result.Faucets["public-l1"]
+	require.NotNil(t, public)
+	require.Equal(t, eth.Ether(10), public.Tokens["mnt"].Amount)
+	require.NotNil(t, public.Public)
+	require.Equal(t, eth.GWei(100_000_000), public.Public.Amount)
+	require.Equal(t, 24*time.Hour, public.Public.Cooldown)
+	require.Equal(t, uint64(1), public.Public.AddressLimit)
+	require.Equal(t, uint64(3), public.Public.IPLimit)
+	require.Equal(t, uint8(20), public.Public.Challenge.ProofOfWork)
+	require.NoError(t, public.Public.Check())
+
+	// A challenge alone is enough to guard a public faucet
+	captcha := result.Faucets["public-captcha"]
+	require.NotNil(t, captcha)
+	require.Zero(t, captcha.Public.Cooldown)
+	require.Equal(t, "https://hcaptcha.com/siteverify", captcha.Public.Challenge.Captcha.VerifyURL)
+	require.NoError(t, captcha.Public.Check())
 }
 
 func TestYamlLoader_NotFound(t *testing.T) {
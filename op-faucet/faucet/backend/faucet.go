@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -31,6 +33,12 @@ type Faucet struct {
 	txMgr    txmgr.TxManager
 	elClient apis.EthBalance
 
+	// tokens are the ERC-20 tokens the faucet funds with, by ID
+	tokens map[ftypes.TokenID]*config.TokenEntry
+
+	// public holds the abuse controls of a public faucet, nil if the faucet is not public
+	public *publicMode
+
 	// true when the faucet is disabled and may not serve any new faucet requests
 	disabled bool
 }
@@ -51,7 +59,32 @@ func FaucetFromConfig(logger log.Logger, m metrics.Metricer, fID ftypes.FaucetID
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial EL client: %w", err)
 	}
-	return faucetWithTxManager(logger, m, fID, txMgr, elClient), nil
+	f := faucetWithTxManager(logger, m, fID, txMgr, elClient)
+	if err := f.setup(fCfg); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *Faucet) setup(fCfg *config.FaucetEntry) error {
+	for id, token := range fCfg.Tokens {
+		if id == "" || token == nil || token.Address == (common.Address{}) {
+			return fmt.Errorf("invalid token %q: an ID and address are required", id)
+		}
+		if fCfg.Public != nil && token.Amount.IsZero() {
+			return fmt.Errorf("invalid token %q: public faucets require an amount", id)
+		}
+	}
+	f.tokens = fCfg.Tokens
+	if fCfg.Public != nil {
+		public, err := newPublicMode(f.log, fCfg.Public)
+		if err != nil {
+			return fmt.Errorf("failed to setup public mode: %w", err)
+		}
+		f.public = public
+	}
+	return nil
 }
 
 func faucetWithTxManager(logger log.Logger, m metrics.Metricer, fID ftypes.FaucetID, txMgr txmgr.TxManager, elClient apis.EthBalance) *Faucet {
@@ -84,6 +117,34 @@ func (f *Faucet) Close() {
 	f.log.Info("Closing faucet")
 	f.Disable()
 	f.txMgr.Close()
+	if f.public != nil {
+		if err := f.public.Close(); err != nil {
+			f.log.Error("Failed to close request log", "err", err)
+		}
+	}
+}
+
+// SetChallenger replaces the challenge that clients of the public faucet have to solve,
+// e.g. to hook in a captcha provider that is not supported by the config.
+func (f *Faucet) SetChallenger(c Challenger) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.public == nil {
+		return errors.New("only public faucets support challenges")
+	}
+	f.public.challenger = c
+	return nil
+}
+
+// Challenge returns a new challenge for the target to solve before requesting funds.
+// It returns nil if the faucet does not require a challenge.
+func (f *Faucet) Challenge(ctx context.Context, target common.Address) (*ftypes.Challenge, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.public == nil || f.public.challenger == nil {
+		return nil, nil
+	}
+	return f.public.challenger.Challenge(ctx, target)
 }
 
 func (f *Faucet) Balance() (eth.ETH, error) {
@@ -102,29 +163,113 @@ func (f *Faucet) ChainID() eth.ChainID {
 	return f.chainID
 }
 
-func (f *Faucet) RequestETH(ctx context.Context, request *ftypes.FaucetRequest) (result error) {
+func (f *Faucet) RequestETH(ctx context.Context, request *ftypes.FaucetRequest) error {
+	req := *request
+	req.Token = ""
+	_, err := f.Request(ctx, &req)
+	return err
+}
+
+// Request funds the target with the native currency, or with the requested token,
+// and returns the hash of the funding tx.
+func (f *Faucet) Request(ctx context.Context, request *ftypes.FaucetRequest) (txHash common.Hash, result error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	logger := f.log.New("to", request.Target, "amount", request.Amount)
+	logger := f.log.New("to", request.Target, "token", request.Token)
 	if f.disabled {
 		logger.Info("Cannot serve request, faucet is disabled")
-		return errors.New("faucet is disabled")
+		return common.Hash{}, errors.New("faucet is disabled")
+	}
+
+	amount, err := f.requestAmount(request)
+	if err != nil {
+		f.m.RecordRejectedRequest(f.id, f.chainID, "invalid")
+		return common.Hash{}, err
 	}
+	logger = logger.New("amount", amount)
 
+	if f.public != nil {
+		client := f.public.clientIP(request)
+		logger = logger.New("client", client)
+		entry := &RequestLogEntry{
+			Time:   f.public.now(),
+			Target: request.Target,
+			Client: client,
+			Token:  request.Token,
+			Amount: amount,
+		}
+		defer func() {
+			// Only funded requests count towards the limits, so only they are logged
+			if result == nil {
+				entry.TxHash = txHash
+				f.public.logRequest(logger, entry)
+			}
+		}()
+		cancel, err := f.public.admit(ctx, request, client, entry.Time)
+		if err != nil {
+			logger.Info("Rejected request", "err", err)
+			reason := "invalid"
+			if errors.Is(err, ErrRateLimited) {
+				reason = "rate_limited"
+			} else if errors.Is(err, ErrChallengeFailed) {
+				reason = "challenge"
+			}
+			f.m.RecordRejectedRequest(f.id, f.chainID, reason)
+			return common.Hash{}, err
+		}
+		defer func() {
+			// Requests that were not funded do not count towards the limits
+			if result != nil {
+				cancel()
+			}
+		}()
+	}
+
+	if request.Token != "" {
+		return f.sendToken(ctx, logger, request.Target, request.Token, amount)
+	}
+	return f.sendNative(ctx, logger, request.Target, amount)
+}
+
+// requestAmount returns the amount to fund the request with.
+// Public faucets fund the configured amount by default, and at most.
+func (f *Faucet) requestAmount(request *ftypes.FaucetRequest) (eth.ETH, error) {
+	var defaultAmount eth.ETH
+	if request.Token == "" {
+		if f.public != nil {
+			defaultAmount = f.public.cfg.Amount
+		}
+	} else {
+		token, ok := f.tokens[request.Token]
+		if !ok {
+			return eth.ETH{}, fmt.Errorf("unknown token %q", request.Token)
+		}
+		defaultAmount = token.Amount
+	}
+	if request.Amount.IsZero() {
+		return defaultAmount, nil
+	}
+	if f.public != nil && request.Amount.Gt(defaultAmount) {
+		return eth.ETH{}, fmt.Errorf("requested amount exceeds the maximum of %s", defaultAmount.Decimal())
+	}
+	return request.Amount, nil
+}
+
+func (f *Faucet) sendNative(ctx context.Context, logger log.Logger, target common.Address, amount eth.ETH) (txHash common.Hash, result error) {
 	logger.Info("Sending funds")
 
 	balance, err := f.Balance()
 	if err != nil {
 		logger.Warn("Failed to get balance, optimistically continuing the request")
 	} else {
-		if balance.ToBig().Cmp(request.Amount.ToBig()) < 0 {
-			logger.Error("Insufficient balance", "balance", balance.String(), "amount", request.Amount)
-			return errors.New("insufficient balance")
+		if balance.ToBig().Cmp(amount.ToBig()) < 0 {
+			logger.Error("Insufficient balance", "balance", balance.String(), "amount", amount)
+			return common.Hash{}, errors.New("insufficient balance")
 		}
 	}
 
-	onDone := f.m.RecordFundAction(f.id, f.chainID, request.Amount)
+	onDone := f.m.RecordFundAction(f.id, f.chainID, amount)
 	defer func() {
 		onDone(result)
 	}()
@@ -139,7 +284,7 @@ func (f *Faucet) RequestETH(ctx context.Context, request *ftypes.FaucetRequest)
 	// These types of ephemeral self-destructs are still allowed post-Cancun.
 	var out []byte
 	out = append(out, byte(vm.PUSH20))
-	out = append(out, request.Target[:]...)
+	out = append(out, target[:]...)
 	out = append(out, byte(vm.SELFDESTRUCT))
 
 	candidate := txmgr.TxCandidate{
@@ -147,20 +292,50 @@ func (f *Faucet) RequestETH(ctx context.Context, request *ftypes.FaucetRequest)
 		Blobs:    nil,
 		To:       nil, // contract-creation, see above
 		GasLimit: 0,   // estimate gas dynamically
-		Value:    request.Amount.ToBig(),
+		Value:    amount.ToBig(),
 	}
+	return f.send(ctx, logger, candidate)
+}
+
+// erc20TransferSelector is the selector of transfer(address,uint256)
+var erc20TransferSelector = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+
+func (f *Faucet) sendToken(ctx context.Context, logger log.Logger, target common.Address, tokenID ftypes.TokenID, amount eth.ETH) (txHash common.Hash, result error) {
+	token := f.tokens[tokenID]
+	logger.Info("Sending tokens", "address", token.Address)
+
+	onDone := f.m.RecordTokenFundAction(f.id, f.chainID, tokenID)
+	defer func() {
+		onDone(result)
+	}()
+
+	amountBytes := amount.Bytes32()
+	data := make([]byte, 0, 4+32+32)
+	data = append(data, erc20TransferSelector...)
+	data = append(data, common.LeftPadBytes(target[:], 32)...)
+	data = append(data, amountBytes[:]...)
+
+	candidate := txmgr.TxCandidate{
+		TxData:   data,
+		To:       &token.Address,
+		GasLimit: 0, // estimate gas dynamically, which also fails early if the faucet has insufficient tokens
+	}
+	return f.send(ctx, logger, candidate)
+}
+
+func (f *Faucet) send(ctx context.Context, logger log.Logger, candidate txmgr.TxCandidate) (common.Hash, error) {
 	rec, err := f.txMgr.Send(ctx, candidate)
 	if err != nil {
 		logger.Error("failed to send funds", "err", err)
-		return fmt.Errorf("failed to send funds: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to send funds: %w", err)
 	}
 	if rec.Status == types.ReceiptStatusFailed {
 		logger.Error("funding tx reverted", "tx", rec.TxHash)
-		return fmt.Errorf("failed to fund, tx %s reverted", rec.TxHash)
+		return common.Hash{}, fmt.Errorf("failed to fund, tx %s reverted", rec.TxHash)
 	}
 	logger.Info("Successfully funded account",
 		"tx", rec.TxHash,
 		"included_hash", rec.BlockHash,
 		"included_num", rec.BlockNumber)
-	return nil
+	return rec.TxHash, nil
 }
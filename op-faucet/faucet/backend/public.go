@@ -0,0 +1,118 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/config"
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
+)
+
+// publicMode holds the abuse controls of a public faucet.
+type publicMode struct {
+	cfg *config.PublicConfig
+
+	// limiter is nil if requests are not limited
+	limiter *requestLimiter
+	// challenger is nil if no challenge is required
+	challenger Challenger
+	// requests is nil if requests are not logged
+	requests *requestLog
+
+	now func() time.Time
+}
+
+func newPublicMode(logger log.Logger, cfg *config.PublicConfig) (*publicMode, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	p := &publicMode{cfg: cfg, now: time.Now}
+	if cfg.AddressLimit != 0 || cfg.IPLimit != 0 {
+		p.limiter = newRequestLimiter(cfg.Cooldown, cfg.AddressLimit, cfg.IPLimit)
+	}
+	if cfg.Challenge != nil {
+		p.challenger = challengerFromConfig(cfg.Challenge)
+	}
+	if cfg.RequestLog != "" {
+		// Only the funded requests within the cooldown count towards the limits
+		requests, entries, err := openRequestLog(logger, cfg.RequestLog, p.now().Add(-cfg.Cooldown))
+		if err != nil {
+			return nil, err
+		}
+		p.requests = requests
+		p.restoreLimits(logger, entries)
+	}
+	return p, nil
+}
+
+// restoreLimits counts the requests of the request log, which are all within the cooldown.
+func (p *publicMode) restoreLimits(logger log.Logger, entries []*RequestLogEntry) {
+	if p.limiter == nil {
+		return
+	}
+	for _, entry := range entries {
+		p.limiter.record(entry.Token, entry.Target, entry.Client, entry.Time)
+	}
+	logger.Info("Restored request limits from request log", "entries", len(entries))
+}
+
+// clientIP identifies the client of the request.
+// Behind a trusted proxy, the client is the last X-Forwarded-For address, which the proxy appended.
+func (p *publicMode) clientIP(request *ftypes.FaucetRequest) string {
+	if p.cfg.TrustForwardedFor && request.ForwardedFor != "" {
+		addrs := strings.Split(request.ForwardedFor, ",")
+		return strings.TrimSpace(addrs[len(addrs)-1])
+	}
+	if request.RpcUser == nil || request.RpcUser.RemoteAddr == "" {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(request.RpcUser.RemoteAddr)
+	if err != nil {
+		return request.RpcUser.RemoteAddr
+	}
+	return host
+}
+
+// admit checks the request against the limits and the challenge.
+// The returned function cancels the counted request, for requests that were not funded after all.
+func (p *publicMode) admit(ctx context.Context, request *ftypes.FaucetRequest, client string, now time.Time) (cancel func(), err error) {
+	cancel = func() {}
+	// Check the limits before the challenge, to not spend captcha verifications on limited clients
+	if p.limiter != nil {
+		cancel, err = p.limiter.reserve(request.Token, request.Target, client, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.challenger != nil {
+		if err := p.challenger.Verify(ctx, request.Target, request.Solution); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	return cancel, nil
+}
+
+func (p *publicMode) logRequest(logger log.Logger, entry *RequestLogEntry) {
+	if p.requests == nil {
+		return
+	}
+	if err := p.requests.append(entry); err != nil {
+		logger.Error("Failed to log request", "err", err)
+	}
+}
+
+func (p *publicMode) Close() error {
+	if p.requests == nil {
+		return nil
+	}
+	if err := p.requests.Close(); err != nil {
+		return fmt.Errorf("failed to close request log: %w", err)
+	}
+	return nil
+}
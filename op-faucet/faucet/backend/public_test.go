@@ -0,0 +1,172 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/config"
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
+	"github.com/ethereum-optimism/optimism/op-faucet/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+func publicFaucet(t *testing.T, fCfg *config.FaucetEntry) (*Faucet, *mocks.TxManager) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	txMgr := mocks.NewTxManager(t)
+	txMgr.On("From").Return(common.Address{0xfa})
+	txMgr.On("ChainID").Return(eth.ChainIDFromUInt64(123))
+	client := testutils.NewSimulatedEthClient(testutils.WithAccountBalance(txMgr.From(), eth.HundredEther.ToBig()))
+	f := faucetWithTxManager(logger, &metrics.NoopMetrics{}, "public", txMgr, client.Client)
+	require.NoError(t, f.setup(fCfg))
+	txMgr.On("Close").Maybe()
+	t.Cleanup(f.Close)
+	return f, txMgr
+}
+
+func TestPublicFaucet(t *testing.T) {
+	ctx := context.Background()
+	mntAddr := common.HexToAddress("0x65e37B558F64E2Be5768DB46DF22F93d85741A9E")
+	logPath := filepath.Join(t.TempDir(), "requests.jsonl")
+	fCfg := &config.FaucetEntry{
+		Tokens: map[ftypes.TokenID]*config.TokenEntry{
+			"mnt": {Address: mntAddr, Amount: eth.Ether(10)},
+		},
+		Public: &config.PublicConfig{
+			Amount:       eth.OneEther,
+			Cooldown:     time.Hour,
+			AddressLimit: 1,
+			IPLimit:      2,
+			RequestLog:   logPath,
+		},
+	}
+	f, txMgr := publicFaucet(t, fCfg)
+	alice := common.Address{0xa1}
+	bob := common.Address{0xb0}
+	carol := common.Address{0xc0}
+	user := &rpc.PeerInfo{RemoteAddr: "1.2.3.4:5678"}
+
+	txHash := common.Hash{0x01}
+	txMgr.On("Send", mock.Anything, mock.MatchedBy(func(c txmgr.TxCandidate) bool { return c.To == nil })).
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(1).(txmgr.TxCandidate)
+			require.Equal(t, eth.OneEther, eth.WeiBig(candidate.Value), "funds the default amount")
+		}).
+		Return(&types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash}, nil)
+	txMgr.On("Send", mock.Anything, mock.MatchedBy(func(c txmgr.TxCandidate) bool { return c.To != nil })).
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(1).(txmgr.TxCandidate)
+			require.Equal(t, mntAddr, *candidate.To)
+			require.Nil(t, candidate.Value)
+			amount := eth.Ether(10).Bytes32()
+			require.Equal(t, erc20TransferSelector, candidate.TxData[:4])
+			require.Equal(t, common.LeftPadBytes(carol[:], 32), candidate.TxData[4:36])
+			require.Equal(t, amount[:], candidate.TxData[36:])
+		}).
+		Return(&types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash}, nil)
+
+	_, err := f.Request(ctx, &ftypes.FaucetRequest{RpcUser: user, Target: alice, Amount: eth.Ether(2)})
+	require.ErrorContains(t, err, "exceeds the maximum")
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{RpcUser: user, Target: alice, Token: "usdc"})
+	require.ErrorContains(t, err, "unknown token")
+
+	result, err := f.Request(ctx, &ftypes.FaucetRequest{RpcUser: user, Target: alice})
+	require.NoError(t, err)
+	require.Equal(t, txHash, result)
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{RpcUser: &rpc.PeerInfo{RemoteAddr: "5.6.7.8:5678"}, Target: alice})
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	require.NoError(t, f.RequestETH(ctx, &ftypes.FaucetRequest{RpcUser: user, Target: bob}))
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{RpcUser: user, Target: carol})
+	require.ErrorIs(t, err, ErrRateLimited, "the client reached its limit")
+
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{RpcUser: user, Target: carol, Token: "mnt"})
+	require.NoError(t, err, "tokens are limited separately")
+	f.Close()
+
+	entries, err := readRequestLog(testlog.Logger(t, log.LevelInfo), logPath, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3, "only funded requests are logged")
+	require.Equal(t, alice, entries[0].Target)
+	require.Equal(t, "1.2.3.4", entries[0].Client)
+	require.Equal(t, eth.OneEther, entries[0].Amount)
+	require.Equal(t, txHash, entries[0].TxHash)
+	require.Equal(t, bob, entries[1].Target)
+	require.Equal(t, ftypes.TokenID("mnt"), entries[2].Token)
+
+	t.Run("restored limits", func(t *testing.T) {
+		f, _ := publicFaucet(t, fCfg)
+		_, err := f.Request(ctx, &ftypes.FaucetRequest{Target: alice})
+		require.ErrorIs(t, err, ErrRateLimited, "limits persist across restarts")
+	})
+}
+
+func TestPublicFaucetChallenge(t *testing.T) {
+	ctx := context.Background()
+	f, txMgr := publicFaucet(t, &config.FaucetEntry{
+		Public: &config.PublicConfig{
+			Amount:       eth.OneEther,
+			Cooldown:     time.Hour,
+			AddressLimit: 1,
+			Challenge:    &config.ChallengeConfig{ProofOfWork: 8},
+		},
+	})
+	alice := common.Address{0xa1}
+
+	_, err := f.Request(ctx, &ftypes.FaucetRequest{Target: alice})
+	require.ErrorIs(t, err, ErrChallengeFailed)
+
+	challenge, err := f.Challenge(ctx, alice)
+	require.NoError(t, err)
+	require.Equal(t, ftypes.ProofOfWorkChallenge, challenge.Kind)
+	solution := ftypes.SolveProofOfWork(challenge, alice)
+
+	txMgr.On("Send", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{Target: alice, Solution: solution})
+	require.ErrorContains(t, err, "boom")
+
+	// the failed request did not count towards the limit, but its solution was used up
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{Target: alice, Solution: solution})
+	require.ErrorIs(t, err, ErrChallengeFailed)
+
+	challenge, err = f.Challenge(ctx, alice)
+	require.NoError(t, err)
+	txMgr.On("Send", mock.Anything, mock.Anything).
+		Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+	_, err = f.Request(ctx, &ftypes.FaucetRequest{Target: alice, Solution: ftypes.SolveProofOfWork(challenge, alice)})
+	require.NoError(t, err)
+}
+
+func TestPublicModeClientIP(t *testing.T) {
+	request := &ftypes.FaucetRequest{
+		RpcUser:      &rpc.PeerInfo{RemoteAddr: "10.0.0.1:1234"},
+		ForwardedFor: "6.6.6.6, 1.2.3.4",
+	}
+	p := &publicMode{cfg: &config.PublicConfig{}}
+	require.Equal(t, "10.0.0.1", p.clientIP(request))
+	p.cfg.TrustForwardedFor = true
+	require.Equal(t, "1.2.3.4", p.clientIP(request), "the proxy appends the client")
+	require.Equal(t, "", p.clientIP(&ftypes.FaucetRequest{}))
+}
+
+func TestNonPublicFaucet(t *testing.T) {
+	f, _ := publicFaucet(t, &config.FaucetEntry{})
+	require.ErrorContains(t, f.SetChallenger(NewProofOfWork(1)), "only public faucets")
+	challenge, err := f.Challenge(context.Background(), common.Address{0xa1})
+	require.NoError(t, err)
+	require.Nil(t, challenge)
+}
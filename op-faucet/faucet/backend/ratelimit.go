@@ -0,0 +1,152 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
+)
+
+var ErrRateLimited = errors.New("rate limited")
+
+type limitKey struct {
+	token ftypes.TokenID
+	id    string
+}
+
+// requestLimiter limits the requests per target address and per client IP,
+// in a sliding window of the cooldown duration.
+type requestLimiter struct {
+	mu sync.Mutex
+
+	cooldown     time.Duration
+	addressLimit uint64
+	ipLimit      uint64
+
+	byAddress map[limitKey][]time.Time
+	byIP      map[limitKey][]time.Time
+
+	lastPrune time.Time
+}
+
+func newRequestLimiter(cooldown time.Duration, addressLimit, ipLimit uint64) *requestLimiter {
+	return &requestLimiter{
+		cooldown:     cooldown,
+		addressLimit: addressLimit,
+		ipLimit:      ipLimit,
+		byAddress:    make(map[limitKey][]time.Time),
+		byIP:         make(map[limitKey][]time.Time),
+	}
+}
+
+// reserve counts a request at the given time, unless the target or the client exceeds its limit.
+// The returned function cancels the reservation, for requests that were not funded after all.
+// An empty ip is not limited.
+func (l *requestLimiter) reserve(token ftypes.TokenID, target common.Address, ip string, now time.Time) (cancel func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maybePrune(now)
+
+	addrKey := limitKey{token: token, id: target.Hex()}
+	ipKey := limitKey{token: token, id: ip}
+	if wait := l.check(l.byAddress, addrKey, l.addressLimit, now); wait > 0 {
+		return nil, fmt.Errorf("%w: address %s may request %s again in %s", ErrRateLimited, target, token, wait.Round(time.Second))
+	}
+	if ip != "" {
+		if wait := l.check(l.byIP, ipKey, l.ipLimit, now); wait > 0 {
+			return nil, fmt.Errorf("%w: client may request %s again in %s", ErrRateLimited, token, wait.Round(time.Second))
+		}
+	}
+	l.add(token, target, ip, now)
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		remove(l.byAddress, addrKey, now)
+		if ip != "" {
+			remove(l.byIP, ipKey, now)
+		}
+	}, nil
+}
+
+// record counts a past request, e.g. when restoring the limits from the request log.
+func (l *requestLimiter) record(token ftypes.TokenID, target common.Address, ip string, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.add(token, target, ip, at)
+}
+
+func (l *requestLimiter) add(token ftypes.TokenID, target common.Address, ip string, at time.Time) {
+	insert(l.byAddress, limitKey{token: token, id: target.Hex()}, at)
+	if ip != "" {
+		insert(l.byIP, limitKey{token: token, id: ip}, at)
+	}
+}
+
+// check returns how long to wait until the key may make another request, 0 if it may request now.
+func (l *requestLimiter) check(requests map[limitKey][]time.Time, key limitKey, limit uint64, now time.Time) time.Duration {
+	if limit == 0 {
+		return 0
+	}
+	times := prune(requests, key, now.Add(-l.cooldown))
+	if uint64(len(times)) < limit {
+		return 0
+	}
+	// the oldest request in the window has to expire first
+	return times[uint64(len(times))-limit].Add(l.cooldown).Sub(now)
+}
+
+// maybePrune drops all expired requests, at most once per cooldown, to bound memory use.
+func (l *requestLimiter) maybePrune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.cooldown {
+		return
+	}
+	l.lastPrune = now
+	cutoff := now.Add(-l.cooldown)
+	for key := range l.byAddress {
+		prune(l.byAddress, key, cutoff)
+	}
+	for key := range l.byIP {
+		prune(l.byIP, key, cutoff)
+	}
+}
+
+// prune drops the requests of the key that happened before the cutoff, and returns the remaining requests.
+func prune(requests map[limitKey][]time.Time, key limitKey, cutoff time.Time) []time.Time {
+	times := requests[key]
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	times = times[i:]
+	if len(times) == 0 {
+		delete(requests, key)
+	} else {
+		requests[key] = times
+	}
+	return times
+}
+
+// insert adds the request time to the key, keeping the request times of the key sorted.
+func insert(requests map[limitKey][]time.Time, key limitKey, at time.Time) {
+	times := requests[key]
+	i := len(times)
+	for i > 0 && times[i-1].After(at) {
+		i--
+	}
+	requests[key] = slices.Insert(times, i, at)
+}
+
+func remove(requests map[limitKey][]time.Time, key limitKey, at time.Time) {
+	times := requests[key]
+	for i := len(times) - 1; i >= 0; i-- {
+		if times[i].Equal(at) {
+			requests[key] = append(times[:i:i], times[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	l := newRequestLimiter(time.Hour, 1, 2)
+	start := time.Unix(1_000_000, 0)
+	alice := common.Address{0xa1}
+	bob := common.Address{0xb0}
+	carol := common.Address{0xc0}
+
+	_, err := l.reserve("", alice, "1.2.3.4", start)
+	require.NoError(t, err)
+
+	_, err = l.reserve("", alice, "5.6.7.8", start.Add(time.Minute))
+	require.ErrorIs(t, err, ErrRateLimited, "address limit applies across clients")
+	require.ErrorContains(t, err, "again in 59m0s")
+
+	_, err = l.reserve("mnt", alice, "1.2.3.4", start.Add(time.Minute))
+	require.NoError(t, err, "tokens are limited separately")
+
+	_, err = l.reserve("", bob, "1.2.3.4", start.Add(time.Minute))
+	require.NoError(t, err)
+	_, err = l.reserve("", carol, "1.2.3.4", start.Add(2*time.Minute))
+	require.ErrorIs(t, err, ErrRateLimited, "ip limit applies across addresses")
+
+	_, err = l.reserve("", carol, "", start.Add(2*time.Minute))
+	require.NoError(t, err, "unknown clients are only limited by address")
+
+	_, err = l.reserve("", alice, "1.2.3.4", start.Add(time.Hour+time.Second))
+	require.NoError(t, err, "the first request of alice left the window")
+}
+
+func TestRequestLimiterCancel(t *testing.T) {
+	l := newRequestLimiter(time.Hour, 1, 1)
+	now := time.Unix(1_000_000, 0)
+	alice := common.Address{0xa1}
+
+	cancel, err := l.reserve("", alice, "1.2.3.4", now)
+	require.NoError(t, err)
+	_, err = l.reserve("", alice, "1.2.3.4", now.Add(time.Second))
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	cancel()
+	_, err = l.reserve("", alice, "1.2.3.4", now.Add(time.Second))
+	require.NoError(t, err, "cancelled requests do not count")
+}
+
+func TestRequestLimiterRecord(t *testing.T) {
+	l := newRequestLimiter(time.Hour, 2, 0)
+	now := time.Unix(1_000_000, 0)
+	alice := common.Address{0xa1}
+
+	// restored out of order
+	l.record("", alice, "", now.Add(-10*time.Minute))
+	l.record("", alice, "", now.Add(-50*time.Minute))
+
+	_, err := l.reserve("", alice, "", now)
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.ErrorContains(t, err, "again in 10m0s", "the oldest request has to expire first")
+
+	_, err = l.reserve("", alice, "", now.Add(10*time.Minute+time.Second))
+	require.NoError(t, err)
+}
+
+func TestRequestLimiterPrune(t *testing.T) {
+	l := newRequestLimiter(time.Hour, 1, 1)
+	now := time.Unix(1_000_000, 0)
+	for i := range 10 {
+		_, err := l.reserve("", common.Address{byte(i)}, "", now)
+		require.NoError(t, err)
+	}
+	require.Len(t, l.byAddress, 10)
+
+	_, err := l.reserve("", common.Address{0xff}, "1.2.3.4", now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, l.byAddress, 1, "expired requests are pruned")
+	require.Len(t, l.byIP, 1)
+}
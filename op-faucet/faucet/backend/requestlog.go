@@ -0,0 +1,121 @@
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// RequestLogEntry is a funded request to a public faucet, as persisted in the request log.
+type RequestLogEntry struct {
+	Time   time.Time      `json:"time"`
+	Target common.Address `json:"target"`
+	// Client is the IP of the client, if known
+	Client string         `json:"client,omitempty"`
+	Token  ftypes.TokenID `json:"token,omitempty"`
+	Amount eth.ETH        `json:"amount"`
+	TxHash common.Hash    `json:"txHash,omitempty"`
+}
+
+// requestLog appends request log entries to a file, as JSON lines.
+type requestLog struct {
+	mu     sync.Mutex
+	f      *os.File
+	enc    *json.Encoder
+	closed bool
+}
+
+// openRequestLog opens the request log at the given path for appending, and returns the entries after the cutoff.
+// The log is never rewritten: older entries are kept as history, and are only skipped when reading.
+func openRequestLog(logger log.Logger, path string, cutoff time.Time) (*requestLog, []*RequestLogEntry, error) {
+	entries, err := readRequestLog(logger, path, cutoff)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open request log: %w", err)
+	}
+	if err := terminateLastLine(f); err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("failed to prepare request log: %w", err)
+	}
+	return &requestLog{f: f, enc: json.NewEncoder(f)}, entries, nil
+}
+
+// terminateLastLine appends a newline if the file does not end with one,
+// so new entries do not continue an incomplete line.
+func terminateLastLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	_, err = f.Write([]byte{'\n'})
+	return err
+}
+
+// readRequestLog returns the entries of the request log after the cutoff.
+// Invalid lines, e.g. incomplete after a crash, are skipped.
+func readRequestLog(logger log.Logger, path string, cutoff time.Time) ([]*RequestLogEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open request log: %w", err)
+	}
+	defer f.Close()
+	var entries []*RequestLogEntry
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		var entry RequestLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logger.Warn("Skipping invalid request log entry", "path", path, "line", line, "err", err)
+			continue
+		}
+		if !entry.Time.After(cutoff) {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read request log: %w", err)
+	}
+	return entries, nil
+}
+
+func (l *requestLog) append(entry *RequestLogEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(entry)
+}
+
+func (l *requestLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	return l.f.Close()
+}
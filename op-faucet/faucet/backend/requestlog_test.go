@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRequestLog(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	now := time.Unix(1_000_000, 0).UTC()
+	cutoff := now.Add(-time.Hour)
+
+	l, entries, err := openRequestLog(logger, path, cutoff)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	old := &RequestLogEntry{Time: cutoff, Target: common.Address{0xa0}, Amount: eth.OneEther}
+	first := &RequestLogEntry{
+		Time:   now,
+		Target: common.Address{0xa1},
+		Client: "1.2.3.4",
+		Amount: eth.OneEther,
+		TxHash: common.Hash{0x01},
+	}
+	require.NoError(t, l.append(old))
+	require.NoError(t, l.append(first))
+	require.NoError(t, l.Close())
+	require.NoError(t, l.Close(), "closing twice is fine")
+
+	// simulate a crash in the middle of writing an entry
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"time":"1970-01-12T13:46:40Z","tar`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l, entries, err = openRequestLog(logger, path, cutoff)
+	require.NoError(t, err)
+	require.Equal(t, []*RequestLogEntry{first}, entries, "old and incomplete entries are skipped")
+	second := &RequestLogEntry{Time: now, Target: common.Address{0xb0}, Token: "mnt", Amount: eth.Ether(10)}
+	require.NoError(t, l.append(second))
+	require.NoError(t, l.Close())
+
+	entries, err = readRequestLog(logger, path, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []*RequestLogEntry{old, first, second}, entries, "the log keeps older entries")
+
+	// with a zero cooldown nothing is replayed, but the log is still kept
+	l, entries, err = openRequestLog(logger, path, now)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.NoError(t, l.Close())
+	entries, err = readRequestLog(logger, path, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+}
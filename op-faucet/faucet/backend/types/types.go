@@ -1,10 +1,14 @@
 package types
 
 import (
+	"encoding/binary"
 	"errors"
+	"math/bits"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -42,9 +46,102 @@ func (id *FaucetID) UnmarshalText(data []byte) error {
 	return nil
 }
 
+// TokenID identifies an ERC-20 token of a faucet, as configured.
+// The empty TokenID identifies the native currency of the chain (ETH, or MNT on Mantle).
+type TokenID string
+
+func (id TokenID) String() string {
+	if id == "" {
+		return "native"
+	}
+	return string(id)
+}
+
+func (id TokenID) MarshalText() ([]byte, error) {
+	if len(id) > maxIDLength {
+		return nil, ErrInvalidID
+	}
+	return []byte(id), nil
+}
+
+func (id *TokenID) UnmarshalText(data []byte) error {
+	if len(data) > maxIDLength {
+		return ErrInvalidID
+	}
+	*id = TokenID(data)
+	return nil
+}
+
 // FaucetRequest represents a request for funding, with metadata attributes, for any rate-limiting
 type FaucetRequest struct {
 	RpcUser *rpc.PeerInfo
-	Target  common.Address
-	Amount  eth.ETH
+	// ForwardedFor is the X-Forwarded-For header of the HTTP request, if any
+	ForwardedFor string
+	Target       common.Address
+	// Token is the token to fund with, empty for the native currency
+	Token TokenID
+	// Amount is in wei, or in the smallest unit of the token.
+	// A zero amount requests the default amount of the faucet.
+	Amount eth.ETH
+	// Solution is the solution to the challenge of a public faucet, if any
+	Solution *ChallengeSolution
+}
+
+// FundsRequest is the RPC form of a FaucetRequest.
+type FundsRequest struct {
+	Target   common.Address     `json:"target"`
+	Token    TokenID            `json:"token,omitempty"`
+	Amount   *eth.ETH           `json:"amount,omitempty"`
+	Solution *ChallengeSolution `json:"solution,omitempty"`
+}
+
+type ChallengeKind string
+
+const (
+	ProofOfWorkChallenge ChallengeKind = "pow"
+	CaptchaChallenge     ChallengeKind = "captcha"
+)
+
+// Challenge is what a client has to solve before a public faucet funds it.
+type Challenge struct {
+	Kind ChallengeKind `json:"kind"`
+	// Seed and Difficulty are set for proof-of-work challenges, see ProofOfWorkHash.
+	Seed       common.Hash `json:"seed,omitempty"`
+	Difficulty uint8       `json:"difficulty,omitempty"`
+}
+
+// ChallengeSolution solves a Challenge.
+type ChallengeSolution struct {
+	// Seed and Nonce solve a proof-of-work challenge.
+	Seed  common.Hash    `json:"seed,omitempty"`
+	Nonce hexutil.Uint64 `json:"nonce,omitempty"`
+	// Captcha is the response token of a solved captcha.
+	Captcha string `json:"captcha,omitempty"`
+}
+
+// ProofOfWorkHash is keccak256(seed ++ target ++ uint64 big-endian nonce).
+// A proof-of-work solution is valid if the hash has at least difficulty leading zero bits.
+func ProofOfWorkHash(seed common.Hash, target common.Address, nonce uint64) common.Hash {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], nonce)
+	return crypto.Keccak256Hash(seed[:], target[:], n[:])
+}
+
+// LeadingZeroBits returns the number of leading zero bits of the hash.
+func LeadingZeroBits(h common.Hash) int {
+	for i, b := range h {
+		if b != 0 {
+			return i*8 + bits.LeadingZeros8(b)
+		}
+	}
+	return len(h) * 8
+}
+
+// SolveProofOfWork brute-forces the proof-of-work challenge for the given target.
+func SolveProofOfWork(challenge *Challenge, target common.Address) *ChallengeSolution {
+	for nonce := uint64(0); ; nonce++ {
+		if LeadingZeroBits(ProofOfWorkHash(challenge.Seed, target, nonce)) >= int(challenge.Difficulty) {
+			return &ChallengeSolution{Seed: challenge.Seed, Nonce: hexutil.Uint64(nonce)}
+		}
+	}
 }
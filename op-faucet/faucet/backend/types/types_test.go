@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 func TestFaucetID(t *testing.T) {
@@ -26,3 +28,34 @@ func TestFaucetID(t *testing.T) {
 	_, err = FaucetID(strings.Repeat("a", maxIDLength+1)).MarshalText()
 	require.ErrorIs(t, err, ErrInvalidID)
 }
+
+func TestTokenID(t *testing.T) {
+	require.Equal(t, "native", TokenID("").String())
+	require.Equal(t, "mnt", TokenID("mnt").String())
+
+	var x TokenID
+	require.NoError(t, x.UnmarshalText([]byte("mnt")))
+	require.Equal(t, TokenID("mnt"), x)
+	// the empty ID is valid, it identifies the native currency
+	require.NoError(t, x.UnmarshalText([]byte{}))
+	require.Equal(t, TokenID(""), x)
+	require.ErrorIs(t, x.UnmarshalText(bytes.Repeat([]byte("a"), maxIDLength+1)), ErrInvalidID)
+
+	_, err := TokenID(strings.Repeat("a", maxIDLength+1)).MarshalText()
+	require.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestProofOfWork(t *testing.T) {
+	require.Equal(t, 256, LeadingZeroBits(common.Hash{}))
+	require.Equal(t, 0, LeadingZeroBits(common.Hash{0x80}))
+	require.Equal(t, 15, LeadingZeroBits(common.Hash{0x00, 0x01}))
+
+	target := common.Address{0xaa}
+	challenge := &Challenge{Kind: ProofOfWorkChallenge, Seed: common.Hash{0x42}, Difficulty: 10}
+	solution := SolveProofOfWork(challenge, target)
+	require.Equal(t, challenge.Seed, solution.Seed)
+	h := ProofOfWorkHash(solution.Seed, target, uint64(solution.Nonce))
+	require.GreaterOrEqual(t, LeadingZeroBits(h), 10)
+	// the solution is bound to the target
+	require.NotEqual(t, h, ProofOfWorkHash(solution.Seed, common.Address{0xbb}, uint64(solution.Nonce)))
+}
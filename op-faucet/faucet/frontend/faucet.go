@@ -2,6 +2,7 @@ package frontend
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -14,6 +15,8 @@ import (
 type FaucetBackend interface {
 	ChainID() eth.ChainID
 	RequestETH(ctx context.Context, request *ftypes.FaucetRequest) error
+	Request(ctx context.Context, request *ftypes.FaucetRequest) (common.Hash, error)
+	Challenge(ctx context.Context, target common.Address) (*ftypes.Challenge, error)
 	Balance() (eth.ETH, error)
 }
 
@@ -34,13 +37,54 @@ func (f *FaucetFrontend) ChainID(ctx context.Context) (eth.ChainID, error) {
 func (f *FaucetFrontend) RequestETH(ctx context.Context, addr common.Address, amount eth.ETH) error {
 	info := rpc.PeerInfoFromContext(ctx)
 	request := &ftypes.FaucetRequest{
-		RpcUser: &info,
-		Target:  addr,
-		Amount:  amount,
+		RpcUser:      &info,
+		ForwardedFor: forwardedForFromContext(ctx),
+		Target:       addr,
+		Amount:       amount,
 	}
 	return f.b.RequestETH(ctx, request)
 }
 
+// Request funds the target with the native currency or a token,
+// with the solution to the challenge of a public faucet, and returns the funding tx hash.
+func (f *FaucetFrontend) Request(ctx context.Context, params ftypes.FundsRequest) (common.Hash, error) {
+	info := rpc.PeerInfoFromContext(ctx)
+	request := &ftypes.FaucetRequest{
+		RpcUser:      &info,
+		ForwardedFor: forwardedForFromContext(ctx),
+		Target:       params.Target,
+		Token:        params.Token,
+		Solution:     params.Solution,
+	}
+	if params.Amount != nil {
+		request.Amount = *params.Amount
+	}
+	return f.b.Request(ctx, request)
+}
+
+// Challenge returns the challenge to solve before requesting funds, or nil if no challenge is required.
+func (f *FaucetFrontend) Challenge(ctx context.Context, addr common.Address) (*ftypes.Challenge, error) {
+	return f.b.Challenge(ctx, addr)
+}
+
 func (f *FaucetFrontend) Balance(ctx context.Context) (eth.ETH, error) {
 	return f.b.Balance()
 }
+
+type forwardedForKey struct{}
+
+// ForwardedForMiddleware makes the X-Forwarded-For header of RPC requests available to the faucet,
+// for public faucets behind a proxy to identify their clients.
+func ForwardedForMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get("X-Forwarded-For"); v != "" {
+			r = r.WithContext(context.WithValue(r.Context(), forwardedForKey{}, v))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func forwardedForFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(forwardedForKey{}).(string)
+	return v
+}
@@ -141,6 +141,7 @@ func (s *Service) initRPCHandler(cfg *config.Config) error {
 	s.rpcHandler = oprpc.NewHandler(cfg.Version,
 		oprpc.WithLogger(s.log),
 		oprpc.WithWebsocketEnabled(),
+		oprpc.WithMiddleware(frontend.ForwardedForMiddleware),
 	)
 	if cfg.RPC.EnableAdmin {
 		s.log.Info("Admin RPC enabled")
@@ -11,6 +11,8 @@ type Metricer interface {
 	RecordUp()
 
 	RecordFundAction(faucet ftypes.FaucetID, chainID eth.ChainID, amount eth.ETH) (onDone func(err error))
+	RecordTokenFundAction(faucet ftypes.FaucetID, chainID eth.ChainID, token ftypes.TokenID) (onDone func(err error))
+	RecordRejectedRequest(faucet ftypes.FaucetID, chainID eth.ChainID, reason string)
 
 	metrics.TxMetricer
 }
@@ -22,6 +22,9 @@ type Metrics struct {
 	totalFundingETH *prometheus.CounterVec
 	totalFundingTxs *prometheus.CounterVec
 
+	totalTokenFundingTxs *prometheus.CounterVec
+	rejectedRequests     *prometheus.CounterVec
+
 	txDuration *prometheus.HistogramVec
 
 	info prometheus.GaugeVec
@@ -73,6 +76,18 @@ func newMetrics(procName string, registry *prometheus.Registry) *Metrics {
 			Help:      "Count of funding txs",
 		}, []string{"faucet", "chain", "err"}),
 
+		totalTokenFundingTxs: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "token_funding_txs_total",
+			Help:      "Count of ERC-20 token funding txs",
+		}, []string{"faucet", "chain", "token", "err"}),
+
+		rejectedRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "rejected_requests_total",
+			Help:      "Count of requests rejected by the abuse controls of public faucets",
+		}, []string{"faucet", "chain", "reason"}),
+
 		txDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "funding_duration_seconds",
@@ -112,3 +127,19 @@ func (m *Metrics) RecordFundAction(faucet ftypes.FaucetID, chainID eth.ChainID,
 		m.totalFundingETH.WithLabelValues(faucet.String(), chainID.String(), errStr).Add(amount.WeiFloat())
 	}
 }
+
+func (m *Metrics) RecordTokenFundAction(faucet ftypes.FaucetID, chainID eth.ChainID, token ftypes.TokenID) (onDone func(err error)) {
+	timer := prometheus.NewTimer(m.txDuration.WithLabelValues(faucet.String(), chainID.String()))
+	return func(err error) {
+		timer.ObserveDuration()
+		errStr := "success"
+		if err != nil {
+			errStr = "failed"
+		}
+		m.totalTokenFundingTxs.WithLabelValues(faucet.String(), chainID.String(), token.String(), errStr).Inc()
+	}
+}
+
+func (m *Metrics) RecordRejectedRequest(faucet ftypes.FaucetID, chainID eth.ChainID, reason string) {
+	m.rejectedRequests.WithLabelValues(faucet.String(), chainID.String(), reason).Inc()
+}
@@ -33,6 +33,11 @@ func TestFaucetMetrics(t *testing.T) {
 	onDone = m.RecordFundAction(faucetB, chainY, eth.Ether(1000))
 	onDone(errors.New("test err"))
 
+	onDone = m.RecordTokenFundAction(faucetA, chainX, "mnt")
+	onDone(nil)
+	m.RecordRejectedRequest(faucetA, chainX, "rate_limited")
+	m.RecordRejectedRequest(faucetA, chainX, "rate_limited")
+
 	c := opmetrics.NewMetricChecker(t, m.Registry())
 
 	prefix := Namespace + "_default_"
@@ -66,6 +71,21 @@ func TestFaucetMetrics(t *testing.T) {
 	record = c.FindByName(prefix + "funding_duration_seconds").FindByLabels(labelsB)
 	require.NotZero(t, record.Histogram.GetSampleSum())
 
+	record = c.FindByName(prefix + "token_funding_txs_total").FindByLabels(map[string]string{
+		"faucet": faucetA.String(),
+		"chain":  chainX.String(),
+		"token":  "mnt",
+		"err":    "success",
+	})
+	require.Equal(t, 1.0, record.Counter.GetValue())
+
+	record = c.FindByName(prefix + "rejected_requests_total").FindByLabels(map[string]string{
+		"faucet": faucetA.String(),
+		"chain":  chainX.String(),
+		"reason": "rate_limited",
+	})
+	require.Equal(t, 2.0, record.Counter.GetValue())
+
 	record = c.FindByName(prefix + "up").FindByLabels(nil)
 	require.Equal(t, 1.0, record.Gauge.GetValue())
 
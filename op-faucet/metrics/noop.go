@@ -18,4 +18,11 @@ func (n NoopMetrics) RecordFundAction(faucet ftypes.FaucetID, chainID eth.ChainI
 	return func(err error) {}
 }
 
+func (n NoopMetrics) RecordTokenFundAction(faucet ftypes.FaucetID, chainID eth.ChainID, token ftypes.TokenID) (onDone func(err error)) {
+	return func(err error) {}
+}
+
+func (n NoopMetrics) RecordRejectedRequest(faucet ftypes.FaucetID, chainID eth.ChainID, reason string) {
+}
+
 var _ Metricer = NoopMetrics{}
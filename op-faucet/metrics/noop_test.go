@@ -13,5 +13,8 @@ func TestNoopMetrics(t *testing.T) {
 	m.RecordUp()
 	onDone := m.RecordFundAction("faucetA", eth.ChainIDFromUInt64(123), eth.OneEther)
 	onDone(errors.New("test err"))
+	onDone = m.RecordTokenFundAction("faucetA", eth.ChainIDFromUInt64(123), "mnt")
+	onDone(nil)
+	m.RecordRejectedRequest("faucetA", eth.ChainIDFromUInt64(123), "rate_limited")
 	m.RecordNonce(123)
 }
@@ -0,0 +1,259 @@
+package safedb
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+var (
+	pathFlag = &cli.PathFlag{
+		Name:     "safedb.path",
+		Usage:    "Path to the safe head database. The op-node using the database must be stopped.",
+		Required: true,
+	}
+	startFlag = &cli.Uint64Flag{
+		Name:  "start",
+		Usage: "First L1 block number to include",
+	}
+	endFlag = &cli.Uint64Flag{
+		Name:  "end",
+		Usage: "Last L1 block number to include",
+		Value: math.MaxUint64,
+	}
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format, either json or csv",
+		Value: FormatJSON,
+	}
+	outFlag = &cli.PathFlag{
+		Name:  "out",
+		Usage: "File to write the export to. Defaults to stdout",
+	}
+	l1RPCFlag = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "L1 RPC endpoint to verify the recorded L1 blocks against",
+		Required: true,
+	}
+	l2RPCFlag = &cli.StringFlag{
+		Name:     "l2",
+		Usage:    "L2 execution client RPC endpoint to verify the recorded safe heads against",
+		Required: true,
+	}
+	pruneToFlag = &cli.Uint64Flag{
+		Name:     "l1-block",
+		Usage:    "L1 block number to prune to. Safe head queries for earlier L1 blocks will no longer be answered",
+		Required: true,
+	}
+)
+
+var Subcommands = []*cli.Command{
+	{
+		Name:   "export",
+		Usage:  "Exports the safe head updates recorded in a safe head database",
+		Flags:  []cli.Flag{pathFlag, startFlag, endFlag, formatFlag, outFlag},
+		Action: exportAction,
+	},
+	{
+		Name:   "verify",
+		Usage:  "Verifies the recorded safe head updates are canonical on L1 and L2",
+		Flags:  []cli.Flag{pathFlag, startFlag, endFlag, l1RPCFlag, l2RPCFlag},
+		Action: verifyAction,
+	},
+	{
+		Name:   "prune",
+		Usage:  "Deletes safe head updates that are not needed to answer queries from the given L1 block onwards",
+		Flags:  []cli.Flag{pathFlag, pruneToFlag},
+		Action: pruneAction,
+	},
+}
+
+func exportAction(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	db, err := safedb.NewSafeDB(logger, ctx.Path(pathFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open safe head database: %w", err)
+	}
+	defer db.Close()
+	var out io.Writer = os.Stdout
+	if path := ctx.Path(outFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	return Export(ctx.Context, db, ctx.Uint64(startFlag.Name), ctx.Uint64(endFlag.Name), ctx.String(formatFlag.Name), out)
+}
+
+func verifyAction(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	db, err := safedb.NewSafeDB(logger, ctx.Path(pathFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open safe head database: %w", err)
+	}
+	defer db.Close()
+	l1, err := ethclient.DialContext(ctx.Context, ctx.String(l1RPCFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1.Close()
+	l2, err := ethclient.DialContext(ctx.Context, ctx.String(l2RPCFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	defer l2.Close()
+	checked, mismatches, err := Verify(ctx.Context, db, ctx.Uint64(startFlag.Name), ctx.Uint64(endFlag.Name), l1, l2)
+	if err != nil {
+		return err
+	}
+	for _, m := range mismatches {
+		logger.Error("Invalid safe head entry", "l1", m.Entry.L1Block, "l2", m.Entry.SafeHead, "reason", m.Reason)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("found %d invalid entries out of %d", len(mismatches), checked)
+	}
+	logger.Info("Safe head database verified", "entries", checked)
+	return nil
+}
+
+func pruneAction(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	db, err := safedb.NewSafeDB(logger, ctx.Path(pathFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open safe head database: %w", err)
+	}
+	defer db.Close()
+	if err := db.Prune(ctx.Uint64(pruneToFlag.Name)); err != nil {
+		return fmt.Errorf("failed to prune safe head database: %w", err)
+	}
+	logger.Info("Pruned safe head database", "l1", ctx.Uint64(pruneToFlag.Name))
+	return nil
+}
+
+// Export writes the safe head updates recorded for L1 blocks in the inclusive range [start, end] to out.
+// The json format writes an array of entries, the csv format writes one entry per row with a header row.
+func Export(ctx context.Context, db *safedb.SafeDB, start uint64, end uint64, format string, out io.Writer) error {
+	switch format {
+	case FormatJSON:
+		// Stream the array rather than collecting it, as the database may contain a very large number of entries.
+		enc := json.NewEncoder(out)
+		sep := "["
+		err := db.SafeHeadsInRange(ctx, start, end, func(entry safedb.SafeHeadEntry) error {
+			if _, err := io.WriteString(out, sep); err != nil {
+				return err
+			}
+			sep = ","
+			return enc.Encode(entry)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export entries: %w", err)
+		}
+		if sep == "[" {
+			_, err = io.WriteString(out, "[]\n")
+		} else {
+			_, err = io.WriteString(out, "]\n")
+		}
+		return err
+	case FormatCSV:
+		w := csv.NewWriter(out)
+		if err := w.Write([]string{"l1_number", "l1_hash", "l2_number", "l2_hash"}); err != nil {
+			return err
+		}
+		err := db.SafeHeadsInRange(ctx, start, end, func(entry safedb.SafeHeadEntry) error {
+			return w.Write([]string{
+				strconv.FormatUint(entry.L1Block.Number, 10),
+				entry.L1Block.Hash.Hex(),
+				strconv.FormatUint(entry.SafeHead.Number, 10),
+				entry.SafeHead.Hash.Hex(),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export entries: %w", err)
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+// HeaderSource provides canonical block headers by number.
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Mismatch describes a recorded safe head update that is inconsistent with the chain.
+type Mismatch struct {
+	Entry  safedb.SafeHeadEntry
+	Reason string
+}
+
+// Verify checks every safe head update recorded for L1 blocks in the inclusive range [start, end]:
+// the L1 block and the safe head must both be canonical, and the safe head must not decrease between updates.
+// Returns the number of entries checked and the entries that failed verification.
+// An error is only returned if verification could not be completed.
+func Verify(ctx context.Context, db *safedb.SafeDB, start uint64, end uint64, l1 HeaderSource, l2 HeaderSource) (int, []Mismatch, error) {
+	var mismatches []Mismatch
+	checked := 0
+	var prev *safedb.SafeHeadEntry
+	err := db.SafeHeadsInRange(ctx, start, end, func(entry safedb.SafeHeadEntry) error {
+		checked++
+		if prev != nil && entry.SafeHead.Number < prev.SafeHead.Number {
+			mismatches = append(mismatches, Mismatch{Entry: entry, Reason: fmt.Sprintf("safe head decreased from %d", prev.SafeHead.Number)})
+		}
+		prev = &entry
+		if reason, err := checkCanonical(ctx, l1, "L1 block", entry.L1Block.Number, entry.L1Block.Hash); err != nil {
+			return err
+		} else if reason != "" {
+			mismatches = append(mismatches, Mismatch{Entry: entry, Reason: reason})
+			return nil
+		}
+		if reason, err := checkCanonical(ctx, l2, "safe head", entry.SafeHead.Number, entry.SafeHead.Hash); err != nil {
+			return err
+		} else if reason != "" {
+			mismatches = append(mismatches, Mismatch{Entry: entry, Reason: reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return checked, mismatches, fmt.Errorf("failed to verify entries: %w", err)
+	}
+	return checked, mismatches, nil
+}
+
+// checkCanonical returns a non-empty reason if the block with the given number and hash is not canonical.
+func checkCanonical(ctx context.Context, src HeaderSource, name string, number uint64, hash common.Hash) (string, error) {
+	header, err := src.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if errors.Is(err, ethereum.NotFound) {
+		return fmt.Sprintf("%s %d not found", name, number), nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to fetch %s %d: %w", name, number, err)
+	}
+	if header.Hash() != hash {
+		return fmt.Sprintf("%s %d has hash %s, expected %s", name, number, header.Hash(), hash), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,124 @@
+package safedb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubHeaders map[uint64]*types.Header
+
+func (s stubHeaders) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	header, ok := s[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func setupDB(t *testing.T) (*safedb.SafeDB, stubHeaders, stubHeaders) {
+	db, err := safedb.NewSafeDB(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	l1 := make(stubHeaders)
+	l2 := make(stubHeaders)
+	for i := uint64(1); i <= 3; i++ {
+		l1Header := &types.Header{Number: new(big.Int).SetUint64(i * 10), Extra: []byte{0x01}}
+		l2Header := &types.Header{Number: new(big.Int).SetUint64(i * 100), Extra: []byte{0x02}}
+		l1[i*10] = l1Header
+		l2[i*100] = l2Header
+		require.NoError(t, db.SafeHeadUpdated(
+			eth.L2BlockRef{Hash: l2Header.Hash(), Number: i * 100},
+			eth.BlockID{Hash: l1Header.Hash(), Number: i * 10}))
+	}
+	return db, l1, l2
+}
+
+func TestExport(t *testing.T) {
+	db, l1, l2 := setupDB(t)
+
+	t.Run("JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, Export(context.Background(), db, 0, math.MaxUint64, FormatJSON, &out))
+		var entries []safedb.SafeHeadEntry
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+		require.Len(t, entries, 3)
+		require.Equal(t, eth.BlockID{Hash: l1[20].Hash(), Number: 20}, entries[1].L1Block)
+		require.Equal(t, eth.BlockID{Hash: l2[200].Hash(), Number: 200}, entries[1].SafeHead)
+	})
+
+	t.Run("JSONEmpty", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, Export(context.Background(), db, 31, math.MaxUint64, FormatJSON, &out))
+		var entries []safedb.SafeHeadEntry
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+		require.Empty(t, entries)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, Export(context.Background(), db, 15, 30, FormatCSV, &out))
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Equal(t, []string{
+			"l1_number,l1_hash,l2_number,l2_hash",
+			"20," + l1[20].Hash().Hex() + ",200," + l2[200].Hash().Hex(),
+			"30," + l1[30].Hash().Hex() + ",300," + l2[300].Hash().Hex(),
+		}, lines)
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		require.Error(t, Export(context.Background(), db, 0, math.MaxUint64, "xml", &bytes.Buffer{}))
+	})
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		db, l1, l2 := setupDB(t)
+		checked, mismatches, err := Verify(context.Background(), db, 0, math.MaxUint64, l1, l2)
+		require.NoError(t, err)
+		require.Equal(t, 3, checked)
+		require.Empty(t, mismatches)
+	})
+
+	t.Run("L1Reorged", func(t *testing.T) {
+		db, l1, l2 := setupDB(t)
+		l1[20] = &types.Header{Number: big.NewInt(20), Extra: []byte{0xff}}
+		checked, mismatches, err := Verify(context.Background(), db, 0, math.MaxUint64, l1, l2)
+		require.NoError(t, err)
+		require.Equal(t, 3, checked)
+		require.Len(t, mismatches, 1)
+		require.Equal(t, uint64(20), mismatches[0].Entry.L1Block.Number)
+	})
+
+	t.Run("L2Missing", func(t *testing.T) {
+		db, l1, l2 := setupDB(t)
+		delete(l2, 300)
+		_, mismatches, err := Verify(context.Background(), db, 0, math.MaxUint64, l1, l2)
+		require.NoError(t, err)
+		require.Len(t, mismatches, 1)
+		require.Equal(t, uint64(300), mismatches[0].Entry.SafeHead.Number)
+		require.Contains(t, mismatches[0].Reason, "not found")
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		db, l1, l2 := setupDB(t)
+		delete(l2, 300)
+		checked, mismatches, err := Verify(context.Background(), db, 0, 20, l1, l2)
+		require.NoError(t, err)
+		require.Equal(t, 2, checked)
+		require.Empty(t, mismatches)
+	})
+}
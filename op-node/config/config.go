@@ -61,6 +61,10 @@ type Config struct {
 	// Path to store safe head database. Disabled when set to empty string
 	SafeDBPath string
 
+	// SafeDBRetention is the number of L1 blocks of safe head history to keep in the safe head database.
+	// Older entries are pruned automatically. Zero keeps all history.
+	SafeDBRetention uint64
+
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
 	// Runtime config changes should be picked up from log-events,
@@ -174,6 +178,11 @@ func (cfg *Config) Check() error {
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
+	if cfg.Driver.SequencerEnabled {
+		if err := cfg.Driver.SequencerL1OriginPolicy.Check(); err != nil {
+			return fmt.Errorf("sequencer L1 origin policy config error: %w", err)
+		}
+	}
 	if cfg.ConductorEnabled {
 		if state, _ := cfg.ConfigPersistence.SequencerState(); state != StateUnset {
 			return fmt.Errorf("config persistence must be disabled when conductor is enabled")
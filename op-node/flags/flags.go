@@ -264,6 +264,31 @@ var (
 		Value:    4,
 		Category: SequencerCategory,
 	}
+	SequencerL1OriginPolicyFlag = &cli.StringFlag{
+		Name: "sequencer.l1-origin-policy",
+		Usage: "Policy for when the sequencer moves on to the next L1 origin. " +
+			"'latest' adopts new L1 origins as soon as allowed, 'fast-adopt' additionally fetches new L1 origins eagerly to minimize deposit latency, " +
+			"'conf-depth' waits for sequencer.l1-origin-policy.conf-depth confirmations on top of new L1 origins, " +
+			"'conservative' waits until new L1 origins are sequencer.l1-origin-policy.min-age seconds old and skips origins that do not build on the current origin. " +
+			"The max sequencer drift is enforced regardless of the policy.",
+		EnvVars:  prefixEnvVars("SEQUENCER_L1_ORIGIN_POLICY"),
+		Value:    "latest",
+		Category: SequencerCategory,
+	}
+	SequencerL1OriginPolicyConfDepthFlag = &cli.Uint64Flag{
+		Name:     "sequencer.l1-origin-policy.conf-depth",
+		Usage:    "Number of L1 blocks required on top of a new L1 origin before adopting it. Only used by the 'conf-depth' L1 origin policy.",
+		EnvVars:  prefixEnvVars("SEQUENCER_L1_ORIGIN_POLICY_CONF_DEPTH"),
+		Value:    2,
+		Category: SequencerCategory,
+	}
+	SequencerL1OriginPolicyMinAgeFlag = &cli.DurationFlag{
+		Name:     "sequencer.l1-origin-policy.min-age",
+		Usage:    "Minimum age of a new L1 origin, relative to the L2 block being built, before adopting it. Only used by the 'conservative' L1 origin policy.",
+		EnvVars:  prefixEnvVars("SEQUENCER_L1_ORIGIN_POLICY_MIN_AGE"),
+		Value:    24 * time.Second,
+		Category: SequencerCategory,
+	}
 	SequencerRecoverMode = &cli.BoolFlag{
 		Name:     "sequencer.recover",
 		Usage:    "Forces the sequencer to strictly prepare the next L1 origin and create empty L2 blocks",
@@ -343,6 +368,12 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	SafeDBRetention = &cli.Uint64Flag{
+		Name:     "safedb.retention",
+		Usage:    "Number of L1 blocks of safe head history to keep in the safe head database. Older entries are pruned automatically. 0 keeps all history.",
+		EnvVars:  prefixEnvVars("SAFEDB_RETENTION"),
+		Category: OperationsCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -490,6 +521,9 @@ var optionalFlags = []cli.Flag{
 	SequencerMaxSafeLagFlag,
 	SequencerL1Confs,
 	SequencerRecoverMode,
+	SequencerL1OriginPolicyFlag,
+	SequencerL1OriginPolicyConfDepthFlag,
+	SequencerL1OriginPolicyMinAgeFlag,
 	FinalityLookbackFlag,
 	FinalityDelayFlag,
 	L1EpochPollIntervalFlag,
@@ -505,6 +539,7 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	SafeDBRetention,
 	L1ChainConfig,
 	L2EngineKind,
 	L2EngineRpcTimeout,
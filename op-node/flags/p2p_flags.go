@@ -34,6 +34,7 @@ var (
 	AdvertiseUDPPortName         = "p2p.advertise.udp"
 	BootnodesName                = "p2p.bootnodes"
 	StaticPeersName              = "p2p.static"
+	PeerGroupsName               = "p2p.peer-groups"
 	NetRestrictName              = "p2p.netrestrict"
 	HostMuxName                  = "p2p.mux"
 	HostSecurityName             = "p2p.security"
@@ -56,6 +57,7 @@ var (
 	GossipTimestampThresholdName = "p2p.gossip.timestamp.threshold"
 	SyncReqRespName              = "p2p.sync.req-resp"
 	SyncOnlyReqToStaticName      = "p2p.sync.onlyreqtostatic"
+	SyncReqRespRangeName         = "p2p.sync.req-resp.range"
 	P2PPingName                  = "p2p.ping"
 )
 
@@ -223,6 +225,16 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "STATIC"),
 			Category: P2PCategory,
 		},
+		&cli.PathFlag{
+			Name: PeerGroupsName,
+			Usage: "Path to a JSON file defining named groups of trusted peers, e.g. the sequencers of the network operator. " +
+				"Members are kept connected with reconnect backoff and are never banned based on their score. " +
+				`Format: [{"name": "sequencers", "peers": ["<multiaddr>"], "gossipPriority": true, "maxConnected": 0}]`,
+			Required:  false,
+			TakesFile: true,
+			EnvVars:   p2pEnv(envPrefix, "PEER_GROUPS"),
+			Category:  P2PCategory,
+		},
 		&cli.StringFlag{
 			Name:     NetRestrictName,
 			Usage:    "Comma-separated list of CIDR masks. P2P will only try to connect on these networks",
@@ -411,6 +423,14 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "SYNC_ONLYREQTOSTATIC"),
 			Category: P2PCategory,
 		},
+		&cli.BoolFlag{
+			Name:     SyncReqRespRangeName,
+			Usage:    "Request contiguous ranges of payloads in the P2P req-resp sync client, instead of one payload per request. Peers that do not support payload-by-range requests are synced from by number.",
+			Value:    false,
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SYNC_REQ_RESP_RANGE"),
+			Category: P2PCategory,
+		},
 		&cli.BoolFlag{
 			Name:     P2PPingName,
 			Usage:    "Enables P2P ping-pong background service",
@@ -49,6 +49,7 @@ type Metricer interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordSequencerL1OriginSelection(policy string, decision string, lag uint64)
 	RecordGossipEvent(evType int32)
 	IncPeerCount()
 	DecPeerCount()
@@ -66,6 +67,8 @@ type Metricer interface {
 	SetPeerScores(allScores []store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration)
+	ServerPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 	RecordPeerUnban()
 	RecordIPUnban()
@@ -100,12 +103,16 @@ type Metrics struct {
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
+	P2PPayloadsByRange    *prometheus.CounterVec
 
 	PayloadsQuarantineTotal prometheus.Gauge
 
 	SequencerInconsistentL1Origin *metrics.Event
 	SequencerResets               *metrics.Event
 
+	SequencerL1OriginSelections *prometheus.CounterVec
+	SequencerL1OriginLag        prometheus.Gauge
+
 	L1RequestDurationSeconds *prometheus.HistogramVec
 
 	SequencerBuildingDiffDurationSeconds prometheus.Histogram
@@ -209,6 +216,17 @@ func NewMetrics(procName string) *Metrics {
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
 
+		SequencerL1OriginSelections: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sequencer_l1_origin_selections_total",
+			Help:      "Count of sequencer L1 origin selections, by origin policy and decision (stay, adopt, forced)",
+		}, []string{"policy", "decision"}),
+		SequencerL1OriginLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_l1_origin_lag_seconds",
+			Help:      "Time difference in seconds between the last sequenced L2 block and its selected L1 origin",
+		}),
+
 		UnsafePayloadsBufferLen: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "unsafe_payloads_buffer_len",
@@ -334,6 +352,14 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"p2p_role", // "client" or "server"
 		}),
+		P2PPayloadsByRange: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "payloads_by_range_total",
+			Help:      "Number of payloads transferred with payload by range requests",
+		}, []string{
+			"p2p_role", // "client" or "server"
+		}),
 		PayloadsQuarantineTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -494,6 +520,11 @@ func (m *Metrics) RecordSequencerReset() {
 	m.SequencerResets.Record()
 }
 
+func (m *Metrics) RecordSequencerL1OriginSelection(policy string, decision string, lag uint64) {
+	m.SequencerL1OriginSelections.WithLabelValues(policy, decision).Inc()
+	m.SequencerL1OriginLag.Set(float64(lag))
+}
+
 func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
@@ -587,6 +618,23 @@ func (m *Metrics) ServerPayloadByNumberEvent(num uint64, resultCode byte, durati
 	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(num))
 }
 
+func (m *Metrics) ClientPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration) {
+	if resultCode > 4 { // summarize all high codes to reduce metrics overhead
+		resultCode = 5
+	}
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("client", "payload_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("client", "payload_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.WithLabelValues("client").Add(float64(payloads))
+}
+
+func (m *Metrics) ServerPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payload_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payload_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.WithLabelValues("server").Add(float64(payloads))
+}
+
 func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
@@ -699,6 +747,9 @@ func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to
 func (n *noopMetricer) RecordSequencerReset() {
 }
 
+func (n *noopMetricer) RecordSequencerL1OriginSelection(policy string, decision string, lag uint64) {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
@@ -736,6 +787,12 @@ func (n *noopMetricer) ClientPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration) {
 }
 
+func (n *noopMetricer) ClientPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ServerPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration) {
+}
+
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
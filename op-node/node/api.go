@@ -35,6 +35,10 @@ type driverClient interface {
 	ResetDerivationPipeline(context.Context) error
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
+	ScheduleSequencerStop(ctx context.Context, blockNum uint64) error
+	ScheduleSequencerStopAtTime(ctx context.Context, timestamp uint64) (uint64, error)
+	ScheduleSequencerStart(ctx context.Context, blockNum uint64, blockHash common.Hash) error
+	CancelSequencerHandover(ctx context.Context) error
 	SequencerActive(context.Context) (bool, error)
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope)
 	OverrideLeader(ctx context.Context) error
@@ -44,8 +48,14 @@ type driverClient interface {
 
 type SafeDBReader interface {
 	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
+	SafeHeadsInRange(ctx context.Context, startL1 uint64, endL1 uint64, fn func(entry safedb.SafeHeadEntry) error) error
 }
 
+// MaxSafeHeadsInRange is the maximum number of safe head updates returned by a single SafeHeadsInRange call.
+const MaxSafeHeadsInRange = 1000
+
+var errSafeHeadsRangeFull = errors.New("safe heads range full")
+
 type adminAPI struct {
 	*rpc.CommonAdminAPI
 	dr driverClient
@@ -72,6 +82,29 @@ func (n *adminAPI) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return n.dr.StopSequencer(ctx)
 }
 
+// ScheduleSequencerStop schedules the active sequencer to stop after sequencing the given block.
+func (n *adminAPI) ScheduleSequencerStop(ctx context.Context, blockNum hexutil.Uint64) error {
+	return n.dr.ScheduleSequencerStop(ctx, uint64(blockNum))
+}
+
+// ScheduleSequencerStopAtTime schedules the active sequencer to stop after sequencing the last block
+// at or before the given timestamp. It returns the number of the last block that will be sequenced.
+func (n *adminAPI) ScheduleSequencerStopAtTime(ctx context.Context, timestamp hexutil.Uint64) (hexutil.Uint64, error) {
+	blockNum, err := n.dr.ScheduleSequencerStopAtTime(ctx, uint64(timestamp))
+	return hexutil.Uint64(blockNum), err
+}
+
+// ScheduleSequencerStart schedules the standby sequencer to start on top of the given block,
+// as soon as it becomes the unsafe head. The block hash is optional.
+func (n *adminAPI) ScheduleSequencerStart(ctx context.Context, blockNum hexutil.Uint64, blockHash common.Hash) error {
+	return n.dr.ScheduleSequencerStart(ctx, uint64(blockNum), blockHash)
+}
+
+// CancelSequencerHandover cancels any scheduled sequencer stop or start.
+func (n *adminAPI) CancelSequencerHandover(ctx context.Context) error {
+	return n.dr.CancelSequencerHandover(ctx)
+}
+
 func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
@@ -159,6 +192,24 @@ func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64)
 	}, nil
 }
 
+// SafeHeadsInRange returns the safe head updates recorded at L1 blocks in the inclusive range [start, end],
+// in ascending order of L1 block number. At most MaxSafeHeadsInRange updates are returned:
+// callers page through larger ranges by continuing from the L1 block after the last returned update.
+func (n *nodeAPI) SafeHeadsInRange(ctx context.Context, start hexutil.Uint64, end hexutil.Uint64) ([]eth.SafeHeadResponse, error) {
+	result := make([]eth.SafeHeadResponse, 0)
+	err := n.safeDB.SafeHeadsInRange(ctx, uint64(start), uint64(end), func(entry safedb.SafeHeadEntry) error {
+		if len(result) >= MaxSafeHeadsInRange {
+			return errSafeHeadsRangeFull
+		}
+		result = append(result, eth.SafeHeadResponse{L1Block: entry.L1Block, SafeHead: entry.SafeHead})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errSafeHeadsRangeFull) {
+		return nil, fmt.Errorf("failed to get safe heads in l1 range [%s, %s]: %w", start, end, err)
+	}
+	return result, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return n.dr.SyncStatus(ctx)
 }
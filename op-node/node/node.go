@@ -596,8 +596,8 @@ func initL2(ctx context.Context, cfg *config.Config, node *OpNode) (*sources.Eng
 	altDA := altda.NewAltDA(node.log, cfg.AltDA, rpCfg, node.metrics.AltDAMetrics)
 	var safeDB closableSafeDB
 	if cfg.SafeDBPath != "" {
-		node.log.Info("Safe head database enabled", "path", cfg.SafeDBPath)
-		safeDB, err = safedb.NewSafeDB(node.log, cfg.SafeDBPath)
+		node.log.Info("Safe head database enabled", "path", cfg.SafeDBPath, "retention", cfg.SafeDBRetention)
+		safeDB, err = safedb.NewSafeDBWithRetention(node.log, cfg.SafeDBPath, cfg.SafeDBRetention)
 		if err != nil {
 			return nil, nil, nil, nil, fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
 		}
@@ -27,6 +27,10 @@ func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID,
 	return
 }
 
+func (d *DisabledDB) SafeHeadsInRange(_ context.Context, _ uint64, _ uint64, _ func(entry SafeHeadEntry) error) error {
+	return ErrNotEnabled
+}
+
 func (d *DisabledDB) SafeHeadReset(_ eth.L2BlockRef) error {
 	return nil
 }
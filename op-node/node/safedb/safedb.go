@@ -17,11 +17,16 @@ import (
 var (
 	ErrNotFound     = errors.New("not found")
 	ErrInvalidEntry = errors.New("invalid db entry")
+	ErrClosed       = errors.New("safe head database closed")
 )
 
 const (
 	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
 	keyPrefixSafeByL1BlockNum byte = 0
+
+	// pruneInterval is the minimum number of L1 blocks between automatic prunes when a retention is configured.
+	// Pruning less frequently avoids creating a range deletion for every safe head update.
+	pruneInterval = 100
 )
 
 var (
@@ -58,9 +63,20 @@ type SafeDB struct {
 
 	writeOpts *pebble.WriteOptions
 
+	// retention is the number of L1 blocks of safe head history to keep. Zero keeps all history.
+	retention uint64
+	// prunedTo is the L1 block number the database was last pruned to.
+	prunedTo uint64
+
 	closed bool
 }
 
+// SafeHeadEntry is a single recorded safe head update.
+type SafeHeadEntry struct {
+	L1Block  eth.BlockID `json:"l1Block"`
+	SafeHead eth.BlockID `json:"safeHead"`
+}
+
 func safeByL1BlockNumValue(l1 eth.BlockID, l2 eth.BlockID) []byte {
 	val := make([]byte, 0, 72)
 	val = append(val, l1.Hash.Bytes()...)
@@ -82,6 +98,12 @@ func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.Bloc
 }
 
 func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
+	return NewSafeDBWithRetention(logger, path, 0)
+}
+
+// NewSafeDBWithRetention opens the safe head database at path, automatically pruning entries recorded
+// more than retention L1 blocks before the latest safe head update. A retention of zero keeps all history.
+func NewSafeDBWithRetention(logger log.Logger, path string, retention uint64) (*SafeDB, error) {
 	db, err := pebble.Open(path, &pebble.Options{})
 	if err != nil {
 		return nil, err
@@ -90,6 +112,7 @@ func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
 		log:       logger,
 		db:        db,
 		writeOpts: &pebble.WriteOptions{Sync: true},
+		retention: retention,
 	}, nil
 }
 
@@ -109,6 +132,14 @@ func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) er
 	if err := batch.Commit(d.writeOpts); err != nil {
 		return fmt.Errorf("failed to commit safe head update: %w", err)
 	}
+	if d.retention > 0 && l1Head.Number > d.retention {
+		if pruneTo := l1Head.Number - d.retention; pruneTo >= d.prunedTo+pruneInterval {
+			if err := d.prune(pruneTo); err != nil {
+				// The update itself was recorded, so only log the failure and retry on a later update.
+				d.log.Warn("Failed to prune safe head db", "pruneTo", pruneTo, "err", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -167,6 +198,10 @@ func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
 func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
 	d.m.RLock()
 	defer d.m.RUnlock()
+	if d.closed {
+		err = ErrClosed
+		return
+	}
 	iter, err := d.db.NewIterWithContext(ctx, safeByL1BlockNumKey.IterRange())
 	if err != nil {
 		return
@@ -185,6 +220,83 @@ func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block e
 	return
 }
 
+// SafeHeadsInRange calls fn for every safe head update recorded at an L1 block number in the inclusive range
+// [startL1, endL1], in ascending order of L1 block number. Iteration stops at the first error returned by fn.
+func (d *SafeDB) SafeHeadsInRange(ctx context.Context, startL1 uint64, endL1 uint64, fn func(entry SafeHeadEntry) error) error {
+	if startL1 > endL1 {
+		return fmt.Errorf("invalid range: start %d is after end %d", startL1, endL1)
+	}
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	opts := &pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey.Of(startL1),
+	}
+	if endL1 < math.MaxUint64 {
+		opts.UpperBound = safeByL1BlockNumKey.Of(endL1 + 1)
+	} else {
+		opts.UpperBound = []byte{keyPrefixSafeByL1BlockNum + 1}
+	}
+	iter, err := d.db.NewIterWithContext(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read entry: %w", err)
+		}
+		l1Block, safeHead, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return fmt.Errorf("invalid entry at key %x: %w", iter.Key(), err)
+		}
+		if err := fn(SafeHeadEntry{L1Block: l1Block, SafeHead: safeHead}); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// Prune deletes safe head updates that are no longer needed to answer SafeHeadAtL1 queries for
+// L1 blocks at or after l1BlockNum. The most recent update at or before l1BlockNum is retained.
+func (d *SafeDB) Prune(l1BlockNum uint64) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return d.prune(l1BlockNum)
+}
+
+func (d *SafeDB) prune(l1BlockNum uint64) error {
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("prune failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if valid := iter.SeekLT(safeByL1BlockNumKey.Of(l1BlockNum + 1)); !valid {
+		// No entries at or before the prune point
+		d.prunedTo = l1BlockNum
+		return iter.Error()
+	}
+	// Keep a copy of this key - it is only valid until the iterator is closed
+	keepFrom := slices.Clone(iter.Key())
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(safeByL1BlockNumKey.Of(0), keepFrom, d.writeOpts); err != nil {
+		return fmt.Errorf("prune failed to delete entries before %x: %w", keepFrom, err)
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("prune failed to commit batch: %w", err)
+	}
+	d.log.Debug("Pruned safe head db", "l1", l1BlockNum)
+	d.prunedTo = l1BlockNum
+	return nil
+}
+
 func (d *SafeDB) Close() error {
 	d.m.Lock()
 	defer d.m.Unlock()
@@ -2,6 +2,7 @@ package safedb
 
 import (
 	"context"
+	"errors"
 	"math"
 	"slices"
 	"testing"
@@ -367,3 +368,118 @@ func TestDecodeSafeByL1BlockNum(t *testing.T) {
 		require.ErrorIs(t, err, ErrInvalidEntry)
 	})
 }
+
+func TestSafeHeadsInRange(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	var entries []SafeHeadEntry
+	for i := uint64(0); i < 5; i++ {
+		entry := SafeHeadEntry{
+			L1Block:  eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: 100 + i*10},
+			SafeHead: eth.BlockID{Hash: common.Hash{0x02, byte(i)}, Number: 200 + i*5},
+		}
+		entries = append(entries, entry)
+		require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: entry.SafeHead.Hash, Number: entry.SafeHead.Number}, entry.L1Block))
+	}
+
+	collect := func(start, end uint64) []SafeHeadEntry {
+		var result []SafeHeadEntry
+		require.NoError(t, db.SafeHeadsInRange(context.Background(), start, end, func(entry SafeHeadEntry) error {
+			result = append(result, entry)
+			return nil
+		}))
+		return result
+	}
+
+	require.Equal(t, entries, collect(0, math.MaxUint64))
+	require.Equal(t, entries[1:4], collect(110, 130))
+	require.Equal(t, entries[1:3], collect(105, 129))
+	require.Equal(t, entries[4:], collect(140, 140))
+	require.Empty(t, collect(141, math.MaxUint64))
+	require.Empty(t, collect(0, 99))
+
+	t.Run("StopOnError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		count := 0
+		err := db.SafeHeadsInRange(context.Background(), 0, math.MaxUint64, func(entry SafeHeadEntry) error {
+			count++
+			return expectedErr
+		})
+		require.ErrorIs(t, err, expectedErr)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		err := db.SafeHeadsInRange(context.Background(), 10, 9, func(entry SafeHeadEntry) error { return nil })
+		require.Error(t, err)
+	})
+}
+
+func TestReadAfterClose(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, _, err = db.SafeHeadAtL1(context.Background(), 1)
+	require.ErrorIs(t, err, ErrClosed)
+	err = db.SafeHeadsInRange(context.Background(), 0, 10, func(entry SafeHeadEntry) error { return nil })
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestPrune(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := uint64(0); i < 5; i++ {
+		require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{0x02, byte(i)}, Number: 200 + i}, eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: 100 + i*10}))
+	}
+
+	require.NoError(t, db.Prune(125))
+
+	// The entry at L1 block 120 is still required to answer queries for L1 blocks 125 to 129
+	l1, l2, err := db.SafeHeadAtL1(context.Background(), 125)
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), l1.Number)
+	require.Equal(t, uint64(202), l2.Number)
+
+	_, _, err = db.SafeHeadAtL1(context.Background(), 119)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var remaining []uint64
+	require.NoError(t, db.SafeHeadsInRange(context.Background(), 0, math.MaxUint64, func(entry SafeHeadEntry) error {
+		remaining = append(remaining, entry.L1Block.Number)
+		return nil
+	}))
+	require.Equal(t, []uint64{120, 130, 140}, remaining)
+
+	// Pruning before the first entry has no effect
+	require.NoError(t, db.Prune(50))
+	l1, _, err = db.SafeHeadAtL1(context.Background(), 120)
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), l1.Number)
+}
+
+func TestRetention(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDBWithRetention(logger, t.TempDir(), 50)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := uint64(0); i <= 30; i++ {
+		require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{0x02, byte(i)}, Number: 1000 + i}, eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: i * 10}))
+	}
+
+	// The last update was at L1 block 300, so the database was pruned to L1 block 200 once the
+	// retention point advanced by the prune interval.
+	l1, _, err := db.SafeHeadAtL1(context.Background(), 200)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), l1.Number)
+	_, _, err = db.SafeHeadAtL1(context.Background(), 199)
+	require.ErrorIs(t, err, ErrNotFound)
+}
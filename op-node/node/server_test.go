@@ -15,12 +15,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
@@ -281,6 +283,48 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestSafeHeadsInRange(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	entries := make([]safedb.SafeHeadEntry, MaxSafeHeadsInRange+1)
+	for i := range entries {
+		entries[i] = safedb.SafeHeadEntry{
+			L1Block:  eth.BlockID{Hash: common.Hash{0xdd, byte(i)}, Number: uint64(100 + i)},
+			SafeHead: eth.BlockID{Hash: common.Hash{0xee, byte(i)}, Number: uint64(1000 + i)},
+		}
+	}
+	safeReader.ExpectSafeHeadsInRange(100, 2000, entries, nil)
+	safeReader.ExpectSafeHeadsInRange(10, 20, nil, nil)
+
+	rpcCfg := &oprpc.CLIConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	m := &opmetrics.NoopRPCMetrics{}
+	server := newRPCServer(rpcCfg, &rollup.Config{}, nil, l2Client, drClient, safeReader, log, m, "0.0")
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialAttempts(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	out, err := rollupClient.SafeHeadsInRange(context.Background(), 100, 2000)
+	require.NoError(t, err)
+	require.Len(t, out, MaxSafeHeadsInRange, "results are limited")
+	require.Equal(t, entries[0].L1Block, out[0].L1Block)
+	require.Equal(t, entries[MaxSafeHeadsInRange-1].SafeHead, out[MaxSafeHeadsInRange-1].SafeHead)
+
+	out, err = rollupClient.SafeHeadsInRange(context.Background(), 10, 20)
+	require.NoError(t, err)
+	require.Empty(t, out)
+	safeReader.Mock.AssertExpectations(t)
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -310,6 +354,23 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 	return c.Mock.MethodCalled("StopSequencer").Get(0).(common.Hash), nil
 }
 
+func (c *mockDriverClient) ScheduleSequencerStop(ctx context.Context, blockNum uint64) error {
+	return c.Mock.MethodCalled("ScheduleSequencerStop", blockNum).Get(0).(error)
+}
+
+func (c *mockDriverClient) ScheduleSequencerStopAtTime(ctx context.Context, timestamp uint64) (uint64, error) {
+	m := c.Mock.MethodCalled("ScheduleSequencerStopAtTime", timestamp)
+	return m.Get(0).(uint64), nil
+}
+
+func (c *mockDriverClient) ScheduleSequencerStart(ctx context.Context, blockNum uint64, blockHash common.Hash) error {
+	return c.Mock.MethodCalled("ScheduleSequencerStart", blockNum, blockHash).Get(0).(error)
+}
+
+func (c *mockDriverClient) CancelSequencerHandover(ctx context.Context) error {
+	return c.Mock.MethodCalled("CancelSequencerHandover").Get(0).(error)
+}
+
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
@@ -343,3 +404,17 @@ func (m *mockSafeDBReader) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64)
 func (m *mockSafeDBReader) ExpectSafeHeadAtL1(l1BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
 	m.Mock.On("SafeHeadAtL1", l1BlockNum).Return(l1, safeHead, &err)
 }
+
+func (m *mockSafeDBReader) SafeHeadsInRange(ctx context.Context, startL1 uint64, endL1 uint64, fn func(entry safedb.SafeHeadEntry) error) error {
+	r := m.Mock.MethodCalled("SafeHeadsInRange", startL1, endL1)
+	for _, entry := range r[0].([]safedb.SafeHeadEntry) {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return *r[1].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectSafeHeadsInRange(startL1 uint64, endL1 uint64, entries []safedb.SafeHeadEntry, err error) {
+	m.Mock.On("SafeHeadsInRange", startL1, endL1).Return(entries, &err)
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -67,10 +68,46 @@ func NewConfig(ctx cliiface.Context, blockTime uint64) (*p2p.Config, error) {
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
 	conf.EnablePingService = ctx.Bool(flags.P2PPingName)
 	conf.SyncOnlyReqToStatic = ctx.Bool(flags.SyncOnlyReqToStaticName)
+	conf.EnableReqRespRangeSync = ctx.Bool(flags.SyncReqRespRangeName)
 
 	return conf, nil
 }
 
+type peerGroupFile struct {
+	Name           string   `json:"name"`
+	Peers          []string `json:"peers"`
+	GossipPriority bool     `json:"gossipPriority"`
+	MaxConnected   uint     `json:"maxConnected"`
+}
+
+func loadPeerGroups(path string) ([]p2p.PeerGroupConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var groups []peerGroupFile
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode peer groups file: %w", err)
+	}
+	out := make([]p2p.PeerGroupConfig, 0, len(groups))
+	for _, g := range groups {
+		cfg := p2p.PeerGroupConfig{
+			Name:           g.Name,
+			GossipPriority: g.GossipPriority,
+			MaxConnected:   g.MaxConnected,
+		}
+		for i, addr := range g.Peers {
+			a, err := multiaddr.NewMultiaddr(addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse multi addr of peer %d in group %q: %q err: %w", i, g.Name, addr, err)
+			}
+			cfg.Peers = append(cfg.Peers, a)
+		}
+		out = append(out, cfg)
+	}
+	return out, nil
+}
+
 func validatePort(p uint) (uint16, error) {
 	if p == 0 {
 		return 0, nil
@@ -257,6 +294,14 @@ func loadLibp2pOpts(conf *p2p.Config, ctx cliiface.Context) error {
 		conf.StaticPeers = append(conf.StaticPeers, a)
 	}
 
+	if path := ctx.Path(flags.PeerGroupsName); path != "" {
+		groups, err := loadPeerGroups(path)
+		if err != nil {
+			return fmt.Errorf("failed to load peer groups: %w", err)
+		}
+		conf.PeerGroups = groups
+	}
+
 	for _, v := range strings.Split(ctx.String(flags.HostMuxName), ",") {
 		v = strings.ToLower(strings.TrimSpace(v))
 		switch v {
@@ -65,6 +65,8 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// ReqRespRangeSyncEnabled reports whether the req-resp sync client should request payloads by range.
+	ReqRespRangeSyncEnabled() bool
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
@@ -103,6 +105,8 @@ type Config struct {
 	NetRestrict      *netutil.Netlist
 
 	StaticPeers []core.Multiaddr
+	// PeerGroups are named groups of trusted peers, kept connected and exempt from score-based banning.
+	PeerGroups []PeerGroupConfig
 
 	HostMux             []libp2p.Option
 	HostSecurity        []libp2p.Option
@@ -137,6 +141,9 @@ type Config struct {
 
 	EnableReqRespSync   bool
 	SyncOnlyReqToStatic bool
+	// EnableReqRespRangeSync makes the req-resp sync client request contiguous ranges of payloads,
+	// falling back to requests by number for peers that do not support the payload-by-range protocol.
+	EnableReqRespRangeSync bool
 
 	EnablePingService bool
 }
@@ -181,6 +188,10 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) ReqRespRangeSyncEnabled() bool {
+	return conf.EnableReqRespRangeSync
+}
+
 func (conf *Config) GetGossipTimestampThreshold() time.Duration {
 	return conf.GossipTimestampThreshold
 }
@@ -192,6 +203,9 @@ func (conf *Config) Check() error {
 		if len(conf.StaticPeers) > 0 {
 			return errors.New("both --p2p.static and --p2p.disable are specified")
 		}
+		if len(conf.PeerGroups) > 0 {
+			return errors.New("both --p2p.peer-groups and --p2p.disable are specified")
+		}
 		return nil
 	}
 	if conf.Store == nil {
@@ -217,5 +231,16 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	groupNames := make(map[string]struct{})
+	for i := range conf.PeerGroups {
+		g := &conf.PeerGroups[i]
+		if err := g.Check(); err != nil {
+			return err
+		}
+		if _, ok := groupNames[g.Name]; ok {
+			return fmt.Errorf("duplicate peer group %q", g.Name)
+		}
+		groupNames[g.Name] = struct{}{}
+	}
 	return nil
 }
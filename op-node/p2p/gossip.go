@@ -156,11 +156,20 @@ func (p *Config) ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option {
 	params.Dhi = p.MeshDHi
 	params.Dlazy = p.MeshDLazy
 
-	// in the future we may add more advanced options like scoring and PX / direct-mesh / episub
-	return []pubsub.Option{
+	// in the future we may add more advanced options like scoring and PX / episub
+	opts := []pubsub.Option{
 		pubsub.WithGossipSubParams(params),
 		pubsub.WithFloodPublish(p.FloodPublish),
 	}
+	var self peer.ID
+	if p.Priv != nil {
+		self, _ = peer.IDFromPrivateKey(p.Priv)
+	}
+	// Direct peers always receive all messages we see, and gossipsub keeps them connected.
+	if direct := gossipPriorityPeers(self, p.PeerGroups); len(direct) > 0 {
+		opts = append(opts, pubsub.WithDirectPeers(direct))
+	}
+	return opts
 }
 
 func BuildGlobalGossipParams(cfg *rollup.Config) pubsub.GossipSubParams {
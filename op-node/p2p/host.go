@@ -49,6 +49,7 @@ type ExtraHostFeatures interface {
 	ConnectionManager() connmgr.ConnManager
 	IsStatic(peerID peer.ID) bool
 	SyncOnlyReqToStatic() bool
+	PeerGroups() *PeerGroups
 }
 
 type extraHost struct {
@@ -60,6 +61,8 @@ type extraHost struct {
 	staticPeers   []*peer.AddrInfo
 	staticPeerIDs map[peer.ID]struct{}
 
+	peerGroups *PeerGroups
+
 	pinging *PingService
 
 	quitC chan struct{}
@@ -84,8 +87,13 @@ func (e *extraHost) SyncOnlyReqToStatic() bool {
 	return e.syncOnlyReqToStatic
 }
 
+func (e *extraHost) PeerGroups() *PeerGroups {
+	return e.peerGroups
+}
+
 func (e *extraHost) Close() error {
 	close(e.quitC)
+	e.peerGroups.Close()
 	if e.pinging != nil {
 		e.pinging.Close()
 	}
@@ -276,6 +284,15 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		syncOnlyReqToStatic: conf.SyncOnlyReqToStatic,
 	}
 
+	// Set up the peer groups before starting any background work, which would leak if this fails.
+	out.peerGroups = NewPeerGroups(log.New("p2p", "peer-groups"), h, connMngr, clock.SystemClock)
+	for _, g := range conf.PeerGroups {
+		if err := out.peerGroups.AddGroup(g); err != nil {
+			_ = h.Close()
+			return nil, fmt.Errorf("failed to add peer group %q: %w", g.Name, err)
+		}
+	}
+
 	if conf.EnablePingService {
 		out.pinging = NewPingService(
 			log,
@@ -291,6 +308,8 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		go out.monitorStaticPeers()
 	}
 
+	out.peerGroups.Start()
+
 	out.gater = connGtr
 	return out, nil
 }
@@ -28,6 +28,102 @@ func (_m *API) EXPECT() *API_Expecter {
 	return &API_Expecter{mock: &_m.Mock}
 }
 
+// AddPeerGroup provides a mock function with given fields: ctx, name, maxConnected
+func (_m *API) AddPeerGroup(ctx context.Context, name string, maxConnected uint) error {
+	ret := _m.Called(ctx, name, maxConnected)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPeerGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, name, maxConnected)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_AddPeerGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPeerGroup'
+type API_AddPeerGroup_Call struct {
+	*mock.Call
+}
+
+// AddPeerGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - maxConnected uint
+func (_e *API_Expecter) AddPeerGroup(ctx interface{}, name interface{}, maxConnected interface{}) *API_AddPeerGroup_Call {
+	return &API_AddPeerGroup_Call{Call: _e.mock.On("AddPeerGroup", ctx, name, maxConnected)}
+}
+
+func (_c *API_AddPeerGroup_Call) Run(run func(ctx context.Context, name string, maxConnected uint)) *API_AddPeerGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *API_AddPeerGroup_Call) Return(_a0 error) *API_AddPeerGroup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_AddPeerGroup_Call) RunAndReturn(run func(context.Context, string, uint) error) *API_AddPeerGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddPeerToGroup provides a mock function with given fields: ctx, name, addr
+func (_m *API) AddPeerToGroup(ctx context.Context, name string, addr string) error {
+	ret := _m.Called(ctx, name, addr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPeerToGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, addr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_AddPeerToGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPeerToGroup'
+type API_AddPeerToGroup_Call struct {
+	*mock.Call
+}
+
+// AddPeerToGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - addr string
+func (_e *API_Expecter) AddPeerToGroup(ctx interface{}, name interface{}, addr interface{}) *API_AddPeerToGroup_Call {
+	return &API_AddPeerToGroup_Call{Call: _e.mock.On("AddPeerToGroup", ctx, name, addr)}
+}
+
+func (_c *API_AddPeerToGroup_Call) Run(run func(ctx context.Context, name string, addr string)) *API_AddPeerToGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *API_AddPeerToGroup_Call) Return(_a0 error) *API_AddPeerToGroup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_AddPeerToGroup_Call) RunAndReturn(run func(context.Context, string, string) error) *API_AddPeerToGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BlockAddr provides a mock function with given fields: ctx, ip
 func (_m *API) BlockAddr(ctx context.Context, ip net.IP) error {
 	ret := _m.Called(ctx, ip)
@@ -495,6 +591,64 @@ func (_c *API_ListBlockedSubnets_Call) RunAndReturn(run func(context.Context) ([
 	return _c
 }
 
+// ListPeerGroups provides a mock function with given fields: ctx
+func (_m *API) ListPeerGroups(ctx context.Context) ([]*p2p.PeerGroup, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPeerGroups")
+	}
+
+	var r0 []*p2p.PeerGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*p2p.PeerGroup, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*p2p.PeerGroup); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*p2p.PeerGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_ListPeerGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPeerGroups'
+type API_ListPeerGroups_Call struct {
+	*mock.Call
+}
+
+// ListPeerGroups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *API_Expecter) ListPeerGroups(ctx interface{}) *API_ListPeerGroups_Call {
+	return &API_ListPeerGroups_Call{Call: _e.mock.On("ListPeerGroups", ctx)}
+}
+
+func (_c *API_ListPeerGroups_Call) Run(run func(ctx context.Context)) *API_ListPeerGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *API_ListPeerGroups_Call) Return(_a0 []*p2p.PeerGroup, _a1 error) *API_ListPeerGroups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_ListPeerGroups_Call) RunAndReturn(run func(context.Context) ([]*p2p.PeerGroup, error)) *API_ListPeerGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PeerStats provides a mock function with given fields: ctx
 func (_m *API) PeerStats(ctx context.Context) (*p2p.PeerStats, error) {
 	ret := _m.Called(ctx)
@@ -659,6 +813,101 @@ func (_c *API_ProtectPeer_Call) RunAndReturn(run func(context.Context, peer.ID)
 	return _c
 }
 
+// RemovePeerFromGroup provides a mock function with given fields: ctx, name, id
+func (_m *API) RemovePeerFromGroup(ctx context.Context, name string, id peer.ID) error {
+	ret := _m.Called(ctx, name, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePeerFromGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, peer.ID) error); ok {
+		r0 = rf(ctx, name, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_RemovePeerFromGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePeerFromGroup'
+type API_RemovePeerFromGroup_Call struct {
+	*mock.Call
+}
+
+// RemovePeerFromGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - id peer.ID
+func (_e *API_Expecter) RemovePeerFromGroup(ctx interface{}, name interface{}, id interface{}) *API_RemovePeerFromGroup_Call {
+	return &API_RemovePeerFromGroup_Call{Call: _e.mock.On("RemovePeerFromGroup", ctx, name, id)}
+}
+
+func (_c *API_RemovePeerFromGroup_Call) Run(run func(ctx context.Context, name string, id peer.ID)) *API_RemovePeerFromGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(peer.ID))
+	})
+	return _c
+}
+
+func (_c *API_RemovePeerFromGroup_Call) Return(_a0 error) *API_RemovePeerFromGroup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_RemovePeerFromGroup_Call) RunAndReturn(run func(context.Context, string, peer.ID) error) *API_RemovePeerFromGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemovePeerGroup provides a mock function with given fields: ctx, name
+func (_m *API) RemovePeerGroup(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePeerGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_RemovePeerGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePeerGroup'
+type API_RemovePeerGroup_Call struct {
+	*mock.Call
+}
+
+// RemovePeerGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *API_Expecter) RemovePeerGroup(ctx interface{}, name interface{}) *API_RemovePeerGroup_Call {
+	return &API_RemovePeerGroup_Call{Call: _e.mock.On("RemovePeerGroup", ctx, name)}
+}
+
+func (_c *API_RemovePeerGroup_Call) Run(run func(ctx context.Context, name string)) *API_RemovePeerGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *API_RemovePeerGroup_Call) Return(_a0 error) *API_RemovePeerGroup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_RemovePeerGroup_Call) RunAndReturn(run func(context.Context, string) error) *API_RemovePeerGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Self provides a mock function with given fields: ctx
 func (_m *API) Self(ctx context.Context) (*p2p.PeerInfo, error) {
 	ret := _m.Called(ctx)
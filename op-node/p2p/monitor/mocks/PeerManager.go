@@ -172,6 +172,52 @@ func (_c *PeerManager_IsStatic_Call) RunAndReturn(run func(peer.ID) bool) *PeerM
 	return _c
 }
 
+// IsTrusted provides a mock function with given fields: _a0
+func (_m *PeerManager) IsTrusted(_a0 peer.ID) bool {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTrusted")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(peer.ID) bool); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// PeerManager_IsTrusted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTrusted'
+type PeerManager_IsTrusted_Call struct {
+	*mock.Call
+}
+
+// IsTrusted is a helper method to define mock.On call
+//   - _a0 peer.ID
+func (_e *PeerManager_Expecter) IsTrusted(_a0 interface{}) *PeerManager_IsTrusted_Call {
+	return &PeerManager_IsTrusted_Call{Call: _e.mock.On("IsTrusted", _a0)}
+}
+
+func (_c *PeerManager_IsTrusted_Call) Run(run func(_a0 peer.ID)) *PeerManager_IsTrusted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(peer.ID))
+	})
+	return _c
+}
+
+func (_c *PeerManager_IsTrusted_Call) Return(_a0 bool) *PeerManager_IsTrusted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PeerManager_IsTrusted_Call) RunAndReturn(run func(peer.ID) bool) *PeerManager_IsTrusted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Peers provides a mock function with no fields
 func (_m *PeerManager) Peers() []peer.ID {
 	ret := _m.Called()
@@ -21,6 +21,8 @@ type PeerManager interface {
 	Peers() []peer.ID
 	GetPeerScore(id peer.ID) (float64, error)
 	IsStatic(peer.ID) bool
+	// IsTrusted reports whether the peer is a member of a trusted peer group.
+	IsTrusted(peer.ID) bool
 	// BanPeer bans the peer until the specified time and disconnects any existing connections.
 	BanPeer(peer.ID, time.Time) error
 }
@@ -89,7 +91,7 @@ func (p *PeerMonitor) checkNextPeer() error {
 	if score >= p.minScore {
 		return nil
 	}
-	if p.manager.IsStatic(id) {
+	if p.manager.IsStatic(id) || p.manager.IsTrusted(id) {
 		return nil
 	}
 	if err := p.manager.BanPeer(id, p.clock.Now().Add(p.banDuration)); err != nil {
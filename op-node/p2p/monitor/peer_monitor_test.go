@@ -102,6 +102,7 @@ func TestCheckNextPeer(t *testing.T) {
 		manager.EXPECT().Peers().Return(peerIDs).Once()
 		manager.EXPECT().GetPeerScore(id).Return(-101, nil).Once()
 		manager.EXPECT().IsStatic(id).Return(false).Once()
+		manager.EXPECT().IsTrusted(id).Return(false).Once()
 		manager.EXPECT().BanPeer(id, clock.Now().Add(testBanDuration)).Return(nil).Once()
 
 		require.NoError(t, monitor.checkNextPeer())
@@ -116,6 +117,17 @@ func TestCheckNextPeer(t *testing.T) {
 
 		require.NoError(t, monitor.checkNextPeer())
 	})
+
+	t.Run("Do not close trusted peer when below min score", func(t *testing.T) {
+		monitor, _, manager := peerMonitorSetup(t)
+		id := peerIDs[0]
+		manager.EXPECT().Peers().Return(peerIDs).Once()
+		manager.EXPECT().GetPeerScore(id).Return(-101, nil).Once()
+		manager.EXPECT().IsStatic(id).Return(false)
+		manager.EXPECT().IsTrusted(id).Return(true)
+
+		require.NoError(t, monitor.checkNextPeer())
+	})
 }
 
 func waitForChan(t *testing.T, ch chan struct{}, msg string) {
@@ -38,6 +38,7 @@ type NodeP2P struct {
 	scorer      Scorer                         // writes score-updates to the peerstore and keeps metrics of score changes
 	connMgr     connmgr.ConnManager            // p2p conn manager, to keep a reliable number of peers, may be nil even with p2p enabled
 	peerMonitor *monitor.PeerMonitor           // peer monitor to disconnect bad peers, may be nil even with p2p enabled
+	peerGroups  *PeerGroups                    // trusted peer groups to keep connected, may be nil even with p2p enabled
 	store       store.ExtendedPeerstore        // peerstore of host, with extra bindings for scoring and banning
 	appScorer   ApplicationScorer
 	log         log.Logger
@@ -117,6 +118,7 @@ func (n *NodeP2P) init(
 	if extra, ok := n.host.(ExtraHostFeatures); ok {
 		n.gater = extra.ConnectionGater()
 		n.connMgr = extra.ConnectionManager()
+		n.peerGroups = extra.PeerGroups()
 	}
 	eps, ok := n.host.Peerstore().(store.ExtendedPeerstore)
 	if !ok {
@@ -132,7 +134,7 @@ func (n *NodeP2P) init(
 	}
 	// Activate the P2P req-resp sync if enabled by feature-flag.
 	if setup.ReqRespSyncEnabled() {
-		n.syncCl = NewSyncClient(log, rollupCfg, n.host, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer)
+		n.syncCl = NewSyncClient(log, rollupCfg, n.host, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer, setup.ReqRespRangeSyncEnabled())
 		n.host.Network().Notify(&network.NotifyBundle{
 			ConnectedF: func(nw network.Network, conn network.Conn) {
 				n.syncCl.AddPeer(conn.RemotePeer())
@@ -154,6 +156,8 @@ func (n *NodeP2P) init(
 			// register the sync protocol with libp2p host
 			payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 			n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+			payloadByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleSyncRangeRequest)
+			n.host.SetStreamHandler(PayloadByRangeProtocolID(rollupCfg.L2ChainID), payloadByRange)
 		}
 	}
 	n.scorer = NewScorer(eps, metrics, n.appScorer, log)
@@ -233,6 +237,10 @@ func (n *NodeP2P) ConnectionManager() connmgr.ConnManager {
 	return n.connMgr
 }
 
+func (n *NodeP2P) PeerGroups() *PeerGroups {
+	return n.peerGroups
+}
+
 func (n *NodeP2P) Peers() []peer.ID {
 	return n.host.Network().Peers()
 }
@@ -245,6 +253,11 @@ func (n *NodeP2P) IsStatic(id peer.ID) bool {
 	return n.connMgr != nil && n.connMgr.IsProtected(id, staticPeerTag)
 }
 
+// IsTrusted reports whether the peer is a member of a trusted peer group.
+func (n *NodeP2P) IsTrusted(id peer.ID) bool {
+	return n.peerGroups != nil && n.peerGroups.IsTrusted(id)
+}
+
 func (n *NodeP2P) BanPeer(id peer.ID, expiration time.Time) error {
 	if err := n.store.SetPeerBanExpiration(id, expiration); err != nil {
 		return fmt.Errorf("failed to set peer ban expiry: %w", err)
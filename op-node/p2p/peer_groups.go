@@ -0,0 +1,445 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// peerGroupCheckInterval is the interval at which disconnected members of peer groups are redialed.
+	peerGroupCheckInterval = 10 * time.Second
+	// minReconnectBackoff is the time to wait before redialing a member after the first failed dial.
+	// The backoff doubles with every consecutive failure, up to maxReconnectBackoff.
+	minReconnectBackoff  = 5 * time.Second
+	maxReconnectBackoff  = 5 * time.Minute
+	peerGroupDialTimeout = 30 * time.Second
+)
+
+var (
+	ErrUnknownPeerGroup = errors.New("unknown peer group")
+	ErrPeerGroupExists  = errors.New("peer group already exists")
+)
+
+// PeerGroupConfig defines a named group of trusted peers, e.g. the sequencers of the network operator.
+// Members of a peer group are protected from connection pruning, are never banned based on their score,
+// and are redialed with backoff when disconnected.
+type PeerGroupConfig struct {
+	Name  string
+	Peers []ma.Multiaddr
+	// GossipPriority makes the members direct gossip peers: they are always sent all gossip messages,
+	// regardless of the mesh state. This can only be configured at startup.
+	GossipPriority bool
+	// MaxConnected is the maximum number of members that are kept connected. 0 keeps all members connected.
+	// Members beyond the limit are not dialed, but are still trusted when they connect to us.
+	MaxConnected uint
+}
+
+func (c *PeerGroupConfig) Check() error {
+	if c.Name == "" {
+		return errors.New("peer group must have a name")
+	}
+	for _, addr := range c.Peers {
+		if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+			return fmt.Errorf("bad address %q in peer group %q: %w", addr, c.Name, err)
+		}
+	}
+	return nil
+}
+
+type peerGroupMember struct {
+	addr *peer.AddrInfo
+}
+
+// reconnectState is the reconnect backoff of a peer. It is shared by all groups the peer is a member of,
+// so a peer in multiple groups is not redialed more often.
+type reconnectState struct {
+	// failures is the number of consecutive failed dials, used to compute the reconnect backoff.
+	failures int
+	nextDial time.Time
+}
+
+type peerGroup struct {
+	name           string
+	gossipPriority bool
+	maxConnected   uint
+	members        map[peer.ID]*peerGroupMember
+}
+
+func (g *peerGroup) protectTag() string {
+	return "group-" + g.name
+}
+
+// PeerGroups maintains the connections to the members of the configured peer groups.
+// Groups and members can be changed at runtime, but such changes are not persisted.
+type PeerGroups struct {
+	log     log.Logger
+	h       host.Host
+	connMgr connmgr.ConnManager
+	clock   clock.Clock
+
+	mu     sync.Mutex
+	groups map[string]*peerGroup
+	// reconnects holds the reconnect backoff of the members that failed to dial, by peer ID
+	reconnects map[peer.ID]*reconnectState
+
+	pokeC chan struct{}
+	quitC chan struct{}
+	wg    sync.WaitGroup
+}
+
+func NewPeerGroups(log log.Logger, h host.Host, connMgr connmgr.ConnManager, clock clock.Clock) *PeerGroups {
+	return &PeerGroups{
+		log:        log,
+		h:          h,
+		connMgr:    connMgr,
+		clock:      clock,
+		groups:     make(map[string]*peerGroup),
+		reconnects: make(map[peer.ID]*reconnectState),
+		pokeC:      make(chan struct{}, 1),
+		quitC:      make(chan struct{}),
+	}
+}
+
+// Start starts redialing disconnected members in the background.
+func (p *PeerGroups) Start() {
+	p.h.Network().Notify(&network.NotifyBundle{
+		DisconnectedF: func(nw network.Network, conn network.Conn) {
+			if nw.Connectedness(conn.RemotePeer()) != network.Connected && p.IsTrusted(conn.RemotePeer()) {
+				p.poke()
+			}
+		},
+	})
+	p.wg.Add(1)
+	go p.loop()
+}
+
+func (p *PeerGroups) Close() {
+	close(p.quitC)
+	p.wg.Wait()
+}
+
+func (p *PeerGroups) poke() {
+	select {
+	case p.pokeC <- struct{}{}:
+	default:
+	}
+}
+
+func (p *PeerGroups) loop() {
+	defer p.wg.Done()
+	tick := p.clock.NewTicker(peerGroupCheckInterval)
+	defer tick.Stop()
+	p.checkGroups()
+	for {
+		select {
+		case <-tick.Ch():
+			p.checkGroups()
+		case <-p.pokeC:
+			p.checkGroups()
+		case <-p.quitC:
+			return
+		}
+	}
+}
+
+// AddGroup adds a new peer group. Members that are already connected become trusted immediately.
+func (p *PeerGroups) AddGroup(cfg PeerGroupConfig) error {
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.groups[cfg.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrPeerGroupExists, cfg.Name)
+	}
+	g := &peerGroup{
+		name:           cfg.Name,
+		gossipPriority: cfg.GossipPriority,
+		maxConnected:   cfg.MaxConnected,
+		members:        make(map[peer.ID]*peerGroupMember),
+	}
+	p.groups[cfg.Name] = g
+	for _, addr := range cfg.Peers {
+		// Check already verified the address
+		info, _ := peer.AddrInfoFromP2pAddr(addr)
+		p.addMember(g, info)
+	}
+	p.poke()
+	return nil
+}
+
+// RemoveGroup removes the peer group. Connections to its members are kept,
+// but they are no longer protected unless they are a member of another group.
+func (p *PeerGroups) RemoveGroup(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g, ok := p.groups[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPeerGroup, name)
+	}
+	delete(p.groups, name)
+	for id := range g.members {
+		p.connMgr.Unprotect(id, g.protectTag())
+		p.forgetReconnect(id)
+	}
+	return nil
+}
+
+// AddPeer adds the peer with the given multi-address, which must include the peer ID, to the group.
+// Adding an existing member adds the addresses to the known addresses of the member.
+func (p *PeerGroups) AddPeer(name string, addr ma.Multiaddr) error {
+	info, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return fmt.Errorf("bad peer address: %w", err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g, ok := p.groups[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPeerGroup, name)
+	}
+	p.addMember(g, info)
+	p.poke()
+	return nil
+}
+
+// RemovePeer removes the peer from the group. The connection to the peer is kept.
+func (p *PeerGroups) RemovePeer(name string, id peer.ID) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g, ok := p.groups[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPeerGroup, name)
+	}
+	if _, ok := g.members[id]; !ok {
+		return fmt.Errorf("peer %s is not a member of peer group %q", id, name)
+	}
+	delete(g.members, id)
+	p.connMgr.Unprotect(id, g.protectTag())
+	p.forgetReconnect(id)
+	return nil
+}
+
+// forgetReconnect drops the reconnect backoff of the peer if it is no longer a member of any group.
+func (p *PeerGroups) forgetReconnect(id peer.ID) {
+	if !p.isTrusted(id) {
+		delete(p.reconnects, id)
+	}
+}
+
+func (p *PeerGroups) addMember(g *peerGroup, info *peer.AddrInfo) {
+	if info.ID == p.h.ID() {
+		p.log.Info("Peer group contains address of local peer, ignoring the address", "group", g.name, "addrs", info.Addrs)
+		return
+	}
+	if m, ok := g.members[info.ID]; ok {
+		for _, addr := range info.Addrs {
+			if !slices.ContainsFunc(m.addr.Addrs, addr.Equal) {
+				m.addr.Addrs = append(m.addr.Addrs, addr)
+			}
+		}
+	} else {
+		g.members[info.ID] = &peerGroupMember{addr: info}
+	}
+	p.h.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+	// Tag the protection with the group, so removing the peer from one group does not affect other groups.
+	p.connMgr.Protect(info.ID, g.protectTag())
+}
+
+// IsTrusted reports whether the peer is a member of any peer group.
+func (p *PeerGroups) IsTrusted(id peer.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isTrusted(id)
+}
+
+func (p *PeerGroups) isTrusted(id peer.ID) bool {
+	for _, g := range p.groups {
+		if _, ok := g.members[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Groups returns the current state of all peer groups, sorted by name.
+func (p *PeerGroups) Groups() []*apis.PeerGroup {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	nw := p.h.Network()
+	out := make([]*apis.PeerGroup, 0, len(p.groups))
+	for _, g := range p.groups {
+		info := &apis.PeerGroup{
+			Name:           g.name,
+			Peers:          make([]string, 0, len(g.members)),
+			Connected:      make([]peer.ID, 0, len(g.members)),
+			GossipPriority: g.gossipPriority,
+			MaxConnected:   g.maxConnected,
+		}
+		for id, m := range g.members {
+			addrs, err := peer.AddrInfoToP2pAddrs(m.addr)
+			if err == nil {
+				for _, addr := range addrs {
+					info.Peers = append(info.Peers, addr.String())
+				}
+			}
+			if nw.Connectedness(id) == network.Connected {
+				info.Connected = append(info.Connected, id)
+			}
+		}
+		sort.Strings(info.Peers)
+		sort.Slice(info.Connected, func(i, j int) bool { return info.Connected[i] < info.Connected[j] })
+		out = append(out, info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// checkGroups dials the disconnected members of all groups whose backoff has passed,
+// as long as the group is below its limit of connected members.
+func (p *PeerGroups) checkGroups() {
+	ctx, cancel := context.WithTimeout(context.Background(), peerGroupDialTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, addr := range p.dueMembers() {
+		wg.Add(1)
+		go func(addr peer.AddrInfo) {
+			defer wg.Done()
+			err := p.h.Connect(ctx, addr)
+			p.onDialResult(addr.ID, err)
+		}(addr)
+	}
+	wg.Wait()
+}
+
+// dueMembers returns the members to dial, with a copy of their addresses that is safe to use without holding the lock.
+// A peer may be a member of multiple groups, but is only dialed once, with the addresses known by all its groups.
+func (p *PeerGroups) dueMembers() []peer.AddrInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	nw := p.h.Network()
+	now := p.clock.Now()
+	due := make(map[peer.ID]*peer.AddrInfo)
+	var ids []peer.ID
+	for _, g := range p.groups {
+		connected := uint(0)
+		var candidates []peer.ID
+		for id := range g.members {
+			if nw.Connectedness(id) == network.Connected {
+				connected++
+				delete(p.reconnects, id)
+				continue
+			}
+			if r, ok := p.reconnects[id]; !ok || !r.nextDial.After(now) {
+				candidates = append(candidates, id)
+			}
+		}
+		// Prefer the members that failed the least, so the limit is filled with healthy peers first.
+		sort.Slice(candidates, func(i, j int) bool { return p.failures(candidates[i]) < p.failures(candidates[j]) })
+		for _, id := range candidates {
+			if g.maxConnected != 0 && connected >= g.maxConnected {
+				break
+			}
+			addrs := g.members[id].addr.Addrs
+			if info, ok := due[id]; ok {
+				for _, addr := range addrs {
+					if !slices.ContainsFunc(info.Addrs, addr.Equal) {
+						info.Addrs = append(info.Addrs, addr)
+					}
+				}
+			} else {
+				due[id] = &peer.AddrInfo{ID: id, Addrs: slices.Clone(addrs)}
+				ids = append(ids, id)
+			}
+			connected++
+		}
+	}
+	out := make([]peer.AddrInfo, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, *due[id])
+	}
+	return out
+}
+
+func (p *PeerGroups) failures(id peer.ID) int {
+	if r, ok := p.reconnects[id]; ok {
+		return r.failures
+	}
+	return 0
+}
+
+func (p *PeerGroups) onDialResult(id peer.ID, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		delete(p.reconnects, id)
+		return
+	}
+	if !p.isTrusted(id) {
+		// The peer was removed from its groups while being dialed
+		return
+	}
+	r, ok := p.reconnects[id]
+	if !ok {
+		r = &reconnectState{}
+		p.reconnects[id] = r
+	}
+	r.failures++
+	backoff := reconnectBackoff(r.failures)
+	r.nextDial = p.clock.Now().Add(backoff)
+	p.log.Warn("Failed to dial peer group member", "peer", id, "failures", r.failures, "backoff", backoff, "err", err)
+}
+
+// reconnectBackoff returns the time to wait before redialing a peer after the given number of consecutive failures.
+func reconnectBackoff(failures int) time.Duration {
+	backoff := minReconnectBackoff
+	for i := 1; i < failures && backoff < maxReconnectBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxReconnectBackoff)
+}
+
+// gossipPriorityPeers returns the members of the configured groups with gossip priority, excluding the local peer.
+func gossipPriorityPeers(self peer.ID, groups []PeerGroupConfig) []peer.AddrInfo {
+	byID := make(map[peer.ID]*peer.AddrInfo)
+	var ids []peer.ID
+	for _, g := range groups {
+		if !g.GossipPriority {
+			continue
+		}
+		for _, addr := range g.Peers {
+			info, err := peer.AddrInfoFromP2pAddr(addr)
+			if err != nil || info.ID == self {
+				continue
+			}
+			if existing, ok := byID[info.ID]; ok {
+				existing.Addrs = append(existing.Addrs, info.Addrs...)
+			} else {
+				byID[info.ID] = info
+				ids = append(ids, info.ID)
+			}
+		}
+	}
+	out := make([]peer.AddrInfo, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, *byID[id])
+	}
+	return out
+}
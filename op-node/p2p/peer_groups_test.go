@@ -0,0 +1,170 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	cmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func p2pAddr(t *testing.T, h host.Host) ma.Multiaddr {
+	addrs, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()})
+	require.NoError(t, err)
+	return addrs[0]
+}
+
+func setupPeerGroups(t *testing.T, n int) (*PeerGroups, *clock.DeterministicClock, mocknet.Mocknet, []host.Host) {
+	mnet, err := mocknet.FullMeshLinked(n + 1)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mnet.Close() })
+	hosts := mnet.Hosts()
+	connMgr, err := cmgr.NewConnManager(1, 10)
+	require.NoError(t, err)
+	clk := clock.NewDeterministicClock(time.Now())
+	groups := NewPeerGroups(testlog.Logger(t, log.LevelError), hosts[0], connMgr, clk)
+	return groups, clk, mnet, hosts[1:]
+}
+
+func TestPeerGroups_ReconnectWithBackoff(t *testing.T) {
+	groups, clk, mnet, peers := setupPeerGroups(t, 1)
+	self, other := groups.h, peers[0]
+	require.NoError(t, groups.AddGroup(PeerGroupConfig{Name: "sequencers", Peers: []ma.Multiaddr{p2pAddr(t, other)}}))
+	require.True(t, groups.IsTrusted(other.ID()))
+	require.True(t, groups.connMgr.IsProtected(other.ID(), "group-sequencers"))
+
+	groups.checkGroups()
+	require.Equal(t, network.Connected, self.Network().Connectedness(other.ID()))
+	require.Equal(t, []peer.ID{other.ID()}, groups.Groups()[0].Connected)
+
+	// The peer becomes unreachable: dials fail and are retried with increasing backoff
+	require.NoError(t, self.Network().ClosePeer(other.ID()))
+	require.NoError(t, mnet.UnlinkPeers(self.ID(), other.ID()))
+	groups.checkGroups()
+	reconnect := groups.reconnects[other.ID()]
+	require.Equal(t, 1, reconnect.failures)
+	require.Equal(t, clk.Now().Add(minReconnectBackoff), reconnect.nextDial)
+
+	groups.checkGroups()
+	require.Equal(t, 1, reconnect.failures, "should not redial during backoff")
+
+	clk.AdvanceTime(minReconnectBackoff)
+	groups.checkGroups()
+	require.Equal(t, 2, reconnect.failures)
+	require.Equal(t, clk.Now().Add(2*minReconnectBackoff), reconnect.nextDial)
+
+	// The peer becomes reachable again, and is reconnected once the backoff passes
+	_, err := mnet.LinkPeers(self.ID(), other.ID())
+	require.NoError(t, err)
+	clk.AdvanceTime(2 * minReconnectBackoff)
+	groups.checkGroups()
+	require.Equal(t, network.Connected, self.Network().Connectedness(other.ID()))
+	require.NotContains(t, groups.reconnects, other.ID())
+}
+
+func TestPeerGroups_BackoffSharedAcrossGroups(t *testing.T) {
+	groups, clk, mnet, peers := setupPeerGroups(t, 1)
+	self, other := groups.h, peers[0]
+	addr := p2pAddr(t, other)
+	require.NoError(t, mnet.UnlinkPeers(self.ID(), other.ID()))
+	require.NoError(t, groups.AddGroup(PeerGroupConfig{Name: "sequencers", Peers: []ma.Multiaddr{addr}}))
+	require.NoError(t, groups.AddGroup(PeerGroupConfig{Name: "partners", Peers: []ma.Multiaddr{addr}}))
+
+	groups.checkGroups()
+	require.Equal(t, 1, groups.reconnects[other.ID()].failures)
+	groups.checkGroups()
+	require.Equal(t, 1, groups.reconnects[other.ID()].failures, "the other group must not redial during the backoff")
+
+	clk.AdvanceTime(minReconnectBackoff)
+	groups.checkGroups()
+	require.Equal(t, 2, groups.reconnects[other.ID()].failures)
+
+	// The backoff is kept while the peer is a member of any group
+	require.NoError(t, groups.RemoveGroup("partners"))
+	require.Contains(t, groups.reconnects, other.ID())
+	require.NoError(t, groups.RemovePeer("sequencers", other.ID()))
+	require.NotContains(t, groups.reconnects, other.ID())
+}
+
+func TestPeerGroups_MaxConnected(t *testing.T) {
+	groups, _, _, peers := setupPeerGroups(t, 3)
+	addrs := make([]ma.Multiaddr, 0, len(peers))
+	for _, p := range peers {
+		addrs = append(addrs, p2pAddr(t, p))
+	}
+	require.NoError(t, groups.AddGroup(PeerGroupConfig{Name: "partners", Peers: addrs, MaxConnected: 2}))
+
+	groups.checkGroups()
+	require.Len(t, groups.h.Network().Peers(), 2)
+	groups.checkGroups()
+	require.Len(t, groups.h.Network().Peers(), 2, "should not exceed the group limit")
+
+	// Members beyond the limit are still trusted
+	for _, p := range peers {
+		require.True(t, groups.IsTrusted(p.ID()))
+	}
+}
+
+func TestPeerGroups_Manage(t *testing.T) {
+	groups, _, _, peers := setupPeerGroups(t, 2)
+	a, b := peers[0], peers[1]
+	require.NoError(t, groups.AddGroup(PeerGroupConfig{Name: "sequencers", Peers: []ma.Multiaddr{p2pAddr(t, a)}}))
+	require.ErrorIs(t, groups.AddGroup(PeerGroupConfig{Name: "sequencers"}), ErrPeerGroupExists)
+	require.NoError(t, groups.AddGroup(PeerGroupConfig{Name: "partners"}))
+
+	require.ErrorIs(t, groups.AddPeer("unknown", p2pAddr(t, b)), ErrUnknownPeerGroup)
+	require.NoError(t, groups.AddPeer("partners", p2pAddr(t, a)))
+	require.NoError(t, groups.AddPeer("partners", p2pAddr(t, b)))
+	require.True(t, groups.IsTrusted(b.ID()))
+
+	info := groups.Groups()
+	require.Len(t, info, 2)
+	require.Equal(t, "partners", info[0].Name)
+	require.Len(t, info[0].Peers, 2)
+	require.Equal(t, "sequencers", info[1].Name)
+
+	// Removing a peer from one group keeps it protected by the other
+	require.NoError(t, groups.RemovePeer("partners", a.ID()))
+	require.Error(t, groups.RemovePeer("partners", a.ID()))
+	require.True(t, groups.IsTrusted(a.ID()))
+	require.True(t, groups.connMgr.IsProtected(a.ID(), "group-sequencers"))
+	require.False(t, groups.connMgr.IsProtected(a.ID(), "group-partners"))
+
+	require.NoError(t, groups.RemoveGroup("partners"))
+	require.ErrorIs(t, groups.RemoveGroup("partners"), ErrUnknownPeerGroup)
+	require.False(t, groups.IsTrusted(b.ID()))
+	require.False(t, groups.connMgr.IsProtected(b.ID(), "group-partners"))
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	require.Equal(t, minReconnectBackoff, reconnectBackoff(1))
+	require.Equal(t, 2*minReconnectBackoff, reconnectBackoff(2))
+	require.Equal(t, 4*minReconnectBackoff, reconnectBackoff(3))
+	require.Equal(t, maxReconnectBackoff, reconnectBackoff(100))
+}
+
+func TestGossipPriorityPeers(t *testing.T) {
+	mnet, err := mocknet.WithNPeers(3)
+	require.NoError(t, err)
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	self, a, b := hosts[0], hosts[1], hosts[2]
+	groups := []PeerGroupConfig{
+		{Name: "sequencers", Peers: []ma.Multiaddr{p2pAddr(t, self), p2pAddr(t, a)}, GossipPriority: true},
+		{Name: "backup", Peers: []ma.Multiaddr{p2pAddr(t, a)}, GossipPriority: true},
+		{Name: "partners", Peers: []ma.Multiaddr{p2pAddr(t, b)}},
+	}
+	direct := gossipPriorityPeers(self.ID(), groups)
+	require.Len(t, direct, 1)
+	require.Equal(t, a.ID(), direct[0].ID)
+}
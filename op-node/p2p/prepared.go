@@ -24,7 +24,8 @@ type Prepared struct {
 	LocalNode *enode.LocalNode
 	UDPv5     *discover.UDPv5
 
-	EnableReqRespSync bool
+	EnableReqRespSync      bool
+	EnableReqRespRangeSync bool
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -93,6 +94,10 @@ func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
 
+func (p *Prepared) ReqRespRangeSyncEnabled() bool {
+	return p.EnableReqRespRangeSync
+}
+
 func (p *Prepared) GetGossipTimestampThreshold() time.Duration {
 	return 60 * time.Second
 }
@@ -12,3 +12,4 @@ type API = apis.P2PClient
 type PeerInfo = apis.PeerInfo
 type PeerDump = apis.PeerDump
 type PeerStats = apis.PeerStats
+type PeerGroup = apis.PeerGroup
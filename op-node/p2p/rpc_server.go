@@ -20,6 +20,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
 
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -36,6 +37,7 @@ var (
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
 	ErrInvalidRequest      = errors.New("invalid request")
+	ErrNoPeerGroups        = errors.New("no peer groups")
 )
 
 type Node interface {
@@ -53,6 +55,8 @@ type Node interface {
 	ConnectionGater() gating.BlockingConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// PeerGroups returns the trusted peer groups, may be nil
+	PeerGroups() *PeerGroups
 }
 
 type APIBackend struct {
@@ -378,3 +382,53 @@ func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	}
 	return nil
 }
+
+func (s *APIBackend) ListPeerGroups(_ context.Context) ([]*apis.PeerGroup, error) {
+	if groups := s.node.PeerGroups(); groups == nil {
+		return nil, ErrNoPeerGroups
+	} else {
+		return groups.Groups(), nil
+	}
+}
+
+// AddPeerGroup adds an empty peer group. Gossip priority can only be configured at startup,
+// so groups added at runtime never have it.
+func (s *APIBackend) AddPeerGroup(_ context.Context, name string, maxConnected uint) error {
+	if groups := s.node.PeerGroups(); groups == nil {
+		return ErrNoPeerGroups
+	} else {
+		return groups.AddGroup(PeerGroupConfig{Name: name, MaxConnected: maxConnected})
+	}
+}
+
+func (s *APIBackend) RemovePeerGroup(_ context.Context, name string) error {
+	if groups := s.node.PeerGroups(); groups == nil {
+		return ErrNoPeerGroups
+	} else {
+		return groups.RemoveGroup(name)
+	}
+}
+
+func (s *APIBackend) AddPeerToGroup(_ context.Context, name string, addr string) error {
+	groups := s.node.PeerGroups()
+	if groups == nil {
+		return ErrNoPeerGroups
+	}
+	a, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return fmt.Errorf("bad peer address: %w", err)
+	}
+	return groups.AddPeer(name, a)
+}
+
+func (s *APIBackend) RemovePeerFromGroup(_ context.Context, name string, id peer.ID) error {
+	if err := id.Validate(); err != nil {
+		s.log.Warn("invalid peer ID", "method", "RemovePeerFromGroup", "peer", id, "err", err)
+		return ErrInvalidRequest
+	}
+	if groups := s.node.PeerGroups(); groups == nil {
+		return ErrNoPeerGroups
+	} else {
+		return groups.RemovePeer(name, id)
+	}
+}
@@ -109,8 +109,11 @@ type syncResult struct {
 	peer    peer.ID
 }
 
+// peerRequest is a request for the count contiguous blocks starting at num.
+// Requests for more than one block are served through the payload-by-range protocol, if the peer supports it.
 type peerRequest struct {
 	num        uint64
+	count      uint64
 	rangeReqId uint64
 }
 
@@ -148,8 +151,15 @@ func (r *requestIdMap) delete(key uint64) {
 	r.mu.Unlock()
 }
 
+func (r *requestIdMap) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 }
 
@@ -233,6 +243,10 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadByRange  protocol.ID
+
+	// rangeSync enables requesting contiguous ranges of blocks from peers that support it.
+	rangeSync bool
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -281,7 +295,7 @@ type SyncClient struct {
 	syncOnlyReqToStatic bool
 }
 
-func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv receivePayloadFn, metrics SyncClientMetrics, appScorer SyncPeerScorer) *SyncClient {
+func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv receivePayloadFn, metrics SyncClientMetrics, appScorer SyncPeerScorer, rangeSync bool) *SyncClient {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := &SyncClient{
@@ -291,6 +305,8 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 		appScorer:           appScorer,
 		newStreamFn:         host.NewStream,
 		payloadByNumber:     PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadByRange:      PayloadByRangeProtocolID(cfg.L2ChainID),
+		rangeSync:           rangeSync,
 		peers:               make(map[peer.ID]context.CancelFunc),
 		quarantineByNum:     make(map[uint64]common.Hash),
 		rangeRequests:       make(chan rangeRequest), // blocking
@@ -312,7 +328,12 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 	// never errors with positive LRU cache size
 	// TODO: if we had an LRU based on on total payloads size, instead of payload count,
 	//  we can safely buffer more data in the happy case.
-	q, _ := simplelru.NewLRU[common.Hash, syncResult](100, c.onQuarantineEvict)
+	quarantineSize := 100
+	if rangeSync {
+		// Range requests deliver many blocks at once, all of which wait in quarantine until they can be promoted.
+		quarantineSize = rangeSyncQuarantineSize
+	}
+	q, _ := simplelru.NewLRU[common.Hash, syncResult](quarantineSize, c.onQuarantineEvict)
 	c.quarantine = q
 	trusted, _ := simplelru.NewLRU[common.Hash, struct{}](10000, nil)
 	c.trusted = trusted
@@ -441,10 +462,45 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 	s.trusted.Add(req.end.Hash, struct{}{})
 	s.trusted.Add(req.end.ParentHash, struct{}{})
 
+	maxSpan := uint64(1)
+	if s.rangeSync {
+		maxSpan = maxPayloadsPerRangeRequest
+	}
+	// Contiguous blocks [spanStart, spanStart+spanLen) that are collected to be requested together.
+	var spanStart, spanLen uint64
+	// schedule the collected span of blocks, returns false if no more requests can be scheduled
+	schedule := func() bool {
+		if spanLen == 0 {
+			return true
+		}
+		if s.rangeSync && s.inFlight.len() >= maxRangeSyncInFlight {
+			log.Info("too many P2P block requests in-flight, not scheduling more", "current", spanStart)
+			return false
+		}
+		pr := peerRequest{num: spanStart, count: spanLen, rangeReqId: req.id}
+		// Mark the blocks as in-flight before scheduling, so a peer cannot complete the request before it is marked.
+		for num := spanStart; num < spanStart+spanLen; num++ {
+			s.inFlight.set(num, true)
+		}
+		log.Debug("Scheduling P2P block request", "num", spanStart, "count", spanLen, "rangeReqId", req.id)
+		select {
+		case s.peerRequests <- pr:
+			spanLen = 0
+			return true
+		case <-ctx.Done():
+			log.Info("did not schedule full P2P sync range", "current", spanStart, "err", ctx.Err())
+		default: // peers may all be busy processing requests already
+			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", spanStart)
+		}
+		s.clearInFlight(pr, 0)
+		return false
+	}
+
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
 		if num <= req.start {
+			schedule()
 			return
 		}
 		// check if we have something in quarantine already
@@ -454,30 +510,36 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			}
 			// Don't fetch things that we have a candidate for already.
 			// We'll evict it from quarantine by finding a conflict, or if we sync enough other blocks
+			if !schedule() {
+				return
+			}
 			continue
 		}
 
 		if s.inFlight.get(num) {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
+			if !schedule() {
+				return
+			}
 			continue // request still in flight
 		}
-		pr := peerRequest{num: num, rangeReqId: req.id}
 
-		log.Debug("Scheduling P2P block request", "num", num, "rangeReqId", req.id)
-		// schedule number
-		select {
-		case s.peerRequests <- pr:
-			s.inFlight.set(num, true)
-		case <-ctx.Done():
-			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
-			return
-		default: // peers may all be busy processing requests already
-			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", num)
+		// extend the span of blocks to request downwards
+		spanStart = num
+		spanLen++
+		if spanLen == maxSpan && !schedule() {
 			return
 		}
 	}
 }
 
+// clearInFlight marks the blocks of the peer request, starting at the given offset, as no longer in-flight.
+func (s *SyncClient) clearInFlight(pr peerRequest, offset uint64) {
+	for num := pr.num + offset; num < pr.num+pr.count; num++ {
+		s.inFlight.delete(num)
+	}
+}
+
 func (s *SyncClient) onQuarantineEvict(key common.Hash, value syncResult) {
 	delete(s.quarantineByNum, uint64(value.payload.ExecutionPayload.BlockNumber))
 	s.metrics.PayloadsQuarantineSize(s.quarantine.Len())
@@ -575,6 +637,11 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		peerRequests = nil
 	}
 
+	// Payloads requested by range are rate-limited separately, matching the limits of the serving side.
+	rangeRL := rate.NewLimiter(peerServerRangePayloadsRateLimit, peerServerRangePayloadsBurst)
+	// Assume the peer supports range requests, until it fails to negotiate the protocol.
+	rangeSupported := true
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
@@ -589,54 +656,96 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		select {
 		case pr := <-peerRequests:
 			if !s.activeRangeRequests.get(pr.rangeReqId) {
-				log.Debug("dropping cancelled p2p sync request", "num", pr.num)
-				s.inFlight.delete(pr.num)
+				log.Debug("dropping cancelled p2p sync request", "num", pr.num, "count", pr.count)
+				s.clearInFlight(pr, 0)
 				continue
 			}
 
-			// We already established the peer is available w.r.t. rate-limiting,
-			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-
-			resultCode := ResultCodeSuccess
-			err := panicGuard(s.doRequest)(ctx, id, pr.num)
-			if err != nil {
-				s.inFlight.delete(pr.num)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				resultCode = ResultCodeNotFoundErr
-				sendResponseError := true
-
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-					if resultCode == ResultCodeNotFoundErr {
-						log.Warn("cancelling p2p sync range request", "rangeReqId", pr.rangeReqId)
-						s.activeRangeRequests.delete(pr.rangeReqId)
-						sendResponseError = false // don't penalize peer for this error
-					}
+			if pr.count > 1 && rangeSupported {
+				err := s.requestByRange(ctx, log, id, rl, rangeRL, pr)
+				if errors.Is(err, errRangeNotSupported) {
+					log.Info("peer does not support payload by range requests, syncing by number instead")
+					rangeSupported = false
+				} else if err != nil {
+					return
+				} else {
+					continue
 				}
+			}
 
-				if sendResponseError {
-					s.appScorer.onResponseError(id)
+			// Request the blocks one at a time, from high to low, so they can be promoted as soon as they arrive.
+			for i := pr.count; i > 0; i-- {
+				num := pr.num + i - 1
+				if i < pr.count {
+					// The first block was already accounted for by the rate-limits above.
+					if err := s.globalRL.Wait(ctx); err != nil {
+						s.clearInFlight(peerRequest{num: pr.num, count: i}, 0)
+						return
+					}
+					if err := rl.Wait(ctx); err != nil {
+						s.clearInFlight(peerRequest{num: pr.num, count: i}, 0)
+						return
+					}
+					if !s.activeRangeRequests.get(pr.rangeReqId) {
+						log.Debug("dropping cancelled p2p sync request", "num", pr.num, "count", i)
+						s.clearInFlight(peerRequest{num: pr.num, count: i}, 0)
+						break
+					}
 				}
-
-				// If we hit an error, then count it as many requests.
-				// We'd like to avoid making more requests for a while, so back off.
-				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+				if err := s.requestByNumber(ctx, log, id, rl, num, pr.rangeReqId); err != nil {
+					s.clearInFlight(peerRequest{num: pr.num, count: i}, 0)
 					return
 				}
-			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
 			}
-
-			took := time.Since(start)
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// requestByNumber requests a single block from the peer, and updates the peer score and metrics with the result.
+// An error is only returned if the peer loop should stop.
+func (s *SyncClient) requestByNumber(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, num uint64, rangeReqId uint64) error {
+	// We already established the peer is available w.r.t. rate-limiting,
+	// and this is the only loop over this peer, so we can request now.
+	start := time.Now()
+
+	resultCode := ResultCodeSuccess
+	err := panicGuard(s.doRequest)(ctx, id, num)
+	if err != nil {
+		s.inFlight.delete(num)
+		log.Warn("failed p2p sync request", "num", num, "err", err)
+		resultCode = ResultCodeNotFoundErr
+		sendResponseError := true
+
+		if re, ok := err.(requestResultErr); ok {
+			resultCode = re.ResultCode()
+			if resultCode == ResultCodeNotFoundErr {
+				log.Warn("cancelling p2p sync range request", "rangeReqId", rangeReqId)
+				s.activeRangeRequests.delete(rangeReqId)
+				sendResponseError = false // don't penalize peer for this error
+			}
+		}
+
+		if sendResponseError && !isLocalCancellation(ctx, err) {
+			s.appScorer.onResponseError(id)
+		}
+
+		// If we hit an error, then count it as many requests.
+		// We'd like to avoid making more requests for a while, so back off.
+		if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			return err
+		}
+	} else {
+		log.Debug("completed p2p sync request", "num", num)
+		s.appScorer.onValidResponse(id)
+	}
+
+	took := time.Since(start)
+	s.metrics.ClientPayloadByNumberEvent(num, resultCode, took)
+	return nil
+}
+
 type requestResultErr byte
 
 func (r requestResultErr) Error() string {
@@ -718,11 +827,20 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	select {
 	case s.results <- syncResult{payload: envelope, peer: id}:
 	case <-ctx.Done():
-		return fmt.Errorf("failed to process response, sync client is too busy")
+		return fmt.Errorf("failed to process response: %w", errSyncClientBusy)
 	}
 	return nil
 }
 
+// errSyncClientBusy is returned when a response was received, but the sync client stopped before it could process it.
+var errSyncClientBusy = errors.New("sync client is too busy")
+
+// isLocalCancellation returns true if the request failed because of the local node,
+// e.g. because the sync client is too busy or shutting down. Such failures are not held against the peer.
+func isLocalCancellation(ctx context.Context, err error) bool {
+	return errors.Is(err, errSyncClientBusy) || ctx.Err() != nil
+}
+
 // panicGuard is a generic function that takes another function with generic arguments and returns an error.
 // It recovers from any panic that occurs during the execution of the function.
 func panicGuard[T, S, U any](fn func(T, S, U) error) func(T, S, U) error {
@@ -782,6 +900,8 @@ func verifyBlock(envelope *eth.ExecutionPayloadEnvelope, expectedNum uint64) err
 type peerStat struct {
 	// Requests tokenizes each request to sync
 	Requests *rate.Limiter
+	// RangePayloads tokenizes each payload served through a range request
+	RangePayloads *rate.Limiter
 }
 
 type L2Chain interface {
@@ -790,6 +910,7 @@ type L2Chain interface {
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration)
 }
 
 type ReqRespServer struct {
@@ -803,6 +924,8 @@ type ReqRespServer struct {
 	peerStatsLock  sync.Mutex
 
 	globalRequestsRL *rate.Limiter
+	// globalRangePayloadsRL limits the payloads served through range requests across all peers
+	globalRangePayloadsRL *rate.Limiter
 }
 
 func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
@@ -813,11 +936,12 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 	globalRequestsRL := rate.NewLimiter(globalServerBlocksRateLimit, globalServerBlocksBurst)
 
 	return &ReqRespServer{
-		cfg:              cfg,
-		l2:               l2,
-		metrics:          metrics,
-		peerRateLimits:   peerRateLimits,
-		globalRequestsRL: globalRequestsRL,
+		cfg:                   cfg,
+		l2:                    l2,
+		metrics:               metrics,
+		peerRateLimits:        peerRateLimits,
+		globalRequestsRL:      globalRequestsRL,
+		globalRangePayloadsRL: rate.NewLimiter(globalServerRangePayloadsRateLimit, globalServerRangePayloadsBurst),
 	}
 }
 
@@ -871,7 +995,8 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
 		ps = &peerStat{
-			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+			Requests:      rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+			RangePayloads: rate.NewLimiter(peerServerRangePayloadsRateLimit, peerServerRangePayloadsBurst),
 		}
 		srv.peerRateLimits.Add(peerId, ps)
 		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
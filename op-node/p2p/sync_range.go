@@ -0,0 +1,376 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	msmux "github.com/multiformats/go-multistream"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The payload-by-range protocol streams a contiguous range of payloads in ascending order over a single stream.
+//
+// Request: start block number (uint64, little endian), followed by the number of payloads (uint64, little endian).
+//
+// Response: a sequence of chunks, one per payload. Each chunk starts with a result code byte.
+// A successful chunk continues with the payload version (uint32, little endian, same as payload-by-number),
+// the length of the compressed payload (uint32, little endian), and the SSZ payload in snappy block compression.
+// The response ends when the stream is closed, or after the first chunk with an error result code.
+// The server may serve fewer payloads than requested, e.g. when the end of the range is not available yet.
+const (
+	// maxPayloadsPerRangeRequest is the maximum number of payloads a single range request may ask for.
+	maxPayloadsPerRangeRequest = 32
+	// Do not serve more than 128 payloads per second by range, across all peers
+	globalServerRangePayloadsRateLimit rate.Limit = 128
+	// Allows a burst of 2x our rate limit
+	globalServerRangePayloadsBurst = 256
+	// Do not serve more than 32 payloads per second by range to the same peer
+	peerServerRangePayloadsRateLimit rate.Limit = 32
+	// Allow a peer to request two full ranges at once
+	peerServerRangePayloadsBurst = 2 * maxPayloadsPerRangeRequest
+	// rangeSyncQuarantineSize is the quarantine size of a sync client with range requests enabled.
+	rangeSyncQuarantineSize = 1000
+	// maxRangeSyncInFlight limits the blocks requested by range at once,
+	// so all results fit in the quarantine without evicting each other.
+	maxRangeSyncInFlight = rangeSyncQuarantineSize / 2
+)
+
+var (
+	// errRangeNotSupported is returned when a peer does not support the payload-by-range protocol.
+	errRangeNotSupported = errors.New("peer does not support payload by range requests")
+	// errInvalidRange is returned when a peer serves payloads that are invalid, or that do not form the requested range.
+	errInvalidRange = errors.New("invalid payload range")
+)
+
+func PayloadByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_range/%d/0", l2ChainID))
+}
+
+// rangeProgress tracks the payloads received for a range request.
+type rangeProgress struct {
+	req      peerRequest
+	received uint64
+}
+
+// requestByRange requests the blocks of the peer request by range, and updates the peer score and metrics with the result.
+// Returns errRangeNotSupported if the peer does not support range requests, and no blocks were requested.
+// Any other error is only returned if the peer loop should stop.
+func (s *SyncClient) requestByRange(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, rangeRL *rate.Limiter, pr peerRequest) error {
+	if err := rangeRL.WaitN(ctx, int(pr.count)); err != nil {
+		s.clearInFlight(pr, 0)
+		return err
+	}
+	start := time.Now()
+	progress := &rangeProgress{req: pr}
+	err := panicGuard(s.doRangeRequest)(ctx, id, progress)
+	if errors.Is(err, errRangeNotSupported) {
+		// The blocks stay in-flight, to be requested by number, and the peer is not penalized.
+		return err
+	}
+	// Blocks that were received are cleared from the in-flight set once processed by the main loop.
+	s.clearInFlight(pr, progress.received)
+
+	resultCode := ResultCodeSuccess
+	var re requestResultErr
+	if errors.As(err, &re) && re.ResultCode() == ResultCodeNotFoundErr && progress.received > 0 {
+		// The peer served the start of the range, but does not have the rest yet.
+		log.Debug("peer served partial p2p sync range", "start", pr.num, "count", pr.count, "received", progress.received)
+		err = nil
+	}
+	if err != nil {
+		log.Warn("failed p2p sync range request", "start", pr.num, "count", pr.count, "received", progress.received, "err", err)
+		resultCode = ResultCodeUnknownErr
+		sendResponseError := true
+		if errors.As(err, &re) {
+			resultCode = re.ResultCode()
+			if resultCode == ResultCodeNotFoundErr {
+				log.Warn("cancelling p2p sync range request", "rangeReqId", pr.rangeReqId)
+				s.activeRangeRequests.delete(pr.rangeReqId)
+				sendResponseError = false // don't penalize peer for this error
+			}
+		} else if errors.Is(err, errInvalidRange) {
+			resultCode = ResultCodeInvalidErr
+			// Down-score the peer for serving payloads that are not part of the requested range.
+			s.appScorer.onRejectedPayload(id)
+		}
+		if sendResponseError && !isLocalCancellation(ctx, err) {
+			s.appScorer.onResponseError(id)
+		}
+		// If we hit an error, then count it as many requests.
+		// We'd like to avoid making more requests for a while, so back off.
+		if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			return err
+		}
+	} else {
+		log.Debug("completed p2p sync range request", "start", pr.num, "count", pr.count, "received", progress.received)
+		s.appScorer.onValidResponse(id)
+	}
+	s.metrics.ClientPayloadByRangeEvent(pr.num, progress.received, resultCode, time.Since(start))
+	return nil
+}
+
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, progress *rangeProgress) error {
+	// open stream to peer
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadByRange)
+	reqCancel()
+	if err != nil {
+		if errors.Is(err, msmux.ErrNotSupported[protocol.ID]{}) {
+			return errRangeNotSupported
+		}
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[:8], progress.req.num)
+	binary.LittleEndian.PutUint64(req[8:], progress.req.count)
+	if _, err := str.Write(req[:]); err != nil {
+		return fmt.Errorf("failed to write range request (%d, %d): %w", progress.req.num, progress.req.count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	var parentHash common.Hash
+	for progress.received < progress.req.count {
+		// The server paces the chunks, so the read timeout (if available) applies to each chunk.
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		expectedBlockNum := progress.req.num + progress.received
+		envelope, err := s.readRangeChunk(str, expectedBlockNum)
+		if errors.Is(err, io.EOF) && progress.received > 0 {
+			// The server ended the range early
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if progress.received > 0 && envelope.ExecutionPayload.ParentHash != parentHash {
+			return fmt.Errorf("%w: block %d has parent %s, expected %s", errInvalidRange, expectedBlockNum, envelope.ExecutionPayload.ParentHash, parentHash)
+		}
+		parentHash = envelope.ExecutionPayload.BlockHash
+		// Sending the result blocks while the sync client is busy. This stops reading from the stream,
+		// which in turn throttles the server through the stream flow control.
+		select {
+		case s.results <- syncResult{payload: envelope, peer: id}:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to process response: %w", errSyncClientBusy)
+		}
+		progress.received++
+	}
+	return nil
+}
+
+// readRangeChunk reads and verifies a single payload chunk of a range response.
+// Returns io.EOF if the stream ended cleanly before the chunk.
+func (s *SyncClient) readRangeChunk(r io.Reader, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	var result [1]byte
+	if _, err := io.ReadFull(r, result[:]); errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := result[0]; res != ResultCodeSuccess {
+		return nil, requestResultErr(res)
+	}
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header part of response: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[:4])
+	size := binary.LittleEndian.Uint32(header[4:])
+	if size > uint32(snappy.MaxEncodedLen(maxGossipSize)) {
+		return nil, fmt.Errorf("%w: payload chunk of %d bytes is too large", errInvalidRange, size)
+	}
+	compressed := make([]byte, size)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, fmt.Errorf("failed to read payload part of response: %w", err)
+	}
+	// Limit the decompressed size as well, to not decompress more data than a payload may contain
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, fmt.Errorf("%w: invalid payload compression: %w", errInvalidRange, err)
+	} else if n > maxGossipSize {
+		return nil, fmt.Errorf("%w: payload of %d bytes is too large", errInvalidRange, n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid payload compression: %w", errInvalidRange, err)
+	}
+
+	timestamp := s.cfg.TimestampForBlock(expectedBlockNum)
+	envelope, err := readExecutionPayload(version, data, s.cfg.IsCanyon(timestamp), s.cfg.IsIsthmus(timestamp), s.cfg.IsMantleSkadi(timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidRange, err)
+	}
+	if err := verifyBlock(envelope, expectedBlockNum); err != nil {
+		return nil, fmt.Errorf("%w: received execution payload is invalid: %w", errInvalidRange, err)
+	}
+	return envelope, nil
+}
+
+// payloadRange is a request for count payloads, starting at block number start.
+type payloadRange struct {
+	start uint64
+	count uint64
+}
+
+// HandleSyncRangeRequest is a stream handler function to register the L2 unsafe payloads alt-sync range protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// Note that the same peer may open parallel streams.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleSyncRangeRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	// Like single payload requests, we throttle the peer instead of disconnecting,
+	// unless serving the range takes unreasonably long.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, served, err := srv.handleSyncRangeRequest(ctx, stream)
+	cancel()
+
+	resultCode := ResultCodeSuccess
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			resultCode = ResultCodeNotFoundErr
+		} else if errors.Is(err, errInvalidRequest) {
+			resultCode = ResultCodeInvalidErr
+		} else {
+			resultCode = ResultCodeUnknownErr
+		}
+		if resultCode == ResultCodeNotFoundErr && served > 0 {
+			log.Debug("served partial sync range response", "start", req.start, "count", req.count, "served", served)
+		} else {
+			log.Warn("failed to serve p2p sync range request", "start", req.start, "count", req.count, "served", served, "err", err)
+		}
+		// try to write error code, so the other peer can understand the reason for the end of the range.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served sync range response", "start", req.start, "count", req.count, "served", served)
+	}
+	srv.metrics.ServerPayloadByRangeEvent(req.start, served, resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleSyncRangeRequest(ctx context.Context, stream network.Stream) (req payloadRange, served uint64, err error) {
+	peerId := stream.Conn().RemotePeer()
+
+	// take a token from the global rate-limiter,
+	// to make sure there's not too much concurrent server work between different peers.
+	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+	}
+
+	// find rate limiting data of peer, or add otherwise
+	srv.peerStatsLock.Lock()
+	ps, _ := srv.peerRateLimits.Get(peerId)
+	if ps == nil {
+		ps = &peerStat{
+			Requests:      rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+			RangePayloads: rate.NewLimiter(peerServerRangePayloadsRateLimit, peerServerRangePayloadsBurst),
+		}
+		srv.peerRateLimits.Add(peerId, ps)
+	}
+	srv.peerStatsLock.Unlock()
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	var data [16]byte
+	if _, err := io.ReadFull(stream, data[:]); err != nil {
+		return req, 0, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	req.start = binary.LittleEndian.Uint64(data[:8])
+	req.count = binary.LittleEndian.Uint64(data[8:])
+	if err := stream.CloseRead(); err != nil {
+		return req, 0, fmt.Errorf("failed to close reading-side of a P2P sync range request call: %w", err)
+	}
+
+	// Check the request is within the expected range of blocks
+	if req.count == 0 || req.count > maxPayloadsPerRangeRequest {
+		return req, 0, fmt.Errorf("cannot serve range of %d payloads, max is %d: %w", req.count, maxPayloadsPerRangeRequest, errInvalidRequest)
+	}
+	if req.start < srv.cfg.Genesis.L2.Number {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req.start, srv.cfg.Genesis.L2.Number, errInvalidRequest)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return req, 0, fmt.Errorf("cannot determine max target block number to verify request: %w", errInvalidRequest)
+	}
+	if req.start > max {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req.start, max, errInvalidRequest)
+	}
+	// Serve what is expected to exist, the client accepts a shorter range.
+	end := min(req.start+req.count-1, max)
+
+	for num := req.start; num <= end; num++ {
+		// Pace the chunks, both per peer and across all peers
+		if err := ps.RangePayloads.Wait(ctx); err != nil {
+			return req, served, fmt.Errorf("timed out waiting for peer range sync rate limit: %w", err)
+		}
+		if err := srv.globalRangePayloadsRL.Wait(ctx); err != nil {
+			return req, served, fmt.Errorf("timed out waiting for global range sync rate limit: %w", err)
+		}
+		envelope, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, served, fmt.Errorf("peer requested unknown block %d by range: %w", num, err)
+			}
+			return req, served, fmt.Errorf("failed to retrieve payload %d to serve to peer: %w", num, err)
+		}
+		// We set write deadline, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		if err := srv.writeRangeChunk(stream, envelope); err != nil {
+			return req, served, err
+		}
+		served++
+	}
+	return req, served, nil
+}
+
+// writeRangeChunk writes a successful payload chunk of a range response.
+func (srv *ReqRespServer) writeRangeChunk(w io.Writer, envelope *eth.ExecutionPayloadEnvelope) error {
+	var buf bytes.Buffer
+	var version uint32
+	if srv.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) || srv.cfg.IsMantleSkadi(uint64(envelope.ExecutionPayload.Timestamp)) {
+		version = 1
+		if _, err := envelope.MarshalSSZ(&buf); err != nil {
+			return fmt.Errorf("failed to encode payload envelope: %w", err)
+		}
+	} else {
+		if _, err := envelope.ExecutionPayload.MarshalSSZ(&buf); err != nil {
+			return fmt.Errorf("failed to encode payload: %w", err)
+		}
+	}
+	compressed := snappy.Encode(nil, buf.Bytes())
+
+	// 0 - resultCode: success = 0
+	// 1:5 - version (little endian)
+	// 5:9 - compressed payload length (little endian)
+	var header [9]byte
+	binary.LittleEndian.PutUint32(header[1:5], version)
+	binary.LittleEndian.PutUint32(header[5:9], uint32(len(compressed)))
+	if _, err := w.Write(header[:]); err != nil {
+		return fmt.Errorf("failed to write response header data: %w", err)
+	}
+	if _, err := w.Write(compressed); err != nil {
+		return fmt.Errorf("failed to write payload to sync range response: %w", err)
+	}
+	return nil
+}
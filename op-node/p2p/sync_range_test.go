@@ -0,0 +1,273 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type rangeTestMetrics struct {
+	metrics.Metricer
+	clientRanges   atomic.Int32
+	clientPayloads atomic.Uint64
+}
+
+func (m *rangeTestMetrics) ClientPayloadByRangeEvent(start uint64, payloads uint64, resultCode byte, duration time.Duration) {
+	m.clientRanges.Add(1)
+	m.clientPayloads.Add(payloads)
+}
+
+type recordingScorer struct {
+	valid    atomic.Int32
+	errors   atomic.Int32
+	rejected atomic.Int32
+}
+
+func (s *recordingScorer) onValidResponse(id peer.ID)   { s.valid.Add(1) }
+func (s *recordingScorer) onResponseError(id peer.ID)   { s.errors.Add(1) }
+func (s *recordingScorer) onRejectedPayload(id peer.ID) { s.rejected.Add(1) }
+
+var _ SyncPeerScorer = (*recordingScorer)(nil)
+
+// setupRangeSync connects a server, optionally serving by range, to a client with range requests enabled.
+func setupRangeSync(t *testing.T, cfg *rollup.Config, servePayload mockPayloadFn, serveRange bool, scorer SyncPeerScorer, m SyncClientMetrics) (*SyncClient, chan *eth.ExecutionPayloadEnvelope) {
+	log := testlog.Logger(t, log.LevelError)
+
+	received := make(chan *eth.ExecutionPayloadEnvelope, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	t.Cleanup(func() { _ = mnet.Close() })
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log.New("serve", "payloads_by_number"), srv.HandleSyncRequest))
+	if serveRange {
+		hostA.SetStreamHandler(PayloadByRangeProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log.New("serve", "payloads_by_range"), srv.HandleSyncRangeRequest))
+	}
+
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB, receivePayload, m, scorer, true)
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	t.Cleanup(func() { _ = cl.Close() })
+	return cl, received
+}
+
+func collectPayloads(t *testing.T, received chan *eth.ExecutionPayloadEnvelope, payloads *syncTestData, count int) {
+	timeout := time.After(time.Minute)
+	for i := 0; i < count; i++ {
+		select {
+		case p := <-received:
+			exp, ok := payloads.getPayload(uint64(p.ExecutionPayload.BlockNumber))
+			require.True(t, ok, "expecting known payload")
+			require.Equal(t, exp.ExecutionPayload.BlockHash, p.ExecutionPayload.BlockHash, "expecting the correct payload")
+			require.Equal(t, exp.ParentBeaconBlockRoot, p.ParentBeaconBlockRoot)
+		case <-timeout:
+			t.Fatalf("timed out waiting for payload, received %d of %d", i, count)
+		}
+	}
+}
+
+func TestRangeSync(t *testing.T) {
+	t.Parallel()
+	cfg, payloads := setupSyncTestData(100)
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+	m := &rangeTestMetrics{Metricer: metrics.NoopMetrics}
+	scorer := &recordingScorer{}
+	cl, received := setupRangeSync(t, cfg, servePayload, true, scorer, m)
+
+	_, err := cl.RequestL2Range(context.Background(), payloads.getBlockRef(10), payloads.getBlockRef(90))
+	require.NoError(t, err)
+	collectPayloads(t, received, payloads, 79)
+
+	require.Equal(t, uint64(79), m.clientPayloads.Load())
+	require.Equal(t, int32(3), m.clientRanges.Load(), "expecting blocks to be requested in ranges of up to %d", maxPayloadsPerRangeRequest)
+	require.Equal(t, int32(3), scorer.valid.Load())
+	require.Zero(t, scorer.errors.Load())
+	require.Zero(t, scorer.rejected.Load())
+}
+
+func TestRangeSync_FallbackByNumber(t *testing.T) {
+	t.Parallel()
+	cfg, payloads := setupSyncTestData(30)
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+	m := &rangeTestMetrics{Metricer: metrics.NoopMetrics}
+	scorer := &recordingScorer{}
+	// The server does not support range requests
+	cl, received := setupRangeSync(t, cfg, servePayload, false, scorer, m)
+
+	_, err := cl.RequestL2Range(context.Background(), payloads.getBlockRef(10), payloads.getBlockRef(20))
+	require.NoError(t, err)
+	collectPayloads(t, received, payloads, 9)
+
+	require.Zero(t, m.clientRanges.Load())
+	require.Zero(t, scorer.errors.Load(), "peer should not be penalized for not supporting range requests")
+}
+
+func TestRangeSync_InvalidRange(t *testing.T) {
+	t.Parallel()
+	cfg, payloads := setupSyncTestData(30)
+	// Block 15 is valid by itself, but does not build on block 14
+	orig, _ := payloads.getPayload(15)
+	tampered := *orig.ExecutionPayload
+	tampered.ParentHash = common.Hash{0xde, 0xad}
+	bad := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &tampered, ParentBeaconBlockRoot: orig.ParentBeaconBlockRoot}
+	tampered.BlockHash, _ = bad.CheckBlockHash()
+
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		if n == 15 {
+			return bad, nil
+		}
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+	m := &rangeTestMetrics{Metricer: metrics.NoopMetrics}
+	scorer := &recordingScorer{}
+	cl, _ := setupRangeSync(t, cfg, servePayload, true, scorer, m)
+
+	_, err := cl.RequestL2Range(context.Background(), payloads.getBlockRef(10), payloads.getBlockRef(20))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return m.clientRanges.Load() > 0
+	}, time.Minute, 10*time.Millisecond, "expecting range request to complete")
+	require.Equal(t, int32(1), scorer.rejected.Load(), "expecting peer to be penalized for invalid range")
+	require.Equal(t, uint64(4), m.clientPayloads.Load(), "expecting the valid start of the range to be received")
+	require.Equal(t, int32(1), scorer.errors.Load())
+}
+
+func TestSync_LocalCancellationNotScored(t *testing.T) {
+	cfg, _ := setupSyncTestData(10)
+	scorer := &recordingScorer{}
+	mnet, err := mocknet.FullMeshConnected(1)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mnet.Close() })
+	cl := NewSyncClient(testlog.Logger(t, log.LevelError), cfg, mnet.Hosts()[0], nil, metrics.NoopMetrics, scorer, true)
+
+	// stoppingCtx returns a context that is cancelled while the stream is being opened,
+	// as when the local node stops during a request.
+	stoppingCtx := func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		cl.newStreamFn = func(streamCtx context.Context, peerId peer.ID, protocolId ...protocol.ID) (network.Stream, error) {
+			cancel()
+			return nil, ctx.Err()
+		}
+		return ctx
+	}
+	require.Error(t, cl.requestByNumber(stoppingCtx(), cl.log, "peer", rate.NewLimiter(rate.Inf, 1), 5, 0))
+	rangeReq := peerRequest{num: 1, count: 4}
+	require.Error(t, cl.requestByRange(stoppingCtx(), cl.log, "peer", rate.NewLimiter(rate.Inf, 1), rate.NewLimiter(rate.Inf, 1), rangeReq))
+
+	require.Zero(t, scorer.errors.Load(), "local cancellations must not be scored as peer errors")
+	require.True(t, isLocalCancellation(context.Background(), fmt.Errorf("wrapped: %w", errSyncClientBusy)))
+	require.False(t, isLocalCancellation(context.Background(), io.ErrUnexpectedEOF))
+}
+
+func TestRangeSync_ServerLimits(t *testing.T) {
+	t.Parallel()
+	log := testlog.Logger(t, log.LevelError)
+	cfg, payloads := setupSyncTestData(10)
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	hostA.SetStreamHandler(PayloadByRangeProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, log, srv.HandleSyncRangeRequest))
+
+	request := func(start, count uint64) []byte {
+		str, err := hostB.NewStream(ctx, hostA.ID(), PayloadByRangeProtocolID(cfg.L2ChainID))
+		require.NoError(t, err)
+		defer str.Close()
+		var req [16]byte
+		binary.LittleEndian.PutUint64(req[:8], start)
+		binary.LittleEndian.PutUint64(req[8:], count)
+		_, err = str.Write(req[:])
+		require.NoError(t, err)
+		require.NoError(t, str.CloseWrite())
+		resp, err := io.ReadAll(str)
+		require.NoError(t, err)
+		return resp
+	}
+
+	require.Equal(t, []byte{ResultCodeInvalidErr}, request(1, 0))
+	require.Equal(t, []byte{ResultCodeInvalidErr}, request(1, maxPayloadsPerRangeRequest+1))
+
+	// The range extends beyond the known blocks: the server serves what it has, then signals the end of the range
+	resp := request(8, 5)
+	cl := NewSyncClient(log, cfg, hostB, nil, metrics.NoopMetrics, &NoopApplicationScorer{}, true)
+	r := &chunkReader{data: resp}
+	for num := uint64(8); num <= 10; num++ {
+		envelope, err := cl.readRangeChunk(r, num)
+		require.NoError(t, err)
+		exp, _ := payloads.getPayload(num)
+		require.Equal(t, exp.ExecutionPayload.BlockHash, envelope.ExecutionPayload.BlockHash)
+	}
+	_, err = cl.readRangeChunk(r, 11)
+	require.ErrorIs(t, err, requestResultErr(ResultCodeNotFoundErr))
+}
+
+type chunkReader struct {
+	data []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
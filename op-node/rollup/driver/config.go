@@ -1,6 +1,9 @@
 package driver
 
-import "github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
+)
 
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
@@ -27,6 +30,10 @@ type Config struct {
 	// to be compatible with verifiers forcefully generating the same block while catching up the sequencing window timeout.
 	RecoverMode bool `json:"recover_mode"`
 
+	// SequencerL1OriginPolicy configures when the sequencer moves on to the next L1 origin.
+	// The zero value selects the default latest-origin policy.
+	SequencerL1OriginPolicy sequencing.L1OriginPolicyConfig `json:"sequencer_l1_origin_policy"`
+
 	// Finalizer contains runtime configuration for finality behavior.
 	Finalizer *finality.Config `json:"finalizer,omitempty"`
 }
@@ -112,7 +112,13 @@ func NewDriver(
 		asyncGossiper := async.NewAsyncGossiper(driverCtx, network, log, metrics)
 		attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1ChainConfig, depSet, l1, l2)
 		sequencerConfDepth := confdepth.NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
-		findL1Origin := sequencing.NewL1OriginSelector(driverCtx, log, cfg, sequencerConfDepth)
+		originPolicy, err := sequencing.NewL1OriginPolicy(driverCfg.SequencerL1OriginPolicy, statusTracker.L1Head)
+		if err != nil {
+			// The policy config is checked as part of the node config, so this should not happen.
+			log.Error("Invalid L1 origin policy, falling back to default", "err", err)
+			originPolicy, _ = sequencing.NewL1OriginPolicy(sequencing.L1OriginPolicyConfig{}, statusTracker.L1Head)
+		}
+		findL1Origin := sequencing.NewL1OriginSelectorWithPolicy(driverCtx, log, cfg, sequencerConfDepth, originPolicy, metrics)
 		sys.Register("origin-selector", findL1Origin)
 
 		// Connect origin selector to the engine controller for force reset notifications
@@ -369,6 +375,28 @@ func (s *Driver) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return s.sequencer.Stop(ctx)
 }
 
+func (s *Driver) ScheduleSequencerStop(ctx context.Context, blockNum uint64) error {
+	return s.sequencer.ScheduleStop(ctx, blockNum)
+}
+
+// ScheduleSequencerStopAtTime schedules the sequencer to stop after the last block with a timestamp at or before
+// the given timestamp, and returns the number of that block.
+func (s *Driver) ScheduleSequencerStopAtTime(ctx context.Context, timestamp uint64) (uint64, error) {
+	blockNum, err := s.SyncDeriver.Config.TargetBlockNumber(timestamp)
+	if err != nil {
+		return 0, err
+	}
+	return blockNum, s.sequencer.ScheduleStop(ctx, blockNum)
+}
+
+func (s *Driver) ScheduleSequencerStart(ctx context.Context, blockNum uint64, blockHash common.Hash) error {
+	return s.sequencer.ScheduleStart(ctx, blockNum, blockHash)
+}
+
+func (s *Driver) CancelSequencerHandover(ctx context.Context) error {
+	return s.sequencer.CancelScheduledHandover(ctx)
+}
+
 func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	return s.sequencer.Active(), nil
 }
@@ -40,6 +40,18 @@ func (ds DisabledSequencer) Stop(ctx context.Context) (hash common.Hash, err err
 	return common.Hash{}, ErrSequencerNotEnabled
 }
 
+func (ds DisabledSequencer) ScheduleStop(ctx context.Context, blockNumber uint64) error {
+	return ErrSequencerNotEnabled
+}
+
+func (ds DisabledSequencer) ScheduleStart(ctx context.Context, blockNumber uint64, blockHash common.Hash) error {
+	return ErrSequencerNotEnabled
+}
+
+func (ds DisabledSequencer) CancelScheduledHandover(ctx context.Context) error {
+	return ErrSequencerNotEnabled
+}
+
 func (ds DisabledSequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	return ErrSequencerNotEnabled
 }
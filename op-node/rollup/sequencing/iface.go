@@ -17,6 +17,9 @@ type SequencerIface interface {
 	Init(ctx context.Context, active bool) error
 	Start(ctx context.Context, head common.Hash) error
 	Stop(ctx context.Context) (hash common.Hash, err error)
+	ScheduleStop(ctx context.Context, blockNumber uint64) error
+	ScheduleStart(ctx context.Context, blockNumber uint64, blockHash common.Hash) error
+	CancelScheduledHandover(ctx context.Context) error
 	SetMaxSafeLag(ctx context.Context, v uint64) error
 	OverrideLeader(ctx context.Context) error
 	ConductorEnabled(ctx context.Context) bool
@@ -0,0 +1,181 @@
+package sequencing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// L1OriginPolicyLatest adopts the next L1 origin as soon as the next L2 block is allowed to build on it.
+	L1OriginPolicyLatest = "latest"
+	// L1OriginPolicyFastAdopt behaves like L1OriginPolicyLatest, but fetches the next L1 origin synchronously
+	// when it is not cached yet, instead of waiting for the background fetch.
+	// This minimizes the delay between an L1 block being available and its deposits being included on L2.
+	L1OriginPolicyFastAdopt = "fast-adopt"
+	// L1OriginPolicyConfDepth only adopts the next L1 origin once it has a number of confirmations on top of it,
+	// relative to the L1 head. Unlike the sequencer L1 confirmation depth, this is a soft limit:
+	// the max sequencer drift still forces adoption of unconfirmed origins.
+	L1OriginPolicyConfDepth = "conf-depth"
+	// L1OriginPolicyConservative avoids L1 origins that are likely to be reorged:
+	// the next L1 origin is only adopted once it is older than a minimum age,
+	// and only if it builds on the current L1 origin.
+	L1OriginPolicyConservative = "conservative"
+)
+
+var ErrUnknownL1OriginPolicy = errors.New("unknown L1 origin policy")
+
+// L1OriginPolicy decides when the sequencer moves on to the next L1 origin.
+// The policy is only consulted when the next L2 block is allowed to build on the next L1 origin.
+// The L1OriginSelector always enforces the max sequencer drift, regardless of the policy.
+type L1OriginPolicy interface {
+	// Name returns the registered name of the policy, used for logging and metrics.
+	Name() string
+	// FetchNextEagerly reports whether the next L1 origin should be fetched synchronously
+	// during origin selection when it is not cached yet.
+	FetchNextEagerly() bool
+	// ShouldAdopt reports whether the L2 block with timestamp nextL2Time should build on the next L1 origin,
+	// rather than on the current L1 origin.
+	ShouldAdopt(nextL2Time uint64, current eth.L1BlockRef, next eth.L1BlockRef) bool
+}
+
+// L1OriginPolicyConfig configures the L1 origin policy of a sequencer.
+type L1OriginPolicyConfig struct {
+	// Name is the name of the registered policy to use. Defaults to L1OriginPolicyLatest if empty.
+	Name string `json:"name"`
+	// ConfDepth is the number of L1 blocks required on top of the next L1 origin before adopting it.
+	// Only used by the conf-depth policy.
+	ConfDepth uint64 `json:"conf_depth"`
+	// MinAge is the minimum age in seconds, relative to the next L2 block, of the next L1 origin before adopting it.
+	// Only used by the conservative policy.
+	MinAge uint64 `json:"min_age"`
+}
+
+func (c *L1OriginPolicyConfig) Check() error {
+	name := c.Name
+	if name == "" {
+		name = L1OriginPolicyLatest
+	}
+	if !slices.Contains(L1OriginPolicyNames(), name) {
+		return fmt.Errorf("%w: %q", ErrUnknownL1OriginPolicy, name)
+	}
+	if name == L1OriginPolicyConfDepth && c.ConfDepth == 0 {
+		return errors.New("conf-depth L1 origin policy requires a non-zero confirmation depth")
+	}
+	return nil
+}
+
+// L1HeadFn returns the latest known L1 head.
+type L1HeadFn func() eth.L1BlockRef
+
+// L1OriginPolicyFactory creates a policy from its configuration.
+type L1OriginPolicyFactory func(cfg L1OriginPolicyConfig, l1Head L1HeadFn) L1OriginPolicy
+
+var (
+	l1OriginPoliciesLock sync.RWMutex
+	l1OriginPolicies     = map[string]L1OriginPolicyFactory{
+		L1OriginPolicyLatest: func(L1OriginPolicyConfig, L1HeadFn) L1OriginPolicy {
+			return &latestPolicy{}
+		},
+		L1OriginPolicyFastAdopt: func(L1OriginPolicyConfig, L1HeadFn) L1OriginPolicy {
+			return &fastAdoptPolicy{}
+		},
+		L1OriginPolicyConfDepth: func(cfg L1OriginPolicyConfig, l1Head L1HeadFn) L1OriginPolicy {
+			return &confDepthPolicy{depth: cfg.ConfDepth, l1Head: l1Head}
+		},
+		L1OriginPolicyConservative: func(cfg L1OriginPolicyConfig, _ L1HeadFn) L1OriginPolicy {
+			return &conservativePolicy{minAge: cfg.MinAge}
+		},
+	}
+)
+
+// RegisterL1OriginPolicy registers a custom L1 origin policy under the given name,
+// so it can be selected through L1OriginPolicyConfig.
+// Registering a name that is already in use replaces the existing policy.
+func RegisterL1OriginPolicy(name string, factory L1OriginPolicyFactory) {
+	l1OriginPoliciesLock.Lock()
+	defer l1OriginPoliciesLock.Unlock()
+	l1OriginPolicies[name] = factory
+}
+
+// L1OriginPolicyNames returns the names of all registered L1 origin policies, in sorted order.
+func L1OriginPolicyNames() []string {
+	l1OriginPoliciesLock.RLock()
+	defer l1OriginPoliciesLock.RUnlock()
+	names := make([]string, 0, len(l1OriginPolicies))
+	for name := range l1OriginPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewL1OriginPolicy creates the L1 origin policy described by cfg.
+// The l1Head function is used by policies that take the L1 head into account.
+func NewL1OriginPolicy(cfg L1OriginPolicyConfig, l1Head L1HeadFn) (L1OriginPolicy, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	name := cfg.Name
+	if name == "" {
+		name = L1OriginPolicyLatest
+	}
+	l1OriginPoliciesLock.RLock()
+	factory := l1OriginPolicies[name]
+	l1OriginPoliciesLock.RUnlock()
+	return factory(cfg, l1Head), nil
+}
+
+type latestPolicy struct{}
+
+func (p *latestPolicy) Name() string { return L1OriginPolicyLatest }
+
+func (p *latestPolicy) FetchNextEagerly() bool { return false }
+
+func (p *latestPolicy) ShouldAdopt(uint64, eth.L1BlockRef, eth.L1BlockRef) bool { return true }
+
+type fastAdoptPolicy struct{}
+
+func (p *fastAdoptPolicy) Name() string { return L1OriginPolicyFastAdopt }
+
+func (p *fastAdoptPolicy) FetchNextEagerly() bool { return true }
+
+func (p *fastAdoptPolicy) ShouldAdopt(uint64, eth.L1BlockRef, eth.L1BlockRef) bool { return true }
+
+type confDepthPolicy struct {
+	depth  uint64
+	l1Head L1HeadFn
+}
+
+func (p *confDepthPolicy) Name() string { return L1OriginPolicyConfDepth }
+
+func (p *confDepthPolicy) FetchNextEagerly() bool { return false }
+
+func (p *confDepthPolicy) ShouldAdopt(_ uint64, _ eth.L1BlockRef, next eth.L1BlockRef) bool {
+	head := p.l1Head()
+	// Without a known L1 head there is no way to tell the confirmations, so don't hold back the origin.
+	if head == (eth.L1BlockRef{}) {
+		return true
+	}
+	return head.Number >= next.Number+p.depth
+}
+
+type conservativePolicy struct {
+	minAge uint64
+}
+
+func (p *conservativePolicy) Name() string { return L1OriginPolicyConservative }
+
+func (p *conservativePolicy) FetchNextEagerly() bool { return false }
+
+func (p *conservativePolicy) ShouldAdopt(nextL2Time uint64, current eth.L1BlockRef, next eth.L1BlockRef) bool {
+	// A next origin that doesn't build on the current origin indicates an L1 reorg is in progress.
+	if next.ParentHash != current.Hash {
+		return false
+	}
+	return nextL2Time >= next.Time+p.minAge
+}
@@ -0,0 +1,165 @@
+package sequencing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedSelection struct {
+	policy   string
+	decision string
+	lag      uint64
+}
+
+type testOriginSelectorMetrics struct {
+	selections []recordedSelection
+}
+
+func (m *testOriginSelectorMetrics) RecordSequencerL1OriginSelection(policy string, decision string, lag uint64) {
+	m.selections = append(m.selections, recordedSelection{policy: policy, decision: decision, lag: lag})
+}
+
+func (m *testOriginSelectorMetrics) last() recordedSelection {
+	return m.selections[len(m.selections)-1]
+}
+
+func TestL1OriginPolicyConfigCheck(t *testing.T) {
+	require.NoError(t, (&L1OriginPolicyConfig{}).Check(), "empty name selects the default policy")
+	for _, name := range []string{L1OriginPolicyLatest, L1OriginPolicyFastAdopt, L1OriginPolicyConservative} {
+		require.NoError(t, (&L1OriginPolicyConfig{Name: name}).Check(), name)
+	}
+	require.NoError(t, (&L1OriginPolicyConfig{Name: L1OriginPolicyConfDepth, ConfDepth: 1}).Check())
+	require.ErrorContains(t, (&L1OriginPolicyConfig{Name: L1OriginPolicyConfDepth}).Check(), "non-zero confirmation depth")
+	require.ErrorIs(t, (&L1OriginPolicyConfig{Name: "nope"}).Check(), ErrUnknownL1OriginPolicy)
+}
+
+func TestNewL1OriginPolicy(t *testing.T) {
+	noHead := func() eth.L1BlockRef { return eth.L1BlockRef{} }
+	policy, err := NewL1OriginPolicy(L1OriginPolicyConfig{}, noHead)
+	require.NoError(t, err)
+	require.Equal(t, L1OriginPolicyLatest, policy.Name())
+
+	_, err = NewL1OriginPolicy(L1OriginPolicyConfig{Name: "nope"}, noHead)
+	require.ErrorIs(t, err, ErrUnknownL1OriginPolicy)
+}
+
+func TestRegisterL1OriginPolicy(t *testing.T) {
+	const name = "test-never"
+	RegisterL1OriginPolicy(name, func(L1OriginPolicyConfig, L1HeadFn) L1OriginPolicy {
+		return &conservativePolicy{minAge: ^uint64(0) / 2}
+	})
+	require.Contains(t, L1OriginPolicyNames(), name)
+	policy, err := NewL1OriginPolicy(L1OriginPolicyConfig{Name: name}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+}
+
+func TestConfDepthPolicy(t *testing.T) {
+	current := eth.L1BlockRef{Hash: common.Hash{'a'}, Number: 10, Time: 20}
+	next := eth.L1BlockRef{Hash: common.Hash{'b'}, Number: 11, Time: 32, ParentHash: current.Hash}
+	head := eth.L1BlockRef{}
+	policy, err := NewL1OriginPolicy(L1OriginPolicyConfig{Name: L1OriginPolicyConfDepth, ConfDepth: 2},
+		func() eth.L1BlockRef { return head })
+	require.NoError(t, err)
+
+	require.True(t, policy.ShouldAdopt(40, current, next), "adopt when the L1 head is unknown")
+	head = eth.L1BlockRef{Number: 12}
+	require.False(t, policy.ShouldAdopt(40, current, next), "only one confirmation")
+	head = eth.L1BlockRef{Number: 13}
+	require.True(t, policy.ShouldAdopt(40, current, next), "two confirmations")
+}
+
+func TestConservativePolicy(t *testing.T) {
+	current := eth.L1BlockRef{Hash: common.Hash{'a'}, Number: 10, Time: 20}
+	next := eth.L1BlockRef{Hash: common.Hash{'b'}, Number: 11, Time: 32, ParentHash: current.Hash}
+	policy, err := NewL1OriginPolicy(L1OriginPolicyConfig{Name: L1OriginPolicyConservative, MinAge: 12}, nil)
+	require.NoError(t, err)
+
+	require.False(t, policy.ShouldAdopt(40, current, next), "next origin too young")
+	require.True(t, policy.ShouldAdopt(44, current, next), "next origin old enough")
+	reorged := next
+	reorged.ParentHash = common.Hash{'x'}
+	require.False(t, policy.ShouldAdopt(44, current, reorged), "next origin does not build on current origin")
+}
+
+// TestOriginSelectorPolicyDelaysAdoption ensures that the origin selector stays on the current origin
+// while the policy rejects the next origin, and that the max sequencer drift still forces adoption.
+func TestOriginSelectorPolicyDelaysAdoption(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := &rollup.Config{
+		MaxSequencerDrift: 8,
+		BlockTime:         2,
+	}
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	a := eth.L1BlockRef{Hash: common.Hash{'a'}, Number: 10, Time: 20}
+	b := eth.L1BlockRef{Hash: common.Hash{'b'}, Number: 11, Time: 22, ParentHash: common.Hash{'x'}}
+
+	policy, err := NewL1OriginPolicy(L1OriginPolicyConfig{Name: L1OriginPolicyConservative}, nil)
+	require.NoError(t, err)
+	m := &testOriginSelectorMetrics{}
+	s := NewL1OriginSelectorWithPolicy(ctx, log, cfg, l1, policy, m)
+	s.currentOrigin = a
+	s.nextOrigin = b
+
+	// b doesn't build on a, so the conservative policy stays on a while within the drift.
+	next, err := s.FindL1Origin(ctx, eth.L2BlockRef{L1Origin: a.ID(), Time: 24})
+	require.NoError(t, err)
+	require.Equal(t, a, next)
+	require.Equal(t, recordedSelection{policy: L1OriginPolicyConservative, decision: OriginDecisionStay, lag: 6}, m.last())
+
+	// Past the drift, b must be adopted regardless of the policy.
+	next, err = s.FindL1Origin(ctx, eth.L2BlockRef{L1Origin: a.ID(), Time: 28})
+	require.NoError(t, err)
+	require.Equal(t, b, next)
+	require.Equal(t, recordedSelection{policy: L1OriginPolicyConservative, decision: OriginDecisionForced, lag: 8}, m.last())
+
+	// In recover mode the next origin is always adopted.
+	s.SetRecoverMode(true)
+	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
+	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	next, err = s.FindL1Origin(ctx, eth.L2BlockRef{L1Origin: a.ID(), Time: 24})
+	require.NoError(t, err)
+	require.Equal(t, b, next)
+	require.Equal(t, OriginDecisionAdopt, m.last().decision)
+}
+
+// TestOriginSelectorFastAdoptFetchesNextOrigin ensures that the fast-adopt policy fetches and adopts
+// the next origin without waiting for a forkchoice update to prefetch it.
+func TestOriginSelectorFastAdoptFetchesNextOrigin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := &rollup.Config{
+		MaxSequencerDrift: 500,
+		BlockTime:         2,
+	}
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	a := eth.L1BlockRef{Hash: common.Hash{'a'}, Number: 10, Time: 20}
+	b := eth.L1BlockRef{Hash: common.Hash{'b'}, Number: 11, Time: 22, ParentHash: a.Hash}
+
+	policy, err := NewL1OriginPolicy(L1OriginPolicyConfig{Name: L1OriginPolicyFastAdopt}, nil)
+	require.NoError(t, err)
+	m := &testOriginSelectorMetrics{}
+	s := NewL1OriginSelectorWithPolicy(ctx, log, cfg, l1, policy, m)
+	s.currentOrigin = a
+
+	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	next, err := s.FindL1Origin(ctx, eth.L2BlockRef{L1Origin: a.ID(), Time: 24})
+	require.NoError(t, err)
+	require.Equal(t, b, next)
+	require.Equal(t, recordedSelection{policy: L1OriginPolicyFastAdopt, decision: OriginDecisionAdopt, lag: 4}, m.last())
+}
@@ -23,11 +23,32 @@ type L1Blocks interface {
 	derive.L1BlockRefByNumberFetcher
 }
 
+// OriginSelectorMetrics records the decisions of the L1 origin selector.
+type OriginSelectorMetrics interface {
+	RecordSequencerL1OriginSelection(policy string, decision string, lag uint64)
+}
+
+// L1 origin selection decisions, as reported to OriginSelectorMetrics.
+const (
+	// OriginDecisionStay means the next L2 block builds on the current L1 origin.
+	OriginDecisionStay = "stay"
+	// OriginDecisionAdopt means the policy chose to move on to the next L1 origin.
+	OriginDecisionAdopt = "adopt"
+	// OriginDecisionForced means the max sequencer drift forced moving on to the next L1 origin.
+	OriginDecisionForced = "forced"
+)
+
+type noopOriginSelectorMetrics struct{}
+
+func (noopOriginSelectorMetrics) RecordSequencerL1OriginSelection(string, string, uint64) {}
+
 type L1OriginSelector struct {
-	ctx  context.Context
-	log  log.Logger
-	cfg  *rollup.Config
-	spec *rollup.ChainSpec
+	ctx     context.Context
+	log     log.Logger
+	cfg     *rollup.Config
+	spec    *rollup.ChainSpec
+	policy  L1OriginPolicy
+	metrics OriginSelectorMetrics
 
 	recoverMode atomic.Bool
 
@@ -40,13 +61,23 @@ type L1OriginSelector struct {
 	mu sync.Mutex
 }
 
+// NewL1OriginSelector creates an L1OriginSelector with the default latest L1 origin policy.
 func NewL1OriginSelector(ctx context.Context, log log.Logger, cfg *rollup.Config, l1 L1Blocks) *L1OriginSelector {
+	return NewL1OriginSelectorWithPolicy(ctx, log, cfg, l1, &latestPolicy{}, noopOriginSelectorMetrics{})
+}
+
+// NewL1OriginSelectorWithPolicy creates an L1OriginSelector that uses the given policy
+// to decide when to move on to the next L1 origin.
+func NewL1OriginSelectorWithPolicy(ctx context.Context, log log.Logger, cfg *rollup.Config, l1 L1Blocks,
+	policy L1OriginPolicy, metrics OriginSelectorMetrics) *L1OriginSelector {
 	return &L1OriginSelector{
-		ctx:  ctx,
-		log:  log,
-		cfg:  cfg,
-		spec: rollup.NewChainSpec(cfg),
-		l1:   l1,
+		ctx:     ctx,
+		log:     log.New("l1_origin_policy", policy.Name()),
+		cfg:     cfg,
+		spec:    rollup.NewChainSpec(cfg),
+		policy:  policy,
+		metrics: metrics,
+		l1:      l1,
 	}
 }
 
@@ -80,25 +111,40 @@ func (los *L1OriginSelector) FindL1Origin(ctx context.Context, l2Head eth.L2Bloc
 	if err != nil {
 		return eth.L1BlockRef{}, err
 	}
+	nextL2Time := l2Head.Time + los.cfg.BlockTime
+
+	if nextOrigin == (eth.L1BlockRef{}) && los.policy.FetchNextEagerly() {
+		// Don't wait for the background fetch on the next forkchoice update,
+		// so a new L1 block can be adopted by the very next L2 block.
+		fetchCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		fetched, err := los.fetch(fetchCtx, currentOrigin.Number+1)
+		cancel()
+		if err == nil {
+			nextOrigin = fetched
+		} else if !errors.Is(err, ethereum.NotFound) {
+			los.log.Debug("Failed to eagerly fetch next L1 origin", "err", err)
+		}
+	}
 
 	// If the next L2 block time is greater than the next origin block's time, we can choose to
 	// start building on top of the next origin. Sequencer implementation has some leeway here and
 	// could decide to continue to build on top of the previous origin until the Sequencer runs out
-	// of slack. For simplicity, we implement our Sequencer to always start building on the latest
-	// L1 block when we can.
-	if nextOrigin != (eth.L1BlockRef{}) && l2Head.Time+los.cfg.BlockTime >= nextOrigin.Time {
-		return nextOrigin, nil
+	// of slack. The L1 origin policy decides whether to make use of that leeway.
+	// In recover mode the next origin is always adopted, to match what verifiers derive.
+	if nextOrigin != (eth.L1BlockRef{}) && nextL2Time >= nextOrigin.Time &&
+		(los.recoverMode.Load() || los.policy.ShouldAdopt(nextL2Time, currentOrigin, nextOrigin)) {
+		return los.selected(OriginDecisionAdopt, nextL2Time, nextOrigin), nil
 	}
 
 	msd := los.spec.MaxSequencerDrift(currentOrigin.Time)
 	log := los.log.New("current", currentOrigin, "current_time", currentOrigin.Time,
 		"l2_head", l2Head, "l2_head_time", l2Head.Time, "max_seq_drift", msd)
 
-	pastSeqDrift := l2Head.Time+los.cfg.BlockTime-currentOrigin.Time > msd
+	pastSeqDrift := nextL2Time-currentOrigin.Time > msd
 
 	// If we are not past the max sequencer drift, we can just return the current origin.
 	if !pastSeqDrift {
-		return currentOrigin, nil
+		return los.selected(OriginDecisionStay, nextL2Time, currentOrigin), nil
 	}
 
 	// Otherwise, we need to find the next L1 origin block in order to continue producing blocks.
@@ -116,11 +162,21 @@ func (los *L1OriginSelector) FindL1Origin(ctx context.Context, l2Head eth.L2Bloc
 	}
 
 	// If the next origin is ahead of the L2 head, we must return the current origin.
-	if l2Head.Time+los.cfg.BlockTime < nextOrigin.Time {
-		return currentOrigin, nil
+	if nextL2Time < nextOrigin.Time {
+		return los.selected(OriginDecisionStay, nextL2Time, currentOrigin), nil
 	}
 
-	return nextOrigin, nil
+	return los.selected(OriginDecisionForced, nextL2Time, nextOrigin), nil
+}
+
+// selected records the origin selection decision and returns the selected origin.
+func (los *L1OriginSelector) selected(decision string, nextL2Time uint64, origin eth.L1BlockRef) eth.L1BlockRef {
+	var lag uint64
+	if nextL2Time > origin.Time {
+		lag = nextL2Time - origin.Time
+	}
+	los.metrics.RecordSequencerL1OriginSelection(los.policy.Name(), decision, lag)
+	return origin
 }
 
 func (los *L1OriginSelector) CurrentAndNextOrigin(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, eth.L1BlockRef, error) {
@@ -27,6 +27,8 @@ const sealingDuration = time.Millisecond * 50
 var (
 	ErrSequencerAlreadyStarted = errors.New("sequencer already running")
 	ErrSequencerAlreadyStopped = errors.New("sequencer not running")
+	ErrHandoverWithConductor   = errors.New("scheduled sequencer handover is not supported when the conductor is enabled")
+	ErrHandoverTargetPassed    = errors.New("scheduled handover block has already passed")
 )
 
 type L1OriginSelectorIface interface {
@@ -124,10 +126,22 @@ type Sequencer struct {
 
 	latestHeadSet chan struct{}
 
+	// scheduledStop is the last block to sequence before stopping, if a stop is scheduled.
+	scheduledStop *handoverTarget
+	// scheduledStart is the block to start sequencing on top of, if a start is scheduled.
+	scheduledStart *handoverTarget
+
 	// toBlockRef converts a payload to a block-ref, and is only configurable for test-purposes
 	toBlockRef func(rollupCfg *rollup.Config, payload *eth.ExecutionPayload) (eth.L2BlockRef, error)
 }
 
+// handoverTarget is the L2 block at which a scheduled sequencer stop or start takes effect.
+type handoverTarget struct {
+	number uint64
+	// hash is the expected block hash. Any block at number is accepted if zero.
+	hash common.Hash
+}
+
 var _ SequencerIface = (*Sequencer)(nil)
 
 func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.Config,
@@ -443,6 +457,7 @@ func (d *Sequencer) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 
 	if !d.active.Load() {
 		d.setLatestHead(x.UnsafeL2Head)
+		d.maybeStartScheduled()
 		return
 	}
 	// If the safe head has fallen behind by a significant number of blocks, delay creating new blocks
@@ -498,6 +513,11 @@ func (d *Sequencer) startBuildingBlock() {
 	if d.latest.Onto == l2Head {
 		return
 	}
+	// If the last block before a scheduled stop has been sequenced, hand over instead of building on top of it.
+	if d.scheduledStop != nil && l2Head.Number >= d.scheduledStop.number {
+		d.stopScheduled()
+		return
+	}
 
 	recoverMode := d.recoverMode.Load()
 
@@ -648,7 +668,11 @@ func (d *Sequencer) Start(ctx context.Context, head common.Hash) error {
 	if head != d.latestHead.Hash {
 		return fmt.Errorf("block hash does not match: head %s, received %s", d.latestHead, head)
 	}
-	return d.forceStart()
+	if err := d.forceStart(); err != nil {
+		return err
+	}
+	d.scheduledStart = nil
+	return nil
 }
 
 func (d *Sequencer) Init(ctx context.Context, active bool) error {
@@ -743,8 +767,17 @@ func (d *Sequencer) Stop(ctx context.Context) (common.Hash, error) {
 		return common.Hash{}, ErrSequencerAlreadyStopped
 	}
 
+	if err := d.deactivate(); err != nil {
+		return common.Hash{}, err
+	}
+	d.log.Info("Sequencer has been stopped")
+	return d.latestHead.Hash, nil
+}
+
+// deactivate stops the sequencer, without waiting for the latest sealed block to become the head.
+func (d *Sequencer) deactivate() error {
 	if err := d.listener.SequencerStopped(); err != nil {
-		return common.Hash{}, fmt.Errorf("failed to notify sequencer-state listener of stop: %w", err)
+		return fmt.Errorf("failed to notify sequencer-state listener of stop: %w", err)
 	}
 
 	// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
@@ -753,9 +786,119 @@ func (d *Sequencer) Stop(ctx context.Context) (common.Hash, error) {
 
 	d.nextActionOK = false
 	d.active.Store(false)
+	d.scheduledStop = nil
 	d.metrics.SetSequencerState(false)
-	d.log.Info("Sequencer has been stopped")
-	return d.latestHead.Hash, nil
+	return nil
+}
+
+// ScheduleStop schedules the active sequencer to stop after sequencing the block with the given number.
+// The sequencer keeps sequencing until then, and does not build any block on top of it,
+// so a standby sequencer can take over at exactly that block with ScheduleStart.
+// The block cannot precede a block that is already being built or sealed.
+// Scheduling a stop replaces any previously scheduled stop.
+func (d *Sequencer) ScheduleStop(ctx context.Context, blockNumber uint64) error {
+	if d.conductor.Enabled(ctx) {
+		return ErrHandoverWithConductor
+	}
+	if err := d.l.LockCtx(ctx); err != nil {
+		return err
+	}
+	defer d.l.Unlock()
+
+	if !d.active.Load() {
+		return ErrSequencerAlreadyStopped
+	}
+	if last := d.lastSequenced(); blockNumber < last {
+		return fmt.Errorf("%w: cannot stop after block %d, head is %s, block %d is sequenced already",
+			ErrHandoverTargetPassed, blockNumber, d.latestHead, last)
+	}
+	d.scheduledStop = &handoverTarget{number: blockNumber}
+	d.log.Info("Scheduled sequencer stop", "last_block", blockNumber, "head", d.latestHead)
+	return nil
+}
+
+// lastSequenced returns the number of the last block that is sequenced, or will be sequenced
+// without the sequencer building on top of the head again: a block that is being built or has been
+// sealed still becomes the head.
+func (d *Sequencer) lastSequenced() uint64 {
+	last := d.latestHead.Number
+	if d.latest.Onto != (eth.L2BlockRef{}) {
+		last = max(last, d.latest.Onto.Number+1)
+	}
+	return max(last, d.latestSealed.Number)
+}
+
+// ScheduleStart schedules the inactive sequencer to start as soon as its unsafe head is the block
+// with the given number, and hash if non-zero. This pairs with ScheduleStop on the active sequencer,
+// to hand over sequencing without missing any block.
+// If the head moves past the block, or a different block is seen at that number, the scheduled start is
+// cancelled. Scheduling a start replaces any previously scheduled start.
+func (d *Sequencer) ScheduleStart(ctx context.Context, blockNumber uint64, blockHash common.Hash) error {
+	if d.conductor.Enabled(ctx) {
+		return ErrHandoverWithConductor
+	}
+	if err := d.l.LockCtx(ctx); err != nil {
+		return err
+	}
+	defer d.l.Unlock()
+
+	if d.active.Load() {
+		return ErrSequencerAlreadyStarted
+	}
+	if blockNumber < d.latestHead.Number {
+		return fmt.Errorf("%w: cannot start on block %d, head is %s", ErrHandoverTargetPassed, blockNumber, d.latestHead)
+	}
+	d.scheduledStart = &handoverTarget{number: blockNumber, hash: blockHash}
+	d.log.Info("Scheduled sequencer start", "onto_number", blockNumber, "onto_hash", blockHash, "head", d.latestHead)
+	d.maybeStartScheduled()
+	return nil
+}
+
+// CancelScheduledHandover cancels any scheduled stop or start.
+func (d *Sequencer) CancelScheduledHandover(ctx context.Context) error {
+	if err := d.l.LockCtx(ctx); err != nil {
+		return err
+	}
+	defer d.l.Unlock()
+
+	if d.scheduledStop != nil || d.scheduledStart != nil {
+		d.log.Info("Cancelled scheduled sequencer handover")
+	}
+	d.scheduledStop = nil
+	d.scheduledStart = nil
+	return nil
+}
+
+// stopScheduled stops the sequencer at the scheduled stop block.
+// If stopping fails, it is retried on the next sequencer action.
+func (d *Sequencer) stopScheduled() {
+	if err := d.deactivate(); err != nil {
+		d.log.Error("Failed to stop sequencer as scheduled, retrying", "err", err)
+		d.nextAction = d.timeNow().Add(time.Second)
+		d.nextActionOK = true
+		return
+	}
+	d.log.Info("Sequencer has been stopped as scheduled", "head", d.latestHead)
+}
+
+// maybeStartScheduled starts the inactive sequencer if the latest head is the scheduled start block.
+func (d *Sequencer) maybeStartScheduled() {
+	target := d.scheduledStart
+	if target == nil || d.latestHead.Number < target.number {
+		return
+	}
+	if d.latestHead.Number > target.number || (target.hash != (common.Hash{}) && target.hash != d.latestHead.Hash) {
+		d.log.Error("Missed scheduled sequencer start, cancelling it",
+			"onto_number", target.number, "onto_hash", target.hash, "head", d.latestHead)
+		d.scheduledStart = nil
+		return
+	}
+	if err := d.forceStart(); err != nil {
+		d.log.Error("Failed to start sequencer as scheduled", "err", err)
+		return
+	}
+	d.scheduledStart = nil
+	d.log.Info("Sequencer has been started as scheduled", "head", d.latestHead)
 }
 
 func (d *Sequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
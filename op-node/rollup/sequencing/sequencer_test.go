@@ -106,13 +106,14 @@ var _ SequencerStateListener = (*BasicSequencerStateListener)(nil)
 type FakeConductor struct {
 	closed    bool
 	leader    bool
+	disabled  bool
 	committed *eth.ExecutionPayloadEnvelope
 }
 
 var _ conductor.SequencerConductor = &FakeConductor{}
 
 func (c *FakeConductor) Enabled(ctx context.Context) bool {
-	return true
+	return !c.disabled
 }
 
 func (c *FakeConductor) Leader(ctx context.Context) (bool, error) {
@@ -259,6 +260,103 @@ func TestSequencer_StartStop(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestSequencer_ScheduledHandover tests a planned handover, where the active sequencer stops after
+// a pre-announced block, and the standby sequencer starts on top of exactly that block.
+func TestSequencer_ScheduledHandover(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	testCtx := context.Background()
+	headA := eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 5}
+	headB := eth.L2BlockRef{Hash: common.Hash{0xbb}, Number: 6, ParentHash: headA.Hash}
+
+	newSequencer := func(t *testing.T, active bool) (*Sequencer, *sequencerTestDeps) {
+		seq, deps := createSequencer(logger)
+		seq.AttachEmitter(&testutils.MockEmitter{})
+		deps.conductor.leader = true
+		deps.conductor.disabled = true
+		require.NoError(t, seq.Init(testCtx, false))
+		seq.OnEvent(testCtx, engine.ForkchoiceUpdateEvent{UnsafeL2Head: headA})
+		if active {
+			require.NoError(t, seq.Start(testCtx, headA.Hash))
+		}
+		return seq, deps
+	}
+
+	t.Run("NotWithConductor", func(t *testing.T) {
+		seq, deps := newSequencer(t, true)
+		deps.conductor.disabled = false
+		require.ErrorIs(t, seq.ScheduleStop(testCtx, 6), ErrHandoverWithConductor)
+		require.ErrorIs(t, seq.ScheduleStart(testCtx, 6, common.Hash{}), ErrHandoverWithConductor)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		seq, deps := newSequencer(t, true)
+		require.ErrorIs(t, seq.ScheduleStop(testCtx, 4), ErrHandoverTargetPassed)
+		require.ErrorIs(t, seq.ScheduleStart(testCtx, 6, common.Hash{}), ErrSequencerAlreadyStarted)
+		require.NoError(t, seq.ScheduleStop(testCtx, headB.Number))
+
+		// The head at the scheduled block is sequenced, and the sequencer stops instead of building on top of it.
+		seq.OnEvent(testCtx, engine.ForkchoiceUpdateEvent{UnsafeL2Head: headB})
+		seq.OnEvent(testCtx, SequencerActionEvent{})
+		require.False(t, seq.Active())
+		require.False(t, deps.seqState.active, "sequencer signaled it is no longer active")
+		_, ok := seq.NextAction()
+		require.False(t, ok)
+		require.Nil(t, seq.scheduledStop)
+	})
+
+	t.Run("StopWhileBuilding", func(t *testing.T) {
+		seq, _ := newSequencer(t, true)
+		// A block on top of the head is being built, and will be sealed even if the sequencer stops after the head.
+		seq.latest = BuildingState{Onto: headA}
+		require.ErrorIs(t, seq.ScheduleStop(testCtx, headA.Number), ErrHandoverTargetPassed)
+		require.Nil(t, seq.scheduledStop)
+		require.NoError(t, seq.ScheduleStop(testCtx, headB.Number))
+
+		// The same applies to a sealed block that is not the head yet.
+		seq.latest = BuildingState{}
+		seq.latestSealed = headB
+		require.ErrorIs(t, seq.ScheduleStop(testCtx, headA.Number), ErrHandoverTargetPassed)
+		require.Equal(t, headB.Number, seq.scheduledStop.number)
+	})
+
+	t.Run("CancelStop", func(t *testing.T) {
+		seq, _ := newSequencer(t, true)
+		require.NoError(t, seq.ScheduleStop(testCtx, headA.Number))
+		require.NoError(t, seq.CancelScheduledHandover(testCtx))
+		seq.OnEvent(testCtx, engine.ForkchoiceUpdateEvent{UnsafeL2Head: headB})
+		require.True(t, seq.Active())
+	})
+
+	t.Run("Start", func(t *testing.T) {
+		seq, deps := newSequencer(t, false)
+		require.ErrorIs(t, seq.ScheduleStart(testCtx, 4, common.Hash{}), ErrHandoverTargetPassed)
+		require.ErrorIs(t, seq.ScheduleStop(testCtx, 6), ErrSequencerAlreadyStopped)
+		require.NoError(t, seq.ScheduleStart(testCtx, headB.Number, headB.Hash))
+		require.False(t, seq.Active(), "waiting for the scheduled head")
+
+		seq.OnEvent(testCtx, engine.ForkchoiceUpdateEvent{UnsafeL2Head: headB})
+		require.True(t, seq.Active())
+		require.True(t, deps.seqState.active, "sequencer signaled it is active")
+		_, ok := seq.NextAction()
+		require.True(t, ok, "sequencer builds on top of the handover block right away")
+		require.Nil(t, seq.scheduledStart)
+	})
+
+	t.Run("StartAtCurrentHead", func(t *testing.T) {
+		seq, _ := newSequencer(t, false)
+		require.NoError(t, seq.ScheduleStart(testCtx, headA.Number, common.Hash{}))
+		require.True(t, seq.Active())
+	})
+
+	t.Run("StartMissed", func(t *testing.T) {
+		seq, _ := newSequencer(t, false)
+		require.NoError(t, seq.ScheduleStart(testCtx, headB.Number, common.Hash{0xcc}))
+		seq.OnEvent(testCtx, engine.ForkchoiceUpdateEvent{UnsafeL2Head: headB})
+		require.False(t, seq.Active(), "different block at the scheduled number")
+		require.Nil(t, seq.scheduledStart)
+	})
+}
+
 // TestSequencer_StaleBuild stops the sequencer after block-building,
 // but before processing the block locally,
 // and then continues it again, to check if the async-gossip gets cleared,
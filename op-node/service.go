@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/cliiface"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -119,6 +120,7 @@ func NewConfig(ctx cliiface.Context, log log.Logger) (*config.Config, error) {
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
 		ConfigPersistence:           configPersistence,
 		SafeDBPath:                  ctx.String(flags.SafeDBPath.Name),
+		SafeDBRetention:             ctx.Uint64(flags.SafeDBRetention.Name),
 		Sync:                        *syncConfig,
 		RollupHalt:                  haltOption,
 
@@ -213,6 +215,11 @@ func NewDriverConfig(ctx cliiface.Context) *driver.Config {
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
 		RecoverMode:         ctx.Bool(flags.SequencerRecoverMode.Name),
+		SequencerL1OriginPolicy: sequencing.L1OriginPolicyConfig{
+			Name:      ctx.String(flags.SequencerL1OriginPolicyFlag.Name),
+			ConfDepth: ctx.Uint64(flags.SequencerL1OriginPolicyConfDepthFlag.Name),
+			MinAge:    uint64(ctx.Duration(flags.SequencerL1OriginPolicyMinAgeFlag.Name).Seconds()),
+		},
 	}
 
 	// Populate finality config from flags. A finality config with null fields
@@ -47,6 +47,7 @@ func run(args []string, action ConfigAction) error {
 	app.Description = "The Optimism Fault Proof Program fault proof program that runs through the rollup state-transition to verify an L2 output from L1 inputs."
 	app.Commands = []*cli.Command{
 		subcmds.ConfigsCommand,
+		subcmds.PreimagesCommand,
 	}
 	app.Action = func(ctx *cli.Context) error {
 		logger, err := setupLogging(ctx)
@@ -166,6 +166,18 @@ func TestDataFormat(t *testing.T) {
 	})
 }
 
+func TestDataRecordKeys(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.DataRecordKeysPath)
+	})
+	t.Run("Set", func(t *testing.T) {
+		expected := "/tmp/mainTestKeys.txt"
+		cfg := configForArgs(t, addRequiredArgs("--data.record-keys", expected))
+		require.Equal(t, expected, cfg.DataRecordKeysPath)
+	})
+}
+
 func TestL2(t *testing.T) {
 	t.Run("Single", func(t *testing.T) {
 		expected := "https://example.com:8545"
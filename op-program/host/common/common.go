@@ -152,6 +152,7 @@ func RunPreimageServer(ctx context.Context, logger log.Logger, cfg *config.Confi
 	var hinterDone chan error
 	logger.Info("Starting preimage server")
 	var kv kvstore.KV
+	var recorder *kvstore.KeyRecorder
 
 	// Close the preimage/hint channels, and then kv store once the server and hinter have exited.
 	defer func() {
@@ -166,6 +167,14 @@ func RunPreimageServer(ctx context.Context, logger log.Logger, cfg *config.Confi
 			<-hinterDone
 		}
 
+		if recorder != nil {
+			keys := recorder.Keys()
+			if err := writeRecordedKeys(cfg.DataRecordKeysPath, keys); err != nil {
+				logger.Error("Failed to write recorded pre-image keys", "path", cfg.DataRecordKeysPath, "err", err)
+			} else {
+				logger.Info("Recorded pre-image keys", "path", cfg.DataRecordKeysPath, "count", len(keys))
+			}
+		}
 		if kv != nil {
 			kv.Close()
 		}
@@ -205,6 +214,11 @@ func RunPreimageServer(ctx context.Context, logger log.Logger, cfg *config.Confi
 		}
 	}
 
+	if cfg.DataRecordKeysPath != "" {
+		recorder = kvstore.NewKeyRecorder()
+		getPreimage = recorder.Wrap(getPreimage)
+	}
+
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
@@ -226,6 +240,18 @@ func RunPreimageServer(ctx context.Context, logger log.Logger, cfg *config.Confi
 	}
 }
 
+func writeRecordedKeys(path string, keys []common.Hash) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create keys file: %w", err)
+	}
+	if err := kvstore.WriteKeys(f, keys); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
 	chErr := make(chan error)
 	hintReader := preimage.NewHintReader(hHostRW)
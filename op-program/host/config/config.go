@@ -59,6 +59,10 @@ type Config struct {
 	// DataFormat specifies the format to use for on-disk storage. Only applies when DataDir is set.
 	DataFormat types.DataFormat
 
+	// DataRecordKeysPath is the file to write the keys of all pre-images read during the run to.
+	// If not set, accessed keys are not recorded.
+	DataRecordKeysPath string
+
 	// L1Head is the block hash of the L1 chain head block
 	L1Head      common.Hash
 	L1URL       string
@@ -401,6 +405,7 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		L1ChainConfig:      l1ChainConfig,
 		DataDir:            ctx.String(flags.DataDir.Name),
 		DataFormat:         dbFormat,
+		DataRecordKeysPath: ctx.Path(flags.DataRecordKeys.Name),
 		L2URLs:             ctx.StringSlice(flags.L2NodeAddr.Name),
 		L2ExperimentalURLs: ctx.StringSlice(flags.L2NodeExperimentalAddr.Name),
 		L2ChainConfigs:     l2ChainConfigs,
@@ -55,6 +55,13 @@ var (
 		EnvVars: prefixEnvVars("DATA_FORMAT"),
 		Value:   string(types.DataFormatDirectory),
 	}
+	DataRecordKeys = &cli.PathFlag{
+		Name: "data.record-keys",
+		Usage: "File to write the keys of all pre-images read during the run to. " +
+			"The recorded keys can be used with the preimages subcommands to extract or prune pre-image data.",
+		EnvVars:   prefixEnvVars("DATA_RECORD_KEYS"),
+		TakesFile: true,
+	}
 	L2NodeAddr = &cli.StringSliceFlag{
 		Name:    "l2",
 		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
@@ -164,6 +171,7 @@ var programFlags = []cli.Flag{
 	Network,
 	DataDir,
 	DataFormat,
+	DataRecordKeys,
 	L2NodeAddr,
 	L2NodeExperimentalAddr,
 	L2GenesisPath,
@@ -3,6 +3,8 @@ package host
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -56,6 +59,51 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestServerModeRecordKeys(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.NewSingleChainConfig(chaincfg.OPSepolia(), params.SepoliaChainConfig, chainconfig.OPSepoliaChainConfig(), common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = filepath.Join(dir, "data")
+	cfg.DataRecordKeysPath = filepath.Join(dir, "keys.txt")
+	cfg.ServerMode = true
+	logger := testlog.Logger(t, log.LevelTrace)
+
+	// Pre-populate the store, as the server runs offline
+	data := []byte("recorded")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data))
+	require.NoError(t, os.MkdirAll(cfg.DataDir, 0755))
+	kv, err := kvstore.NewDiskKV(logger, cfg.DataDir, cfg.DataFormat)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(key.PreimageKey(), data))
+	require.NoError(t, kv.Close())
+
+	preimageServer, preimageClient, err := preimage.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer preimageClient.Close()
+	hintServer, hintClient, err := preimage.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer hintClient.Close()
+	result := make(chan error)
+	go func() {
+		result <- hostcommon.RunPreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, makeDefaultPrefetcher)
+	}()
+
+	pClient := preimage.NewOracleClient(preimageClient)
+	require.Equal(t, data, pClient.Get(key))
+	require.Equal(t, cfg.L1Head.Bytes(), pClient.Get(boot.L1HeadLocalIndex))
+	require.Panics(t, func() {
+		pClient.Get(preimage.Keccak256Key(common.Hash{0xff}))
+	}, "Preimage should not be available")
+	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
+
+	// Only the pre-images read from the store are recorded, not local or missing ones
+	f, err := os.Open(cfg.DataRecordKeysPath)
+	require.NoError(t, err)
+	defer f.Close()
+	keys, err := kvstore.ReadKeys(f)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{key.PreimageKey()}, keys)
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
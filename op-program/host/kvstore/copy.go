@@ -0,0 +1,51 @@
+package kvstore
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Copy puts every pre-image from src into dst for which include returns true.
+// If include is nil, all pre-images are copied.
+// Returns the number of pre-images copied.
+func Copy(dst KV, src IterableKV, include func(k common.Hash) bool) (int, error) {
+	count := 0
+	err := src.ForEach(func(k common.Hash, v []byte) error {
+		if include != nil && !include(k) {
+			return nil
+		}
+		if err := dst.Put(k, v); err != nil {
+			return fmt.Errorf("failed to copy pre-image %s: %w", k, err)
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// Prune deletes every pre-image from kv for which keep returns false.
+// If dryRun is true the pre-images to delete are counted but not removed.
+// Returns the number of pre-images deleted, or that would be deleted when dryRun is set.
+func Prune(kv IterableKV, keep func(k common.Hash) bool, dryRun bool) (int, error) {
+	// Collect the keys first as the store can't be modified during iteration.
+	var unused []common.Hash
+	err := kv.ForEach(func(k common.Hash, _ []byte) error {
+		if !keep(k) {
+			unused = append(unused, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pre-images: %w", err)
+	}
+	if dryRun {
+		return len(unused), nil
+	}
+	for i, k := range unused {
+		if err := kv.Delete(k); err != nil {
+			return i, err
+		}
+	}
+	return len(unused), nil
+}
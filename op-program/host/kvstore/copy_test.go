@@ -0,0 +1,71 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	src := NewMemKV()
+	require.NoError(t, src.Put(common.Hash{0x01}, []byte("one")))
+	require.NoError(t, src.Put(common.Hash{0x02}, []byte("two")))
+
+	t.Run("All", func(t *testing.T) {
+		dst := newFileKV(t.TempDir())
+		count, err := Copy(dst, src, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		requirePreimage(t, dst, common.Hash{0x01}, "one")
+		requirePreimage(t, dst, common.Hash{0x02}, "two")
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		dst := NewMemKV()
+		count, err := Copy(dst, src, func(k common.Hash) bool { return k == common.Hash{0x02} })
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		requirePreimage(t, dst, common.Hash{0x02}, "two")
+		_, err = dst.Get(common.Hash{0x01})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestPrune(t *testing.T) {
+	setup := func() *MemKV {
+		kv := NewMemKV()
+		require.NoError(t, kv.Put(common.Hash{0x01}, []byte("one")))
+		require.NoError(t, kv.Put(common.Hash{0x02}, []byte("two")))
+		require.NoError(t, kv.Put(common.Hash{0x03}, []byte("three")))
+		return kv
+	}
+	keep := func(k common.Hash) bool { return k == common.Hash{0x02} }
+
+	t.Run("Delete", func(t *testing.T) {
+		kv := setup()
+		count, err := Prune(kv, keep, false)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		requirePreimage(t, kv, common.Hash{0x02}, "two")
+		_, err = kv.Get(common.Hash{0x01})
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = kv.Get(common.Hash{0x03})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		kv := setup()
+		count, err := Prune(kv, keep, true)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		requirePreimage(t, kv, common.Hash{0x01}, "one")
+		requirePreimage(t, kv, common.Hash{0x03}, "three")
+	})
+}
+
+func requirePreimage(t *testing.T, kv KV, k common.Hash, expected string) {
+	actual, err := kv.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(actual))
+}
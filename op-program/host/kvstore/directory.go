@@ -79,8 +79,53 @@ func (d *directoryKV) Get(k common.Hash) ([]byte, error) {
 	return hex.DecodeString(string(dat))
 }
 
+func (d *directoryKV) ForEach(fn func(k common.Hash, v []byte) error) error {
+	dirs, err := os.ReadDir(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to list pre-image directories: %w", err)
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(path.Join(d.path, dir.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to list pre-images in %v: %w", dir.Name(), err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			k, ok := parseKeyFilename("0x" + dir.Name() + entry.Name())
+			if !ok {
+				continue
+			}
+			v, err := d.Get(k)
+			if errors.Is(err, ErrNotFound) {
+				// Deleted since the directory was listed
+				continue
+			} else if err != nil {
+				return err
+			}
+			if err := fn(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *directoryKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := os.Remove(d.pathKey(k)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete pre-image %s: %w", k, err)
+	}
+	return nil
+}
+
 func (d *directoryKV) Close() error {
 	return nil
 }
 
-var _ KV = (*directoryKV)(nil)
+var _ IterableKV = (*directoryKV)(nil)
@@ -26,3 +26,9 @@ func TestDirectoryKV_CreateMissingDirectory(t *testing.T) {
 	key := crypto.Keccak256Hash(val)
 	require.NoError(t, kv.Put(key, val))
 }
+
+func TestDirectoryKV_Iterable(t *testing.T) {
+	kv := newDirectoryKV(t.TempDir())
+	defer kv.Close()
+	iterableKVTest(t, kv)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -90,8 +91,54 @@ func (d *fileKV) Get(k common.Hash) ([]byte, error) {
 	return hex.DecodeString(string(dat))
 }
 
+func (d *fileKV) ForEach(fn func(k common.Hash, v []byte) error) error {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to list pre-images: %w", err)
+	}
+	for _, entry := range entries {
+		k, ok := parseKeyFilename(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+		v, err := d.Get(k)
+		if errors.Is(err, ErrNotFound) {
+			// Deleted since the directory was listed
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *fileKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := os.Remove(d.pathKey(k)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete pre-image %s: %w", k, err)
+	}
+	return nil
+}
+
+// parseKeyFilename parses the key from a pre-image file name of the form 0x<hex key>.txt.
+func parseKeyFilename(name string) (common.Hash, bool) {
+	hexKey, ok := strings.CutSuffix(name, ".txt")
+	if !ok || len(hexKey) != 2+2*common.HashLength || !strings.HasPrefix(hexKey, "0x") {
+		return common.Hash{}, false
+	}
+	b, err := hex.DecodeString(hexKey[2:])
+	if err != nil {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(b), true
+}
+
 func (d *fileKV) Close() error {
 	return nil
 }
 
-var _ KV = (*fileKV)(nil)
+var _ IterableKV = (*fileKV)(nil)
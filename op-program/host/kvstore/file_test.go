@@ -26,3 +26,9 @@ func TestFileKV_CreateMissingDirectory(t *testing.T) {
 	key := crypto.Keccak256Hash(val)
 	require.NoError(t, kv.Put(key, val))
 }
+
+func TestFileKV_Iterable(t *testing.T) {
+	kv := newFileKV(t.TempDir())
+	defer kv.Close()
+	iterableKVTest(t, kv)
+}
@@ -36,6 +36,12 @@ func readKVFormat(dir string) (types.DataFormat, error) {
 	return format, nil
 }
 
+// DiskKVFormat returns the format recorded for the KV store in dir.
+// ErrFormatUnavailable is returned if the directory does not have the format recorded.
+func DiskKVFormat(dir string) (types.DataFormat, error) {
+	return readKVFormat(dir)
+}
+
 // NewDiskKV creates a new KV implementation. If the specified directly contains an existing KV store
 // that has the format recorded, the recorded format is used ensuring compatibility with the existing data.
 // If the directory does not contain existing data or doesn't have the format recorded, defaultFormat is used
@@ -55,7 +61,21 @@ func NewDiskKV(logger log.Logger, dir string, defaultFormat types.DataFormat) (K
 	} else {
 		logger.Info("Using existing disk storage", "datadir", dir, "format", format)
 	}
+	return newDiskKV(dir, format)
+}
+
+// OpenDiskKV opens an existing KV store in the specified directory using its recorded format.
+// ErrFormatUnavailable is returned if the directory does not have the format recorded.
+func OpenDiskKV(logger log.Logger, dir string) (IterableKV, error) {
+	format, err := readKVFormat(dir)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Opening existing disk storage", "datadir", dir, "format", format)
+	return newDiskKV(dir, format)
+}
 
+func newDiskKV(dir string, format types.DataFormat) (IterableKV, error) {
 	switch format {
 	case types.DataFormatFile:
 		return newFileKV(dir), nil
@@ -64,3 +64,31 @@ func TestNewDiskKV(t *testing.T) {
 		}
 	}
 }
+
+func TestOpenDiskKV(t *testing.T) {
+	for _, format := range types.SupportedDataFormats {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			dir := t.TempDir()
+			logger := testlog.Logger(t, log.LevelError)
+			hash := common.Hash{0xaa}
+			value := []byte{1, 2, 3, 4, 5, 6}
+			kv1, err := NewDiskKV(logger, dir, format)
+			require.NoError(t, err)
+			require.NoError(t, kv1.Put(hash, value))
+			require.NoError(t, kv1.Close())
+
+			kv2, err := OpenDiskKV(logger, dir)
+			require.NoError(t, err)
+			actual, err := kv2.Get(hash)
+			require.NoError(t, err)
+			require.Equal(t, value, actual)
+			require.NoError(t, kv2.Close())
+		})
+	}
+
+	t.Run("NotRecorded", func(t *testing.T) {
+		_, err := OpenDiskKV(testlog.Logger(t, log.LevelError), t.TempDir())
+		require.ErrorIs(t, err, ErrFormatUnavailable)
+	})
+}
@@ -23,3 +23,17 @@ type KV interface {
 	// Closes the KV store.
 	Close() error
 }
+
+// IterableKV is a KV store that can enumerate and delete its pre-images.
+type IterableKV interface {
+	KV
+
+	// ForEach calls fn with each pre-image in the store, in no particular order.
+	// Iteration stops at the first error returned by fn, which is then returned.
+	// fn must not modify the store.
+	ForEach(fn func(k common.Hash, v []byte) error) error
+
+	// Delete removes the pre-image with key k from the store.
+	// Deleting a key that is not in the store is not an error.
+	Delete(k common.Hash) error
+}
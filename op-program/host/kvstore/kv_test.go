@@ -1,6 +1,7 @@
 package kvstore
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -51,3 +52,45 @@ func kvTest(t *testing.T, kv KV) {
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
 	})
 }
+
+func iterableKVTest(t *testing.T, kv IterableKV) {
+	t.Run("iterate", func(t *testing.T) {
+		expected := map[common.Hash][]byte{
+			{0x01}:       []byte("one"),
+			{0x02, 0xff}: []byte("two"),
+			{0x03}:       {},
+		}
+		for k, v := range expected {
+			require.NoError(t, kv.Put(k, v))
+		}
+		actual := make(map[common.Hash][]byte)
+		require.NoError(t, kv.ForEach(func(k common.Hash, v []byte) error {
+			actual[k] = v
+			return nil
+		}))
+		require.Len(t, actual, len(expected))
+		for k, v := range expected {
+			require.Equal(t, v, actual[k])
+		}
+	})
+
+	t.Run("stop iteration on error", func(t *testing.T) {
+		require.NoError(t, kv.Put(common.Hash{0x04}, []byte("four")))
+		stop := errors.New("stop")
+		calls := 0
+		err := kv.ForEach(func(k common.Hash, v []byte) error {
+			calls++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, kv.Put(common.Hash{0x05}, []byte("five")))
+		require.NoError(t, kv.Delete(common.Hash{0x05}))
+		_, err := kv.Get(common.Hash{0x05})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.NoError(t, kv.Delete(common.Hash{0x05}), "deleting missing key")
+	})
+}
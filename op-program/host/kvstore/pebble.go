@@ -1,6 +1,7 @@
 package kvstore
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"runtime"
@@ -59,6 +60,35 @@ func (d *pebbleKV) Get(k common.Hash) ([]byte, error) {
 	return ret, nil
 }
 
+func (d *pebbleKV) ForEach(fn func(k common.Hash, v []byte) error) error {
+	d.RLock()
+	defer d.RUnlock()
+	iter, err := d.db.NewIter(nil)
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if len(iter.Key()) != common.HashLength {
+			continue
+		}
+		v, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read pre-image %x: %w", iter.Key(), err)
+		}
+		if err := fn(common.BytesToHash(iter.Key()), bytes.Clone(v)); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (d *pebbleKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	return d.db.Delete(k.Bytes(), pebble.NoSync)
+}
+
 func (d *pebbleKV) Close() error {
 	d.Lock()
 	defer d.Unlock()
@@ -66,4 +96,4 @@ func (d *pebbleKV) Close() error {
 	return d.db.Close()
 }
 
-var _ KV = (*pebbleKV)(nil)
+var _ IterableKV = (*pebbleKV)(nil)
@@ -26,3 +26,9 @@ func TestPebbleKV_CreateMissingDirectory(t *testing.T) {
 	key := crypto.Keccak256Hash(val)
 	require.NoError(t, kv.Put(key, val))
 }
+
+func TestPebbleKV_Iterable(t *testing.T) {
+	kv := newPebbleKV(t.TempDir())
+	defer kv.Close()
+	iterableKVTest(t, kv)
+}
@@ -0,0 +1,83 @@
+package kvstore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// KeyRecorder records the key of every pre-image successfully retrieved from a PreimageSource.
+// When wrapping the source used to serve the client program, the recorded keys form the minimal
+// set of pre-images required to re-execute the program offline.
+// KeyRecorder is safe for concurrent use.
+type KeyRecorder struct {
+	lock sync.Mutex
+	keys map[common.Hash]struct{}
+}
+
+func NewKeyRecorder() *KeyRecorder {
+	return &KeyRecorder{keys: make(map[common.Hash]struct{})}
+}
+
+// Wrap returns a PreimageSource that reads from source and records the keys it successfully returns.
+func (r *KeyRecorder) Wrap(source PreimageSource) PreimageSource {
+	return func(key common.Hash) ([]byte, error) {
+		v, err := source(key)
+		if err != nil {
+			return nil, err
+		}
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.keys[key] = struct{}{}
+		return v, nil
+	}
+}
+
+// Keys returns the recorded keys, sorted in ascending order.
+func (r *KeyRecorder) Keys() []common.Hash {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	keys := make([]common.Hash, 0, len(r.keys))
+	for k := range r.keys {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b common.Hash) int { return a.Cmp(b) })
+	return keys
+}
+
+// WriteKeys writes keys to w, one 0x-prefixed hex key per line.
+func WriteKeys(w io.Writer, keys []common.Hash) error {
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		if _, err := bw.WriteString(k.Hex() + "\n"); err != nil {
+			return fmt.Errorf("failed to write key %s: %w", k, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadKeys reads keys in the format written by WriteKeys. Blank lines are ignored.
+func ReadKeys(r io.Reader) ([]common.Hash, error) {
+	var keys []common.Hash
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var k common.Hash
+		if err := k.UnmarshalText([]byte(text)); err != nil {
+			return nil, fmt.Errorf("invalid key on line %d: %w", line, err)
+		}
+		keys = append(keys, k)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read keys: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,48 @@
+package kvstore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyRecorder(t *testing.T) {
+	kv := NewMemKV()
+	require.NoError(t, kv.Put(common.Hash{0x02}, []byte("two")))
+	require.NoError(t, kv.Put(common.Hash{0x01}, []byte("one")))
+	require.NoError(t, kv.Put(common.Hash{0x03}, []byte("unused")))
+	recorder := NewKeyRecorder()
+	source := recorder.Wrap(kv.Get)
+
+	for _, k := range []common.Hash{{0x02}, {0x01}, {0x02}} {
+		_, err := source(k)
+		require.NoError(t, err)
+	}
+	_, err := source(common.Hash{0x04})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Equal(t, []common.Hash{{0x01}, {0x02}}, recorder.Keys(), "should only record successful reads")
+}
+
+func TestWriteAndReadKeys(t *testing.T) {
+	keys := []common.Hash{{0x01}, {0xaa, 0xbb}, {}}
+	var buf bytes.Buffer
+	require.NoError(t, WriteKeys(&buf, keys))
+	actual, err := ReadKeys(&buf)
+	require.NoError(t, err)
+	require.Equal(t, keys, actual)
+
+	t.Run("IgnoreBlankLines", func(t *testing.T) {
+		actual, err := ReadKeys(strings.NewReader("\n" + common.Hash{0x01}.Hex() + "\n\n"))
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{{0x01}}, actual)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ReadKeys(strings.NewReader(common.Hash{0x01}.Hex() + "\n0x1234\n"))
+		require.ErrorContains(t, err, "line 2")
+	})
+}
@@ -0,0 +1,215 @@
+package subcmds
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PreimagesSourceFlag = &cli.StringSliceFlag{
+		Name:     "source",
+		Usage:    "Directory containing an existing pre-image store to read from",
+		Required: true,
+	}
+	PreimagesDestFlag = &cli.StringFlag{
+		Name:     "dest",
+		Usage:    "Directory to write pre-images to",
+		Required: true,
+	}
+	PreimagesDestFormatFlag = &cli.StringFlag{
+		Name:  "dest.format",
+		Usage: fmt.Sprintf("Format to use when creating the destination store. Ignored if the destination already has a recorded format. Available formats: %s", openum.EnumString(types.SupportedDataFormats)),
+		Value: string(types.DataFormatDirectory),
+	}
+	PreimagesDataDirFlag = &cli.StringFlag{
+		Name:     "datadir",
+		Usage:    "Directory containing the pre-image store to prune",
+		Required: true,
+	}
+	PreimagesKeysFlag = &cli.StringSliceFlag{
+		Name:     "keys",
+		Usage:    "File of pre-image keys as written by --data.record-keys. May be specified multiple times to combine key sets",
+		Required: true,
+	}
+	PreimagesDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Report the number of pre-images that would be deleted without deleting them",
+	}
+)
+
+var PreimagesCommand = &cli.Command{
+	Name:        "preimages",
+	Usage:       "Manage pre-image data stores",
+	Description: "Convert, merge, extract and prune on-disk pre-image data stores.",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "convert",
+			Usage:  "Copy all pre-images from a store into a new store, which may use a different format",
+			Action: ConvertPreimages,
+			Flags: []cli.Flag{
+				PreimagesSourceFlag,
+				PreimagesDestFlag,
+				PreimagesDestFormatFlag,
+			},
+		},
+		{
+			Name:   "merge",
+			Usage:  "Copy all pre-images from one or more stores into a new or existing store",
+			Action: MergePreimages,
+			Flags: []cli.Flag{
+				PreimagesSourceFlag,
+				PreimagesDestFlag,
+				PreimagesDestFormatFlag,
+			},
+		},
+		{
+			Name:   "extract",
+			Usage:  "Copy only the pre-images listed in the keys files into a new or existing store",
+			Action: ExtractPreimages,
+			Flags: []cli.Flag{
+				PreimagesSourceFlag,
+				PreimagesDestFlag,
+				PreimagesDestFormatFlag,
+				PreimagesKeysFlag,
+			},
+		},
+		{
+			Name:   "prune",
+			Usage:  "Delete all pre-images not listed in the keys files from a store",
+			Action: PrunePreimages,
+			Flags: []cli.Flag{
+				PreimagesDataDirFlag,
+				PreimagesKeysFlag,
+				PreimagesDryRunFlag,
+			},
+		},
+	},
+}
+
+func ConvertPreimages(ctx *cli.Context) error {
+	if len(ctx.StringSlice(PreimagesSourceFlag.Name)) != 1 {
+		return fmt.Errorf("exactly one --%s must be specified", PreimagesSourceFlag.Name)
+	}
+	dest := ctx.String(PreimagesDestFlag.Name)
+	if _, err := kvstore.DiskKVFormat(dest); err == nil {
+		return fmt.Errorf("destination %v already contains a pre-image store", dest)
+	} else if !errors.Is(err, kvstore.ErrFormatUnavailable) {
+		return fmt.Errorf("failed to check destination: %w", err)
+	}
+	return copyPreimages(ctx, nil)
+}
+
+func MergePreimages(ctx *cli.Context) error {
+	return copyPreimages(ctx, nil)
+}
+
+func ExtractPreimages(ctx *cli.Context) error {
+	keep, err := readKeySet(ctx.StringSlice(PreimagesKeysFlag.Name))
+	if err != nil {
+		return err
+	}
+	return copyPreimages(ctx, func(k common.Hash) bool {
+		_, ok := keep[k]
+		return ok
+	})
+}
+
+func PrunePreimages(ctx *cli.Context) error {
+	logger := newLogger(ctx)
+	keep, err := readKeySet(ctx.StringSlice(PreimagesKeysFlag.Name))
+	if err != nil {
+		return err
+	}
+	dir := ctx.String(PreimagesDataDirFlag.Name)
+	kv, err := kvstore.OpenDiskKV(logger, dir)
+	if err != nil {
+		return fmt.Errorf("failed to open pre-image store %v: %w", dir, err)
+	}
+	defer kv.Close()
+	dryRun := ctx.Bool(PreimagesDryRunFlag.Name)
+	count, err := kvstore.Prune(kv, func(k common.Hash) bool {
+		_, ok := keep[k]
+		return ok
+	}, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to prune pre-images after deleting %d: %w", count, err)
+	}
+	logger.Info("Pruned pre-images", "datadir", dir, "deleted", count, "keys", len(keep), "dryRun", dryRun)
+	return nil
+}
+
+// copyPreimages copies the pre-images accepted by include from every source store into the destination store.
+func copyPreimages(ctx *cli.Context, include func(k common.Hash) bool) error {
+	logger := newLogger(ctx)
+	format := types.DataFormat(ctx.String(PreimagesDestFormatFlag.Name))
+	if !slices.Contains(types.SupportedDataFormats, format) {
+		return fmt.Errorf("invalid destination format: %v", format)
+	}
+	dest := ctx.String(PreimagesDestFlag.Name)
+	sources := ctx.StringSlice(PreimagesSourceFlag.Name)
+	for _, source := range sources {
+		if filepath.Clean(source) == filepath.Clean(dest) {
+			return fmt.Errorf("source %v must not be the same as the destination", source)
+		}
+	}
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("failed to create destination: %w", err)
+	}
+	dst, err := kvstore.NewDiskKV(logger, dest, format)
+	if err != nil {
+		return fmt.Errorf("failed to open destination: %w", err)
+	}
+	defer dst.Close()
+	total := 0
+	for _, source := range sources {
+		src, err := kvstore.OpenDiskKV(logger, source)
+		if err != nil {
+			return fmt.Errorf("failed to open source %v: %w", source, err)
+		}
+		count, err := kvstore.Copy(dst, src, include)
+		_ = src.Close()
+		if err != nil {
+			return fmt.Errorf("failed to copy pre-images from %v: %w", source, err)
+		}
+		logger.Info("Copied pre-images", "source", source, "dest", dest, "count", count)
+		total += count
+	}
+	logger.Info("Pre-image copy complete", "dest", dest, "total", total)
+	return nil
+}
+
+func readKeySet(paths []string) (map[common.Hash]struct{}, error) {
+	keys := make(map[common.Hash]struct{})
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open keys file: %w", err)
+		}
+		fileKeys, err := kvstore.ReadKeys(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keys file %v: %w", path, err)
+		}
+		for _, k := range fileKeys {
+			keys[k] = struct{}{}
+		}
+	}
+	return keys, nil
+}
+
+func newLogger(ctx *cli.Context) log.Logger {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	oplog.SetGlobalLogHandler(logger.Handler())
+	return logger
+}
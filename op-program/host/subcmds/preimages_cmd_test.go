@@ -0,0 +1,119 @@
+package subcmds
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	keyA = common.Hash{0xaa}
+	keyB = common.Hash{0xbb}
+	keyC = common.Hash{0xcc}
+)
+
+func TestConvertPreimages(t *testing.T) {
+	src := createStore(t, types.DataFormatDirectory, map[common.Hash][]byte{keyA: {1}, keyB: {2}})
+	dest := filepath.Join(t.TempDir(), "dest")
+	require.NoError(t, runPreimages(t, "convert", "--source", src, "--dest", dest, "--dest.format", string(types.DataFormatPebble)))
+
+	format, err := kvstore.DiskKVFormat(dest)
+	require.NoError(t, err)
+	require.Equal(t, types.DataFormatPebble, format)
+	require.Equal(t, map[common.Hash][]byte{keyA: {1}, keyB: {2}}, readStore(t, dest))
+
+	err = runPreimages(t, "convert", "--source", src, "--dest", dest)
+	require.ErrorContains(t, err, "already contains a pre-image store")
+
+	err = runPreimages(t, "convert", "--source", src, "--source", src, "--dest", filepath.Join(t.TempDir(), "other"))
+	require.ErrorContains(t, err, "exactly one --source")
+}
+
+func TestMergePreimages(t *testing.T) {
+	srcA := createStore(t, types.DataFormatDirectory, map[common.Hash][]byte{keyA: {1}})
+	srcB := createStore(t, types.DataFormatFile, map[common.Hash][]byte{keyB: {2}})
+	dest := createStore(t, types.DataFormatPebble, map[common.Hash][]byte{keyC: {3}})
+	require.NoError(t, runPreimages(t, "merge", "--source", srcA, "--source", srcB, "--dest", dest))
+
+	format, err := kvstore.DiskKVFormat(dest)
+	require.NoError(t, err)
+	require.Equal(t, types.DataFormatPebble, format, "existing destination format is kept")
+	require.Equal(t, map[common.Hash][]byte{keyA: {1}, keyB: {2}, keyC: {3}}, readStore(t, dest))
+
+	err = runPreimages(t, "merge", "--source", srcA, "--dest", srcA)
+	require.ErrorContains(t, err, "must not be the same as the destination")
+}
+
+func TestExtractPreimages(t *testing.T) {
+	src := createStore(t, types.DataFormatDirectory, map[common.Hash][]byte{keyA: {1}, keyB: {2}, keyC: {3}})
+	keysA := writeKeys(t, keyA)
+	keysC := writeKeys(t, keyC)
+	dest := filepath.Join(t.TempDir(), "dest")
+	require.NoError(t, runPreimages(t, "extract", "--source", src, "--dest", dest, "--keys", keysA, "--keys", keysC))
+	require.Equal(t, map[common.Hash][]byte{keyA: {1}, keyC: {3}}, readStore(t, dest))
+}
+
+func TestPrunePreimages(t *testing.T) {
+	for _, format := range types.SupportedDataFormats {
+		t.Run(string(format), func(t *testing.T) {
+			dir := createStore(t, format, map[common.Hash][]byte{keyA: {1}, keyB: {2}, keyC: {3}})
+			keys := writeKeys(t, keyB)
+
+			require.NoError(t, runPreimages(t, "prune", "--datadir", dir, "--keys", keys, "--dry-run"))
+			require.Len(t, readStore(t, dir), 3, "dry run does not delete")
+
+			require.NoError(t, runPreimages(t, "prune", "--datadir", dir, "--keys", keys))
+			require.Equal(t, map[common.Hash][]byte{keyB: {2}}, readStore(t, dir))
+		})
+	}
+}
+
+func runPreimages(t *testing.T, args ...string) error {
+	app := cli.NewApp()
+	app.Writer = os.Stdout
+	app.Flags = oplog.CLIFlags("OP_PROGRAM")
+	app.Commands = []*cli.Command{PreimagesCommand}
+	return app.Run(append([]string{"op-program", "preimages"}, args...))
+}
+
+func createStore(t *testing.T, format types.DataFormat, preimages map[common.Hash][]byte) string {
+	dir := t.TempDir()
+	kv, err := kvstore.NewDiskKV(testlog.Logger(t, log.LevelInfo), dir, format)
+	require.NoError(t, err)
+	for k, v := range preimages {
+		require.NoError(t, kv.Put(k, v))
+	}
+	require.NoError(t, kv.Close())
+	return dir
+}
+
+func readStore(t *testing.T, dir string) map[common.Hash][]byte {
+	kv, err := kvstore.OpenDiskKV(testlog.Logger(t, log.LevelInfo), dir)
+	require.NoError(t, err)
+	defer kv.Close()
+	preimages := make(map[common.Hash][]byte)
+	require.NoError(t, kv.ForEach(func(k common.Hash, v []byte) error {
+		preimages[k] = common.CopyBytes(v)
+		return nil
+	}))
+	return preimages
+}
+
+func writeKeys(t *testing.T, keys ...common.Hash) string {
+	path := filepath.Join(t.TempDir(), "keys.txt")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, kvstore.WriteKeys(f, keys))
+	require.NoError(t, f.Close())
+	return path
+}
@@ -0,0 +1,45 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+const methodMessageNonce = "messageNonce"
+
+// nonceMask strips the message version from the upper two bytes of a versioned message nonce.
+var nonceMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 240), big.NewInt(1))
+
+// MessagePasser reads the L2ToL1MessagePasser predeploy on L2.
+// Mantle's message passer also records the ETH value of withdrawals, but shares the nonce layout with the OP Stack one.
+type MessagePasser struct {
+	caller         *batching.MultiCaller
+	contract       *batching.BoundContract
+	networkTimeout time.Duration
+}
+
+func NewMessagePasser(caller *batching.MultiCaller, networkTimeout time.Duration) *MessagePasser {
+	return &MessagePasser{
+		caller:         caller,
+		contract:       batching.NewBoundContract(snapshots.LoadL2ToL1MessagePasserABI(), predeploys.L2ToL1MessagePasserAddr),
+		networkTimeout: networkTimeout,
+	}
+}
+
+// WithdrawalCount returns the number of withdrawals initiated up to and including the given L2 block.
+func (m *MessagePasser) WithdrawalCount(ctx context.Context, l2BlockNum uint64) (uint64, error) {
+	cCtx, cancel := context.WithTimeout(ctx, m.networkTimeout)
+	defer cancel()
+	result, err := m.caller.SingleCall(cCtx, rpcblock.ByNumber(l2BlockNum), m.contract.Call(methodMessageNonce))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load message nonce at block %d: %w", l2BlockNum, err)
+	}
+	return new(big.Int).And(result.GetBigInt(0), nonceMask).Uint64(), nil
+}
@@ -0,0 +1,28 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawalCount(t *testing.T) {
+	stubRpc := batchingTest.NewAbiBasedRpc(t, predeploys.L2ToL1MessagePasserAddr, snapshots.LoadL2ToL1MessagePasserABI())
+	passer := NewMessagePasser(batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize), time.Minute)
+
+	// Version 1 in the upper two bytes, as encoded by the message passer
+	versioned := new(big.Int).Or(new(big.Int).Lsh(big.NewInt(1), 240), big.NewInt(42))
+	stubRpc.SetResponse(predeploys.L2ToL1MessagePasserAddr, methodMessageNonce, rpcblock.ByNumber(100), nil, []interface{}{versioned})
+
+	count, err := passer.WithdrawalCount(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), count)
+}
@@ -84,6 +84,58 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:    "l2-eth-rpc",
+		Usage:   "HTTP provider URL for the L2 execution engine. Used to measure the withdrawal demand for the proposal cadence.",
+		EnvVars: prefixEnvVars("L2_ETH_RPC"),
+	}
+	MaxCatchUpProposalsFlag = &cli.Uint64Flag{
+		Name: "max-catch-up-proposals",
+		Usage: "Maximum number of overdue L2OutputOracle outputs to propose in a single poll interval when the proposer is behind. " +
+			"The outputs are proposed in separate transactions, each sent once the previous one is confirmed.",
+		Value:   10,
+		EnvVars: prefixEnvVars("MAX_CATCH_UP_PROPOSALS"),
+	}
+	HighL1BaseFeeFlag = &cli.Uint64Flag{
+		Name: "high-l1-base-fee",
+		Usage: "L1 base fee in gwei above which proposals are deferred, for at most the max proposal delay. " +
+			"Overdue outputs are never deferred. 0 disables deferring proposals.",
+		EnvVars: prefixEnvVars("HIGH_L1_BASE_FEE"),
+	}
+	MaxProposalDelayFlag = &cli.DurationFlag{
+		Name:    "max-proposal-delay",
+		Usage:   "Maximum time a due proposal is deferred while the L1 base fee is high.",
+		Value:   30 * time.Minute,
+		EnvVars: prefixEnvVars("MAX_PROPOSAL_DELAY"),
+	}
+	WithdrawalThresholdFlag = &cli.Uint64Flag{
+		Name: "withdrawal-threshold",
+		Usage: "Number of withdrawals initiated since the last proposal at which proposals are no longer deferred, " +
+			"and the proposal interval is shortened to the min proposal interval. Requires the L2 execution engine RPC. 0 disables.",
+		EnvVars: prefixEnvVars("WITHDRAWAL_THRESHOLD"),
+	}
+	MinProposalIntervalFlag = &cli.DurationFlag{
+		Name:    "min-proposal-interval",
+		Usage:   "Interval between submitting L2 output proposals to the dispute game factory while the withdrawal threshold is reached.",
+		EnvVars: prefixEnvVars("MIN_PROPOSAL_INTERVAL"),
+	}
+	VerifierRollupRpcsFlag = &cli.StringSliceFlag{
+		Name: "verifier-rollup-rpcs",
+		Usage: "HTTP provider URLs for independent rollup nodes to verify each output against before proposing it. " +
+			"Proposals are refused if any node disagrees with the rollup rpc.",
+		EnvVars: prefixEnvVars("VERIFIER_ROLLUP_RPCS"),
+	}
+	OutputQuorumFlag = &cli.UintFlag{
+		Name: "output-quorum",
+		Usage: "Number of rollup nodes, including the rollup rpc, that have to return the same output before it is proposed. " +
+			"Defaults to all of the rollup rpc and verifier rollup rpcs.",
+		EnvVars: prefixEnvVars("OUTPUT_QUORUM"),
+	}
+	VerifyOutputRootsFlag = &cli.BoolFlag{
+		Name:    "verify-output-roots",
+		Usage:   "Recompute each output root from the L2 execution engine state before proposing it. Requires the L2 execution engine RPC.",
+		EnvVars: prefixEnvVars("VERIFY_OUTPUT_ROOTS"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -104,6 +156,15 @@ var optionalFlags = []cli.Flag{
 	DisputeGameTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	WaitNodeSyncFlag,
+	L2EthRpcFlag,
+	MaxCatchUpProposalsFlag,
+	HighL1BaseFeeFlag,
+	MaxProposalDelayFlag,
+	WithdrawalThresholdFlag,
+	MinProposalIntervalFlag,
+	VerifierRollupRpcsFlag,
+	OutputQuorumFlag,
+	VerifyOutputRootsFlag,
 }
 
 func init() {
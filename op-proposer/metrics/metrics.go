@@ -35,6 +35,8 @@ type Metricer interface {
 
 	RecordL2Proposal(sequenceNum uint64)
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+
+	RecordOutputDisagreement(sequenceNum uint64)
 }
 
 type Metrics struct {
@@ -48,6 +50,9 @@ type Metrics struct {
 
 	proposalSequenceNum prometheus.Gauge
 
+	outputDisagreements      prometheus.Counter
+	outputDisagreementSeqNum prometheus.Gauge
+
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 }
@@ -77,6 +82,16 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "proposed_sequence_number",
 			Help:      "Sequence number (block number or timestamp) of the latest proposal",
 		}),
+		outputDisagreements: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_disagreements_total",
+			Help:      "Number of times the output sources disagreed on a proposal, and the proposal was refused",
+		}),
+		outputDisagreementSeqNum: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_disagreement_sequence_number",
+			Help:      "Sequence number (block number or timestamp) of the latest proposal the output sources disagreed on",
+		}),
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "info",
@@ -124,6 +139,12 @@ func (m *Metrics) RecordL2Proposal(seqNum uint64) {
 	m.proposalSequenceNum.Set(float64(seqNum))
 }
 
+// RecordOutputDisagreement should be called when a proposal is refused because the output sources disagree on it
+func (m *Metrics) RecordOutputDisagreement(seqNum uint64) {
+	m.outputDisagreements.Inc()
+	m.outputDisagreementSeqNum.Set(float64(seqNum))
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -20,13 +20,18 @@ func TestMetrics(test *testing.T) {
 	metrics.RecordL2Proposal(uint64(expectedSequenceNumber))
 	metrics.RecordInfo(infoLabel)
 	metrics.RecordUp()
+	metrics.RecordOutputDisagreement(uint64(expectedSequenceNumber))
 
 	checker := opmetrics.NewMetricChecker(test, metrics.Registry())
 	sequenceNumberMetric := checker.FindByName(prefix + "proposed_sequence_number").FindByLabels(nil).Gauge.GetValue()
 	infoMetric := checker.FindByName(prefix + "info").FindByLabels(map[string]string{"version": infoLabel}).Gauge.GetValue()
 	upMetric := checker.FindByName(prefix + "up").FindByLabels(nil).Gauge.GetValue()
+	disagreementsMetric := checker.FindByName(prefix + "output_disagreements_total").FindByLabels(nil).Counter.GetValue()
+	disagreementSeqNumMetric := checker.FindByName(prefix + "output_disagreement_sequence_number").FindByLabels(nil).Gauge.GetValue()
 
 	require.Equal(test, expectedSequenceNumber, sequenceNumberMetric)
 	require.Equal(test, expectedInfo, infoMetric)
 	require.Equal(test, expectedUp, upMetric)
+	require.Equal(test, 1.0, disagreementsMetric)
+	require.Equal(test, expectedSequenceNumber, disagreementSeqNumMetric)
 }
@@ -27,6 +27,8 @@ func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
 
 func (m *noopMetrics) RecordL2Proposal(_ uint64) {}
 
+func (*noopMetrics) RecordOutputDisagreement(_ uint64) {}
+
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
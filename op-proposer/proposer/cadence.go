@@ -0,0 +1,190 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+)
+
+// WithdrawalCounter counts the withdrawals initiated on L2, to measure the demand for new proposals.
+type WithdrawalCounter interface {
+	// WithdrawalCount returns the number of withdrawals initiated up to and including the given L2 block.
+	WithdrawalCount(ctx context.Context, l2BlockNum uint64) (uint64, error)
+}
+
+// cadence adapts when proposals are made to the L1 fee level and the withdrawal demand on L2,
+// and tracks the resulting schedule.
+// Proposals are deferred while the L1 base fee is high, unless withdrawals are waiting for a proposal,
+// the output is overdue, or the proposal has been deferred for the maximum delay since it became due.
+type cadence struct {
+	log         log.Logger
+	cfg         ProposerConfig
+	l1          L1Client
+	withdrawals WithdrawalCounter
+	now         func() time.Time
+
+	mu       sync.Mutex
+	schedule apis.ProposalSchedule
+}
+
+func newCadence(log log.Logger, cfg ProposerConfig, l1 L1Client, withdrawals WithdrawalCounter) *cadence {
+	return &cadence{
+		log:         log,
+		cfg:         cfg,
+		l1:          l1,
+		withdrawals: withdrawals,
+		now:         time.Now,
+		schedule:    apis.ProposalSchedule{ProposalInterval: cfg.ProposalInterval},
+	}
+}
+
+// Schedule returns a snapshot of the current proposal schedule.
+func (c *cadence) Schedule() apis.ProposalSchedule {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.schedule
+}
+
+// observe records the next block to propose, the latest proposable block and the number of overdue outputs.
+func (c *cadence) observe(next uint64, current uint64, behind uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schedule.NextBlockNumber = next
+	c.schedule.CurrentBlockNumber = current
+	c.schedule.OutputsBehind = behind
+}
+
+// onProposed records a successful proposal of the output at the given L2 block.
+func (c *cadence) onProposed(l2BlockNum uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schedule.LastProposalBlock = l2BlockNum
+	c.schedule.LastProposalTime = c.now()
+	if c.schedule.OutputsBehind > 0 {
+		c.schedule.OutputsBehind--
+	}
+	c.clearDeferral()
+}
+
+func (c *cadence) clearDeferral() {
+	c.schedule.DeferredSince = time.Time{}
+	c.schedule.DeferReason = ""
+}
+
+// highL1Fee reports whether the current L1 base fee is above the configured threshold.
+// If the base fee cannot be determined, it is not considered high, so proposals are not held back.
+func (c *cadence) highL1Fee(ctx context.Context) bool {
+	if c.cfg.HighL1BaseFee == nil {
+		return false
+	}
+	cCtx, cancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+	defer cancel()
+	head, err := c.l1.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		c.log.Warn("Failed to fetch L1 head to check base fee", "err", err)
+		return false
+	}
+	if head.BaseFee == nil {
+		return false
+	}
+	c.mu.Lock()
+	c.schedule.L1BaseFee = (*hexutil.Big)(new(big.Int).Set(head.BaseFee))
+	c.mu.Unlock()
+	return head.BaseFee.Cmp(c.cfg.HighL1BaseFee) > 0
+}
+
+// withdrawalDemand reports whether enough withdrawals were initiated between the last proposed block
+// and the given L2 block to warrant a proposal.
+func (c *cadence) withdrawalDemand(ctx context.Context, lastProposed uint64, current uint64) bool {
+	if c.withdrawals == nil || c.cfg.WithdrawalThreshold == 0 || current <= lastProposed {
+		return false
+	}
+	pending, err := c.pendingWithdrawals(ctx, lastProposed, current)
+	if err != nil {
+		c.log.Warn("Failed to count pending withdrawals", "err", err)
+		return false
+	}
+	c.mu.Lock()
+	c.schedule.PendingWithdrawals = pending
+	c.mu.Unlock()
+	return pending >= c.cfg.WithdrawalThreshold
+}
+
+func (c *cadence) pendingWithdrawals(ctx context.Context, lastProposed uint64, current uint64) (uint64, error) {
+	before, err := c.withdrawals.WithdrawalCount(ctx, lastProposed)
+	if err != nil {
+		return 0, fmt.Errorf("at last proposed block %d: %w", lastProposed, err)
+	}
+	after, err := c.withdrawals.WithdrawalCount(ctx, current)
+	if err != nil {
+		return 0, fmt.Errorf("at current block %d: %w", current, err)
+	}
+	if after < before {
+		return 0, nil
+	}
+	return after - before, nil
+}
+
+// shouldDefer reports whether the proposal of the output at l2BlockNum should be deferred.
+// lastProposed is the L2 block of the previous proposal, used to measure the withdrawal demand.
+// The delay is measured from the first deferral, when the proposal became due, until the next proposal is made.
+// It is not restarted when the proposed block changes, as it does on every poll for the DisputeGameFactory.
+func (c *cadence) shouldDefer(ctx context.Context, l2BlockNum uint64, lastProposed uint64) bool {
+	if c.cfg.HighL1BaseFee == nil {
+		return false
+	}
+	c.mu.Lock()
+	behind := c.schedule.OutputsBehind
+	c.mu.Unlock()
+	// Overdue outputs are never deferred: the proposer has to catch up first.
+	// Without a known previous proposal, e.g. after a restart, there is no baseline for the withdrawal demand.
+	if behind > 1 || !c.highL1Fee(ctx) || (lastProposed != 0 && c.withdrawalDemand(ctx, lastProposed, l2BlockNum)) {
+		c.mu.Lock()
+		c.clearDeferral()
+		c.mu.Unlock()
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if c.schedule.DeferredSince.IsZero() {
+		c.schedule.DeferredSince = now
+		c.schedule.DeferReason = "L1 base fee above threshold"
+	}
+	if now.Sub(c.schedule.DeferredSince) >= c.cfg.MaxProposalDelay {
+		c.log.Info("Proposing despite high L1 base fee, maximum proposal delay reached", "l2_block", l2BlockNum, "delay", c.cfg.MaxProposalDelay)
+		c.clearDeferral()
+		return false
+	}
+	c.log.Debug("Deferring proposal, L1 base fee above threshold", "l2_block", l2BlockNum, "threshold", c.cfg.HighL1BaseFee, "deferred_since", c.schedule.DeferredSince)
+	return true
+}
+
+// proposalInterval returns the interval between proposals to the DisputeGameFactory.
+// The interval is shortened to MinProposalInterval when withdrawals are waiting for a proposal.
+// It is not stretched while the L1 base fee is high: the proposal is deferred by shouldDefer instead,
+// so the total delay is bounded by MaxProposalDelay.
+func (c *cadence) proposalInterval(ctx context.Context) time.Duration {
+	c.mu.Lock()
+	lastProposed, current := c.schedule.LastProposalBlock, c.schedule.CurrentBlockNumber
+	c.mu.Unlock()
+
+	interval := c.cfg.ProposalInterval
+	// Without a known previous proposal there is no baseline to measure the withdrawal demand against.
+	if c.cfg.MinProposalInterval != 0 && lastProposed != 0 && c.withdrawalDemand(ctx, lastProposed, current) {
+		interval = c.cfg.MinProposalInterval
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schedule.ProposalInterval = interval
+	return interval
+}
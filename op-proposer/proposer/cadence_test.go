@@ -0,0 +1,194 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubL1Client struct {
+	baseFee *big.Int
+	err     error
+}
+
+func (s *stubL1Client) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &types.Header{BaseFee: s.baseFee}, nil
+}
+
+func (s *stubL1Client) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	panic("not implemented")
+}
+
+func (s *stubL1Client) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	panic("not implemented")
+}
+
+// stubWithdrawalCounter reports count withdrawals per L2 block
+type stubWithdrawalCounter struct {
+	perBlock uint64
+	err      error
+}
+
+func (s *stubWithdrawalCounter) WithdrawalCount(_ context.Context, l2BlockNum uint64) (uint64, error) {
+	return l2BlockNum * s.perBlock, s.err
+}
+
+func setupCadence(t *testing.T, baseFee int64, perBlock uint64) (*cadence, *time.Time) {
+	cfg := ProposerConfig{
+		NetworkTimeout:      time.Second,
+		ProposalInterval:    time.Hour,
+		HighL1BaseFee:       big.NewInt(100),
+		MaxProposalDelay:    30 * time.Minute,
+		WithdrawalThreshold: 50,
+		MinProposalInterval: 10 * time.Minute,
+	}
+	c := newCadence(testlog.Logger(t, log.LevelDebug), cfg, &stubL1Client{baseFee: big.NewInt(baseFee)}, &stubWithdrawalCounter{perBlock: perBlock})
+	now := time.Unix(1000, 0)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCadence_DeferOnHighL1Fee(t *testing.T) {
+	ctx := context.Background()
+	c, now := setupCadence(t, 200, 0)
+	c.observe(110, 110, 1)
+
+	require.True(t, c.shouldDefer(ctx, 110, 100))
+	schedule := c.Schedule()
+	require.Equal(t, time.Unix(1000, 0), schedule.DeferredSince)
+	require.NotEmpty(t, schedule.DeferReason)
+	require.Equal(t, int64(200), schedule.L1BaseFee.ToInt().Int64())
+
+	*now = now.Add(29 * time.Minute)
+	require.True(t, c.shouldDefer(ctx, 110, 100))
+	require.Equal(t, time.Unix(1000, 0), c.Schedule().DeferredSince, "deferral start should be kept for the same block")
+
+	*now = now.Add(time.Minute)
+	require.False(t, c.shouldDefer(ctx, 110, 100), "should propose once the max delay is reached")
+	require.Zero(t, c.Schedule().DeferredSince)
+}
+
+func TestCadence_DeferralNotRestartedByNewBlocks(t *testing.T) {
+	ctx := context.Background()
+	c, now := setupCadence(t, 200, 0)
+	// The DisputeGameFactory proposes the latest block, which advances on every poll.
+	c.observe(110, 110, 1)
+	require.True(t, c.shouldDefer(ctx, 110, 100))
+
+	*now = now.Add(29 * time.Minute)
+	c.observe(150, 150, 1)
+	require.True(t, c.shouldDefer(ctx, 150, 100))
+	require.Equal(t, time.Unix(1000, 0), c.Schedule().DeferredSince)
+
+	*now = now.Add(time.Minute)
+	c.observe(160, 160, 1)
+	require.False(t, c.shouldDefer(ctx, 160, 100), "the max delay counts from the first deferral")
+
+	// A new deferral starts after the proposal.
+	c.onProposed(160)
+	require.True(t, c.shouldDefer(ctx, 170, 160))
+	require.Equal(t, *now, c.Schedule().DeferredSince)
+}
+
+func TestCadence_DeferAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	c, _ := setupCadence(t, 200, 5)
+	// After a restart the last proposal to the DisputeGameFactory is unknown,
+	// so the withdrawals since genesis must not count as demand.
+	c.observe(110, 110, 1)
+	require.True(t, c.shouldDefer(ctx, 110, 0))
+	require.Zero(t, c.Schedule().PendingWithdrawals)
+
+	c.onProposed(110)
+	c.observe(120, 120, 1)
+	require.False(t, c.shouldDefer(ctx, 120, 110), "withdrawals since the last proposal count once it is known")
+}
+
+func TestCadence_NoDeferral(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("LowL1Fee", func(t *testing.T) {
+		c, _ := setupCadence(t, 100, 0)
+		c.observe(110, 110, 1)
+		require.False(t, c.shouldDefer(ctx, 110, 100))
+	})
+
+	t.Run("Behind", func(t *testing.T) {
+		c, _ := setupCadence(t, 200, 0)
+		c.observe(110, 130, 3)
+		require.False(t, c.shouldDefer(ctx, 110, 100))
+	})
+
+	t.Run("WithdrawalDemand", func(t *testing.T) {
+		c, _ := setupCadence(t, 200, 5)
+		c.observe(110, 110, 1)
+		require.False(t, c.shouldDefer(ctx, 110, 100))
+		require.Equal(t, uint64(50), c.Schedule().PendingWithdrawals)
+	})
+
+	t.Run("L1HeadUnavailable", func(t *testing.T) {
+		c, _ := setupCadence(t, 200, 0)
+		c.l1 = &stubL1Client{err: errors.New("boom")}
+		c.observe(110, 110, 1)
+		require.False(t, c.shouldDefer(ctx, 110, 100))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c, _ := setupCadence(t, 200, 0)
+		c.cfg.HighL1BaseFee = nil
+		c.observe(110, 110, 1)
+		require.False(t, c.shouldDefer(ctx, 110, 100))
+	})
+}
+
+func TestCadence_ProposalInterval(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Default", func(t *testing.T) {
+		c, _ := setupCadence(t, 100, 0)
+		require.Equal(t, time.Hour, c.proposalInterval(ctx))
+	})
+
+	t.Run("HighL1Fee", func(t *testing.T) {
+		// The high fee defers the proposal once it is due, the interval itself is not stretched.
+		c, _ := setupCadence(t, 200, 0)
+		require.Equal(t, time.Hour, c.proposalInterval(ctx))
+		require.Equal(t, time.Hour, c.Schedule().ProposalInterval)
+	})
+
+	t.Run("WithdrawalDemand", func(t *testing.T) {
+		c, _ := setupCadence(t, 200, 5)
+		// without a previous proposal there is no baseline for the withdrawal demand
+		c.observe(100, 100, 1)
+		require.Equal(t, time.Hour, c.proposalInterval(ctx))
+
+		c.onProposed(100)
+		c.observe(109, 109, 1)
+		require.Equal(t, time.Hour, c.proposalInterval(ctx), "45 withdrawals are below the threshold")
+		c.observe(110, 110, 1)
+		require.Equal(t, 10*time.Minute, c.proposalInterval(ctx))
+	})
+}
+
+func TestCadence_OnProposed(t *testing.T) {
+	c, _ := setupCadence(t, 200, 0)
+	c.observe(110, 130, 3)
+	c.onProposed(110)
+	schedule := c.Schedule()
+	require.Equal(t, uint64(110), schedule.LastProposalBlock)
+	require.Equal(t, time.Unix(1000, 0), schedule.LastProposalTime)
+	require.Equal(t, uint64(2), schedule.OutputsBehind)
+}
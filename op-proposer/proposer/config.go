@@ -2,6 +2,7 @@ package proposer
 
 import (
 	"errors"
+	"fmt"
 	"slices"
 	"time"
 
@@ -19,6 +20,7 @@ var (
 	ErrMissingRollupRpc     = errors.New("missing rollup rpc")
 	ErrMissingSupervisorRpc = errors.New("missing supervisor rpc")
 	ErrConflictingSource    = errors.New("must not specify both a rollup rpc and supervisor rpc")
+	ErrMissingL2EthRpc      = errors.New("missing L2 eth rpc, required by the withdrawal threshold and output root verification")
 
 	// preInteropGameTypes are  game types that enforce having a rollup rpc.
 	// It is ok if this list isn't complete, unknown game types will allow either rollup or supervisor
@@ -80,6 +82,33 @@ type CLIConfig struct {
 
 	// Whether to wait for the sequencer to sync to a recent block at startup.
 	WaitNodeSync bool
+
+	// L2EthRpc is the HTTP provider URL for the L2 execution engine, used to measure the withdrawal demand.
+	L2EthRpc string
+
+	// MaxCatchUpProposals is the maximum number of overdue outputs to propose in a single poll interval when the proposer is behind.
+	MaxCatchUpProposals uint64
+
+	// HighL1BaseFeeGwei is the L1 base fee above which proposals are deferred. 0 disables deferring proposals.
+	HighL1BaseFeeGwei uint64
+
+	// MaxProposalDelay is the maximum time a proposal is deferred while the L1 base fee is high.
+	MaxProposalDelay time.Duration
+
+	// WithdrawalThreshold is the number of withdrawals since the last proposal at which proposals are no longer deferred.
+	WithdrawalThreshold uint64
+
+	// MinProposalInterval is the interval between DisputeGameFactory proposals while the withdrawal threshold is reached.
+	MinProposalInterval time.Duration
+
+	// VerifierRollupRpcs is the list of HTTP provider URLs for independent rollup nodes to verify outputs against.
+	VerifierRollupRpcs []string
+
+	// OutputQuorum is the number of rollup nodes that have to agree on an output. 0 requires all of them.
+	OutputQuorum uint
+
+	// VerifyOutputRoots enables recomputing output roots from the L2 execution engine state before proposing them.
+	VerifyOutputRoots bool
 }
 
 func (c *CLIConfig) Check() error {
@@ -123,6 +152,32 @@ func (c *CLIConfig) Check() error {
 	if c.DGFAddress != "" && slices.Contains(postInteropGameTypes, c.DisputeGameType) && len(c.SupervisorRpcs) == 0 {
 		return ErrMissingSupervisorRpc
 	}
+	if c.WithdrawalThreshold != 0 && c.L2EthRpc == "" {
+		return ErrMissingL2EthRpc
+	}
+	if c.VerifyOutputRoots && c.L2EthRpc == "" {
+		return ErrMissingL2EthRpc
+	}
+	if (len(c.VerifierRollupRpcs) != 0 || c.VerifyOutputRoots) && c.RollupRpc == "" {
+		return errors.New("output verification is only supported with a rollup rpc")
+	}
+	if c.OutputQuorum > uint(len(c.VerifierRollupRpcs))+1 {
+		return fmt.Errorf("output quorum %d exceeds the number of rollup nodes %d", c.OutputQuorum, len(c.VerifierRollupRpcs)+1)
+	}
+	if c.HighL1BaseFeeGwei != 0 && c.MaxProposalDelay == 0 {
+		return errors.New("the high L1 base fee was provided but the max proposal delay was not set")
+	}
+	if c.MinProposalInterval != 0 {
+		if c.DGFAddress == "" {
+			return errors.New("the min proposal interval was provided but the `DisputeGameFactory` address was not set")
+		}
+		if c.WithdrawalThreshold == 0 {
+			return errors.New("the min proposal interval was provided but the withdrawal threshold was not set")
+		}
+		if c.MinProposalInterval > c.ProposalInterval {
+			return errors.New("the min proposal interval must not exceed the proposal interval")
+		}
+	}
 
 	return nil
 }
@@ -146,5 +201,14 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		DisputeGameType:              uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
+		L2EthRpc:                     ctx.String(flags.L2EthRpcFlag.Name),
+		MaxCatchUpProposals:          ctx.Uint64(flags.MaxCatchUpProposalsFlag.Name),
+		HighL1BaseFeeGwei:            ctx.Uint64(flags.HighL1BaseFeeFlag.Name),
+		MaxProposalDelay:             ctx.Duration(flags.MaxProposalDelayFlag.Name),
+		WithdrawalThreshold:          ctx.Uint64(flags.WithdrawalThresholdFlag.Name),
+		MinProposalInterval:          ctx.Duration(flags.MinProposalIntervalFlag.Name),
+		VerifierRollupRpcs:           ctx.StringSlice(flags.VerifierRollupRpcsFlag.Name),
+		OutputQuorum:                 ctx.Uint(flags.OutputQuorumFlag.Name),
+		VerifyOutputRoots:            ctx.Bool(flags.VerifyOutputRootsFlag.Name),
 	}
 }
@@ -94,6 +94,88 @@ func TestDisallowRollupAndSupervisorRPC(t *testing.T) {
 	require.ErrorIs(t, cfg.Check(), ErrConflictingSource)
 }
 
+func TestProposalCadence(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2EthRpc = "http://localhost:8888/l2-eth"
+		cfg.HighL1BaseFeeGwei = 50
+		cfg.MaxProposalDelay = 100
+		cfg.WithdrawalThreshold = 10
+		cfg.MinProposalInterval = 20
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("WithdrawalThresholdRequiresL2EthRpc", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.WithdrawalThreshold = 10
+		require.ErrorIs(t, cfg.Check(), ErrMissingL2EthRpc)
+	})
+
+	t.Run("HighL1BaseFeeRequiresDelay", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.HighL1BaseFeeGwei = 50
+		require.ErrorContains(t, cfg.Check(), "max proposal delay")
+	})
+
+	t.Run("MinProposalIntervalRequiresDGF", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DGFAddress = ""
+		cfg.L2OOAddress = common.Address{0xaa}.Hex()
+		cfg.ProposalInterval = 0
+		cfg.L2EthRpc = "http://localhost:8888/l2-eth"
+		cfg.WithdrawalThreshold = 10
+		cfg.MinProposalInterval = 20
+		require.ErrorContains(t, cfg.Check(), "DisputeGameFactory")
+	})
+
+	t.Run("MinProposalIntervalRequiresWithdrawalThreshold", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.MinProposalInterval = 20
+		require.ErrorContains(t, cfg.Check(), "withdrawal threshold")
+	})
+
+	t.Run("MinProposalIntervalExceedsProposalInterval", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2EthRpc = "http://localhost:8888/l2-eth"
+		cfg.WithdrawalThreshold = 10
+		cfg.MinProposalInterval = 60
+		require.ErrorContains(t, cfg.Check(), "must not exceed")
+	})
+}
+
+func TestOutputVerification(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.VerifierRollupRpcs = []string{"http://localhost:8888/verifier1", "http://localhost:8888/verifier2"}
+		cfg.OutputQuorum = 2
+		cfg.L2EthRpc = "http://localhost:8888/l2-eth"
+		cfg.VerifyOutputRoots = true
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("QuorumExceedsNodes", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.VerifierRollupRpcs = []string{"http://localhost:8888/verifier1"}
+		cfg.OutputQuorum = 3
+		require.ErrorContains(t, cfg.Check(), "exceeds the number of rollup nodes")
+	})
+
+	t.Run("VerifyOutputRootsRequiresL2EthRpc", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.VerifyOutputRoots = true
+		require.ErrorIs(t, cfg.Check(), ErrMissingL2EthRpc)
+	})
+
+	t.Run("NotSupportedWithSupervisor", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.RollupRpc = ""
+		cfg.SupervisorRpcs = []string{"http://localhost:8882/supervisor"}
+		cfg.DisputeGameType = 492743
+		cfg.VerifierRollupRpcs = []string{"http://localhost:8888/verifier1"}
+		require.ErrorContains(t, cfg.Check(), "only supported with a rollup rpc")
+	})
+}
+
 func validConfig() *CLIConfig {
 	return &CLIConfig{
 		L1EthRpc:                     "http://localhost:8888/l1",
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-proposer/contracts"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/source"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
@@ -43,6 +44,7 @@ type L1Client interface {
 type L2OOContract interface {
 	Version(*bind.CallOpts) (string, error)
 	NextBlockNumber(*bind.CallOpts) (*big.Int, error)
+	SubmissionInterval(*bind.CallOpts) (*big.Int, error)
 }
 
 type DGFContract interface {
@@ -66,6 +68,10 @@ type DriverSetup struct {
 
 	// ProposalSource retrieves the proposal data to submit
 	ProposalSource source.ProposalSource
+
+	// Withdrawals counts the withdrawals initiated on L2, to adapt the proposal cadence to the withdrawal demand.
+	// May be nil, in which case the withdrawal demand is not taken into account.
+	Withdrawals WithdrawalCounter
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -82,8 +88,12 @@ type L2OutputSubmitter struct {
 
 	l2ooContract L2OOContract
 	l2ooABI      *abi.ABI
+	// submissionInterval is the number of L2 blocks between outputs in the L2OutputOracle
+	submissionInterval uint64
 
 	dgfContract DGFContract
+
+	cadence *cadence
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
@@ -123,6 +133,12 @@ func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup Driv
 	}
 	log.Info("Connected to L2OutputOracle", "address", setup.Cfg.L2OutputOracleAddr, "version", version)
 
+	submissionInterval, err := l2ooContract.SubmissionInterval(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to query submission interval: %w", err)
+	}
+
 	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
 	if err != nil {
 		cancel()
@@ -135,8 +151,11 @@ func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup Driv
 		ctx:         ctx,
 		cancel:      cancel,
 
-		l2ooContract: l2ooContract,
-		l2ooABI:      parsed,
+		l2ooContract:       l2ooContract,
+		l2ooABI:            parsed,
+		submissionInterval: submissionInterval.Uint64(),
+
+		cadence: newCadence(setup.Log, setup.Cfg, setup.L1Client, setup.Withdrawals),
 	}, nil
 }
 
@@ -157,6 +176,8 @@ func newDGFSubmitter(ctx context.Context, cancel context.CancelFunc, setup Drive
 		cancel:      cancel,
 
 		dgfContract: dgfCaller,
+
+		cadence: newCadence(setup.Log, setup.Cfg, setup.L1Client, setup.Withdrawals),
 	}, nil
 }
 
@@ -231,6 +252,8 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (source.Proposa
 		return source.Proposal{}, false, err
 	}
 
+	l.cadence.observe(nextCheckpointBlock, currentBlockNumber, l.outputsBehind(nextCheckpointBlock, currentBlockNumber))
+
 	// Ensure that we do not submit a block in the future
 	if currentBlockNumber < nextCheckpointBlock {
 		l.Log.Debug("Proposer submission interval has not elapsed", "currentBlockNumber", currentBlockNumber, "nextBlockNumber", nextCheckpointBlock)
@@ -254,13 +277,25 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (source.Proposa
 	return output, true, nil
 }
 
+// outputsBehind returns the number of L2OutputOracle outputs, starting at the next output,
+// that can already be proposed given the current L2 block.
+func (l *L2OutputSubmitter) outputsBehind(next uint64, current uint64) uint64 {
+	if current < next {
+		return 0
+	}
+	if l.submissionInterval == 0 {
+		return 1
+	}
+	return (current-next)/l.submissionInterval + 1
+}
+
 // FetchDGFOutput queries the DGF for the latest game and infers whether it is time to make another proposal
 // If necessary, it gets the next output proposal for the DGF, and returns it along with
 // a boolean for whether the proposal should be submitted at all.
 // The passed context is expected to be a lifecycle context. A network timeout
 // context will be derived from it.
 func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (source.Proposal, bool, error) {
-	cutoff := time.Now().Add(-l.Cfg.ProposalInterval)
+	cutoff := time.Now().Add(-l.cadence.proposalInterval(ctx))
 	proposedRecently, proposalTime, claim, err := l.dgfContract.HasProposedSince(ctx, l.Txmgr.From(), cutoff, l.Cfg.DisputeGameType)
 	if err != nil {
 		return source.Proposal{}, false, fmt.Errorf("could not check for recent proposal: %w", err)
@@ -277,6 +312,8 @@ func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (source.Proposal
 		return source.Proposal{}, false, fmt.Errorf("could not fetch current block number: %w", err)
 	}
 
+	l.cadence.observe(currentBlockNumber, currentBlockNumber, 1)
+
 	if currentBlockNumber == 0 {
 		l.Log.Info("Skipping proposal for genesis block")
 		return source.Proposal{}, false, nil
@@ -292,7 +329,7 @@ func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (source.Proposal
 		return source.Proposal{}, false, nil
 	}
 
-	l.Log.Info("No proposals found for at least proposal interval, submitting proposal now", "proposalInterval", l.Cfg.ProposalInterval)
+	l.Log.Info("No proposals found for at least proposal interval, submitting proposal now", "proposalInterval", l.cadence.Schedule().ProposalInterval)
 
 	return output, true, nil
 }
@@ -433,31 +470,80 @@ func (l *L2OutputSubmitter) loop() {
 			default:
 			}
 
-			// A note on retrying: the outer ticker already runs on a short
-			// poll interval, which has a default value of 6 seconds. So no
-			// retry logic is needed around proposal fetching here.
-			var proposal source.Proposal
-			var shouldPropose bool
-			var err error
-			if l.dgfContract == nil {
-				proposal, shouldPropose, err = l.FetchL2OOOutput(ctx)
-			} else {
-				proposal, shouldPropose, err = l.FetchDGFOutput(ctx)
+			l.proposeNext(ctx)
+		case <-l.done:
+			return
+		}
+	}
+
+}
+
+// proposeNext fetches the next output and proposes it, if it is time to do so.
+// When the proposer is behind on the L2OutputOracle, up to MaxCatchUpProposals overdue outputs
+// are proposed one after another, rather than one per poll interval. The proposals are not batched:
+// each is sent once the previous one is confirmed, as the L2OutputOracle only accepts the next output.
+func (l *L2OutputSubmitter) proposeNext(ctx context.Context) {
+	maxProposals := max(l.Cfg.MaxCatchUpProposals, 1)
+	var prev source.Proposal
+	for i := uint64(0); i < maxProposals; i++ {
+		if i > 0 {
+			// prioritize quit signal between catch-up proposals
+			select {
+			case <-l.done:
+				return
+			default:
 			}
-			if err != nil {
-				l.Log.Warn("Error getting proposal", "err", err)
-				continue
-			} else if !shouldPropose {
-				// debug logging already in Fetch(DGF|L2OO)Output
-				continue
+		}
+
+		// A note on retrying: the outer ticker already runs on a short
+		// poll interval, which has a default value of 6 seconds. So no
+		// retry logic is needed around proposal fetching here.
+		var proposal source.Proposal
+		var shouldPropose bool
+		var err error
+		var lastProposed uint64
+		if l.dgfContract == nil {
+			proposal, shouldPropose, err = l.FetchL2OOOutput(ctx)
+			if proposal.SequenceNum >= l.submissionInterval {
+				lastProposed = proposal.SequenceNum - l.submissionInterval
 			}
+		} else {
+			proposal, shouldPropose, err = l.FetchDGFOutput(ctx)
+			lastProposed = l.cadence.Schedule().LastProposalBlock
+		}
+		if err != nil {
+			l.Log.Warn("Error getting proposal", "err", err)
+			return
+		} else if !shouldPropose {
+			// debug logging already in Fetch(DGF|L2OO)Output
+			return
+		}
+		if i > 0 && proposal.SequenceNum == prev.SequenceNum {
+			// The previous proposal did not advance the L2OutputOracle, e.g. because it reverted.
+			// Leave retrying to the next poll interval.
+			return
+		}
+		prev = proposal
+		if l.cadence.shouldDefer(ctx, proposal.SequenceNum, lastProposed) {
+			return
+		}
 
-			l.proposeOutput(ctx, proposal)
-		case <-l.done:
+		if !l.proposeOutput(ctx, proposal) {
+			return
+		}
+		// Only the latest output is proposed to the DisputeGameFactory, there are no overdue outputs.
+		if l.dgfContract != nil {
 			return
 		}
+		if behind := l.cadence.Schedule().OutputsBehind; behind > 0 {
+			l.Log.Info("Proposer is behind, proposing next output", "outputs_behind", behind)
+		}
 	}
+}
 
+// ProposalSchedule returns the current proposal schedule.
+func (l *L2OutputSubmitter) ProposalSchedule() apis.ProposalSchedule {
+	return l.cadence.Schedule()
 }
 
 func (l *L2OutputSubmitter) waitNodeSync() error {
@@ -478,7 +564,8 @@ func (l *L2OutputSubmitter) waitNodeSync() error {
 	})
 }
 
-func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output source.Proposal) {
+// proposeOutput sends the proposal transaction, and returns whether it was successfully published.
+func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output source.Proposal) bool {
 	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
@@ -493,11 +580,13 @@ func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output source.Pro
 			logCtx = append(logCtx, "l1head", output.Legacy.HeadL1.Number)
 		}
 		l.Log.Error("Failed to send proposal transaction", logCtx...)
-		return
+		return false
 	}
+	l.cadence.onProposed(output.SequenceNum)
 	l.Metr.RecordL2Proposal(output.SequenceNum)
 	if output.Legacy.BlockRef != (eth.L2BlockRef{}) {
 		// Record legacy metrics when available
 		l.Metr.RecordL2BlocksProposed(output.Legacy.BlockRef)
 	}
+	return true
 }
@@ -39,6 +39,11 @@ func (m *MockL2OOContract) NextBlockNumber(opts *bind.CallOpts) (*big.Int, error
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockL2OOContract) SubmissionInterval(opts *bind.CallOpts) (*big.Int, error) {
+	args := m.Called(opts)
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
 type StubDGFContract struct {
 	hasProposedCount int
 }
@@ -106,6 +111,7 @@ func setup(t *testing.T, testName string) (*L2OutputSubmitter, *mockRollupEndpoi
 		l2ooABI:     parsed,
 		ctx:         ctx,
 		cancel:      cancel,
+		cadence:     newCadence(lgr, proposerConfig, nil, nil),
 	}
 	var mockDGFContract *StubDGFContract
 	var mockL2OOContract *MockL2OOContract
@@ -180,3 +186,84 @@ func TestL2OutputSubmitter_OutputRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestL2OutputSubmitter_CatchUp(t *testing.T) {
+	const submissionInterval = 10
+	output := func(block uint64) *eth.OutputResponse {
+		return &eth.OutputResponse{
+			Version:  eth.OutputVersionV0,
+			BlockRef: eth.L2BlockRef{Number: block},
+			Status: &eth.SyncStatus{
+				FinalizedL2: eth.L2BlockRef{Number: 100},
+			},
+		}
+	}
+	newSubmitter := func(t *testing.T, maxCatchUp uint64) (*L2OutputSubmitter, *mockRollupEndpointProvider, *MockL2OOContract, *txmgrmocks.TxManager) {
+		ep := newEndpointProvider()
+		l2ooContract := new(MockL2OOContract)
+		txmgr := txmgrmocks.NewTxManager(t)
+		lgr := testlog.Logger(t, log.LevelDebug)
+		cfg := ProposerConfig{PollInterval: time.Microsecond, MaxCatchUpProposals: maxCatchUp}
+		parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
+		require.NoError(t, err)
+		ps := &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:            lgr,
+				Metr:           metrics.NoopMetrics,
+				Cfg:            cfg,
+				Txmgr:          txmgr,
+				ProposalSource: source.NewRollupProposalSource(ep),
+			},
+			done:               make(chan struct{}),
+			l2ooContract:       l2ooContract,
+			l2ooABI:            parsed,
+			submissionInterval: submissionInterval,
+			cadence:            newCadence(lgr, cfg, nil, nil),
+		}
+		ep.rollupClient.On("SyncStatus").Return(&eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 100}}, nil)
+		txmgr.On("From").Return(common.Address{0xab})
+		txmgr.On("BlockNumber", mock.Anything).Return(uint64(100), nil)
+		return ps, ep, l2ooContract, txmgr
+	}
+
+	t.Run("ProposesOverdueOutputs", func(t *testing.T) {
+		ps, ep, l2ooContract, txmgr := newSubmitter(t, 3)
+		for _, block := range []uint64{42, 52, 62} {
+			l2ooContract.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(new(big.Int).SetUint64(block), nil).Once()
+			ep.rollupClient.ExpectOutputAtBlock(block, output(block), nil)
+		}
+		txmgr.On("Send", mock.Anything, mock.Anything).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Times(3)
+
+		ps.proposeNext(context.Background())
+
+		l2ooContract.AssertExpectations(t)
+		ep.rollupClient.AssertExpectations(t)
+		schedule := ps.ProposalSchedule()
+		require.Equal(t, uint64(62), schedule.LastProposalBlock)
+		require.Equal(t, uint64(3), schedule.OutputsBehind, "outputs 72 through 100 are still overdue")
+	})
+
+	t.Run("StopsWhenOutputDoesNotAdvance", func(t *testing.T) {
+		ps, ep, l2ooContract, txmgr := newSubmitter(t, 3)
+		l2ooContract.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(42), nil).Twice()
+		ep.rollupClient.ExpectOutputAtBlock(42, output(42), nil).Twice()
+		txmgr.On("Send", mock.Anything, mock.Anything).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+
+		ps.proposeNext(context.Background())
+
+		l2ooContract.AssertExpectations(t)
+		ep.rollupClient.AssertExpectations(t)
+	})
+
+	t.Run("StopsOnFailedProposal", func(t *testing.T) {
+		ps, ep, l2ooContract, txmgr := newSubmitter(t, 3)
+		l2ooContract.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(42), nil).Once()
+		ep.rollupClient.ExpectOutputAtBlock(42, output(42), nil)
+		txmgr.On("Send", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("TEST: failed to send")).Once()
+
+		ps.proposeNext(context.Background())
+
+		l2ooContract.AssertExpectations(t)
+		require.Zero(t, ps.ProposalSchedule().LastProposalBlock)
+	})
+}
@@ -13,6 +13,7 @@ import (
 type ProposerDriver interface {
 	StartL2OutputSubmitting() error
 	StopL2OutputSubmitting() error
+	ProposalSchedule() apis.ProposalSchedule
 }
 
 type adminAPI struct {
@@ -43,3 +44,8 @@ func (a *adminAPI) StartProposer(_ context.Context) error {
 func (a *adminAPI) StopProposer(ctx context.Context) error {
 	return a.b.StopL2OutputSubmitting()
 }
+
+func (a *adminAPI) ProposalSchedule(_ context.Context) (*apis.ProposalSchedule, error) {
+	schedule := a.b.ProposalSchedule()
+	return &schedule, nil
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-proposer/contracts"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/source"
@@ -26,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var ErrAlreadyStopped = errors.New("already stopped")
@@ -49,6 +52,20 @@ type ProposerConfig struct {
 	AllowNonFinalized bool
 
 	WaitNodeSync bool
+
+	// MaxCatchUpProposals is the maximum number of overdue L2OutputOracle outputs that are proposed in a single
+	// poll interval when the proposer is behind, e.g. after an L1 outage. Each output is a separate transaction,
+	// sent once the previous one is confirmed. 0 or 1 proposes one output per poll interval.
+	MaxCatchUpProposals uint64
+	// HighL1BaseFee defers proposals while the L1 base fee is above it, for at most MaxProposalDelay.
+	// Overdue outputs are never deferred. Nil disables deferring proposals.
+	HighL1BaseFee    *big.Int
+	MaxProposalDelay time.Duration
+	// WithdrawalThreshold is the number of withdrawals initiated since the last proposal at which proposals
+	// are no longer deferred, and at which the DisputeGameFactory proposal interval is shortened to MinProposalInterval.
+	// 0 disables adapting to the withdrawal demand.
+	WithdrawalThreshold uint64
+	MinProposalInterval time.Duration
 }
 
 type ProposerService struct {
@@ -59,6 +76,7 @@ type ProposerService struct {
 
 	TxManager      txmgr.TxManager
 	L1Client       *ethclient.Client
+	L2Client       *ethclient.Client
 	ProposalSource source.ProposalSource
 
 	driver *L2OutputSubmitter
@@ -95,6 +113,13 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	ps.AllowNonFinalized = cfg.AllowNonFinalized
 	ps.WaitNodeSync = cfg.WaitNodeSync
+	ps.MaxCatchUpProposals = cfg.MaxCatchUpProposals
+	if cfg.HighL1BaseFeeGwei != 0 {
+		ps.HighL1BaseFee = new(big.Int).Mul(new(big.Int).SetUint64(cfg.HighL1BaseFeeGwei), big.NewInt(params.GWei))
+	}
+	ps.MaxProposalDelay = cfg.MaxProposalDelay
+	ps.WithdrawalThreshold = cfg.WithdrawalThreshold
+	ps.MinProposalInterval = cfg.MinProposalInterval
 
 	ps.initL2ooAddress(cfg)
 	ps.initDGF(cfg)
@@ -131,6 +156,14 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 	}
 	ps.L1Client = l1Client
 
+	if cfg.L2EthRpc != "" {
+		l2Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, cfg.L2EthRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial L2 RPC: %w", err)
+		}
+		ps.L2Client = l2Client
+	}
+
 	if cfg.RollupRpc != "" {
 		var rollupProvider dial.RollupProvider
 		if strings.Contains(cfg.RollupRpc, ",") {
@@ -143,6 +176,11 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 			return fmt.Errorf("failed to build L2 endpoint provider: %w", err)
 		}
 		ps.ProposalSource = source.NewRollupProposalSource(rollupProvider)
+		if len(cfg.VerifierRollupRpcs) != 0 || cfg.VerifyOutputRoots {
+			if err := ps.initOutputVerification(ctx, cfg); err != nil {
+				return err
+			}
+		}
 	}
 	if len(cfg.SupervisorRpcs) != 0 {
 		var clients []source.SupervisorClient
@@ -159,6 +197,34 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 	return nil
 }
 
+// initOutputVerification wraps the rollup proposal source, to verify each output
+// against the verifier rollup nodes and, optionally, the L2 execution engine state.
+func (ps *ProposerService) initOutputVerification(ctx context.Context, cfg *CLIConfig) error {
+	var verifiers []source.ProposalSource
+	for _, url := range cfg.VerifierRollupRpcs {
+		provider, err := dial.NewStaticL2RollupProvider(ctx, ps.Log, url)
+		if err != nil {
+			// Close the verifiers dialed so far, they are not owned by the proposal source yet
+			for _, verifier := range verifiers {
+				verifier.Close()
+			}
+			return fmt.Errorf("failed to dial verifier rollup RPC (%v): %w", url, err)
+		}
+		verifiers = append(verifiers, source.NewRollupProposalSource(provider))
+	}
+	quorum := int(cfg.OutputQuorum)
+	if quorum == 0 {
+		quorum = len(verifiers) + 1
+	}
+	var outputRoots source.OutputRootVerifier
+	if cfg.VerifyOutputRoots {
+		outputRoots = source.NewL2OutputRootVerifier(ps.L2Client)
+	}
+	ps.Log.Info("Verifying outputs before proposing", "verifiers", len(verifiers), "quorum", quorum, "recompute", cfg.VerifyOutputRoots)
+	ps.ProposalSource = source.NewQuorumProposalSource(ps.Log, ps.Metrics, quorum, outputRoots, ps.ProposalSource, verifiers...)
+	return nil
+}
+
 func (ps *ProposerService) initMetrics(cfg *CLIConfig) {
 	if cfg.MetricsConfig.Enabled {
 		procName := "default"
@@ -241,6 +307,10 @@ func (ps *ProposerService) initDGF(cfg *CLIConfig) {
 }
 
 func (ps *ProposerService) initDriver() error {
+	var withdrawals WithdrawalCounter
+	if ps.L2Client != nil {
+		withdrawals = contracts.NewMessagePasser(batching.NewMultiCaller(ps.L2Client.Client(), batching.DefaultBatchSize), ps.NetworkTimeout)
+	}
 	driver, err := NewL2OutputSubmitter(DriverSetup{
 		Log:            ps.Log,
 		Metr:           ps.Metrics,
@@ -249,6 +319,7 @@ func (ps *ProposerService) initDriver() error {
 		L1Client:       ps.L1Client,
 		Multicaller:    batching.NewMultiCaller(ps.L1Client.Client(), batching.DefaultBatchSize),
 		ProposalSource: ps.ProposalSource,
+		Withdrawals:    withdrawals,
 	})
 	if err != nil {
 		return err
@@ -342,6 +413,10 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		ps.L1Client.Close()
 	}
 
+	if ps.L2Client != nil {
+		ps.L2Client.Close()
+	}
+
 	if ps.ProposalSource != nil {
 		ps.ProposalSource.Close()
 	}
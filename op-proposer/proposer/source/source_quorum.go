@@ -0,0 +1,189 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrOutputDisagreement = errors.New("output sources disagree on the proposal root")
+	ErrInsufficientQuorum = errors.New("insufficient output sources agree on the proposal root")
+	ErrOutputRootMismatch = errors.New("proposal root does not match the output root recomputed from L2 state")
+)
+
+// QuorumMetrics records disagreements between the output sources of a QuorumProposalSource.
+type QuorumMetrics interface {
+	RecordOutputDisagreement(sequenceNum uint64)
+}
+
+// OutputRootVerifier independently computes the output root at an L2 block.
+type OutputRootVerifier interface {
+	OutputRootAtBlock(ctx context.Context, blockNum uint64) (common.Hash, error)
+}
+
+// QuorumProposalSource fetches each proposal from a primary source and a set of independent verifying sources,
+// and only returns it if enough sources agree on the root.
+// Any disagreement between sources is an error, regardless of the quorum, as it indicates a faulty node.
+// Sources that fail to return the proposal, e.g. because they are not synced up to it yet, are not counted.
+type QuorumProposalSource struct {
+	log       log.Logger
+	metrics   QuorumMetrics
+	primary   ProposalSource
+	verifiers []ProposalSource
+	// quorum is the number of sources, including the primary, that have to return the same root.
+	quorum int
+	// outputRoots, if not nil, recomputes the output root from L2 state to check against the proposal.
+	outputRoots OutputRootVerifier
+}
+
+// NewQuorumProposalSource creates a QuorumProposalSource that requires quorum of the primary and verifier sources
+// to agree on the proposal root. The sync status is always taken from the primary source.
+// outputRoots is optional.
+func NewQuorumProposalSource(logger log.Logger, metrics QuorumMetrics, quorum int, outputRoots OutputRootVerifier, primary ProposalSource, verifiers ...ProposalSource) *QuorumProposalSource {
+	if quorum < 1 || quorum > len(verifiers)+1 {
+		panic(fmt.Errorf("invalid quorum %d for %d output sources", quorum, len(verifiers)+1))
+	}
+	return &QuorumProposalSource{
+		log:         logger,
+		metrics:     metrics,
+		primary:     primary,
+		verifiers:   verifiers,
+		quorum:      quorum,
+		outputRoots: outputRoots,
+	}
+}
+
+func (q *QuorumProposalSource) SyncStatus(ctx context.Context) (SyncStatus, error) {
+	return q.primary.SyncStatus(ctx)
+}
+
+type proposalResult struct {
+	idx      int
+	proposal Proposal
+	err      error
+}
+
+func (q *QuorumProposalSource) ProposalAtSequenceNum(ctx context.Context, seqNum uint64) (Proposal, error) {
+	sources := append([]ProposalSource{q.primary}, q.verifiers...)
+	var wg sync.WaitGroup
+	results := make([]proposalResult, len(sources))
+	wg.Add(len(sources))
+	for i, src := range sources {
+		i := i
+		src := src
+		go func() {
+			defer wg.Done()
+			proposal, err := src.ProposalAtSequenceNum(ctx, seqNum)
+			results[i] = proposalResult{idx: i, proposal: proposal, err: err}
+		}()
+	}
+	wg.Wait()
+
+	primary := results[0]
+	if primary.err != nil {
+		return Proposal{}, primary.err
+	}
+	agreeing := 1
+	var errs []error
+	for _, result := range results[1:] {
+		if result.err != nil {
+			q.log.Warn("Failed to retrieve proposal from verifying source", "idx", result.idx, "seqNum", seqNum, "err", result.err)
+			errs = append(errs, result.err)
+			continue
+		}
+		if result.proposal.Root != primary.proposal.Root || result.proposal.SequenceNum != primary.proposal.SequenceNum {
+			q.log.Error("Output sources disagree on proposal, refusing to propose",
+				"seqNum", seqNum, "idx", result.idx,
+				"root", result.proposal.Root, "primaryRoot", primary.proposal.Root)
+			q.metrics.RecordOutputDisagreement(seqNum)
+			return Proposal{}, fmt.Errorf("%w: source %d returned %s, primary returned %s at %d",
+				ErrOutputDisagreement, result.idx, result.proposal.Root, primary.proposal.Root, seqNum)
+		}
+		agreeing++
+	}
+	if agreeing < q.quorum {
+		return Proposal{}, fmt.Errorf("%w: %d of %d required at %d: %w", ErrInsufficientQuorum, agreeing, q.quorum, seqNum, errors.Join(errs...))
+	}
+
+	if q.outputRoots != nil {
+		root, err := q.outputRoots.OutputRootAtBlock(ctx, primary.proposal.SequenceNum)
+		if err != nil {
+			return Proposal{}, fmt.Errorf("failed to recompute output root at %d: %w", primary.proposal.SequenceNum, err)
+		}
+		if root != primary.proposal.Root {
+			q.log.Error("Output root recomputed from L2 state does not match proposal, refusing to propose",
+				"seqNum", seqNum, "root", root, "primaryRoot", primary.proposal.Root)
+			q.metrics.RecordOutputDisagreement(seqNum)
+			return Proposal{}, fmt.Errorf("%w: recomputed %s, sources returned %s at %d", ErrOutputRootMismatch, root, primary.proposal.Root, seqNum)
+		}
+	}
+	return primary.proposal, nil
+}
+
+func (q *QuorumProposalSource) Close() {
+	q.primary.Close()
+	for _, src := range q.verifiers {
+		src.Close()
+	}
+}
+
+type l2StateClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	GetProof(ctx context.Context, address common.Address, blockHash common.Hash) (*eth.AccountResult, error)
+}
+
+type ethProofClient struct {
+	*ethclient.Client
+}
+
+func (c ethProofClient) GetProof(ctx context.Context, address common.Address, blockHash common.Hash) (*eth.AccountResult, error) {
+	var result *eth.AccountResult
+	err := c.Client.Client().CallContext(ctx, &result, "eth_getProof", address, []common.Hash{}, blockHash)
+	return result, err
+}
+
+// L2OutputRootVerifier recomputes V0 output roots from the L2 execution engine, using the block header
+// and a proof of the L2ToL1MessagePasser storage root.
+type L2OutputRootVerifier struct {
+	client l2StateClient
+}
+
+var _ OutputRootVerifier = (*L2OutputRootVerifier)(nil)
+
+func NewL2OutputRootVerifier(client *ethclient.Client) *L2OutputRootVerifier {
+	return &L2OutputRootVerifier{client: ethProofClient{client}}
+}
+
+func (v *L2OutputRootVerifier) OutputRootAtBlock(ctx context.Context, blockNum uint64) (common.Hash, error) {
+	header, err := v.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNum))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get L2 block header: %w", err)
+	}
+	blockHash := header.Hash()
+	proof, err := v.client.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, blockHash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get message passer proof at block %s: %w", blockHash, err)
+	}
+	if proof == nil {
+		return common.Hash{}, fmt.Errorf("no message passer proof at block %s", blockHash)
+	}
+	// Verify the proof against the state root, so the storage root can be trusted as much as the header.
+	if err := proof.Verify(header.Root); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid message passer proof, state root was %s: %w", header.Root, err)
+	}
+	return common.Hash(eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
+		BlockHash:                blockHash,
+	})), nil
+}
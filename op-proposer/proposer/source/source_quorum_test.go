@@ -0,0 +1,192 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumSource_ProposalAtSequenceNum(t *testing.T) {
+	proposal := Proposal{Root: common.Hash{0xaa}, SequenceNum: 42}
+	other := Proposal{Root: common.Hash{0xbb}, SequenceNum: 42}
+	notSynced := errors.New("not synced")
+
+	t.Run("AllAgree", func(t *testing.T) {
+		metrics := &stubQuorumMetrics{}
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), metrics, 3, nil,
+			&stubProposalSource{proposal: proposal}, &stubProposalSource{proposal: proposal}, &stubProposalSource{proposal: proposal})
+		actual, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.NoError(t, err)
+		require.Equal(t, proposal, actual)
+		require.Zero(t, metrics.disagreements)
+	})
+
+	t.Run("Disagreement", func(t *testing.T) {
+		metrics := &stubQuorumMetrics{}
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), metrics, 1, nil,
+			&stubProposalSource{proposal: proposal}, &stubProposalSource{proposal: proposal}, &stubProposalSource{proposal: other})
+		_, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.ErrorIs(t, err, ErrOutputDisagreement)
+		require.Equal(t, 1, metrics.disagreements)
+	})
+
+	t.Run("QuorumWithUnavailableVerifier", func(t *testing.T) {
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), &stubQuorumMetrics{}, 2, nil,
+			&stubProposalSource{proposal: proposal}, &stubProposalSource{err: notSynced}, &stubProposalSource{proposal: proposal})
+		actual, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.NoError(t, err)
+		require.Equal(t, proposal, actual)
+	})
+
+	t.Run("InsufficientQuorum", func(t *testing.T) {
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), &stubQuorumMetrics{}, 3, nil,
+			&stubProposalSource{proposal: proposal}, &stubProposalSource{err: notSynced}, &stubProposalSource{proposal: proposal})
+		_, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.ErrorIs(t, err, ErrInsufficientQuorum)
+		require.ErrorIs(t, err, notSynced)
+	})
+
+	t.Run("PrimaryError", func(t *testing.T) {
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), &stubQuorumMetrics{}, 1, nil,
+			&stubProposalSource{err: notSynced}, &stubProposalSource{proposal: proposal})
+		_, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.ErrorIs(t, err, notSynced)
+	})
+
+	t.Run("RecomputedRootMatches", func(t *testing.T) {
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), &stubQuorumMetrics{}, 1, &stubOutputRootVerifier{root: proposal.Root},
+			&stubProposalSource{proposal: proposal})
+		actual, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.NoError(t, err)
+		require.Equal(t, proposal, actual)
+	})
+
+	t.Run("RecomputedRootMismatch", func(t *testing.T) {
+		metrics := &stubQuorumMetrics{}
+		source := NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), metrics, 1, &stubOutputRootVerifier{root: other.Root},
+			&stubProposalSource{proposal: proposal})
+		_, err := source.ProposalAtSequenceNum(context.Background(), 42)
+		require.ErrorIs(t, err, ErrOutputRootMismatch)
+		require.Equal(t, 1, metrics.disagreements)
+	})
+}
+
+func TestQuorumSource_InvalidQuorum(t *testing.T) {
+	require.Panics(t, func() {
+		NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), &stubQuorumMetrics{}, 3, nil, &stubProposalSource{}, &stubProposalSource{})
+	})
+	require.Panics(t, func() {
+		NewQuorumProposalSource(testlog.Logger(t, log.LvlInfo), &stubQuorumMetrics{}, 0, nil, &stubProposalSource{})
+	})
+}
+
+func TestL2OutputRootVerifier(t *testing.T) {
+	storageRoot := common.Hash{0xcc}
+	stateRoot, proof := messagePasserProof(t, storageRoot)
+	header := &types.Header{Number: big.NewInt(42), Root: stateRoot}
+
+	t.Run("Valid", func(t *testing.T) {
+		verifier := &L2OutputRootVerifier{client: &stubL2StateClient{header: header, proof: proof}}
+		root, err := verifier.OutputRootAtBlock(context.Background(), 42)
+		require.NoError(t, err)
+		expected := eth.OutputRoot(&eth.OutputV0{
+			StateRoot:                eth.Bytes32(stateRoot),
+			MessagePasserStorageRoot: eth.Bytes32(storageRoot),
+			BlockHash:                header.Hash(),
+		})
+		require.Equal(t, common.Hash(expected), root)
+	})
+
+	t.Run("InvalidProof", func(t *testing.T) {
+		invalid := *proof
+		invalid.StorageHash = common.Hash{0xdd}
+		verifier := &L2OutputRootVerifier{client: &stubL2StateClient{header: header, proof: &invalid}}
+		_, err := verifier.OutputRootAtBlock(context.Background(), 42)
+		require.ErrorContains(t, err, "invalid message passer proof")
+	})
+}
+
+// messagePasserProof builds a state trie with only the L2ToL1MessagePasser account, and returns its root and account proof.
+func messagePasserProof(t *testing.T, storageRoot common.Hash) (common.Hash, *eth.AccountResult) {
+	result := &eth.AccountResult{
+		Address:     predeploys.L2ToL1MessagePasserAddr,
+		Balance:     (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:    common.Hash{0xee},
+		Nonce:       1,
+		StorageHash: storageRoot,
+	}
+	account, err := rlp.EncodeToBytes([]any{uint64(result.Nonce), result.Balance.ToInt().Bytes(), result.StorageHash, result.CodeHash})
+	require.NoError(t, err)
+
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	key := crypto.Keccak256(result.Address[:])
+	require.NoError(t, tr.Update(key, account))
+	proofDB := memorydb.New()
+	require.NoError(t, tr.Prove(key, proofDB))
+	it := proofDB.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		result.AccountProof = append(result.AccountProof, common.CopyBytes(it.Value()))
+	}
+	return tr.Hash(), result
+}
+
+type stubProposalSource struct {
+	proposal Proposal
+	err      error
+}
+
+func (s *stubProposalSource) ProposalAtSequenceNum(_ context.Context, _ uint64) (Proposal, error) {
+	return s.proposal, s.err
+}
+
+func (s *stubProposalSource) SyncStatus(_ context.Context) (SyncStatus, error) {
+	return SyncStatus{}, nil
+}
+
+func (s *stubProposalSource) Close() {}
+
+type stubQuorumMetrics struct {
+	disagreements int
+}
+
+func (s *stubQuorumMetrics) RecordOutputDisagreement(_ uint64) {
+	s.disagreements++
+}
+
+type stubOutputRootVerifier struct {
+	root common.Hash
+}
+
+func (s *stubOutputRootVerifier) OutputRootAtBlock(_ context.Context, _ uint64) (common.Hash, error) {
+	return s.root, nil
+}
+
+type stubL2StateClient struct {
+	header *types.Header
+	proof  *eth.AccountResult
+}
+
+func (s *stubL2StateClient) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	return s.header, nil
+}
+
+func (s *stubL2StateClient) GetProof(_ context.Context, _ common.Address, _ common.Hash) (*eth.AccountResult, error) {
+	return s.proof, nil
+}
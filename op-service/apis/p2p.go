@@ -29,6 +29,11 @@ type P2PClient interface {
 	UnprotectPeer(ctx context.Context, p peer.ID) error
 	ConnectPeer(ctx context.Context, addr string) error
 	DisconnectPeer(ctx context.Context, id peer.ID) error
+	ListPeerGroups(ctx context.Context) ([]*PeerGroup, error)
+	AddPeerGroup(ctx context.Context, name string, maxConnected uint) error
+	RemovePeerGroup(ctx context.Context, name string) error
+	AddPeerToGroup(ctx context.Context, name string, addr string) error
+	RemovePeerFromGroup(ctx context.Context, name string, id peer.ID) error
 }
 
 type PeerDump struct {
@@ -60,6 +65,16 @@ type PeerInfo struct {
 	PeerScores store.PeerScores `json:"scores"`
 }
 
+// PeerGroup is a named group of trusted peers.
+// Members of a group are kept connected and are never banned for their score.
+type PeerGroup struct {
+	Name           string    `json:"name"`
+	Peers          []string  `json:"peers"`          // multi-addresses of the members, each including the peer ID
+	Connected      []peer.ID `json:"connected"`      // members that are currently connected
+	GossipPriority bool      `json:"gossipPriority"` // if the members are direct gossip peers, receiving all messages
+	MaxConnected   uint      `json:"maxConnected"`   // maximum number of members to keep connected, 0 for all
+}
+
 type PeerStats struct {
 	Connected     uint `json:"connected"`
 	Table         uint `json:"table"`
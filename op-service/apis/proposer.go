@@ -1,18 +1,54 @@
 package apis
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 type ProposerActivity interface {
 	StartProposer(ctx context.Context) error
 	StopProposer(ctx context.Context) error
 }
 
+type ProposerSchedule interface {
+	ProposalSchedule(ctx context.Context) (*ProposalSchedule, error)
+}
+
 type ProposerAdminServer interface {
 	CommonAdminServer
 	ProposerActivity
+	ProposerSchedule
 }
 
 type ProposerAdminClient interface {
 	CommonAdminClient
 	ProposerActivity
+	ProposerSchedule
+}
+
+// ProposalSchedule describes when the proposer will make its next proposal, and why.
+type ProposalSchedule struct {
+	// NextBlockNumber is the L2 block number of the next output to propose.
+	// For the DisputeGameFactory this is the latest proposable block.
+	NextBlockNumber uint64 `json:"nextBlockNumber"`
+	// CurrentBlockNumber is the latest safe or finalized L2 block that can be proposed.
+	CurrentBlockNumber uint64 `json:"currentBlockNumber"`
+	// OutputsBehind is the number of outputs that are ready to be proposed but have not been yet.
+	OutputsBehind uint64 `json:"outputsBehind"`
+	// ProposalInterval is the effective interval between proposals to the DisputeGameFactory.
+	ProposalInterval time.Duration `json:"proposalInterval"`
+	// L1BaseFee is the base fee of the latest L1 block, if known.
+	L1BaseFee *hexutil.Big `json:"l1BaseFee,omitempty"`
+	// PendingWithdrawals is the number of withdrawals initiated on L2 since the last proposal, if known.
+	PendingWithdrawals uint64 `json:"pendingWithdrawals"`
+	// DeferredSince is the time since which the next proposal has been deferred, zero if not deferred.
+	DeferredSince time.Time `json:"deferredSince"`
+	// DeferReason explains why the next proposal is deferred, empty if not deferred.
+	DeferReason string `json:"deferReason,omitempty"`
+	// LastProposalBlock is the L2 block number of the last output proposed by this proposer since it started.
+	LastProposalBlock uint64 `json:"lastProposalBlock"`
+	// LastProposalTime is the time of the last proposal by this proposer since it started.
+	LastProposalTime time.Time `json:"lastProposalTime"`
 }
@@ -33,10 +33,12 @@ type RollupOutputServer interface {
 
 type RollupSafeAtClient interface {
 	SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error)
+	SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error)
 }
 
 type RollupSafeAtServer interface {
 	SafeHeadAtL1Block(ctx context.Context, blockNum hexutil.Uint64) (*eth.SafeHeadResponse, error)
+	SafeHeadsInRange(ctx context.Context, start hexutil.Uint64, end hexutil.Uint64) ([]eth.SafeHeadResponse, error)
 }
 
 type SequencerActivity interface {
@@ -45,6 +47,22 @@ type SequencerActivity interface {
 	SequencerActive(ctx context.Context) (bool, error)
 }
 
+// SequencerHandoverClient schedules a planned sequencer handover: the active sequencer stops after a
+// pre-announced block, and a standby sequencer starts on top of exactly that block.
+type SequencerHandoverClient interface {
+	ScheduleSequencerStop(ctx context.Context, blockNum uint64) error
+	ScheduleSequencerStopAtTime(ctx context.Context, timestamp uint64) (uint64, error)
+	ScheduleSequencerStart(ctx context.Context, blockNum uint64, blockHash common.Hash) error
+	CancelSequencerHandover(ctx context.Context) error
+}
+
+type SequencerHandoverServer interface {
+	ScheduleSequencerStop(ctx context.Context, blockNum hexutil.Uint64) error
+	ScheduleSequencerStopAtTime(ctx context.Context, timestamp hexutil.Uint64) (hexutil.Uint64, error)
+	ScheduleSequencerStart(ctx context.Context, blockNum hexutil.Uint64, blockHash common.Hash) error
+	CancelSequencerHandover(ctx context.Context) error
+}
+
 type UnsignedPayloadPoster interface {
 	PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
@@ -61,6 +79,7 @@ type RecoverMode interface {
 type RollupAdminClient interface {
 	CommonAdminClient
 	SequencerActivity
+	SequencerHandoverClient
 	UnsignedPayloadPoster
 	RollupConductor
 	RecoverMode
@@ -69,6 +88,7 @@ type RollupAdminClient interface {
 type RollupAdminServer interface {
 	CommonAdminServer
 	SequencerActivity
+	SequencerHandoverServer
 	UnsignedPayloadPoster
 	RollupConductor
 	RecoverMode
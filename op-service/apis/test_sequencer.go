@@ -20,6 +20,9 @@ type TestSequencerBuildAPI interface {
 	New(context.Context, seqtypes.BuilderID, *seqtypes.BuildOpts) (seqtypes.BuildJobID, error)
 	Open(context.Context, seqtypes.BuildJobID) error
 	Cancel(context.Context, seqtypes.BuildJobID) error
+	// Seal seals the block of the build job, and returns it. Over RPC, build_seal returns the block
+	// as built by the builder: an execution payload envelope for the standard builder,
+	// which sources.BuilderClient.SealEnvelope decodes.
 	Seal(context.Context, seqtypes.BuildJobID) (work.Block, error)
 	CloseJob(seqtypes.BuildJobID) error
 }
@@ -38,6 +41,7 @@ type TestSequencerControlAPI interface {
 	PrebuiltEnvelope(ctx context.Context, block *eth.ExecutionPayloadEnvelope) error
 	Publish(ctx context.Context) error
 	Open(ctx context.Context) error
+	Reset(ctx context.Context) error
 	Seal(ctx context.Context) error
 	Sign(ctx context.Context) error
 	Start(ctx context.Context, head common.Hash) error
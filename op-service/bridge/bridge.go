@@ -0,0 +1,231 @@
+// Package bridge implements the Mantle bridge flows, deposits of MNT and ETH from L1 to L2,
+// and withdrawals of MNT and ETH from L2 to L1, against arbitrary L1 and L2 RPC endpoints.
+//
+// Deposits and withdrawals are plain values that can be serialized as JSON,
+// so a flow can be resumed after a restart: their status is always read from the chains.
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/contractio"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const (
+	DefaultDepositGasLimit    uint32 = 300_000
+	DefaultWithdrawalGasLimit uint64 = 100_000
+	DefaultGasMultiplier             = 1.5
+	DefaultPollInterval              = 2 * time.Second
+)
+
+var (
+	ErrNoSender        = errors.New("no transaction sender given")
+	ErrTxFailed        = errors.New("transaction failed")
+	ErrNotProven       = errors.New("withdrawal has not been proven")
+	ErrAlreadyProven   = errors.New("withdrawal is already proven")
+	ErrAlreadyFinal    = errors.New("withdrawal is already finalized")
+	ErrNotFinalizable  = errors.New("withdrawal cannot be finalized yet")
+	ErrInvalidProof    = errors.New("withdrawal proof can never be finalized")
+	ErrLowMNTAllowance = errors.New("MNT allowance of the L1StandardBridge is too low")
+)
+
+// TxSender sends transactions and waits for their receipt. txmgr.TxManager implements it.
+type TxSender interface {
+	From() common.Address
+	Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error)
+}
+
+var _ TxSender = (txmgr.TxManager)(nil)
+
+// Config is the configuration of a bridge client.
+type Config struct {
+	// OptimismPortal is the address of the OptimismPortal proxy on L1.
+	OptimismPortal common.Address
+	// L1StandardBridge is the address of the L1StandardBridge proxy on L1.
+	L1StandardBridge common.Address
+	// L2OutputOracle is the address of the L2OutputOracle proxy on L1, if the OptimismPortal
+	// proves withdrawals against L2 outputs.
+	L2OutputOracle common.Address
+	// DisputeGameFactory is the address of the DisputeGameFactory proxy on L1, if the OptimismPortal
+	// proves withdrawals against dispute games.
+	// If neither DisputeGameFactory nor L2OutputOracle is set, they are read from the OptimismPortal.
+	DisputeGameFactory common.Address
+
+	// GasMultiplier scales the estimated gas limit of the sent transactions. Defaults to DefaultGasMultiplier.
+	GasMultiplier float64
+	// PollInterval is the interval at which the Wait methods poll the chains. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// Client executes the Mantle bridge flows.
+// Transactions are sent with the TxSender that is passed to each operation,
+// so a single client can serve many accounts.
+type Client struct {
+	log log.Logger
+	cfg Config
+
+	l1 apis.EthClient
+	l2 apis.EthClient
+
+	portal        bindings.MantleOptimismPortal
+	l1Bridge      bindings.MantleL1StandardBridge
+	messagePasser bindings.MantleL2ToL1MessagePasser
+
+	// Set if the portal proves withdrawals against L2 outputs.
+	oracle bindings.MantleL2OutputOracle
+	// Set if the portal proves withdrawals against dispute games.
+	portal2 bindings.OptimismPortal2
+	factory bindings.DisputeGameFactory
+}
+
+// NewClient creates a bridge client. If neither the L2OutputOracle nor the DisputeGameFactory is configured,
+// the one that the OptimismPortal proves withdrawals against is read from it.
+func NewClient(ctx context.Context, logger log.Logger, cfg Config, l1 apis.EthClient, l2 apis.EthClient) (*Client, error) {
+	if cfg.GasMultiplier == 0 {
+		cfg.GasMultiplier = DefaultGasMultiplier
+	}
+	if cfg.GasMultiplier < 1 {
+		return nil, fmt.Errorf("gas multiplier must be at least 1, got %v", cfg.GasMultiplier)
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	c := &Client{
+		log: logger,
+		cfg: cfg,
+		l1:  l1,
+		l2:  l2,
+		portal: bindings.NewBindings[bindings.MantleOptimismPortal](
+			bindings.WithClient(l1),
+			bindings.WithTo(cfg.OptimismPortal)),
+		l1Bridge: bindings.NewBindings[bindings.MantleL1StandardBridge](
+			bindings.WithClient(l1),
+			bindings.WithTo(cfg.L1StandardBridge)),
+		messagePasser: bindings.NewBindings[bindings.MantleL2ToL1MessagePasser](
+			bindings.WithClient(l2),
+			bindings.WithTo(predeploys.L2ToL1MessagePasserAddr)),
+	}
+	c.portal2 = bindings.NewBindings[bindings.OptimismPortal2](
+		bindings.WithClient(l1),
+		bindings.WithTo(cfg.OptimismPortal))
+	if cfg.L2OutputOracle != (common.Address{}) && cfg.DisputeGameFactory != (common.Address{}) {
+		return nil, errors.New("only one of the L2OutputOracle and the DisputeGameFactory can be configured")
+	}
+	if cfg.L2OutputOracle == (common.Address{}) && cfg.DisputeGameFactory == (common.Address{}) {
+		// Portals that prove withdrawals against L2 outputs do not have a dispute game factory.
+		if addr, err := contractio.Read(c.portal2.DisputeGameFactoryAddr(), ctx); err == nil && addr != (common.Address{}) {
+			c.cfg.DisputeGameFactory = addr
+		} else {
+			addr, err := contractio.Read(c.portal.L2Oracle(), ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read L2OutputOracle address from portal: %w", err)
+			}
+			c.cfg.L2OutputOracle = addr
+		}
+	}
+	if c.UsesDisputeGames() {
+		c.factory = bindings.NewBindings[bindings.DisputeGameFactory](
+			bindings.WithClient(l1),
+			bindings.WithTo(c.cfg.DisputeGameFactory))
+	} else {
+		c.oracle = bindings.NewBindings[bindings.MantleL2OutputOracle](
+			bindings.WithClient(l1),
+			bindings.WithTo(c.cfg.L2OutputOracle))
+	}
+	return c, nil
+}
+
+// UsesDisputeGames returns whether the OptimismPortal proves withdrawals against dispute games,
+// rather than against outputs of the L2OutputOracle.
+func (c *Client) UsesDisputeGames() bool {
+	return c.cfg.DisputeGameFactory != (common.Address{})
+}
+
+// Config returns the configuration of the client, with defaults filled in.
+func (c *Client) Config() Config {
+	return c.cfg
+}
+
+// EstimateGas estimates the gas limit of the candidate when sent by from, scaled by the gas multiplier.
+func (c *Client) EstimateGas(ctx context.Context, cl apis.EthClient, from common.Address, candidate txmgr.TxCandidate) (uint64, error) {
+	gas, err := cl.EstimateGas(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    candidate.To,
+		Value: candidate.Value,
+		Data:  candidate.TxData,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(float64(gas) * c.cfg.GasMultiplier), nil
+}
+
+// send estimates the gas limit of the candidate, sends it, and checks that it succeeded.
+func (c *Client) send(ctx context.Context, cl apis.EthClient, sender TxSender, candidate txmgr.TxCandidate, op string) (*types.Receipt, error) {
+	if sender == nil {
+		return nil, ErrNoSender
+	}
+	if candidate.GasLimit == 0 {
+		gas, err := c.EstimateGas(ctx, cl, sender.From(), candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas of %s: %w", op, err)
+		}
+		candidate.GasLimit = gas
+	}
+	rcpt, err := sender.Send(ctx, candidate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s: %w", op, err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		return rcpt, fmt.Errorf("%w: %s %s", ErrTxFailed, op, rcpt.TxHash)
+	}
+	c.log.Debug("Sent bridge transaction", "op", op, "tx", rcpt.TxHash, "block", rcpt.BlockNumber, "gasUsed", rcpt.GasUsed)
+	return rcpt, nil
+}
+
+// candidate encodes the call into a transaction candidate with the given value.
+func candidate[O any](call bindings.TypedCall[O], value *big.Int) (txmgr.TxCandidate, error) {
+	target, err := call.To()
+	if err != nil {
+		return txmgr.TxCandidate{}, err
+	}
+	to := *target
+	data, err := call.EncodeInput()
+	if err != nil {
+		return txmgr.TxCandidate{}, fmt.Errorf("failed to encode %s: %w", call.MethodName, err)
+	}
+	return txmgr.TxCandidate{TxData: data, To: &to, Value: value}, nil
+}
+
+// poll calls fn every poll interval until it returns true or an error, or the context is done.
+func (c *Client) poll(ctx context.Context, fn func() (bool, error)) error {
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := fn()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
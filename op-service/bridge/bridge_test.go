@@ -0,0 +1,159 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var (
+	portalAddr  = common.Address{0xaa}
+	oracleAddr  = common.Address{0xbb}
+	factoryAddr = common.Address{0xcc}
+)
+
+// fakeEthClient answers contract calls with the results registered for their exact calldata,
+// and reverts calls without a registered result. Unused methods of apis.EthClient panic.
+type fakeEthClient struct {
+	apis.EthClient
+	t        *testing.T
+	time     uint64
+	results  map[string][]byte
+	receipts map[common.Hash]*types.Receipt
+	// receiptErr is returned by TransactionReceipt if set.
+	receiptErr error
+}
+
+func newFakeEthClient(t *testing.T) *fakeEthClient {
+	return &fakeEthClient{
+		t:        t,
+		results:  make(map[string][]byte),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+// setCall registers the result of the call.
+func setCall[O any](f *fakeEthClient, call bindings.TypedCall[O], result O) {
+	to, err := call.To()
+	require.NoError(f.t, err)
+	input, err := call.EncodeInput()
+	require.NoError(f.t, err)
+	output, err := bindings.ABIEncoder("", result)
+	require.NoError(f.t, err)
+	// Strip the selector of the unnamed method
+	f.results[string(append(to.Bytes(), input...))] = output[4:]
+}
+
+func (f *fakeEthClient) Call(_ context.Context, msg ethereum.CallMsg, _ rpc.BlockNumber) ([]byte, error) {
+	output, ok := f.results[string(append(msg.To.Bytes(), msg.Data...))]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return output, nil
+}
+
+func (f *fakeEthClient) InfoByLabel(_ context.Context, _ eth.BlockLabel) (eth.BlockInfo, error) {
+	return eth.HeaderBlockInfo(&types.Header{Number: big.NewInt(1), Time: f.time, BaseFee: big.NewInt(1)}), nil
+}
+
+func (f *fakeEthClient) EstimateGas(_ context.Context, _ ethereum.CallMsg) (uint64, error) {
+	return 100_000, nil
+}
+
+func (f *fakeEthClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if f.receiptErr != nil {
+		return nil, f.receiptErr
+	}
+	rcpt, ok := f.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return rcpt, nil
+}
+
+// fakeSender records the sent transactions, and returns successful receipts.
+type fakeSender struct {
+	from common.Address
+	sent []txmgr.TxCandidate
+}
+
+func (s *fakeSender) From() common.Address {
+	return s.from
+}
+
+func (s *fakeSender) Send(_ context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	s.sent = append(s.sent, candidate)
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}, nil
+}
+
+func testBindings[T any](cl apis.EthClient, to common.Address) T {
+	return bindings.NewBindings[T](bindings.WithClient(cl), bindings.WithTo(to))
+}
+
+// newOracleClient creates a client of a portal that proves withdrawals against the outputs of an L2OutputOracle.
+func newOracleClient(t *testing.T) (*Client, *fakeEthClient) {
+	l1 := newFakeEthClient(t)
+	setCall(l1, testBindings[bindings.MantleOptimismPortal](l1, portalAddr).L2Oracle(), oracleAddr)
+	c, err := NewClient(context.Background(), testlog.Logger(t, log.LevelInfo), Config{OptimismPortal: portalAddr}, l1, newFakeEthClient(t))
+	require.NoError(t, err)
+	require.False(t, c.UsesDisputeGames())
+	require.Equal(t, oracleAddr, c.Config().L2OutputOracle)
+	return c, l1
+}
+
+// newGameClient creates a client of a portal that proves withdrawals against dispute games.
+func newGameClient(t *testing.T) (*Client, *fakeEthClient) {
+	l1 := newFakeEthClient(t)
+	setCall(l1, testBindings[bindings.OptimismPortal2](l1, portalAddr).DisputeGameFactoryAddr(), factoryAddr)
+	c, err := NewClient(context.Background(), testlog.Logger(t, log.LevelInfo), Config{OptimismPortal: portalAddr}, l1, newFakeEthClient(t))
+	require.NoError(t, err)
+	require.True(t, c.UsesDisputeGames())
+	require.Equal(t, factoryAddr, c.Config().DisputeGameFactory)
+	return c, l1
+}
+
+func TestNewClient(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("DetectsOracle", func(t *testing.T) {
+		newOracleClient(t)
+	})
+
+	t.Run("DetectsDisputeGames", func(t *testing.T) {
+		newGameClient(t)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		l1 := newFakeEthClient(t)
+		c, err := NewClient(ctx, logger, Config{OptimismPortal: portalAddr, DisputeGameFactory: factoryAddr}, l1, l1)
+		require.NoError(t, err)
+		require.True(t, c.UsesDisputeGames())
+	})
+
+	t.Run("BothConfigured", func(t *testing.T) {
+		l1 := newFakeEthClient(t)
+		_, err := NewClient(ctx, logger, Config{OptimismPortal: portalAddr, L2OutputOracle: oracleAddr, DisputeGameFactory: factoryAddr}, l1, l1)
+		require.ErrorContains(t, err, "only one of")
+	})
+
+	t.Run("UnknownPortal", func(t *testing.T) {
+		l1 := newFakeEthClient(t)
+		_, err := NewClient(ctx, logger, Config{OptimismPortal: portalAddr}, l1, l1)
+		require.ErrorContains(t, err, "failed to read L2OutputOracle address")
+	})
+}
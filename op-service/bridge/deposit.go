@@ -0,0 +1,183 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/contractio"
+)
+
+type DepositStatus string
+
+const (
+	// DepositPending is a deposit that is not on L2 yet.
+	DepositPending DepositStatus = "pending"
+	// DepositIncluded is a deposit that was executed successfully on L2.
+	DepositIncluded DepositStatus = "included"
+	// DepositFailed is a deposit whose execution failed on L2.
+	// Minted MNT and ETH are kept by the sender on L2, but the values are not transferred.
+	DepositFailed DepositStatus = "failed"
+)
+
+// Deposit is a deposit made on L1.
+type Deposit struct {
+	L1TxHash      common.Hash `json:"l1TxHash"`
+	L1BlockNumber uint64      `json:"l1BlockNumber"`
+	// L2TxHash is the hash of the L2 deposit transaction.
+	L2TxHash common.Hash `json:"l2TxHash"`
+
+	// L1Receipt is the receipt of the L1 transaction, if the deposit was made or loaded by this client.
+	L1Receipt *types.Receipt `json:"-"`
+}
+
+// DepositETH deposits ETH to the given L2 account, where it is minted as BVM_ETH.
+func (c *Client) DepositETH(ctx context.Context, sender TxSender, to common.Address, amount *big.Int, minGasLimit uint32) (*Deposit, error) {
+	cand, err := candidate(c.l1Bridge.DepositETHTo(to, minGasLimit, []byte{}), amount)
+	if err != nil {
+		return nil, err
+	}
+	rcpt, err := c.send(ctx, c.l1, sender, cand, "ETH deposit")
+	if err != nil {
+		return nil, err
+	}
+	return c.depositFromReceipt(rcpt)
+}
+
+// DepositMNT deposits MNT to the given L2 account.
+// The L1StandardBridge must be allowed to transfer the amount of L1 MNT of the sender, see ApproveMNT.
+func (c *Client) DepositMNT(ctx context.Context, sender TxSender, to common.Address, amount *big.Int, minGasLimit uint32) (*Deposit, error) {
+	if sender == nil {
+		return nil, ErrNoSender
+	}
+	allowance, err := c.MNTAllowance(ctx, sender.From())
+	if err != nil {
+		return nil, err
+	}
+	if allowance.ToBig().Cmp(amount) < 0 {
+		return nil, fmt.Errorf("%w: %s < %s", ErrLowMNTAllowance, allowance.ToBig(), amount)
+	}
+	cand, err := candidate(c.l1Bridge.DepositMNTTo(to, eth.WeiBig(amount), minGasLimit, []byte{}), nil)
+	if err != nil {
+		return nil, err
+	}
+	rcpt, err := c.send(ctx, c.l1, sender, cand, "MNT deposit")
+	if err != nil {
+		return nil, err
+	}
+	return c.depositFromReceipt(rcpt)
+}
+
+// MNTAllowance returns the amount of L1 MNT of the owner that the L1StandardBridge may transfer.
+func (c *Client) MNTAllowance(ctx context.Context, owner common.Address) (eth.ETH, error) {
+	token, err := c.l1MNT(ctx)
+	if err != nil {
+		return eth.ETH{}, err
+	}
+	allowance, err := contractio.Read(token.Allowance(owner, c.cfg.L1StandardBridge), ctx)
+	if err != nil {
+		return eth.ETH{}, fmt.Errorf("failed to read MNT allowance: %w", err)
+	}
+	return allowance, nil
+}
+
+// ApproveMNT allows the L1StandardBridge to transfer the given amount of L1 MNT of the sender.
+func (c *Client) ApproveMNT(ctx context.Context, sender TxSender, amount *big.Int) (*types.Receipt, error) {
+	token, err := c.l1MNT(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cand, err := candidate(token.Approve(c.cfg.L1StandardBridge, eth.WeiBig(amount)), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(ctx, c.l1, sender, cand, "MNT approval")
+}
+
+// LoadDeposit loads the first deposit made by the given L1 transaction.
+func (c *Client) LoadDeposit(ctx context.Context, l1TxHash common.Hash) (*Deposit, error) {
+	rcpt, err := c.l1.TransactionReceipt(ctx, l1TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 receipt %s: %w", l1TxHash, err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("L1 transaction %s failed, no deposit was made", l1TxHash)
+	}
+	return c.depositFromReceipt(rcpt)
+}
+
+func (c *Client) depositFromReceipt(rcpt *types.Receipt) (*Deposit, error) {
+	for _, log := range rcpt.Logs {
+		if log.Address != c.cfg.OptimismPortal || len(log.Topics) == 0 || log.Topics[0] != derive.DepositEventABIHash {
+			continue
+		}
+		dep, err := derive.UnmarshalDepositLogEvent(log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode deposit: %w", err)
+		}
+		d := &Deposit{
+			L1TxHash:      rcpt.TxHash,
+			L1BlockNumber: rcpt.BlockNumber.Uint64(),
+			L2TxHash:      types.NewTx(dep).Hash(),
+			L1Receipt:     rcpt,
+		}
+		c.log.Info("Made deposit", "l1Tx", d.L1TxHash, "l2Tx", d.L2TxHash, "mint", dep.Mint, "ethValue", dep.EthValue)
+		return d, nil
+	}
+	return nil, fmt.Errorf("no deposit found in L1 transaction %s", rcpt.TxHash)
+}
+
+// DepositStatus returns the status of the deposit on L2, and its L2 receipt if it is on L2.
+func (c *Client) DepositStatus(ctx context.Context, d *Deposit) (DepositStatus, *types.Receipt, error) {
+	rcpt, err := c.l2.TransactionReceipt(ctx, d.L2TxHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return DepositPending, nil, nil
+	} else if err != nil {
+		return "", nil, fmt.Errorf("failed to get L2 receipt %s: %w", d.L2TxHash, err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		return DepositFailed, rcpt, nil
+	}
+	return DepositIncluded, rcpt, nil
+}
+
+// WaitForDeposit waits until the deposit is on L2, and returns its L2 receipt.
+// The receipt of a failed deposit is returned with an error wrapping ErrTxFailed.
+func (c *Client) WaitForDeposit(ctx context.Context, d *Deposit) (*types.Receipt, error) {
+	var rcpt *types.Receipt
+	var status DepositStatus
+	err := c.poll(ctx, func() (bool, error) {
+		var err error
+		status, rcpt, err = c.DepositStatus(ctx, d)
+		if err != nil {
+			c.log.Warn("Failed to get deposit status", "l2Tx", d.L2TxHash, "err", err)
+			return false, nil
+		}
+		return status != DepositPending, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deposit %s not found on L2: %w", d.L2TxHash, err)
+	}
+	if status == DepositFailed {
+		return rcpt, fmt.Errorf("%w: L2 deposit %s", ErrTxFailed, d.L2TxHash)
+	}
+	return rcpt, nil
+}
+
+func (c *Client) l1MNT(ctx context.Context) (bindings.OptimismMintableERC20, error) {
+	addr, err := contractio.Read(c.l1Bridge.L1MNTAddress(), ctx)
+	if err != nil {
+		return bindings.OptimismMintableERC20{}, fmt.Errorf("failed to read L1 MNT address: %w", err)
+	}
+	return bindings.NewBindings[bindings.OptimismMintableERC20](
+		bindings.WithClient(c.l1),
+		bindings.WithTo(addr)), nil
+}
@@ -0,0 +1,39 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositStatus(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newOracleClient(t)
+	l2 := c.l2.(*fakeEthClient)
+	d := &Deposit{L2TxHash: common.Hash{0x01}}
+
+	status, rcpt, err := c.DepositStatus(ctx, d)
+	require.NoError(t, err)
+	require.Equal(t, DepositPending, status)
+	require.Nil(t, rcpt)
+
+	l2.receipts[d.L2TxHash] = &types.Receipt{Status: types.ReceiptStatusSuccessful}
+	status, rcpt, err = c.DepositStatus(ctx, d)
+	require.NoError(t, err)
+	require.Equal(t, DepositIncluded, status)
+	require.Equal(t, l2.receipts[d.L2TxHash], rcpt)
+
+	l2.receipts[d.L2TxHash] = &types.Receipt{Status: types.ReceiptStatusFailed}
+	status, rcpt, err = c.DepositStatus(ctx, d)
+	require.NoError(t, err)
+	require.Equal(t, DepositFailed, status)
+	require.Equal(t, l2.receipts[d.L2TxHash], rcpt)
+
+	l2.receiptErr = errors.New("connection refused")
+	_, _, err = c.DepositStatus(ctx, d)
+	require.ErrorIs(t, err, l2.receiptErr)
+}
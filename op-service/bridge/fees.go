@@ -0,0 +1,43 @@
+package bridge
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// HasOperatorFee returns true if the receipt was charged an operator fee.
+func HasOperatorFee(rcpt *types.Receipt) bool {
+	return rcpt.OperatorFeeConstant != nil && rcpt.OperatorFeeScalar != nil
+}
+
+// GasCost returns the total fee paid for the transaction of the receipt: the execution fee, the L1 fee, and the operator fee.
+// The rollup config and the timestamp of the block of the receipt are only needed if the receipt was charged an operator fee.
+func GasCost(rcpt *types.Receipt, rollupCfg *rollup.Config, blockTimestamp *uint64) (eth.ETH, error) {
+	cost := eth.WeiBig(new(big.Int).Mul(new(big.Int).SetUint64(rcpt.GasUsed), rcpt.EffectiveGasPrice))
+	if rcpt.L1Fee != nil {
+		cost = cost.Add(eth.WeiBig(rcpt.L1Fee))
+	}
+	if HasOperatorFee(rcpt) {
+		if rollupCfg == nil {
+			return eth.ETH{}, errors.New("rollup config is required to compute operator fee")
+		}
+		if blockTimestamp == nil {
+			return eth.ETH{}, errors.New("block timestamp is required to compute operator fee")
+		}
+		operatorCost := new(big.Int).SetUint64(rcpt.GasUsed)
+		operatorCost.Mul(operatorCost, new(big.Int).SetUint64(*rcpt.OperatorFeeScalar))
+		if rollupCfg.IsOperatorFeeFix(*blockTimestamp) {
+			operatorCost.Mul(operatorCost, big.NewInt(100))
+		} else {
+			operatorCost.Div(operatorCost, big.NewInt(1_000_000))
+		}
+		operatorCost.Add(operatorCost, new(big.Int).SetUint64(*rcpt.OperatorFeeConstant))
+		cost = cost.Add(eth.WeiBig(operatorCost))
+	}
+	return cost, nil
+}
@@ -0,0 +1,681 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/contractio"
+)
+
+type WithdrawalStatus string
+
+const (
+	// WithdrawalWaitingForOutput is a withdrawal that is not covered by an L2 output yet.
+	WithdrawalWaitingForOutput WithdrawalStatus = "waiting-for-output"
+	// WithdrawalReadyToProve is a withdrawal that is covered by an L2 output, but not proven.
+	// A withdrawal must also be proven again if the output it was proven against was deleted.
+	WithdrawalReadyToProve WithdrawalStatus = "ready-to-prove"
+	// WithdrawalProven is a proven withdrawal that is waiting for the finalization period to elapse.
+	WithdrawalProven WithdrawalStatus = "proven"
+	// WithdrawalReadyToFinalize is a proven withdrawal that can be finalized.
+	WithdrawalReadyToFinalize WithdrawalStatus = "ready-to-finalize"
+	// WithdrawalFinalized is a withdrawal that was finalized on L1.
+	WithdrawalFinalized WithdrawalStatus = "finalized"
+)
+
+// Withdrawal is a withdrawal initiated on L2.
+type Withdrawal struct {
+	L2TxHash      common.Hash                   `json:"l2TxHash"`
+	L2BlockNumber uint64                        `json:"l2BlockNumber"`
+	Hash          common.Hash                   `json:"withdrawalHash"`
+	Transaction   *crossdomain.MantleWithdrawal `json:"transaction"`
+
+	// InitReceipt is the receipt of the L2 transaction, if the withdrawal was initiated or loaded by this client.
+	InitReceipt *types.Receipt `json:"-"`
+}
+
+// WithdrawalParams are the parameters of a withdrawal.
+type WithdrawalParams struct {
+	// Target receives the withdrawal on L1.
+	Target common.Address
+	// MNTValue is the MNT value to withdraw.
+	MNTValue *big.Int
+	// ETHValue is the ETH value to withdraw, burnt from the BVM_ETH balance of the sender.
+	ETHValue *big.Int
+	// GasLimit is the gas limit of the call to the target on L1. Defaults to DefaultWithdrawalGasLimit.
+	GasLimit uint64
+	// Data is the calldata of the call to the target on L1.
+	Data []byte
+}
+
+// Output is an output that withdrawals can be proven against: an L2 output proposed to the L2OutputOracle,
+// or the root claim of a dispute game if the OptimismPortal proves withdrawals against dispute games.
+type Output struct {
+	// Index is the L2OutputOracle output index, or the index of the dispute game in the DisputeGameFactory.
+	Index         *big.Int    `json:"index"`
+	OutputRoot    common.Hash `json:"outputRoot"`
+	L2BlockNumber uint64      `json:"l2BlockNumber"`
+	// Timestamp is the L1 time at which the output was proposed.
+	Timestamp uint64 `json:"timestamp"`
+	// Game is the dispute game proxy. Zero if the OptimismPortal uses an L2OutputOracle.
+	Game common.Address `json:"game"`
+}
+
+// Proof is a proof of a withdrawal submitted to the OptimismPortal.
+type Proof struct {
+	// Submitter is the account that proved the withdrawal. Zero if the OptimismPortal uses an L2OutputOracle,
+	// as only the latest proof of a withdrawal is kept then.
+	Submitter common.Address
+	// Timestamp is the L1 time at which the withdrawal was proven.
+	Timestamp uint64
+	// OutputRoot and OutputIndex are the L2 output the withdrawal was proven against,
+	// if the OptimismPortal uses an L2OutputOracle.
+	OutputRoot  common.Hash
+	OutputIndex *big.Int
+	// Game is the dispute game the withdrawal was proven against, if the OptimismPortal uses dispute games.
+	Game common.Address
+}
+
+// WithdrawalState is the state of a withdrawal on L1.
+type WithdrawalState struct {
+	Status WithdrawalStatus `json:"status"`
+	// Output is the output the withdrawal is proven against, or can be proven against. Nil if there is none.
+	Output *Output `json:"output,omitempty"`
+	// Submitter is the account whose proof the state refers to. Zero if the OptimismPortal uses an L2OutputOracle.
+	Submitter common.Address `json:"submitter"`
+	// ProvenAt is the L1 time at which the withdrawal was proven. Zero if it is not proven.
+	ProvenAt uint64 `json:"provenAt,omitempty"`
+	// FinalizableAt is the first L1 time at which the proven withdrawal can be finalized.
+	// Zero if it is not proven, or if the dispute game it is proven against is not resolved yet.
+	FinalizableAt uint64 `json:"finalizableAt,omitempty"`
+}
+
+// InitiateWithdrawal initiates a withdrawal of MNT and ETH on L2.
+func (c *Client) InitiateWithdrawal(ctx context.Context, sender TxSender, params WithdrawalParams) (*Withdrawal, error) {
+	mntValue, ethValue := params.MNTValue, params.ETHValue
+	if mntValue == nil {
+		mntValue = new(big.Int)
+	}
+	if ethValue == nil {
+		ethValue = new(big.Int)
+	}
+	if mntValue.Sign() == 0 && ethValue.Sign() == 0 {
+		return nil, errors.New("must withdraw MNT or ETH")
+	}
+	gasLimit := params.GasLimit
+	if gasLimit == 0 {
+		gasLimit = DefaultWithdrawalGasLimit
+	}
+	data := params.Data
+	if data == nil {
+		data = []byte{}
+	}
+	cand, err := candidate(c.messagePasser.InitiateWithdrawal(ethValue, params.Target, new(big.Int).SetUint64(gasLimit), data), mntValue)
+	if err != nil {
+		return nil, err
+	}
+	rcpt, err := c.send(ctx, c.l2, sender, cand, "withdrawal initiation")
+	if err != nil {
+		return nil, err
+	}
+	return c.withdrawalFromReceipt(rcpt)
+}
+
+// InitiateWithdrawalMNT initiates a withdrawal of MNT to the given L1 account.
+func (c *Client) InitiateWithdrawalMNT(ctx context.Context, sender TxSender, target common.Address, amount *big.Int) (*Withdrawal, error) {
+	return c.InitiateWithdrawal(ctx, sender, WithdrawalParams{Target: target, MNTValue: amount})
+}
+
+// InitiateWithdrawalETH initiates a withdrawal of ETH to the given L1 account.
+func (c *Client) InitiateWithdrawalETH(ctx context.Context, sender TxSender, target common.Address, amount *big.Int) (*Withdrawal, error) {
+	return c.InitiateWithdrawal(ctx, sender, WithdrawalParams{Target: target, ETHValue: amount})
+}
+
+// LoadWithdrawal loads the withdrawal initiated by the given L2 transaction.
+func (c *Client) LoadWithdrawal(ctx context.Context, l2TxHash common.Hash) (*Withdrawal, error) {
+	rcpt, err := c.l2.TransactionReceipt(ctx, l2TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 receipt %s: %w", l2TxHash, err)
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("L2 transaction %s failed, no withdrawal was initiated", l2TxHash)
+	}
+	return c.withdrawalFromReceipt(rcpt)
+}
+
+func (c *Client) withdrawalFromReceipt(rcpt *types.Receipt) (*Withdrawal, error) {
+	tx, err := crossdomain.ParseMantleMessagePassedFromReceipt(rcpt, predeploys.L2ToL1MessagePasserAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse withdrawal: %w", err)
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return nil, err
+	}
+	c.log.Info("Initiated withdrawal", "l2Tx", rcpt.TxHash, "withdrawalHash", hash, "mntValue", tx.MNTValue, "ethValue", tx.ETHValue, "target", tx.Target)
+	return &Withdrawal{
+		L2TxHash:      rcpt.TxHash,
+		L2BlockNumber: rcpt.BlockNumber.Uint64(),
+		Hash:          hash,
+		Transaction:   tx,
+		InitReceipt:   rcpt,
+	}, nil
+}
+
+// FinalizationPeriod returns the minimum time between proving and finalizing a withdrawal:
+// the finalization period of the L2OutputOracle, or the proof maturity delay of the OptimismPortal with dispute games.
+func (c *Client) FinalizationPeriod(ctx context.Context) (time.Duration, error) {
+	call := c.oracle.FINALIZATIONPERIODSECONDS
+	if c.UsesDisputeGames() {
+		call = c.portal2.ProofMaturityDelaySeconds
+	}
+	secs, err := contractio.Read(call(), ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read finalization period: %w", err)
+	}
+	if !secs.IsUint64() {
+		return 0, fmt.Errorf("finalization period overflows uint64: %v", secs)
+	}
+	return time.Duration(secs.Uint64()) * time.Second, nil
+}
+
+// OutputAt returns the output at the given index, or nil if there is no output at the index.
+func (c *Client) OutputAt(ctx context.Context, index *big.Int) (*Output, error) {
+	if c.UsesDisputeGames() {
+		count, err := contractio.Read(c.factory.GameCount(), ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read game count: %w", err)
+		}
+		if index.Cmp(count) >= 0 {
+			return nil, nil
+		}
+		game, err := contractio.Read(c.factory.GameAtIndex(index), ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read game %v: %w", index, err)
+		}
+		return c.gameOutput(ctx, index, game.Proxy)
+	}
+
+	next, err := contractio.Read(c.oracle.NextOutputIndex(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read next output index: %w", err)
+	}
+	if index.Cmp(next) >= 0 {
+		return nil, nil
+	}
+	proposal, err := contractio.Read(c.oracle.GetL2Output(index), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output %v: %w", index, err)
+	}
+	if !proposal.L2BlockNumber.IsUint64() || !proposal.Timestamp.IsUint64() {
+		return nil, fmt.Errorf("invalid output %v: block number %v, timestamp %v", index, proposal.L2BlockNumber, proposal.Timestamp)
+	}
+	return &Output{
+		Index:         index,
+		OutputRoot:    proposal.OutputRoot,
+		L2BlockNumber: proposal.L2BlockNumber.Uint64(),
+		Timestamp:     proposal.Timestamp.Uint64(),
+	}, nil
+}
+
+// gameOutput returns the output claimed by the dispute game. The index of the game may be nil if it is not known.
+func (c *Client) gameOutput(ctx context.Context, index *big.Int, proxy common.Address) (*Output, error) {
+	game := bindings.NewFaultDisputeGame(bindings.WithClient(c.l1), bindings.WithTo(proxy))
+	rootClaim, err := contractio.Read(game.RootClaim(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root claim of game %s: %w", proxy, err)
+	}
+	l2BlockNumber, err := contractio.Read(game.L2SequenceNumber(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read L2 block number of game %s: %w", proxy, err)
+	}
+	if !l2BlockNumber.IsUint64() {
+		return nil, fmt.Errorf("invalid L2 block number of game %s: %v", proxy, l2BlockNumber)
+	}
+	createdAt, err := contractio.Read(game.CreatedAt(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read creation time of game %s: %w", proxy, err)
+	}
+	return &Output{
+		Index:         index,
+		OutputRoot:    rootClaim,
+		L2BlockNumber: l2BlockNumber.Uint64(),
+		Timestamp:     createdAt,
+		Game:          proxy,
+	}, nil
+}
+
+// OutputCovering returns an output at or after the given L2 block, or nil if there is none yet.
+// With an L2OutputOracle, this is the first output after the block.
+// With dispute games, this is the latest game of the respected game type.
+func (c *Client) OutputCovering(ctx context.Context, l2BlockNumber uint64) (*Output, error) {
+	if c.UsesDisputeGames() {
+		return c.latestGameCovering(ctx, l2BlockNumber)
+	}
+
+	latest, err := contractio.Read(c.oracle.LatestBlockNumber(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read latest output block number: %w", err)
+	}
+	next, err := contractio.Read(c.oracle.NextOutputIndex(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read next output index: %w", err)
+	}
+	// Without outputs, the latest block number is the starting block number of the oracle.
+	if next.Sign() == 0 || latest.Cmp(new(big.Int).SetUint64(l2BlockNumber)) < 0 {
+		return nil, nil
+	}
+	index, err := contractio.Read(c.oracle.GetL2OutputIndexAfter(new(big.Int).SetUint64(l2BlockNumber)), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output index after L2 block %d: %w", l2BlockNumber, err)
+	}
+	return c.OutputAt(ctx, index)
+}
+
+func (c *Client) latestGameCovering(ctx context.Context, l2BlockNumber uint64) (*Output, error) {
+	gameType, err := contractio.Read(c.portal2.RespectedGameType(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read respected game type: %w", err)
+	}
+	count, err := contractio.Read(c.factory.GameCount(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read game count: %w", err)
+	}
+	if count.Sign() == 0 {
+		return nil, nil
+	}
+	games, err := contractio.Read(c.factory.FindLatestGames(gameType, new(big.Int).Sub(count, common.Big1), common.Big1), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest game: %w", err)
+	}
+	if len(games) == 0 {
+		return nil, nil
+	}
+	latest := games[0]
+	if len(latest.ExtraData) < 32 {
+		return nil, fmt.Errorf("invalid extra data of game %v: %x", latest.Index, latest.ExtraData)
+	}
+	gameBlock := new(big.Int).SetBytes(latest.ExtraData[:32])
+	if !gameBlock.IsUint64() {
+		return nil, fmt.Errorf("invalid L2 block number of game %v: %v", latest.Index, gameBlock)
+	}
+	if gameBlock.Uint64() < l2BlockNumber {
+		return nil, nil
+	}
+	game, err := contractio.Read(c.factory.GameAtIndex(latest.Index), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read game %v: %w", latest.Index, err)
+	}
+	return &Output{
+		Index:         latest.Index,
+		OutputRoot:    latest.RootClaim,
+		L2BlockNumber: gameBlock.Uint64(),
+		Timestamp:     latest.Timestamp,
+		Game:          game.Proxy,
+	}, nil
+}
+
+// WaitForOutput waits until an output covering the given L2 block is proposed.
+func (c *Client) WaitForOutput(ctx context.Context, l2BlockNumber uint64) (*Output, error) {
+	var output *Output
+	err := c.poll(ctx, func() (bool, error) {
+		var err error
+		output, err = c.OutputCovering(ctx, l2BlockNumber)
+		if err != nil {
+			c.log.Warn("Failed to find output", "l2Block", l2BlockNumber, "err", err)
+			return false, nil
+		}
+		return output != nil, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("no output covering L2 block %d: %w", l2BlockNumber, err)
+	}
+	return output, nil
+}
+
+// Proofs returns the proofs that have been submitted for the withdrawal.
+// With an L2OutputOracle, there is at most one proof.
+func (c *Client) Proofs(ctx context.Context, withdrawalHash common.Hash) ([]Proof, error) {
+	if !c.UsesDisputeGames() {
+		proven, err := contractio.Read(c.portal.ProvenWithdrawals(withdrawalHash), ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proven withdrawals: %w", err)
+		}
+		if proven.Timestamp.Sign() == 0 {
+			return nil, nil
+		}
+		return []Proof{{Timestamp: proven.Timestamp.Uint64(), OutputRoot: proven.OutputRoot, OutputIndex: proven.L2OutputIndex}}, nil
+	}
+
+	count, err := contractio.Read(c.portal2.NumProofSubmitters(withdrawalHash), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read number of proof submitters: %w", err)
+	}
+	var proofs []Proof
+	for i := int64(0); i < count.Int64(); i++ {
+		submitter, err := contractio.Read(c.portal2.ProofSubmitters(withdrawalHash, big.NewInt(i)), ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proof submitter %d: %w", i, err)
+		}
+		proven, err := contractio.Read(c.portal2.ProvenWithdrawals(withdrawalHash, submitter), ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proven withdrawal of %s: %w", submitter, err)
+		}
+		proofs = append(proofs, Proof{Submitter: submitter, Timestamp: proven.Timestamp, Game: proven.DisputeGameProxy})
+	}
+	return proofs, nil
+}
+
+// provenWithdrawal is a proof that the withdrawal can be finalized with, now or later.
+type provenWithdrawal struct {
+	Proof
+	// Output is the output the withdrawal was proven against.
+	Output *Output
+	// FinalizableAt is the first L1 time at which the withdrawal can be finalized with the proof,
+	// or zero if the dispute game of the proof is not resolved yet.
+	FinalizableAt uint64
+}
+
+// checkProof returns when the withdrawal can be finalized with the proof.
+// It returns an error wrapping ErrInvalidProof if the withdrawal can never be finalized with the proof,
+// because the output it was proven against was deleted, or the dispute game was lost.
+func (c *Client) checkProof(ctx context.Context, proof Proof) (provenWithdrawal, error) {
+	if !c.UsesDisputeGames() {
+		output, err := c.OutputAt(ctx, proof.OutputIndex)
+		if err != nil {
+			return provenWithdrawal{}, err
+		}
+		if output == nil || output.OutputRoot != proof.OutputRoot {
+			return provenWithdrawal{}, fmt.Errorf("%w: output %v was deleted", ErrInvalidProof, proof.OutputIndex)
+		}
+		period, err := c.FinalizationPeriod(ctx)
+		if err != nil {
+			return provenWithdrawal{}, err
+		}
+		// Both the proof and the output must be older than the finalization period.
+		at := max(proof.Timestamp, output.Timestamp) + uint64(period/time.Second) + 1
+		return provenWithdrawal{Proof: proof, Output: output, FinalizableAt: at}, nil
+	}
+
+	output, err := c.gameOutput(ctx, nil, proof.Game)
+	if err != nil {
+		return provenWithdrawal{}, err
+	}
+	game := bindings.NewFaultDisputeGame(bindings.WithClient(c.l1), bindings.WithTo(proof.Game))
+	status, err := contractio.Read(game.Status(), ctx)
+	if err != nil {
+		return provenWithdrawal{}, fmt.Errorf("failed to read status of game %s: %w", proof.Game, err)
+	}
+	switch gameTypes.GameStatus(status) {
+	case gameTypes.GameStatusInProgress:
+		return provenWithdrawal{Proof: proof, Output: output}, nil
+	case gameTypes.GameStatusDefenderWon:
+	default:
+		return provenWithdrawal{}, fmt.Errorf("%w: game %s resolved as %v", ErrInvalidProof, proof.Game, gameTypes.GameStatus(status))
+	}
+	resolvedAt, err := contractio.Read(game.ResolvedAt(), ctx)
+	if err != nil {
+		return provenWithdrawal{}, fmt.Errorf("failed to read resolution time of game %s: %w", proof.Game, err)
+	}
+	maturityDelay, err := contractio.Read(c.portal2.ProofMaturityDelaySeconds(), ctx)
+	if err != nil {
+		return provenWithdrawal{}, fmt.Errorf("failed to read proof maturity delay: %w", err)
+	}
+	finalityDelay, err := contractio.Read(c.portal2.DisputeGameFinalityDelaySeconds(), ctx)
+	if err != nil {
+		return provenWithdrawal{}, fmt.Errorf("failed to read dispute game finality delay: %w", err)
+	}
+	// Both the proof must be mature, and the game resolution must be final.
+	at := max(proof.Timestamp+maturityDelay.Uint64(), resolvedAt+finalityDelay.Uint64()) + 1
+	return provenWithdrawal{Proof: proof, Output: output, FinalizableAt: at}, nil
+}
+
+// WithdrawalState reads the state of the withdrawal on L1.
+func (c *Client) WithdrawalState(ctx context.Context, w *Withdrawal) (*WithdrawalState, error) {
+	return c.withdrawalState(ctx, w, common.Address{})
+}
+
+// withdrawalState reads the state of the withdrawal on L1,
+// preferring the proof of the given sender if there are several proofs that can be finalized.
+func (c *Client) withdrawalState(ctx context.Context, w *Withdrawal, sender common.Address) (*WithdrawalState, error) {
+	finalized, err := contractio.Read(c.portal.FinalizedWithdrawals(w.Hash), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read finalized withdrawals: %w", err)
+	}
+	if finalized {
+		return &WithdrawalState{Status: WithdrawalFinalized}, nil
+	}
+	proofs, err := c.Proofs(ctx, w.Hash)
+	if err != nil {
+		return nil, err
+	}
+	var proven []provenWithdrawal
+	for _, proof := range proofs {
+		p, err := c.checkProof(ctx, proof)
+		if errors.Is(err, ErrInvalidProof) {
+			c.log.Debug("Ignoring withdrawal proof", "withdrawalHash", w.Hash, "submitter", proof.Submitter, "err", err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		proven = append(proven, p)
+	}
+	var covering *Output
+	if len(proven) == 0 {
+		covering, err = c.OutputCovering(ctx, w.L2BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+	head, err := c.l1.InfoByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	return withdrawalState(proven, covering, sender, head.Time()), nil
+}
+
+// withdrawalState derives the state of a withdrawal that is not finalized,
+// from the proofs it can be finalized with, and the output covering the withdrawal, if any.
+func withdrawalState(proven []provenWithdrawal, covering *Output, sender common.Address, l1Time uint64) *WithdrawalState {
+	if p, ok := selectProof(proven, sender, l1Time); ok {
+		status := WithdrawalProven
+		if p.FinalizableAt != 0 && l1Time >= p.FinalizableAt {
+			status = WithdrawalReadyToFinalize
+		}
+		return &WithdrawalState{
+			Status:        status,
+			Output:        p.Output,
+			Submitter:     p.Submitter,
+			ProvenAt:      p.Timestamp,
+			FinalizableAt: p.FinalizableAt,
+		}
+	}
+	if covering == nil {
+		return &WithdrawalState{Status: WithdrawalWaitingForOutput}
+	}
+	return &WithdrawalState{Status: WithdrawalReadyToProve, Output: covering}
+}
+
+// selectProof returns the proof to finalize the withdrawal with. Proofs that can be finalized at l1Time come first,
+// preferring the proof of the sender, then the proof that can be finalized the earliest,
+// then proofs against dispute games that are not resolved yet.
+func selectProof(proven []provenWithdrawal, sender common.Address, l1Time uint64) (provenWithdrawal, bool) {
+	if len(proven) == 0 {
+		return provenWithdrawal{}, false
+	}
+	ready := func(p provenWithdrawal) bool {
+		return p.FinalizableAt != 0 && l1Time >= p.FinalizableAt
+	}
+	best := proven[0]
+	for _, p := range proven[1:] {
+		switch {
+		case ready(p) != ready(best):
+			if ready(p) {
+				best = p
+			}
+		case ready(p):
+			if p.Submitter == sender && best.Submitter != sender {
+				best = p
+			}
+		case p.FinalizableAt != 0 && (best.FinalizableAt == 0 || p.FinalizableAt < best.FinalizableAt):
+			best = p
+		}
+	}
+	return best, true
+}
+
+// ProveWithdrawal waits for an output covering the withdrawal, and proves the withdrawal against it.
+func (c *Client) ProveWithdrawal(ctx context.Context, sender TxSender, w *Withdrawal) (*types.Receipt, error) {
+	state, err := c.WithdrawalState(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	switch state.Status {
+	case WithdrawalFinalized:
+		return nil, ErrAlreadyFinal
+	case WithdrawalProven, WithdrawalReadyToFinalize:
+		return nil, ErrAlreadyProven
+	}
+	output, err := c.WaitForOutput(ctx, w.L2BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	outputRootProof, withdrawalProof, err := c.withdrawalProof(ctx, w, output)
+	if err != nil {
+		return nil, err
+	}
+	// The output index is the index of the dispute game if the portal uses dispute games.
+	cand, err := candidate(c.portal.ProveWithdrawalTransaction(withdrawalTransaction(w.Transaction), output.Index, outputRootProof, withdrawalProof), nil)
+	if err != nil {
+		return nil, err
+	}
+	rcpt, err := c.send(ctx, c.l1, sender, cand, "withdrawal proof")
+	if err != nil {
+		return nil, err
+	}
+	c.log.Info("Proved withdrawal", "withdrawalHash", w.Hash, "l1Tx", rcpt.TxHash, "outputIndex", output.Index, "game", output.Game)
+	return rcpt, nil
+}
+
+// FinalizeWithdrawal finalizes a proven withdrawal, once the finalization period has elapsed.
+// With dispute games, the withdrawal is finalized with the proof of another account if the sender has no finalizable proof.
+func (c *Client) FinalizeWithdrawal(ctx context.Context, sender TxSender, w *Withdrawal) (*types.Receipt, error) {
+	if sender == nil {
+		return nil, ErrNoSender
+	}
+	state, err := c.withdrawalState(ctx, w, sender.From())
+	if err != nil {
+		return nil, err
+	}
+	switch state.Status {
+	case WithdrawalFinalized:
+		return nil, ErrAlreadyFinal
+	case WithdrawalWaitingForOutput, WithdrawalReadyToProve:
+		return nil, ErrNotProven
+	case WithdrawalProven:
+		if state.FinalizableAt == 0 {
+			return nil, fmt.Errorf("%w: dispute game %s is not resolved yet", ErrNotFinalizable, state.Output.Game)
+		}
+		return nil, fmt.Errorf("%w: finalizable at %s", ErrNotFinalizable, time.Unix(int64(state.FinalizableAt), 0).UTC().Format(time.RFC3339))
+	}
+	call := c.portal.FinalizeWithdrawalTransaction(withdrawalTransaction(w.Transaction))
+	if c.UsesDisputeGames() && state.Submitter != sender.From() {
+		c.log.Info("Finalizing withdrawal with the proof of another account", "withdrawalHash", w.Hash, "submitter", state.Submitter)
+		call = c.portal.FinalizeWithdrawalTransactionExternalProof(withdrawalTransaction(w.Transaction), state.Submitter)
+	}
+	cand, err := candidate(call, nil)
+	if err != nil {
+		return nil, err
+	}
+	rcpt, err := c.send(ctx, c.l1, sender, cand, "withdrawal finalization")
+	if err != nil {
+		return nil, err
+	}
+	c.log.Info("Finalized withdrawal", "withdrawalHash", w.Hash, "l1Tx", rcpt.TxHash)
+	return rcpt, nil
+}
+
+// WaitForFinalizable waits until the proven withdrawal can be finalized.
+// It returns early if the withdrawal is finalized, or must be proven (again).
+func (c *Client) WaitForFinalizable(ctx context.Context, w *Withdrawal) (*WithdrawalState, error) {
+	var state *WithdrawalState
+	err := c.poll(ctx, func() (bool, error) {
+		var err error
+		state, err = c.WithdrawalState(ctx, w)
+		if err != nil {
+			c.log.Warn("Failed to get withdrawal state", "withdrawalHash", w.Hash, "err", err)
+			return false, nil
+		}
+		return state.Status != WithdrawalProven, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("withdrawal %s not finalizable: %w", w.Hash, err)
+	}
+	return state, nil
+}
+
+// withdrawalProof builds the proof of the withdrawal against the given output.
+// It checks that the output root reproduced from the L2 chain matches the proposed output root.
+func (c *Client) withdrawalProof(ctx context.Context, w *Withdrawal, output *Output) (bindings.MantleOutputRootProof, [][]byte, error) {
+	header, err := c.l2.InfoByNumber(ctx, output.L2BlockNumber)
+	if err != nil {
+		return bindings.MantleOutputRootProof{}, nil, fmt.Errorf("failed to get L2 block %d: %w", output.L2BlockNumber, err)
+	}
+	slot, err := w.Transaction.StorageSlot()
+	if err != nil {
+		return bindings.MantleOutputRootProof{}, nil, err
+	}
+	proof, err := c.l2.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{slot}, hexutil.Uint64(output.L2BlockNumber).String())
+	if err != nil {
+		return bindings.MantleOutputRootProof{}, nil, fmt.Errorf("failed to get withdrawal proof: %w", err)
+	}
+	if len(proof.StorageProof) != 1 {
+		return bindings.MantleOutputRootProof{}, nil, errors.New("invalid amount of storage proofs")
+	}
+	if err := proof.Verify(header.Root()); err != nil {
+		return bindings.MantleOutputRootProof{}, nil, fmt.Errorf("failed to verify withdrawal proof: %w", err)
+	}
+
+	outputRoot := eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(header.Root()),
+		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
+		BlockHash:                header.Hash(),
+	})
+	if common.Hash(outputRoot) != output.OutputRoot {
+		return bindings.MantleOutputRootProof{}, nil, fmt.Errorf("output root of L2 block %d is %s, but %s was proposed", output.L2BlockNumber, common.Hash(outputRoot), output.OutputRoot)
+	}
+
+	trieNodes := make([][]byte, len(proof.StorageProof[0].Proof))
+	for i, node := range proof.StorageProof[0].Proof {
+		trieNodes[i] = node
+	}
+	return bindings.MantleOutputRootProof{
+		StateRoot:                header.Root(),
+		MessagePasserStorageRoot: proof.StorageHash,
+		LatestBlockhash:          header.Hash(),
+	}, trieNodes, nil
+}
+
+func withdrawalTransaction(w *crossdomain.MantleWithdrawal) bindings.MantleWithdrawalTransaction {
+	return bindings.MantleWithdrawalTransaction{
+		Nonce:    w.Nonce,
+		Sender:   *w.Sender,
+		Target:   *w.Target,
+		MNTValue: w.MNTValue,
+		ETHValue: w.ETHValue,
+		GasLimit: w.GasLimit,
+		Data:     w.Data,
+	}
+}
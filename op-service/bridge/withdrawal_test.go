@@ -0,0 +1,420 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/txintent/bindings"
+)
+
+func newTestWithdrawal(t *testing.T) *Withdrawal {
+	sender, target := common.Address{0xaa}, common.Address{0xbb}
+	tx := crossdomain.NewMantleWithdrawal(big.NewInt(1), &sender, &target, big.NewInt(2), big.NewInt(3), big.NewInt(100_000), []byte{0x01})
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	return &Withdrawal{L2TxHash: common.Hash{0x01}, L2BlockNumber: 150, Hash: hash, Transaction: tx}
+}
+
+func setOutput(c *Client, l1 *fakeEthClient, index int64, l2Block int64, timestamp int64) common.Hash {
+	root := common.Hash{byte(index + 1)}
+	setCall(l1, c.oracle.GetL2Output(big.NewInt(index)), bindings.MantleOutputProposal{
+		OutputRoot:    root,
+		Timestamp:     big.NewInt(timestamp),
+		L2BlockNumber: big.NewInt(l2Block),
+	})
+	return root
+}
+
+func setOutputs(c *Client, l1 *fakeEthClient, next int64, latestBlock int64) {
+	setCall(l1, c.oracle.NextOutputIndex(), big.NewInt(next))
+	setCall(l1, c.oracle.LatestBlockNumber(), big.NewInt(latestBlock))
+}
+
+func setLatestGame(c *Client, l1 *fakeEthClient, index int64, l2Block int64, timestamp uint64, proxy common.Address) common.Hash {
+	root := common.Hash{byte(index + 1)}
+	setCall(l1, c.portal2.RespectedGameType(), uint32(1))
+	setCall(l1, c.factory.GameCount(), big.NewInt(index+1))
+	setCall(l1, c.factory.FindLatestGames(1, big.NewInt(index), big.NewInt(1)), []bindings.GameSearchResult{{
+		Index:     big.NewInt(index),
+		Timestamp: timestamp,
+		RootClaim: root,
+		ExtraData: common.BigToHash(big.NewInt(l2Block)).Bytes(),
+	}})
+	setCall(l1, c.factory.GameAtIndex(big.NewInt(index)), bindings.DisputeGame{GameType: 1, Timestamp: timestamp, Proxy: proxy})
+	return root
+}
+
+func setGame(l1 *fakeEthClient, proxy common.Address, status gameTypes.GameStatus, resolvedAt uint64) {
+	game := bindings.NewFaultDisputeGame(bindings.WithClient(l1), bindings.WithTo(proxy))
+	setCall(l1, game.RootClaim(), common.Hash(proxy.Hash()))
+	setCall(l1, game.L2SequenceNumber(), big.NewInt(200))
+	setCall(l1, game.CreatedAt(), uint64(900))
+	setCall(l1, game.Status(), uint8(status))
+	setCall(l1, game.ResolvedAt(), resolvedAt)
+}
+
+func setGameDelays(c *Client, l1 *fakeEthClient, maturity int64, finality int64) {
+	setCall(l1, c.portal2.ProofMaturityDelaySeconds(), big.NewInt(maturity))
+	setCall(l1, c.portal2.DisputeGameFinalityDelaySeconds(), big.NewInt(finality))
+}
+
+func setProofs(c *Client, l1 *fakeEthClient, hash common.Hash, proofs ...Proof) {
+	setCall(l1, c.portal2.NumProofSubmitters(hash), big.NewInt(int64(len(proofs))))
+	for i, proof := range proofs {
+		setCall(l1, c.portal2.ProofSubmitters(hash, big.NewInt(int64(i))), proof.Submitter)
+		setCall(l1, c.portal2.ProvenWithdrawals(hash, proof.Submitter), bindings.ProvenWithdrawalsResult{
+			DisputeGameProxy: proof.Game,
+			Timestamp:        proof.Timestamp,
+		})
+	}
+}
+
+func TestOutputCovering(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("L2OutputOracle", func(t *testing.T) {
+		c, l1 := newOracleClient(t)
+		// Without outputs, the latest block number is the starting block number
+		setOutputs(c, l1, 0, 200)
+		output, err := c.OutputCovering(ctx, 150)
+		require.NoError(t, err)
+		require.Nil(t, output, "no output proposed yet")
+
+		setOutputs(c, l1, 3, 200)
+		setCall(l1, c.oracle.GetL2OutputIndexAfter(big.NewInt(150)), big.NewInt(2))
+		setCall(l1, c.oracle.GetL2OutputIndexAfter(big.NewInt(200)), big.NewInt(2))
+		root := setOutput(c, l1, 2, 200, 1000)
+
+		output, err = c.OutputCovering(ctx, 201)
+		require.NoError(t, err)
+		require.Nil(t, output, "block after the latest output is not covered yet")
+
+		for _, block := range []uint64{150, 200} {
+			output, err = c.OutputCovering(ctx, block)
+			require.NoError(t, err)
+			require.Equal(t, &Output{Index: big.NewInt(2), OutputRoot: root, L2BlockNumber: 200, Timestamp: 1000}, output)
+		}
+	})
+
+	t.Run("DisputeGames", func(t *testing.T) {
+		c, l1 := newGameClient(t)
+		setCall(l1, c.portal2.RespectedGameType(), uint32(1))
+		setCall(l1, c.factory.GameCount(), new(big.Int))
+		output, err := c.OutputCovering(ctx, 150)
+		require.NoError(t, err)
+		require.Nil(t, output, "no game created yet")
+
+		proxy := common.Address{0xdd}
+		root := setLatestGame(c, l1, 4, 300, 1000, proxy)
+
+		output, err = c.OutputCovering(ctx, 301)
+		require.NoError(t, err)
+		require.Nil(t, output, "block after the latest game is not covered yet")
+
+		for _, block := range []uint64{150, 300} {
+			output, err = c.OutputCovering(ctx, block)
+			require.NoError(t, err)
+			require.Equal(t, &Output{Index: big.NewInt(4), OutputRoot: root, L2BlockNumber: 300, Timestamp: 1000, Game: proxy}, output)
+		}
+	})
+}
+
+func TestCheckProof(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("L2OutputOracle", func(t *testing.T) {
+		c, l1 := newOracleClient(t)
+		setCall(l1, c.oracle.FINALIZATIONPERIODSECONDS(), big.NewInt(100))
+		setOutputs(c, l1, 3, 300)
+		root1 := setOutput(c, l1, 1, 100, 1000)
+		root2 := setOutput(c, l1, 2, 200, 2000)
+
+		proven, err := c.checkProof(ctx, Proof{Timestamp: 1500, OutputRoot: root1, OutputIndex: big.NewInt(1)})
+		require.NoError(t, err)
+		// The finalization period must have elapsed strictly since the proof
+		require.Equal(t, uint64(1601), proven.FinalizableAt, "proven after the output was proposed")
+		require.Equal(t, root1, proven.Output.OutputRoot)
+
+		proven, err = c.checkProof(ctx, Proof{Timestamp: 1500, OutputRoot: root2, OutputIndex: big.NewInt(2)})
+		require.NoError(t, err)
+		require.Equal(t, uint64(2101), proven.FinalizableAt, "proven before the output was proposed")
+
+		_, err = c.checkProof(ctx, Proof{Timestamp: 1500, OutputRoot: common.Hash{0xff}, OutputIndex: big.NewInt(2)})
+		require.ErrorIs(t, err, ErrInvalidProof, "output was replaced")
+		_, err = c.checkProof(ctx, Proof{Timestamp: 1500, OutputRoot: root2, OutputIndex: big.NewInt(3)})
+		require.ErrorIs(t, err, ErrInvalidProof, "output was deleted")
+	})
+
+	t.Run("DisputeGames", func(t *testing.T) {
+		c, l1 := newGameClient(t)
+		setGameDelays(c, l1, 100, 50)
+		early, late := common.Address{0x01}, common.Address{0x02}
+		inProgress, lost := common.Address{0x03}, common.Address{0x04}
+		setGame(l1, early, gameTypes.GameStatusDefenderWon, 1000)
+		setGame(l1, late, gameTypes.GameStatusDefenderWon, 2000)
+		setGame(l1, inProgress, gameTypes.GameStatusInProgress, 0)
+		setGame(l1, lost, gameTypes.GameStatusChallengerWon, 1000)
+
+		proven, err := c.checkProof(ctx, Proof{Timestamp: 1500, Game: early})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1601), proven.FinalizableAt, "proof maturity ends after the game finality delay")
+		require.Equal(t, &Output{OutputRoot: common.Hash(early.Hash()), L2BlockNumber: 200, Timestamp: 900, Game: early}, proven.Output)
+
+		proven, err = c.checkProof(ctx, Proof{Timestamp: 1500, Game: late})
+		require.NoError(t, err)
+		require.Equal(t, uint64(2051), proven.FinalizableAt, "game finality delay ends after the proof maturity")
+
+		proven, err = c.checkProof(ctx, Proof{Timestamp: 1500, Game: inProgress})
+		require.NoError(t, err)
+		require.Zero(t, proven.FinalizableAt, "game is not resolved yet")
+
+		_, err = c.checkProof(ctx, Proof{Timestamp: 1500, Game: lost})
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+}
+
+func TestSelectProof(t *testing.T) {
+	sender, other := common.Address{0x22}, common.Address{0x33}
+	const now = 10_000
+	ready := func(submitter common.Address) provenWithdrawal {
+		return provenWithdrawal{Proof: Proof{Submitter: submitter}, FinalizableAt: now - 1000}
+	}
+	pending := func(submitter common.Address, remaining uint64) provenWithdrawal {
+		return provenWithdrawal{Proof: Proof{Submitter: submitter}, FinalizableAt: now + remaining}
+	}
+	unresolved := func(submitter common.Address) provenWithdrawal {
+		return provenWithdrawal{Proof: Proof{Submitter: submitter}}
+	}
+
+	for _, test := range []struct {
+		name     string
+		proven   []provenWithdrawal
+		expected provenWithdrawal
+	}{
+		{"PrefersSender", []provenWithdrawal{ready(other), ready(sender)}, ready(sender)},
+		{"FallsBackToOtherSubmitter", []provenWithdrawal{pending(sender, 500), unresolved(sender), ready(other)}, ready(other)},
+		{"EarliestFinalizable", []provenWithdrawal{unresolved(sender), pending(sender, 5000), pending(other, 500)}, pending(other, 500)},
+		{"Unresolved", []provenWithdrawal{unresolved(other)}, unresolved(other)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			proof, ok := selectProof(test.proven, sender, now)
+			require.True(t, ok)
+			require.Equal(t, test.expected, proof)
+		})
+	}
+
+	t.Run("NotProven", func(t *testing.T) {
+		_, ok := selectProof(nil, sender, now)
+		require.False(t, ok)
+	})
+}
+
+func TestWithdrawalState(t *testing.T) {
+	output := &Output{Index: big.NewInt(3), OutputRoot: common.Hash{0x01}, L2BlockNumber: 50, Timestamp: 1000}
+	proven := provenWithdrawal{Proof: Proof{Timestamp: 1010}, Output: output, FinalizableAt: 1111}
+
+	t.Run("WaitingForOutput", func(t *testing.T) {
+		state := withdrawalState(nil, nil, common.Address{}, 2000)
+		require.Equal(t, WithdrawalWaitingForOutput, state.Status)
+		require.Nil(t, state.Output)
+	})
+
+	t.Run("ReadyToProve", func(t *testing.T) {
+		state := withdrawalState(nil, output, common.Address{}, 2000)
+		require.Equal(t, WithdrawalReadyToProve, state.Status)
+		require.Equal(t, output, state.Output)
+	})
+
+	t.Run("Proven", func(t *testing.T) {
+		state := withdrawalState([]provenWithdrawal{proven}, nil, common.Address{}, 1110)
+		require.Equal(t, WithdrawalProven, state.Status)
+		require.Equal(t, output, state.Output)
+		require.Equal(t, uint64(1010), state.ProvenAt)
+		require.Equal(t, uint64(1111), state.FinalizableAt)
+	})
+
+	t.Run("ReadyToFinalize", func(t *testing.T) {
+		state := withdrawalState([]provenWithdrawal{proven}, nil, common.Address{}, 1111)
+		require.Equal(t, WithdrawalReadyToFinalize, state.Status)
+	})
+
+	t.Run("GameNotResolved", func(t *testing.T) {
+		unresolved := proven
+		unresolved.FinalizableAt = 0
+		state := withdrawalState([]provenWithdrawal{unresolved}, nil, common.Address{}, 2000)
+		require.Equal(t, WithdrawalProven, state.Status)
+		require.Zero(t, state.FinalizableAt)
+	})
+}
+
+func TestProveWithdrawalGuards(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWithdrawal(t)
+
+	t.Run("AlreadyFinalized", func(t *testing.T) {
+		c, l1 := newOracleClient(t)
+		setCall(l1, c.portal.FinalizedWithdrawals(w.Hash), true)
+		sender := &fakeSender{}
+		_, err := c.ProveWithdrawal(ctx, sender, w)
+		require.ErrorIs(t, err, ErrAlreadyFinal)
+		require.Empty(t, sender.sent)
+	})
+
+	t.Run("AlreadyProven", func(t *testing.T) {
+		c, l1 := newOracleClient(t)
+		setCall(l1, c.portal.FinalizedWithdrawals(w.Hash), false)
+		setCall(l1, c.oracle.FINALIZATIONPERIODSECONDS(), big.NewInt(100))
+		setOutputs(c, l1, 3, 300)
+		root := setOutput(c, l1, 2, 200, 1000)
+		setCall(l1, c.portal.ProvenWithdrawals(w.Hash), bindings.MantleProvenWithdrawal{OutputRoot: root, Timestamp: big.NewInt(1010), L2OutputIndex: big.NewInt(2)})
+		sender := &fakeSender{}
+		_, err := c.ProveWithdrawal(ctx, sender, w)
+		require.ErrorIs(t, err, ErrAlreadyProven)
+		require.Empty(t, sender.sent)
+	})
+}
+
+// requireSent checks that the sender sent exactly the call.
+func requireSent(t *testing.T, s *fakeSender, call bindings.TypedCall[any]) {
+	expected, err := candidate(call, nil)
+	require.NoError(t, err)
+	require.Len(t, s.sent, 1)
+	require.Equal(t, expected.TxData, s.sent[0].TxData)
+	require.Equal(t, expected.To, s.sent[0].To)
+}
+
+func TestFinalizeWithdrawal(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWithdrawal(t)
+	sender, other := common.Address{0x22}, common.Address{0x33}
+
+	newOracle := func(t *testing.T, proven bindings.MantleProvenWithdrawal) (*Client, *fakeEthClient) {
+		c, l1 := newOracleClient(t)
+		setCall(l1, c.portal.FinalizedWithdrawals(w.Hash), false)
+		setCall(l1, c.oracle.FINALIZATIONPERIODSECONDS(), big.NewInt(100))
+		setOutputs(c, l1, 3, 300)
+		setCall(l1, c.portal.ProvenWithdrawals(w.Hash), proven)
+		return c, l1
+	}
+	newGames := func(t *testing.T, proofs ...Proof) (*Client, *fakeEthClient) {
+		c, l1 := newGameClient(t)
+		setCall(l1, c.portal.FinalizedWithdrawals(w.Hash), false)
+		setGameDelays(c, l1, 100, 0)
+		setProofs(c, l1, w.Hash, proofs...)
+		return c, l1
+	}
+
+	t.Run("NoSender", func(t *testing.T) {
+		c, _ := newOracleClient(t)
+		_, err := c.FinalizeWithdrawal(ctx, nil, w)
+		require.ErrorIs(t, err, ErrNoSender)
+	})
+
+	t.Run("AlreadyFinalized", func(t *testing.T) {
+		c, l1 := newOracleClient(t)
+		setCall(l1, c.portal.FinalizedWithdrawals(w.Hash), true)
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.ErrorIs(t, err, ErrAlreadyFinal)
+		require.Empty(t, s.sent)
+	})
+
+	t.Run("NotProven", func(t *testing.T) {
+		c, l1 := newOracle(t, bindings.MantleProvenWithdrawal{Timestamp: new(big.Int), L2OutputIndex: new(big.Int)})
+		setOutputs(c, l1, 1, 100)
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.ErrorIs(t, err, ErrNotProven)
+		require.Empty(t, s.sent)
+	})
+
+	t.Run("NotFinalizableYet", func(t *testing.T) {
+		c, l1 := newOracle(t, bindings.MantleProvenWithdrawal{OutputRoot: common.Hash{0x03}, Timestamp: big.NewInt(1010), L2OutputIndex: big.NewInt(2)})
+		setOutput(c, l1, 2, 200, 1000)
+		l1.time = 1110
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.ErrorIs(t, err, ErrNotFinalizable)
+		require.Empty(t, s.sent)
+	})
+
+	t.Run("L2OutputOracle", func(t *testing.T) {
+		c, l1 := newOracle(t, bindings.MantleProvenWithdrawal{OutputRoot: common.Hash{0x03}, Timestamp: big.NewInt(1010), L2OutputIndex: big.NewInt(2)})
+		setOutput(c, l1, 2, 200, 1000)
+		l1.time = 1111
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.NoError(t, err)
+		requireSent(t, s, c.portal.FinalizeWithdrawalTransaction(withdrawalTransaction(w.Transaction)))
+	})
+
+	t.Run("GameNotResolved", func(t *testing.T) {
+		game := common.Address{0x01}
+		c, l1 := newGames(t, Proof{Submitter: sender, Timestamp: 1000, Game: game})
+		setGame(l1, game, gameTypes.GameStatusInProgress, 0)
+		l1.time = 5000
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.ErrorIs(t, err, ErrNotFinalizable)
+		require.ErrorContains(t, err, "not resolved")
+		require.Empty(t, s.sent)
+	})
+
+	t.Run("OwnProof", func(t *testing.T) {
+		own, external := common.Address{0x01}, common.Address{0x02}
+		c, l1 := newGames(t, Proof{Submitter: other, Timestamp: 1000, Game: external}, Proof{Submitter: sender, Timestamp: 1000, Game: own})
+		setGame(l1, own, gameTypes.GameStatusDefenderWon, 1000)
+		setGame(l1, external, gameTypes.GameStatusDefenderWon, 1000)
+		l1.time = 5000
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.NoError(t, err)
+		requireSent(t, s, c.portal.FinalizeWithdrawalTransaction(withdrawalTransaction(w.Transaction)))
+	})
+
+	t.Run("ExternalProof", func(t *testing.T) {
+		lost, external := common.Address{0x01}, common.Address{0x02}
+		c, l1 := newGames(t, Proof{Submitter: sender, Timestamp: 1000, Game: lost}, Proof{Submitter: other, Timestamp: 1000, Game: external})
+		setGame(l1, lost, gameTypes.GameStatusChallengerWon, 1000)
+		setGame(l1, external, gameTypes.GameStatusDefenderWon, 1000)
+		l1.time = 5000
+		s := &fakeSender{from: sender}
+		_, err := c.FinalizeWithdrawal(ctx, s, w)
+		require.NoError(t, err)
+		requireSent(t, s, c.portal.FinalizeWithdrawalTransactionExternalProof(withdrawalTransaction(w.Transaction), other))
+	})
+}
+
+func TestWithdrawalJSON(t *testing.T) {
+	sender, target := common.Address{0xaa}, common.Address{0xbb}
+	tx := crossdomain.NewMantleWithdrawal(big.NewInt(1), &sender, &target, big.NewInt(2), big.NewInt(3), big.NewInt(100_000), []byte{0x01})
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	w := &Withdrawal{L2TxHash: common.Hash{0x01}, L2BlockNumber: 10, Hash: hash, Transaction: tx}
+
+	data, err := json.Marshal(w)
+	require.NoError(t, err)
+	var loaded Withdrawal
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	require.Equal(t, w, &loaded)
+
+	loadedHash, err := loaded.Transaction.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, loadedHash)
+	require.Equal(t, bindings.MantleWithdrawalTransaction{
+		Nonce:    big.NewInt(1),
+		Sender:   sender,
+		Target:   target,
+		MNTValue: big.NewInt(2),
+		ETHValue: big.NewInt(3),
+		GasLimit: big.NewInt(100_000),
+		Data:     []byte{0x01},
+	}, withdrawalTransaction(loaded.Transaction))
+}
@@ -1,7 +1,11 @@
 package eth
 
 import (
+	"encoding/json"
+	"fmt"
 	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // FCUState represents the Fork Choice Update state with Latest, Safe, and Finalized block numbers
@@ -44,6 +48,35 @@ type SyncTesterSession struct {
 	ELSyncActive bool         `json:"el_sync_active"`
 
 	InitialState FCUState `json:"initial_state"`
+
+	// Divergence is the first engine API call of the session that did not match the expected chain
+	Divergence *SyncTesterDivergence `json:"divergence,omitempty"`
+}
+
+// SyncTesterDivergence describes where the chain built by the node under test
+// first diverged from the chain served by the sync tester.
+type SyncTesterDivergence struct {
+	// Method is the engine API method that diverged, without version suffix, e.g. engine_newPayload
+	Method string `json:"method"`
+	// BlockNumber is the number of the block that diverged
+	BlockNumber uint64 `json:"block_number"`
+	// ExpectedHash is the hash of the expected block at BlockNumber
+	ExpectedHash common.Hash `json:"expected_hash"`
+	// Reason is the validation error that caused the divergence
+	Reason string `json:"reason"`
+	// Diff lists the fields that differ between the expected and the received data
+	Diff []SyncTesterFieldDiff `json:"diff,omitempty"`
+}
+
+// SyncTesterFieldDiff is a single field that differs between the expected and the received data.
+type SyncTesterFieldDiff struct {
+	Field    string          `json:"field"`
+	Expected json.RawMessage `json:"expected,omitempty"`
+	Actual   json.RawMessage `json:"actual,omitempty"`
+}
+
+func (d SyncTesterFieldDiff) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Field, d.Expected, d.Actual)
 }
 
 func (s *SyncTesterSession) UpdateFCULatest(latest uint64) {
@@ -62,6 +95,7 @@ func (s *SyncTesterSession) ResetSession() {
 	s.CurrentState = s.InitialState
 	s.Validated = s.InitialState.Latest
 	s.Payloads = make(map[PayloadID]*ExecutionPayloadEnvelope)
+	s.Divergence = nil
 }
 
 func (s *SyncTesterSession) IsELSyncActive() bool {
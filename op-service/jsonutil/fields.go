@@ -0,0 +1,27 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Fields encodes v as a JSON object, and returns its top-level fields.
+// The field values are compacted, so that equal values can be compared byte by byte.
+func Fields(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, raw := range fields {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err != nil {
+			return nil, err
+		}
+		fields[name] = buf.Bytes()
+	}
+	return fields, nil
+}
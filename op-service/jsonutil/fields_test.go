@@ -0,0 +1,34 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type spacedValue struct{}
+
+func (spacedValue) MarshalJSON() ([]byte, error) {
+	return []byte(`{ "x" : [ 1, 2 ] }`), nil
+}
+
+func TestFields(t *testing.T) {
+	type testStruct struct {
+		A string      `json:"a"`
+		B *int        `json:"b"`
+		C spacedValue `json:"c"`
+		D int         `json:"d,omitempty"`
+	}
+
+	fields, err := Fields(testStruct{A: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]json.RawMessage{
+		"a": json.RawMessage(`"hello"`),
+		"b": json.RawMessage(`null`),
+		"c": json.RawMessage(`{"x":[1,2]}`),
+	}, fields)
+
+	_, err = Fields([]int{1})
+	require.Error(t, err, "not a JSON object")
+}
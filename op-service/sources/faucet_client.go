@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 
+	ftypes "github.com/ethereum-optimism/optimism/op-faucet/faucet/backend/types"
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -36,3 +37,19 @@ func (cl *FaucetClient) Balance(ctx context.Context) (eth.ETH, error) {
 	err := cl.client.CallContext(ctx, &result, "faucet_balance")
 	return result, err
 }
+
+// Request requests funds with the native currency or a token, from a faucet that may be public,
+// and returns the hash of the funding tx.
+func (cl *FaucetClient) Request(ctx context.Context, request ftypes.FundsRequest) (common.Hash, error) {
+	var result common.Hash
+	err := cl.client.CallContext(ctx, &result, "faucet_request", request)
+	return result, err
+}
+
+// Challenge fetches the challenge to solve before requesting funds for addr.
+// It returns nil if the faucet does not require a challenge.
+func (cl *FaucetClient) Challenge(ctx context.Context, addr common.Address) (*ftypes.Challenge, error) {
+	var result *ftypes.Challenge
+	err := cl.client.CallContext(ctx, &result, "faucet_challenge", addr)
+	return result, err
+}
@@ -109,3 +109,25 @@ func (pc *P2PClient) ConnectPeer(ctx context.Context, addr string) error {
 func (pc *P2PClient) DisconnectPeer(ctx context.Context, id peer.ID) error {
 	return pc.client.CallContext(ctx, nil, prefixP2PRPC("disconnectPeer"), id)
 }
+
+func (pc *P2PClient) ListPeerGroups(ctx context.Context) ([]*apis.PeerGroup, error) {
+	output := []*apis.PeerGroup{}
+	err := pc.client.CallContext(ctx, &output, prefixP2PRPC("listPeerGroups"))
+	return output, err
+}
+
+func (pc *P2PClient) AddPeerGroup(ctx context.Context, name string, maxConnected uint) error {
+	return pc.client.CallContext(ctx, nil, prefixP2PRPC("addPeerGroup"), name, maxConnected)
+}
+
+func (pc *P2PClient) RemovePeerGroup(ctx context.Context, name string) error {
+	return pc.client.CallContext(ctx, nil, prefixP2PRPC("removePeerGroup"), name)
+}
+
+func (pc *P2PClient) AddPeerToGroup(ctx context.Context, name string, addr string) error {
+	return pc.client.CallContext(ctx, nil, prefixP2PRPC("addPeerToGroup"), name, addr)
+}
+
+func (pc *P2PClient) RemovePeerFromGroup(ctx context.Context, name string, id peer.ID) error {
+	return pc.client.CallContext(ctx, nil, prefixP2PRPC("removePeerFromGroup"), name, id)
+}
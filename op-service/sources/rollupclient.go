@@ -41,6 +41,15 @@ func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (
 	return output, err
 }
 
+// SafeHeadsInRange returns the safe head updates recorded at L1 blocks in the inclusive range [start, end].
+// The node limits the number of updates per call, see op-node's MaxSafeHeadsInRange:
+// continue from the L1 block after the last returned update to fetch the rest of the range.
+func (r *RollupClient) SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error) {
+	var output []eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadsInRange", hexutil.Uint64(start), hexutil.Uint64(end))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
@@ -75,6 +84,24 @@ func (r *RollupClient) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return result, err
 }
 
+func (r *RollupClient) ScheduleSequencerStop(ctx context.Context, blockNum uint64) error {
+	return r.rpc.CallContext(ctx, nil, "admin_scheduleSequencerStop", hexutil.Uint64(blockNum))
+}
+
+func (r *RollupClient) ScheduleSequencerStopAtTime(ctx context.Context, timestamp uint64) (uint64, error) {
+	var result hexutil.Uint64
+	err := r.rpc.CallContext(ctx, &result, "admin_scheduleSequencerStopAtTime", hexutil.Uint64(timestamp))
+	return uint64(result), err
+}
+
+func (r *RollupClient) ScheduleSequencerStart(ctx context.Context, blockNum uint64, blockHash common.Hash) error {
+	return r.rpc.CallContext(ctx, nil, "admin_scheduleSequencerStart", hexutil.Uint64(blockNum), blockHash)
+}
+
+func (r *RollupClient) CancelSequencerHandover(ctx context.Context) error {
+	return r.rpc.CallContext(ctx, nil, "admin_cancelSequencerHandover")
+}
+
 func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	var result bool
 	err := r.rpc.CallContext(ctx, &result, "admin_sequencerActive")
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-test-sequencer/sequencer/backend/work"
 	"github.com/ethereum-optimism/optimism/op-test-sequencer/sequencer/seqtypes"
 )
@@ -60,6 +61,13 @@ func (sc *BuilderClient) Seal(ctx context.Context, jobID seqtypes.BuildJobID) (r
 	return result, err
 }
 
+// SealEnvelope seals the job like Seal, and decodes the sealed block as execution payload envelope,
+// as built by the standard builder.
+func (sc *BuilderClient) SealEnvelope(ctx context.Context, jobID seqtypes.BuildJobID) (result *eth.ExecutionPayloadEnvelope, err error) {
+	err = sc.client.CallContext(ctx, &result, "build_seal", jobID)
+	return result, err
+}
+
 func (sc *BuilderClient) CloseJob(id seqtypes.BuildJobID) error {
 	return sc.client.CallContext(context.Background(), nil, "build_closeJob", id)
 }
@@ -77,6 +77,10 @@ func (sc *ControlClient) Publish(ctx context.Context) error {
 	return sc.client.CallContext(ctx, nil, "sequencer_publish")
 }
 
+func (sc *ControlClient) Reset(ctx context.Context) error {
+	return sc.client.CallContext(ctx, nil, "sequencer_reset")
+}
+
 func (sc *ControlClient) Seal(ctx context.Context) error {
 	return sc.client.CallContext(ctx, nil, "sequencer_seal")
 }
@@ -23,6 +23,11 @@ type Proxy struct {
 	lgr          log.Logger
 	upstreamAddr string
 	stopped      atomic.Bool
+
+	// delay is added once before every burst of data that is sent back downstream, in nanoseconds.
+	delay atomic.Int64
+	// resume is non-nil while the proxy is paused, and closed when it is resumed.
+	resume chan struct{}
 }
 
 func New(lgr log.Logger) *Proxy {
@@ -43,6 +48,47 @@ func (p *Proxy) SetUpstream(addr string) {
 	p.mu.Unlock()
 }
 
+// SetDelay delays every burst of data that is sent back downstream by the given duration,
+// e.g. to simulate a slow upstream. A burst is all data sent downstream after data was sent upstream,
+// so the delay is applied once per response of a request-response protocol like JSON-RPC,
+// however many chunks the response is read in. A zero duration removes the delay.
+func (p *Proxy) SetDelay(d time.Duration) {
+	p.delay.Store(int64(d))
+	p.lgr.Info("set delay", "delay", d)
+}
+
+// Pause stops forwarding data in both directions, without closing any connections.
+// New connections are still accepted, but stall until the proxy is resumed.
+func (p *Proxy) Pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resume == nil {
+		p.resume = make(chan struct{})
+		p.lgr.Info("paused")
+	}
+}
+
+// Resume continues forwarding data after Pause.
+func (p *Proxy) Resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resume != nil {
+		close(p.resume)
+		p.resume = nil
+		p.lgr.Info("resumed")
+	}
+}
+
+// waitResumed blocks while the proxy is paused.
+func (p *Proxy) waitResumed() {
+	p.mu.Lock()
+	resume := p.resume
+	p.mu.Unlock()
+	if resume != nil {
+		<-resume
+	}
+}
+
 func (p *Proxy) Start() error {
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -110,9 +156,19 @@ func (p *Proxy) handleConn(downConn net.Conn) {
 		wg.Done()
 	}
 
+	// burst is set when data was sent upstream, so the next data sent downstream starts a new, delayed burst.
+	var burst atomic.Bool
 	pump := func(dst io.Writer, src io.Reader, direction string) {
 		defer closeBoth()
-		if _, err := io.Copy(dst, src); err != nil {
+		beforeWrite := func() { burst.Store(true) }
+		if direction == "downstream" {
+			beforeWrite = func() {
+				if d := time.Duration(p.delay.Load()); d > 0 && burst.Swap(false) {
+					time.Sleep(d)
+				}
+			}
+		}
+		if err := p.forward(dst, src, beforeWrite); err != nil {
 			// ignore net.ErrClosed since it creates a huge amount of log spam
 			if !errors.Is(err, net.ErrClosed) {
 				p.lgr.Error("failed to proxy", "direction", direction, "err", err)
@@ -129,8 +185,31 @@ func (p *Proxy) handleConn(downConn net.Conn) {
 	p.mu.Unlock()
 }
 
+// forward is like io.Copy, but holds every chunk of data while the proxy is paused,
+// and calls beforeWrite before forwarding the chunk.
+func (p *Proxy) forward(dst io.Writer, src io.Reader, beforeWrite func()) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			p.waitResumed()
+			beforeWrite()
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (p *Proxy) Close() error {
 	p.stopped.Store(true)
+	p.Resume()
 	p.lis.Close()
 	p.mu.Lock()
 	for conn := range p.conns {
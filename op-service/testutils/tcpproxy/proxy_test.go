@@ -0,0 +1,148 @@
+package tcpproxy
+
+import (
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// startEcho starts a TCP server that echoes back everything it receives.
+func startEcho(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// startResponder starts a TCP server that answers every byte it receives with size bytes.
+func startResponder(t *testing.T, size int) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				resp := make([]byte, size)
+				req := make([]byte, 1)
+				for {
+					if _, err := conn.Read(req); err != nil {
+						return
+					}
+					if _, err := conn.Write(resp); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func setupProxy(t *testing.T) (*Proxy, net.Conn) {
+	return setupProxyTo(t, startEcho(t))
+}
+
+func setupProxyTo(t *testing.T, upstream string) (*Proxy, net.Conn) {
+	p := New(testlog.Logger(t, log.LevelInfo))
+	require.NoError(t, p.Start())
+	t.Cleanup(func() { _ = p.Close() })
+	p.SetUpstream(upstream)
+
+	conn, err := net.Dial("tcp", p.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return p, conn
+}
+
+func roundTrip(t *testing.T, conn net.Conn, msg string, timeout time.Duration) error {
+	_, err := conn.Write([]byte(msg))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(timeout)))
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return err
+	}
+	require.Equal(t, msg, string(buf))
+	return nil
+}
+
+func TestProxy(t *testing.T) {
+	_, conn := setupProxy(t)
+	require.NoError(t, roundTrip(t, conn, "hello", 5*time.Second))
+}
+
+func TestProxyPause(t *testing.T) {
+	p, conn := setupProxy(t)
+	require.NoError(t, roundTrip(t, conn, "before", 5*time.Second))
+
+	p.Pause()
+	err := roundTrip(t, conn, "paused", 200*time.Millisecond)
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	// The data held back while paused is delivered once resumed
+	p.Resume()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, len("paused"))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, "paused", string(buf))
+	require.NoError(t, roundTrip(t, conn, "after", 5*time.Second))
+}
+
+func TestProxyDelay(t *testing.T) {
+	p, conn := setupProxy(t)
+	const delay = 300 * time.Millisecond
+	p.SetDelay(delay)
+	start := time.Now()
+	require.NoError(t, roundTrip(t, conn, "slow", 5*time.Second))
+	require.GreaterOrEqual(t, time.Since(start), delay)
+
+	p.SetDelay(0)
+	start = time.Now()
+	require.NoError(t, roundTrip(t, conn, "fast", 5*time.Second))
+	require.Less(t, time.Since(start), delay)
+}
+
+func TestProxyDelayPerBurst(t *testing.T) {
+	// The response is forwarded in many chunks, but only delayed once
+	const size = 4 * 1024 * 1024
+	p, conn := setupProxyTo(t, startResponder(t, size))
+	const delay = 300 * time.Millisecond
+	p.SetDelay(delay)
+
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		_, err := conn.Write([]byte{0x01})
+		require.NoError(t, err)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, err = io.ReadFull(conn, make([]byte, size))
+		require.NoError(t, err)
+		elapsed := time.Since(start)
+		require.GreaterOrEqual(t, elapsed, delay, "every response is delayed")
+		require.Less(t, elapsed, 3*delay, "response is delayed once, not per chunk")
+	}
+}
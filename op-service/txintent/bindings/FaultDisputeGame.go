@@ -46,6 +46,9 @@ type FaultDisputeGame struct {
 	L2SequenceNumber func() TypedCall[*big.Int]    `sol:"l2SequenceNumber"`
 	Status           func() TypedCall[uint8]       `sol:"status"`
 	GameType         func() TypedCall[uint32]      `sol:"gameType"`
+	RootClaim        func() TypedCall[common.Hash] `sol:"rootClaim"`
+	CreatedAt        func() TypedCall[uint64]      `sol:"createdAt"`
+	ResolvedAt       func() TypedCall[uint64]      `sol:"resolvedAt"`
 
 	// IFaultDisputeGame.sol read methods
 	AbsolutePrestate       func() TypedCall[common.Hash]                                              `sol:"absolutePrestate"`
@@ -0,0 +1,33 @@
+package bindings
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type MantleOutputProposal struct {
+	OutputRoot    [32]byte
+	Timestamp     *big.Int
+	L2BlockNumber *big.Int
+}
+
+// MantleL2OutputOracle matches Mantle's L2OutputOracle ABI.
+type MantleL2OutputOracle struct {
+	// Read-only functions
+	CHALLENGER                func() TypedCall[common.Address]                             `sol:"CHALLENGER"`
+	FINALIZATIONPERIODSECONDS func() TypedCall[*big.Int]                                   `sol:"FINALIZATION_PERIOD_SECONDS"`
+	L2BLOCKTIME               func() TypedCall[*big.Int]                                   `sol:"L2_BLOCK_TIME"`
+	PROPOSER                  func() TypedCall[common.Address]                             `sol:"PROPOSER"`
+	SUBMISSIONINTERVAL        func() TypedCall[*big.Int]                                   `sol:"SUBMISSION_INTERVAL"`
+	GetL2Output               func(l2OutputIndex *big.Int) TypedCall[MantleOutputProposal] `sol:"getL2Output"`
+	GetL2OutputAfter          func(l2BlockNumber *big.Int) TypedCall[MantleOutputProposal] `sol:"getL2OutputAfter"`
+	GetL2OutputIndexAfter     func(l2BlockNumber *big.Int) TypedCall[*big.Int]             `sol:"getL2OutputIndexAfter"`
+	LatestBlockNumber         func() TypedCall[*big.Int]                                   `sol:"latestBlockNumber"`
+	LatestOutputIndex         func() TypedCall[*big.Int]                                   `sol:"latestOutputIndex"`
+	NextBlockNumber           func() TypedCall[*big.Int]                                   `sol:"nextBlockNumber"`
+	NextOutputIndex           func() TypedCall[*big.Int]                                   `sol:"nextOutputIndex"`
+	StartingBlockNumber       func() TypedCall[*big.Int]                                   `sol:"startingBlockNumber"`
+	StartingTimestamp         func() TypedCall[*big.Int]                                   `sol:"startingTimestamp"`
+	Version                   func() TypedCall[string]                                     `sol:"version"`
+}
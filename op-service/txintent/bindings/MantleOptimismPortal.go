@@ -49,8 +49,10 @@ type MantleOptimismPortal struct {
 	DepositTransaction            func(ethTxValue eth.ETH, mntValue eth.ETH, to common.Address, mntTxValue eth.ETH, gasLimit uint64, isCreation bool, data []byte) TypedCall[any] `sol:"depositTransaction"`
 	DonateETH                     func() TypedCall[any]                                                                                                                           `sol:"donateETH"`
 	FinalizeWithdrawalTransaction func(tx MantleWithdrawalTransaction) TypedCall[any]                                                                                             `sol:"finalizeWithdrawalTransaction"`
-	ProveWithdrawalTransaction    func(tx MantleWithdrawalTransaction, l2OutputIndex *big.Int, outputRootProof MantleOutputRootProof, withdrawalProof [][]byte) TypedCall[any]    `sol:"proveWithdrawalTransaction"`
-	Pause                         func() TypedCall[any]                                                                                                                           `sol:"pause"`
-	Unpause                       func() TypedCall[any]                                                                                                                           `sol:"unpause"`
-	Receive                       func() TypedCall[any]                                                                                                                           `sol:"receive"`
+	// FinalizeWithdrawalTransactionExternalProof is only available on portals that prove withdrawals against dispute games.
+	FinalizeWithdrawalTransactionExternalProof func(tx MantleWithdrawalTransaction, proofSubmitter common.Address) TypedCall[any]                                                           `sol:"finalizeWithdrawalTransactionExternalProof"`
+	ProveWithdrawalTransaction                 func(tx MantleWithdrawalTransaction, l2OutputIndex *big.Int, outputRootProof MantleOutputRootProof, withdrawalProof [][]byte) TypedCall[any] `sol:"proveWithdrawalTransaction"`
+	Pause                                      func() TypedCall[any]                                                                                                                        `sol:"pause"`
+	Unpause                                    func() TypedCall[any]                                                                                                                        `sol:"unpause"`
+	Receive                                    func() TypedCall[any]                                                                                                                        `sol:"receive"`
 }
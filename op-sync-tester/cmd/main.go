@@ -51,6 +51,7 @@ func run(ctx context.Context, w io.Writer, ew io.Writer, args []string, fn synct
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		recordCommand(),
 	}
 	return app.RunContext(ctx, args)
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-sync-tester/flags"
+	"github.com/ethereum-optimism/optimism/op-sync-tester/synctester/backend"
+)
+
+var (
+	RecordELRPCFlag = &cli.StringFlag{
+		Name:     "el-rpc",
+		Usage:    "RPC of the EL to record the blocks from",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "RECORD_EL_RPC"),
+		Required: true,
+	}
+	RecordFromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "First block number to record",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "RECORD_FROM"),
+		Required: true,
+	}
+	RecordToFlag = &cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "Last block number to record (inclusive)",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "RECORD_TO"),
+		Required: true,
+	}
+	RecordOutFlag = &cli.StringFlag{
+		Name:     "out",
+		Usage:    "Path to write the recording to. Compressed if the path ends with .gz",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "RECORD_OUT"),
+		Required: true,
+	}
+)
+
+func recordCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "record",
+		Usage: "Records a block range of an EL, to replay it later with the recording sync tester config option",
+		Flags: append([]cli.Flag{RecordELRPCFlag, RecordFromFlag, RecordToFlag, RecordOutFlag}, oplog.CLIFlags(flags.EnvVarPrefix)...),
+		Action: func(cliCtx *cli.Context) error {
+			logger := oplog.NewLogger(cliCtx.App.Writer, oplog.ReadCLIConfig(cliCtx))
+			ctx := ctxinterrupt.WithCancelOnInterrupt(cliCtx.Context)
+			elClient, err := ethclient.DialContext(ctx, cliCtx.String(RecordELRPCFlag.Name))
+			if err != nil {
+				return err
+			}
+			defer elClient.Close()
+			rec, err := backend.RecordELRange(ctx, logger, backend.NewELReader(elClient), cliCtx.Uint64(RecordFromFlag.Name), cliCtx.Uint64(RecordToFlag.Name))
+			if err != nil {
+				return err
+			}
+			return backend.WriteRecording(cliCtx.String(RecordOutFlag.Name), rec)
+		},
+	}
+}
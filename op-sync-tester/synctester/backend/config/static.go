@@ -10,7 +10,10 @@ import (
 )
 
 type SyncTesterEntry struct {
-	ELRPC endpoint.MustRPC `yaml:"el_rpc"`
+	ELRPC endpoint.MustRPC `yaml:"el_rpc,omitempty"`
+
+	// Recording is the path of a recorded block range, which is replayed instead of reading from ELRPC.
+	Recording string `yaml:"recording,omitempty"`
 
 	// ChainID is used to sanity-check we are connected to the right chain,
 	// and never accidentally try to use a different chain for sync tester work.
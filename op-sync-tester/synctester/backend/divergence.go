@@ -0,0 +1,211 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	methodNewPayload         = "engine_newPayload"
+	methodForkchoiceUpdated  = "engine_forkchoiceUpdated"
+	maxDivergenceDiffEntries = 64
+)
+
+// reportDivergence records the divergence in the session, unless an earlier divergence was already recorded.
+// Only the first divergence is kept, as every later one usually follows from it.
+func (s *SyncTester) reportDivergence(session *eth.SyncTesterSession, logger log.Logger, method string, expected *types.Block, reason string, expectedData, actualData any) {
+	if session.Divergence != nil {
+		logger.Debug("Ignoring divergence after the first one", "method", method, "number", expected.NumberU64(), "first", session.Divergence.BlockNumber)
+		return
+	}
+	diff, err := diffFields(expectedData, actualData)
+	if err != nil {
+		logger.Warn("Failed to diff diverged data", "err", err)
+	}
+	div := &eth.SyncTesterDivergence{
+		Method:       method,
+		BlockNumber:  expected.NumberU64(),
+		ExpectedHash: expected.Hash(),
+		Reason:       reason,
+		Diff:         diff,
+	}
+	session.Divergence = div
+	fields := make([]string, 0, len(diff))
+	for _, d := range diff {
+		fields = append(fields, d.Field)
+	}
+	logger.Warn("Node diverged from the expected chain", "method", method, "number", div.BlockNumber, "expected", div.ExpectedHash, "reason", reason, "fields", fields)
+}
+
+// reportPayloadDivergence records a divergence between the given payload and the payload of the expected block.
+func (s *SyncTester) reportPayloadDivergence(session *eth.SyncTesterSession, logger log.Logger, expected *types.Block, payload *eth.ExecutionPayload, isIsthmus bool, reason string) {
+	config := &params.ChainConfig{}
+	if expected.Withdrawals() != nil {
+		config.CanyonTime = new(uint64)
+	}
+	if isIsthmus {
+		config.IsthmusTime = new(uint64)
+	}
+	expectedPayload, err := eth.BlockAsPayload(expected, config)
+	if err != nil {
+		logger.Warn("Failed to convert expected block to payload", "number", expected.NumberU64(), "err", err)
+		s.reportDivergence(session, logger, methodNewPayload, expected, reason, nil, nil)
+		return
+	}
+	s.reportDivergence(session, logger, methodNewPayload, expected, reason, expectedPayload, payload)
+}
+
+// reportAttributesDivergence records a divergence between the given payload attributes
+// and the attributes that build the expected block.
+func (s *SyncTester) reportAttributesDivergence(session *eth.SyncTesterSession, logger log.Logger, expected *types.Block, attr *eth.PayloadAttributes, isHolocene, isJovian bool, reason string) {
+	expectedAttr, err := attributesForBlock(attr, expected, isHolocene, isJovian)
+	if err != nil {
+		logger.Warn("Failed to derive expected payload attributes", "number", expected.NumberU64(), "err", err)
+		s.reportDivergence(session, logger, methodForkchoiceUpdated, expected, reason, nil, nil)
+		return
+	}
+	s.reportDivergence(session, logger, methodForkchoiceUpdated, expected, reason, expectedAttr, attr)
+}
+
+// attributesForBlock returns the payload attributes that build the given block.
+// Fields which cannot be derived from the block are copied from attr,
+// so they do not show up in the diff.
+func attributesForBlock(attr *eth.PayloadAttributes, block *types.Block, isHolocene, isJovian bool) (*eth.PayloadAttributes, error) {
+	h := block.Header()
+	expected := *attr
+	expected.Timestamp = eth.Uint64Quantity(h.Time)
+	expected.PrevRandao = eth.Bytes32(h.MixDigest)
+	expected.SuggestedFeeRecipient = h.Coinbase
+	expected.ParentBeaconBlockRoot = h.ParentBeaconRoot
+	expected.NoTxPool = true
+	gasLimit := eth.Uint64Quantity(h.GasLimit)
+	expected.GasLimit = &gasLimit
+	expected.Transactions = make([]eth.Data, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal block tx: %w", err)
+		}
+		expected.Transactions = append(expected.Transactions, raw)
+	}
+	expected.EIP1559Params = nil
+	if isHolocene && len(h.Extra) >= 1+8 {
+		eip1559Params := eth.Bytes8(h.Extra[1 : 1+8])
+		// Zero parameters make the EL fall back to the prior constants, so they cannot be derived from the block
+		if attr.EIP1559Params != nil {
+			if denominator, elasticity := eip1559.DecodeHolocene1559Params(attr.EIP1559Params[:]); denominator == 0 && elasticity == 0 {
+				eip1559Params = *attr.EIP1559Params
+			}
+		}
+		expected.EIP1559Params = &eip1559Params
+	}
+	expected.MinBaseFee = nil
+	if isJovian && len(h.Extra) >= 1+8+8 {
+		minBaseFee := binary.BigEndian.Uint64(h.Extra[1+8 : 1+8+8])
+		expected.MinBaseFee = &minBaseFee
+	}
+	return &expected, nil
+}
+
+// diffFields compares the JSON encodings of expected and actual field by field,
+// and returns the top-level fields that differ, sorted by name.
+// Array fields are compared element by element, so a single diverged transaction is reported as e.g. transactions[3].
+func diffFields(expected, actual any) ([]eth.SyncTesterFieldDiff, error) {
+	if expected == nil || actual == nil {
+		return nil, nil
+	}
+	expectedFields, err := jsonFields(expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode expected data: %w", err)
+	}
+	actualFields, err := jsonFields(actual)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode actual data: %w", err)
+	}
+	names := make([]string, 0, len(expectedFields)+len(actualFields))
+	for name := range expectedFields {
+		names = append(names, name)
+	}
+	for name := range actualFields {
+		if _, ok := expectedFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	var out []eth.SyncTesterFieldDiff
+	for _, name := range names {
+		out = append(out, diffValues(name, expectedFields[name], actualFields[name])...)
+		if len(out) >= maxDivergenceDiffEntries {
+			return out[:maxDivergenceDiffEntries], nil
+		}
+	}
+	return out, nil
+}
+
+func diffValues(name string, expected, actual json.RawMessage) []eth.SyncTesterFieldDiff {
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+	var expectedElems, actualElems []json.RawMessage
+	if json.Unmarshal(expected, &expectedElems) != nil || json.Unmarshal(actual, &actualElems) != nil {
+		return []eth.SyncTesterFieldDiff{{Field: name, Expected: expected, Actual: actual}}
+	}
+	var out []eth.SyncTesterFieldDiff
+	for i := range max(len(expectedElems), len(actualElems)) {
+		var e, a json.RawMessage
+		if i < len(expectedElems) {
+			e = expectedElems[i]
+		}
+		if i < len(actualElems) {
+			a = actualElems[i]
+		}
+		if !bytes.Equal(e, a) {
+			out = append(out, eth.SyncTesterFieldDiff{Field: fmt.Sprintf("%s[%d]", name, i), Expected: e, Actual: a})
+		}
+	}
+	return out
+}
+
+// jsonFields encodes v as JSON object, and returns its compacted top-level fields.
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, raw := range fields {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err != nil {
+			return nil, err
+		}
+		fields[name] = buf.Bytes()
+	}
+	return fields, nil
+}
+
+// checkUnknownPayload reports a divergence if the payload, which is not part of the expected chain,
+// replaces an expected block at the same height.
+func (s *SyncTester) checkUnknownPayload(ctx context.Context, session *eth.SyncTesterSession, logger log.Logger, payload *eth.ExecutionPayload, isIsthmus bool) {
+	expected, err := s.elReader.GetBlockByNumber(ctx, rpc.BlockNumber(payload.BlockNumber))
+	if err != nil || expected == nil {
+		// The payload may be ahead of the read only EL, which is not a divergence
+		return
+	}
+	reason := fmt.Sprintf("block hash mismatch: expected=%s, payload=%s", expected.Hash(), payload.BlockHash)
+	s.reportPayloadDivergence(session, logger, expected, payload, isIsthmus, reason)
+}
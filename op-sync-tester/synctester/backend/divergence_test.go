@@ -0,0 +1,120 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-sync-tester/synctester/backend/session"
+)
+
+func TestDiffFields(t *testing.T) {
+	type data struct {
+		A   uint64   `json:"a"`
+		B   string   `json:"b"`
+		Txs []string `json:"txs"`
+		C   *uint64  `json:"c,omitempty"`
+	}
+	one := uint64(1)
+	diff, err := diffFields(
+		data{A: 1, B: "x", Txs: []string{"0x01", "0x02", "0x03"}},
+		data{A: 1, B: "y", Txs: []string{"0x01", "0x04"}, C: &one},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []eth.SyncTesterFieldDiff{
+		{Field: "b", Expected: json.RawMessage(`"x"`), Actual: json.RawMessage(`"y"`)},
+		{Field: "c", Expected: nil, Actual: json.RawMessage(`1`)},
+		{Field: "txs[1]", Expected: json.RawMessage(`"0x02"`), Actual: json.RawMessage(`"0x04"`)},
+		{Field: "txs[2]", Expected: json.RawMessage(`"0x03"`), Actual: nil},
+	}, diff)
+
+	diff, err = diffFields(data{A: 1}, data{A: 1})
+	require.NoError(t, err)
+	require.Empty(t, diff)
+}
+
+func TestSyncTester_NewPayloadDivergence(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(5000)
+	blocks := makeTestChain(t, chainID, 10, 3)
+	st := initTestSyncTester(t, chainID, recordTestChain(t, chainID, blocks))
+
+	sess := eth.NewSyncTesterSession(uuid.New().String(), 10, 10, 10, false, nil)
+	ctx := session.WithSyncTesterSession(context.Background(), sess)
+
+	payload, err := eth.BlockAsPayload(blocks[1], &params.ChainConfig{})
+	require.NoError(t, err)
+	status, err := st.NewPayloadV2(ctx, payload)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, status.Status)
+	require.Nil(t, sess.Divergence)
+
+	diverged, err := eth.BlockAsPayload(blocks[2], &params.ChainConfig{})
+	require.NoError(t, err)
+	expectedRoot := diverged.StateRoot
+	diverged.StateRoot = eth.Bytes32{0xff}
+	status, err = st.NewPayloadV2(ctx, diverged)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionInvalid, status.Status)
+
+	div := sess.Divergence
+	require.NotNil(t, div)
+	require.Equal(t, methodNewPayload, div.Method)
+	require.Equal(t, uint64(12), div.BlockNumber)
+	require.Equal(t, blocks[2].Hash(), div.ExpectedHash)
+	require.Len(t, div.Diff, 1)
+	require.Equal(t, "stateRoot", div.Diff[0].Field)
+	expectedJSON, err := json.Marshal(expectedRoot)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(div.Diff[0].Expected))
+
+	// Only the first divergence is kept
+	diverged.GasUsed++
+	_, err = st.NewPayloadV2(ctx, diverged)
+	require.NoError(t, err)
+	require.Same(t, div, sess.Divergence)
+
+	// The divergence is reported through the session, and cleared on reset
+	got, err := st.GetSession(ctx)
+	require.NoError(t, err)
+	require.Equal(t, div, got.Divergence)
+	require.NoError(t, st.ResetSession(ctx))
+	require.Nil(t, sess.Divergence)
+}
+
+func TestSyncTester_AttributesDivergence(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(5000)
+	blocks := makeTestChain(t, chainID, 10, 2)
+	st := initTestSyncTester(t, chainID, recordTestChain(t, chainID, blocks))
+
+	sess := eth.NewSyncTesterSession(uuid.New().String(), 10, 10, 10, false, nil)
+	ctx := session.WithSyncTesterSession(context.Background(), sess)
+
+	next := blocks[1]
+	txRaw, err := next.Transactions()[0].MarshalBinary()
+	require.NoError(t, err)
+	gasLimit := eth.Uint64Quantity(next.GasLimit())
+	attr := &eth.PayloadAttributes{
+		Timestamp:             eth.Uint64Quantity(next.Time()),
+		PrevRandao:            eth.Bytes32(next.MixDigest()),
+		SuggestedFeeRecipient: common.Address{0x99},
+		Transactions:          []eth.Data{txRaw},
+		NoTxPool:              true,
+		GasLimit:              &gasLimit,
+	}
+	state := &eth.ForkchoiceState{HeadBlockHash: blocks[0].Hash()}
+	_, err = st.ForkchoiceUpdatedV1(ctx, state, attr)
+	require.Error(t, err, "attributes must be rejected")
+
+	div := sess.Divergence
+	require.NotNil(t, div)
+	require.Equal(t, methodForkchoiceUpdated, div.Method)
+	require.Equal(t, next.Hash(), div.ExpectedHash)
+	require.Len(t, div.Diff, 1)
+	require.Equal(t, "suggestedFeeRecipient", div.Diff[0].Field)
+}
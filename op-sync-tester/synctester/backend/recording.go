@@ -0,0 +1,257 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// Recording holds the responses of a read only EL for a contiguous range of blocks.
+// A recording is written to disk once, and replayed by ReplayELReader,
+// so sync tests can run deterministically against a captured chain segment without the EL.
+type Recording struct {
+	ChainID hexutil.Big      `json:"chainId"`
+	Blocks  []*RecordedBlock `json:"blocks"`
+}
+
+// RecordedBlock holds the responses of a read only EL for a single block.
+type RecordedBlock struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	// FullBlock is the block as returned with full transactions
+	FullBlock json.RawMessage `json:"fullBlock"`
+	// Block is the block as returned with transaction hashes only
+	Block    json.RawMessage  `json:"block"`
+	Receipts []*types.Receipt `json:"receipts"`
+}
+
+// RecordELRange fetches the blocks from..to (inclusive) and their receipts from the EL.
+// It fails if the blocks do not form a chain, e.g. because the EL reorged while recording.
+func RecordELRange(ctx context.Context, logger log.Logger, el ReadOnlyELBackend, from, to uint64) (*Recording, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d..%d", from, to)
+	}
+	chainID, err := el.ChainId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain ID: %w", err)
+	}
+	rec := &Recording{ChainID: chainID}
+	for num := from; num <= to; num++ {
+		number := rpc.BlockNumber(num)
+		fullBlock, err := el.GetBlockByNumberJSON(ctx, number, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d: %w", num, err)
+		}
+		block, err := el.GetBlockByNumberJSON(ctx, number, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d without txs: %w", num, err)
+		}
+		receipts, err := el.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch receipts of block %d: %w", num, err)
+		}
+		header, err := headerFromJSON(fullBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode block %d: %w", num, err)
+		}
+		var ref struct {
+			Hash common.Hash `json:"hash"`
+		}
+		if err := json.Unmarshal(fullBlock, &ref); err != nil {
+			return nil, fmt.Errorf("failed to decode hash of block %d: %w", num, err)
+		}
+		if ref.Hash != header.Hash() {
+			// The header has fields which are not decoded, so the block could not be replayed
+			return nil, fmt.Errorf("decoded header of block %d hashes to %s, but EL returned %s", num, header.Hash(), ref.Hash)
+		}
+		if header.Number.Uint64() != num {
+			return nil, fmt.Errorf("EL returned block %d when asked for block %d", header.Number, num)
+		}
+		if len(rec.Blocks) > 0 {
+			if parent := rec.Blocks[len(rec.Blocks)-1]; header.ParentHash != parent.Hash {
+				return nil, fmt.Errorf("block %d does not build on recorded parent %s, EL may have reorged", num, parent.Hash)
+			}
+		}
+		rec.Blocks = append(rec.Blocks, &RecordedBlock{
+			Number:    hexutil.Uint64(num),
+			Hash:      ref.Hash,
+			FullBlock: fullBlock,
+			Block:     block,
+			Receipts:  receipts,
+		})
+		if num%100 == 0 {
+			logger.Info("Recording blocks", "number", num, "to", to)
+		}
+	}
+	logger.Info("Recorded blocks", "from", from, "to", to, "chainID", chainID.ToInt())
+	return rec, nil
+}
+
+// WriteRecording writes the recording to the given path. The file is compressed if the path ends with .gz.
+func WriteRecording(path string, rec *Recording) error {
+	return jsonutil.WriteJSON(rec, ioutil.ToAtomicFile(path, 0o644))
+}
+
+// LoadRecording loads a recording written by WriteRecording.
+func LoadRecording(path string) (*Recording, error) {
+	return jsonutil.LoadJSON[Recording](path)
+}
+
+var _ ReadOnlyELBackend = (*ReplayELReader)(nil)
+
+// ReplayELReader serves the blocks of a Recording as read only EL.
+// Every block label resolves to the last recorded block, as the recorded chain does not progress.
+type ReplayELReader struct {
+	chainID hexutil.Big
+
+	byNumber map[uint64]*RecordedBlock
+	byHash   map[common.Hash]*RecordedBlock
+	blocks   map[common.Hash]*types.Block
+
+	head uint64
+}
+
+func NewReplayELReader(rec *Recording) (*ReplayELReader, error) {
+	if len(rec.Blocks) == 0 {
+		return nil, errors.New("recording has no blocks")
+	}
+	r := &ReplayELReader{
+		chainID:  rec.ChainID,
+		byNumber: make(map[uint64]*RecordedBlock, len(rec.Blocks)),
+		byHash:   make(map[common.Hash]*RecordedBlock, len(rec.Blocks)),
+		blocks:   make(map[common.Hash]*types.Block, len(rec.Blocks)),
+	}
+	for _, b := range rec.Blocks {
+		// Decode every block once upfront, so a corrupted recording is detected before any test runs
+		block, err := blockFromJSON(b.FullBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode recorded block %d: %w", b.Number, err)
+		}
+		if block.Hash() != b.Hash || block.NumberU64() != uint64(b.Number) {
+			return nil, fmt.Errorf("recorded block %d:%s does not match its contents %d:%s", b.Number, b.Hash, block.NumberU64(), block.Hash())
+		}
+		r.byNumber[uint64(b.Number)] = b
+		r.byHash[b.Hash] = b
+		r.blocks[b.Hash] = block
+		r.head = max(r.head, uint64(b.Number))
+	}
+	return r, nil
+}
+
+func (r *ReplayELReader) lookup(number rpc.BlockNumber) (*RecordedBlock, error) {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		return r.byNumber[r.head], nil
+	}
+	if number < 0 {
+		return nil, ethereum.NotFound
+	}
+	b, ok := r.byNumber[uint64(number)]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return b, nil
+}
+
+func (r *ReplayELReader) GetBlockByNumberJSON(ctx context.Context, number rpc.BlockNumber, fullTx bool) (json.RawMessage, error) {
+	b, err := r.lookup(number)
+	if err != nil {
+		return nil, err
+	}
+	return b.json(fullTx), nil
+}
+
+func (r *ReplayELReader) GetBlockByHashJSON(ctx context.Context, hash common.Hash, fullTx bool) (json.RawMessage, error) {
+	b, ok := r.byHash[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return b.json(fullTx), nil
+}
+
+func (r *ReplayELReader) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	b, err := r.lookup(number)
+	if err != nil {
+		return nil, err
+	}
+	return r.blocks[b.Hash], nil
+}
+
+func (r *ReplayELReader) GetBlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	block, ok := r.blocks[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func (r *ReplayELReader) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		b, ok := r.byHash[hash]
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return b.Receipts, nil
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	b, err := r.lookup(number)
+	if err != nil {
+		return nil, err
+	}
+	return b.Receipts, nil
+}
+
+func (r *ReplayELReader) ChainId(ctx context.Context) (hexutil.Big, error) {
+	return r.chainID, nil
+}
+
+func (b *RecordedBlock) json(fullTx bool) json.RawMessage {
+	if fullTx {
+		return b.FullBlock
+	}
+	return b.Block
+}
+
+func headerFromJSON(raw json.RawMessage) (*types.Header, error) {
+	var header *types.Header
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+// blockFromJSON decodes a block with full transactions, the same way as ethclient does.
+func blockFromJSON(raw json.RawMessage) (*types.Block, error) {
+	header, err := headerFromJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		Transactions []*types.Transaction `json:"transactions"`
+		Withdrawals  []*types.Withdrawal  `json:"withdrawals,omitempty"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(header).WithBody(types.Body{
+		Transactions: body.Transactions,
+		Withdrawals:  body.Withdrawals,
+	}), nil
+}
@@ -0,0 +1,197 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-sync-tester/synctester/backend/config"
+)
+
+// makeTestChain builds count blocks starting at block number first, each with a single signed transaction.
+func makeTestChain(t *testing.T, chainID eth.ChainID, first uint64, count int) []*types.Block {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(chainID.ToBig())
+	parent := common.Hash{0xaa}
+	var blocks []*types.Block
+	for i := range count {
+		num := first + uint64(i)
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   chainID.ToBig(),
+			Nonce:     uint64(i),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(10),
+			Gas:       21000,
+			To:        &common.Address{0x01},
+			Value:     big.NewInt(1),
+		})
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(num),
+			Time:       1000 + 2*num,
+			GasLimit:   30_000_000,
+			GasUsed:    21000,
+			Difficulty: new(big.Int),
+			BaseFee:    big.NewInt(7),
+			MixDigest:  common.Hash{byte(num)},
+			Root:       common.Hash{0x01, byte(num)},
+		}
+		receipt := &types.Receipt{Type: tx.Type(), Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, TxHash: tx.Hash(), Logs: []*types.Log{}}
+		block := types.NewBlock(header, &types.Body{Transactions: []*types.Transaction{tx}}, []*types.Receipt{receipt}, trie.NewStackTrie(nil), types.DefaultBlockConfig)
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	return blocks
+}
+
+// rpcBlockJSON encodes the block the way an EL serves it over RPC.
+func rpcBlockJSON(t *testing.T, block *types.Block, fullTx bool) json.RawMessage {
+	headerJSON, err := json.Marshal(block.Header())
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(headerJSON, &fields))
+	if fullTx {
+		fields["transactions"] = block.Transactions()
+	} else {
+		hashes := make([]common.Hash, 0, len(block.Transactions()))
+		for _, tx := range block.Transactions() {
+			hashes = append(hashes, tx.Hash())
+		}
+		fields["transactions"] = hashes
+	}
+	fields["uncles"] = []common.Hash{}
+	raw, err := json.Marshal(fields)
+	require.NoError(t, err)
+	return raw
+}
+
+func mockELForChain(t *testing.T, chainID eth.ChainID, blocks []*types.Block) *MockELReader {
+	el := NewMockELReader(chainID)
+	for _, block := range blocks {
+		raw := rpcBlockJSON(t, block, true)
+		el.BlocksByNumber[rpc.BlockNumber(block.NumberU64())] = &raw
+		el.BlocksByHash[block.Hash()] = &raw
+		receipts := []*types.Receipt{{Status: types.ReceiptStatusSuccessful, BlockHash: block.Hash(), BlockNumber: block.Number(), Logs: []*types.Log{}}}
+		el.ReceiptsByNumber[rpc.BlockNumber(block.NumberU64())] = receipts
+		el.ReceiptsByHash[block.Hash()] = receipts
+	}
+	return el
+}
+
+func recordTestChain(t *testing.T, chainID eth.ChainID, blocks []*types.Block) *ReplayELReader {
+	logger := testlog.Logger(t, log.LevelInfo)
+	first, last := blocks[0].NumberU64(), blocks[len(blocks)-1].NumberU64()
+	rec, err := RecordELRange(context.Background(), logger, mockELForChain(t, chainID, blocks), first, last)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "recording.json.gz")
+	require.NoError(t, WriteRecording(path, rec))
+	loaded, err := LoadRecording(path)
+	require.NoError(t, err)
+	replay, err := NewReplayELReader(loaded)
+	require.NoError(t, err)
+	return replay
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	chainID := eth.ChainIDFromUInt64(5000)
+	blocks := makeTestChain(t, chainID, 10, 3)
+	replay := recordTestChain(t, chainID, blocks)
+
+	gotChainID, err := replay.ChainId(ctx)
+	require.NoError(t, err)
+	require.Equal(t, chainID.ToBig(), gotChainID.ToInt())
+
+	for _, expected := range blocks {
+		block, err := replay.GetBlockByNumber(ctx, rpc.BlockNumber(expected.NumberU64()))
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), block.Hash())
+		require.Equal(t, expected.Transactions()[0].Hash(), block.Transactions()[0].Hash())
+
+		block, err = replay.GetBlockByHash(ctx, expected.Hash())
+		require.NoError(t, err)
+		require.Equal(t, expected.NumberU64(), block.NumberU64())
+
+		raw, err := replay.GetBlockByHashJSON(ctx, expected.Hash(), true)
+		require.NoError(t, err)
+		require.JSONEq(t, string(rpcBlockJSON(t, expected, true)), string(raw))
+
+		receipts, err := replay.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(expected.Hash(), false))
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, expected.Hash(), receipts[0].BlockHash)
+	}
+
+	latest, err := replay.GetBlockByNumber(ctx, rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, blocks[2].Hash(), latest.Hash())
+
+	_, err = replay.GetBlockByNumber(ctx, rpc.BlockNumber(13))
+	require.ErrorIs(t, err, ethereum.NotFound)
+	_, err = replay.GetBlockByHash(ctx, common.Hash{0x01})
+	require.ErrorIs(t, err, ethereum.NotFound)
+	_, err = replay.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(9))
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
+
+func TestRecordELRange_Reorg(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(5000)
+	blocks := makeTestChain(t, chainID, 10, 2)
+	// Block 11 of another chain does not build on block 10
+	other := makeTestChain(t, chainID, 10, 2)
+	el := mockELForChain(t, chainID, []*types.Block{blocks[0], other[1]})
+
+	_, err := RecordELRange(context.Background(), testlog.Logger(t, log.LevelInfo), el, 10, 11)
+	require.ErrorContains(t, err, "does not build on recorded parent")
+}
+
+func TestNewReplayELReader_Corrupted(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(5000)
+	blocks := makeTestChain(t, chainID, 10, 1)
+	rec := &Recording{Blocks: []*RecordedBlock{{
+		Number:    10,
+		Hash:      common.Hash{0x01},
+		FullBlock: rpcBlockJSON(t, blocks[0], true),
+		Block:     rpcBlockJSON(t, blocks[0], false),
+	}}}
+	_, err := NewReplayELReader(rec)
+	require.ErrorContains(t, err, "does not match its contents")
+
+	_, err = NewReplayELReader(&Recording{})
+	require.ErrorContains(t, err, "no blocks")
+}
+
+func TestSyncTesterFromConfig_Recording(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(5000)
+	blocks := makeTestChain(t, chainID, 10, 2)
+	logger := testlog.Logger(t, log.LevelInfo)
+	rec, err := RecordELRange(context.Background(), logger, mockELForChain(t, chainID, blocks), 10, 11)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, WriteRecording(path, rec))
+
+	st, err := SyncTesterFromConfig(logger, nil, "test", &config.SyncTesterEntry{Recording: path, ChainID: chainID})
+	require.NoError(t, err)
+	require.IsType(t, &ReplayELReader{}, st.elReader)
+
+	_, err = SyncTesterFromConfig(logger, nil, "test", &config.SyncTesterEntry{Recording: path, ChainID: eth.ChainIDFromUInt64(1)})
+	require.ErrorContains(t, err, "recording is of chain 5000")
+
+	_, err = SyncTesterFromConfig(logger, nil, "test", &config.SyncTesterEntry{ChainID: chainID})
+	require.ErrorContains(t, err, "either an EL RPC or a recording is required")
+}
@@ -55,11 +55,31 @@ var _ frontend.EthBackend = (*SyncTester)(nil)
 
 func SyncTesterFromConfig(logger log.Logger, m metrics.Metricer, stID sttypes.SyncTesterID, stCfg *config.SyncTesterEntry) (*SyncTester, error) {
 	logger = logger.New("syncTester", stID, "chain", stCfg.ChainID)
-	elClient, err := ethclient.Dial(stCfg.ELRPC.Value.RPC())
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial EL client: %w", err)
+	var elReader ReadOnlyELBackend
+	if stCfg.Recording != "" {
+		rec, err := LoadRecording(stCfg.Recording)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load recording: %w", err)
+		}
+		if rec.ChainID.ToInt().Cmp(stCfg.ChainID.ToBig()) != 0 {
+			return nil, fmt.Errorf("recording is of chain %s, but sync tester is configured for chain %s", rec.ChainID.ToInt(), stCfg.ChainID)
+		}
+		replay, err := NewReplayELReader(rec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay recording: %w", err)
+		}
+		logger.Info("Replaying recorded blocks", "path", stCfg.Recording, "blocks", len(rec.Blocks))
+		elReader = replay
+	} else {
+		if stCfg.ELRPC.Value == nil {
+			return nil, errors.New("either an EL RPC or a recording is required")
+		}
+		elClient, err := ethclient.Dial(stCfg.ELRPC.Value.RPC())
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial EL client: %w", err)
+		}
+		elReader = NewELReader(elClient)
 	}
-	elReader := NewELReader(elClient)
 	logger.Info("Initialized sync tester from config", "syncTester", stID)
 	return NewSyncTester(logger, m, stID, stCfg.ChainID, elReader), nil
 }
@@ -438,6 +458,7 @@ func (s *SyncTester) forkchoiceUpdated(ctx context.Context, session *eth.SyncTes
 		}
 		// Sanity check attr comparing with newBlock
 		if err := s.validateAttributesForBlock(attr, newBlock, isHolocene, isJovian); err != nil {
+			s.reportAttributesDivergence(session, logger, newBlock, attr, isHolocene, isJovian, err.Error())
 			// https://github.com/ethereum/execution-apis/blob/584905270d8ad665718058060267061ecfd79ca5/src/engine/paris.md#specification-1
 			// Client software MUST respond to this method call in the following way: {error: {code: -38003, message: "Invalid payload attributes"}} if the payload is deemed VALID and forkchoiceState has been applied successfully, but no build process has been started due to invalid payloadAttributes.
 			return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, PayloadID: nil}, engine.InvalidPayloadAttributes.With(err)
@@ -740,6 +761,7 @@ func (s *SyncTester) newPayload(ctx context.Context, session *eth.SyncTesterSess
 		block, err = s.elReader.GetBlockByHash(ctx, payload.BlockHash)
 		if err != nil {
 			if errors.Is(err, ethereum.NotFound) {
+				s.checkUnknownPayload(ctx, session, logger, payload, isIsthmus)
 				return &eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, engine.GenericServerError.With(wrapSyncTesterError("block not found after retry", err))
 			}
 			return &eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, engine.GenericServerError.With(wrapSyncTesterError("failed to fetch block after retry", err))
@@ -763,6 +785,9 @@ func (s *SyncTester) newPayload(ctx context.Context, session *eth.SyncTesterSess
 	// We only attempt to advance non-canonical view of the chain, following the read only EL
 	if blockNumber <= session.Validated+1 {
 		if status, err := s.validatePayload(logger, isCanyon, isIsthmus, block, payload, beaconRoot); status != nil {
+			if status.Status == eth.ExecutionInvalid && status.ValidationError != nil {
+				s.reportPayloadDivergence(session, logger, block, payload, isIsthmus, *status.ValidationError)
+			}
 			return status, err
 		}
 		if blockNumber == session.Validated+1 {
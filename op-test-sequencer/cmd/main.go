@@ -51,6 +51,7 @@ func run(ctx context.Context, w io.Writer, ew io.Writer, args []string, fn seque
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		scenarioCommand(),
 	}
 	return app.RunContext(ctx, args)
 }
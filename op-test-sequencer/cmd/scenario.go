@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-test-sequencer/flags"
+	"github.com/ethereum-optimism/optimism/op-test-sequencer/scenario"
+)
+
+var (
+	ScenarioFileFlag = &cli.StringFlag{
+		Name:      "scenario",
+		Usage:     "Path to the scenario file to run, YAML or JSON",
+		EnvVars:   opservice.PrefixEnvVar(flags.EnvVarPrefix, "SCENARIO"),
+		TakesFile: true,
+		Required:  true,
+	}
+	ScenarioSequencerRPCFlag = &cli.StringFlag{
+		Name:     "test-sequencer-rpc",
+		Usage:    "RPC of the op-test-sequencer to run the scenario with",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "SCENARIO_TEST_SEQUENCER_RPC"),
+		Required: true,
+	}
+	ScenarioSequencerIDFlag = &cli.StringFlag{
+		Name:     "sequencer-id",
+		Usage:    "ID of the sequencer of the op-test-sequencer that sequences the L2 chain",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "SCENARIO_SEQUENCER_ID"),
+		Required: true,
+	}
+	ScenarioL1RPCFlag = &cli.StringFlag{
+		Name:     "l1-rpc",
+		Usage:    "RPC of an L1 EL, to resolve L1 origins",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "SCENARIO_L1_RPC"),
+		Required: true,
+	}
+	ScenarioL2RPCFlag = &cli.StringFlag{
+		Name:     "l2-rpc",
+		Usage:    "RPC of an L2 EL of the sequenced chain, to check the built blocks",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "SCENARIO_L2_RPC"),
+		Required: true,
+	}
+	ScenarioRollupRPCFlag = &cli.StringFlag{
+		Name:     "rollup-rpc",
+		Usage:    "RPC of an L2 CL of the sequenced chain, to fetch the rollup config",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "SCENARIO_ROLLUP_RPC"),
+		Required: true,
+	}
+)
+
+func scenarioCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "scenario",
+		Usage: "Runs a scripted block-building scenario against a running op-test-sequencer",
+		Description: "Builds the blocks described by the scenario file with the op-test-sequencer, " +
+			"and checks the outcome of every step on the L2 chain. " +
+			"The op-test-sequencer must be the only active sequencer of the L2 chain.",
+		Flags: append([]cli.Flag{
+			ScenarioFileFlag,
+			ScenarioSequencerRPCFlag,
+			ScenarioSequencerIDFlag,
+			ScenarioL1RPCFlag,
+			ScenarioL2RPCFlag,
+			ScenarioRollupRPCFlag,
+			flags.RPCJWTSecretFlag,
+		}, oplog.CLIFlags(flags.EnvVarPrefix)...),
+		Action: func(cliCtx *cli.Context) error {
+			logger := oplog.NewLogger(cliCtx.App.Writer, oplog.ReadCLIConfig(cliCtx))
+			ctx := ctxinterrupt.WithCancelOnInterrupt(cliCtx.Context)
+			sc, err := scenario.Load(cliCtx.String(ScenarioFileFlag.Name))
+			if err != nil {
+				return err
+			}
+			runner, closeFn, err := scenarioRunner(ctx, cliCtx, logger)
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+			results, err := runner.Run(ctx, sc)
+			for _, res := range results {
+				_, _ = fmt.Fprintln(cliCtx.App.Writer, res)
+			}
+			if err != nil {
+				return fmt.Errorf("scenario %q failed: %w", sc.Name, err)
+			}
+			return nil
+		},
+	}
+}
+
+func scenarioRunner(ctx context.Context, cliCtx *cli.Context, logger log.Logger) (*scenario.Runner, func(), error) {
+	secret, err := oprpc.ObtainJWTSecret(logger, cliCtx.String(flags.RPCJWTSecretFlag.Name), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rpcs []client.RPC
+	closeFn := func() {
+		for _, cl := range rpcs {
+			cl.Close()
+		}
+	}
+	dial := func(addr string, opts ...client.RPCOption) (client.RPC, error) {
+		cl, err := client.NewRPC(ctx, logger, addr, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+		}
+		rpcs = append(rpcs, cl)
+		return cl, nil
+	}
+	authOpt := client.WithGethRPCOptions(rpc.WithHTTPAuth(gn.NewJWTAuth(secret)))
+	seqAddr := strings.TrimSuffix(cliCtx.String(ScenarioSequencerRPCFlag.Name), "/")
+	builderRPC, err := dial(seqAddr, authOpt)
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	controlRPC, err := dial(seqAddr+"/sequencers/"+cliCtx.String(ScenarioSequencerIDFlag.Name), authOpt)
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	rollupRPC, err := dial(cliCtx.String(ScenarioRollupRPCFlag.Name))
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	rollupCfg, err := sources.NewRollupClient(rollupRPC).RollupConfig(ctx)
+	if err != nil {
+		closeFn()
+		return nil, nil, fmt.Errorf("failed to fetch rollup config: %w", err)
+	}
+	l1RPC, err := dial(cliCtx.String(ScenarioL1RPCFlag.Name))
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	l1, err := sources.NewL1Client(l1RPC, logger, nil, sources.L1ClientSimpleConfig(true, sources.RPCKindStandard, 100))
+	if err != nil {
+		closeFn()
+		return nil, nil, fmt.Errorf("failed to create L1 client: %w", err)
+	}
+	l2RPC, err := dial(cliCtx.String(ScenarioL2RPCFlag.Name))
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	l2, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(rollupCfg, true))
+	if err != nil {
+		closeFn()
+		return nil, nil, fmt.Errorf("failed to create L2 client: %w", err)
+	}
+	runner := scenario.NewRunner(logger, sources.NewControlClient(controlRPC), sources.NewBuilderClient(builderRPC), l1, l2)
+	return runner, closeFn, nil
+}
@@ -0,0 +1,390 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-test-sequencer/sequencer/seqtypes"
+)
+
+// ErrNotIncluded is the error of a block that was built, but did not become part of the unsafe chain in time.
+var ErrNotIncluded = errors.New("block was not included")
+
+// Sequencer is the subset of the test sequencer control API that the runner uses.
+type Sequencer interface {
+	New(ctx context.Context, opts seqtypes.BuildOpts) error
+	IncludeTx(ctx context.Context, tx hexutil.Bytes) error
+	PrebuiltEnvelope(ctx context.Context, block *eth.ExecutionPayloadEnvelope) error
+	Next(ctx context.Context) error
+	Reset(ctx context.Context) error
+}
+
+// Builder is the subset of the test sequencer build API that the runner uses to build the blocks of mutate steps.
+type Builder interface {
+	New(ctx context.Context, builderID seqtypes.BuilderID, opts *seqtypes.BuildOpts) (seqtypes.BuildJobID, error)
+	Open(ctx context.Context, jobID seqtypes.BuildJobID) error
+	SealEnvelope(ctx context.Context, jobID seqtypes.BuildJobID) (*eth.ExecutionPayloadEnvelope, error)
+	CloseJob(jobID seqtypes.BuildJobID) error
+}
+
+type L1Chain interface {
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+}
+
+type L2Chain interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error)
+	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
+}
+
+// StepResult is the outcome of building a single block of a step.
+type StepResult struct {
+	Step  string
+	Index uint64
+	// Block is the included block, zero if the block was not included.
+	Block eth.L2BlockRef
+	// Err is why the block was not included, nil if the block was included.
+	Err error
+}
+
+func (r *StepResult) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s[%d]: not included: %v", r.Step, r.Index, r.Err)
+	}
+	return fmt.Sprintf("%s[%d]: included %s", r.Step, r.Index, r.Block)
+}
+
+// Runner runs scenarios against a test sequencer, and checks the resulting L2 chain.
+// The runner assumes it is the only sequencer of the L2 chain while it runs.
+type Runner struct {
+	log log.Logger
+
+	seq     Sequencer
+	builder Builder
+	l1      L1Chain
+	l2      L2Chain
+
+	pollInterval time.Duration
+}
+
+func NewRunner(log log.Logger, seq Sequencer, builder Builder, l1 L1Chain, l2 L2Chain) *Runner {
+	return &Runner{
+		log:          log,
+		seq:          seq,
+		builder:      builder,
+		l1:           l1,
+		l2:           l2,
+		pollInterval: 500 * time.Millisecond,
+	}
+}
+
+// Run runs the steps of the scenario in order, and stops at the first step with an unexpected outcome.
+// The results of all blocks built so far are returned, also on error.
+func (r *Runner) Run(ctx context.Context, sc *Scenario) ([]*StepResult, error) {
+	if err := sc.Check(); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	timeout := sc.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	settleTime := sc.SettleTime
+	if settleTime == 0 {
+		settleTime = DefaultSettleTime
+	}
+	r.log.Info("Running scenario", "name", sc.Name, "steps", len(sc.Steps))
+	// the last included block of every named step
+	named := make(map[string]eth.L2BlockRef)
+	var results []*StepResult
+	for i, step := range sc.Steps {
+		label := step.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i)
+		}
+		var prev *eth.L2BlockRef
+		for n := range step.Count() {
+			parent, err := r.parent(ctx, step, named, prev)
+			if err != nil {
+				return results, fmt.Errorf("step %s: failed to resolve parent: %w", label, err)
+			}
+			wait := timeout
+			if step.ExpectFail() {
+				wait = settleTime
+			}
+			res, err := r.runBlock(ctx, sc.Builder, step, parent, wait)
+			if err != nil {
+				return results, fmt.Errorf("step %s: %w", label, err)
+			}
+			res.Step, res.Index = label, n
+			results = append(results, res)
+			r.log.Info("Ran step", "result", res)
+			if err := r.check(ctx, step, res); err != nil {
+				return results, fmt.Errorf("step %s[%d]: %w", label, n, err)
+			}
+			if res.Err == nil {
+				prev = &res.Block
+			}
+		}
+		if prev != nil && step.Name != "" {
+			named[step.Name] = *prev
+		}
+	}
+	r.log.Info("Scenario passed", "name", sc.Name, "blocks", len(results))
+	return results, nil
+}
+
+func (r *Runner) parent(ctx context.Context, step *Step, named map[string]eth.L2BlockRef, prev *eth.L2BlockRef) (eth.L2BlockRef, error) {
+	if prev != nil {
+		return *prev, nil
+	}
+	switch step.Parent {
+	case "", ParentHead:
+		return r.l2.L2BlockRefByLabel(ctx, eth.Unsafe)
+	}
+	if ref, ok := named[step.Parent]; ok {
+		return ref, nil
+	}
+	if !isHash(step.Parent) {
+		return eth.L2BlockRef{}, fmt.Errorf("step %q has no included block", step.Parent)
+	}
+	return r.l2.L2BlockRefByHash(ctx, common.HexToHash(step.Parent))
+}
+
+func (r *Runner) buildOpts(ctx context.Context, step *Step, parent eth.L2BlockRef) (seqtypes.BuildOpts, error) {
+	opts := seqtypes.BuildOpts{Parent: parent.Hash}
+	switch step.L1Origin {
+	case "", L1OriginSame:
+		// the builder keeps the L1 origin of the parent
+	case L1OriginNext:
+		ref, err := r.l1.L1BlockRefByNumber(ctx, parent.L1Origin.Number+1)
+		if err != nil {
+			return seqtypes.BuildOpts{}, fmt.Errorf("failed to fetch next L1 origin: %w", err)
+		}
+		opts.L1Origin = &ref.Hash
+	default:
+		if isHash(step.L1Origin) {
+			h := common.HexToHash(step.L1Origin)
+			opts.L1Origin = &h
+			break
+		}
+		num, err := strconv.ParseUint(step.L1Origin, 0, 64)
+		if err != nil {
+			return seqtypes.BuildOpts{}, fmt.Errorf("invalid L1 origin %q: %w", step.L1Origin, err)
+		}
+		ref, err := r.l1.L1BlockRefByNumber(ctx, num)
+		if err != nil {
+			return seqtypes.BuildOpts{}, fmt.Errorf("failed to fetch L1 origin %d: %w", num, err)
+		}
+		opts.L1Origin = &ref.Hash
+	}
+	if step.Timestamp != nil {
+		ts := hexutil.Uint64(*step.Timestamp)
+		opts.Timestamp = &ts
+	} else if step.TimestampDelta != nil {
+		ts := hexutil.Uint64(parent.Time + *step.TimestampDelta)
+		opts.Timestamp = &ts
+	}
+	return opts, nil
+}
+
+// runBlock builds a single block of the step on top of parent, and waits for it to be included.
+// A block that is not included is reported through the result. The returned error is for failures
+// of the runner itself, which do not tell anything about the tested chain.
+func (r *Runner) runBlock(ctx context.Context, builderID seqtypes.BuilderID, step *Step, parent eth.L2BlockRef, wait time.Duration) (*StepResult, error) {
+	opts, err := r.buildOpts(ctx, step, parent)
+	if err != nil {
+		return nil, err
+	}
+	r.log.Info("Building block", "step", step.Name, "parent", parent, "l1Origin", opts.L1Origin, "timestamp", opts.Timestamp)
+	var hash *common.Hash
+	if step.Mutate != nil {
+		hash, err = r.buildMutated(ctx, builderID, step, &opts)
+	} else {
+		err = r.build(ctx, step, opts)
+	}
+	if err == nil {
+		var ref eth.L2BlockRef
+		ref, err = r.waitForBlock(ctx, parent, hash, wait)
+		if err == nil {
+			return &StepResult{Block: ref}, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	// Discard whatever the sequencer still holds of the failed block, so the next step starts clean
+	if resetErr := r.seq.Reset(ctx); resetErr != nil {
+		return nil, fmt.Errorf("failed to reset sequencer: %w", resetErr)
+	}
+	return &StepResult{Err: err}, nil
+}
+
+func (r *Runner) build(ctx context.Context, step *Step, opts seqtypes.BuildOpts) error {
+	if err := r.seq.New(ctx, opts); err != nil {
+		return fmt.Errorf("failed to start block: %w", err)
+	}
+	for i, tx := range step.Txs {
+		if err := r.seq.IncludeTx(ctx, tx); err != nil {
+			return fmt.Errorf("failed to include tx %d: %w", i, err)
+		}
+	}
+	if err := r.seq.Next(ctx); err != nil {
+		return fmt.Errorf("failed to sequence block: %w", err)
+	}
+	return nil
+}
+
+// buildMutated builds the block with the builder, mutates it, and hands it to the sequencer as prebuilt block.
+// It returns the hash of the mutated block.
+func (r *Runner) buildMutated(ctx context.Context, builderID seqtypes.BuilderID, step *Step, opts *seqtypes.BuildOpts) (*common.Hash, error) {
+	jobID, err := r.builder.New(ctx, builderID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start build job: %w", err)
+	}
+	defer func() {
+		if err := r.builder.CloseJob(jobID); err != nil {
+			r.log.Warn("Failed to close build job", "job", jobID, "err", err)
+		}
+	}()
+	if err := r.builder.Open(ctx, jobID); err != nil {
+		return nil, fmt.Errorf("failed to open block: %w", err)
+	}
+	envelope, err := r.builder.SealEnvelope(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal block: %w", err)
+	}
+	original := envelope.ExecutionPayload.BlockHash
+	applyMutation(envelope, step.Mutate, step.Txs)
+	hash := envelope.ExecutionPayload.BlockHash
+	r.log.Info("Mutated block", "number", uint64(envelope.ExecutionPayload.BlockNumber), "original", original, "mutated", hash)
+	if err := r.seq.PrebuiltEnvelope(ctx, envelope); err != nil {
+		return nil, fmt.Errorf("failed to submit mutated block: %w", err)
+	}
+	if err := r.seq.Next(ctx); err != nil {
+		return nil, fmt.Errorf("failed to sequence mutated block: %w", err)
+	}
+	return &hash, nil
+}
+
+func applyMutation(envelope *eth.ExecutionPayloadEnvelope, m *Mutation, txs []hexutil.Bytes) {
+	p := envelope.ExecutionPayload
+	if m.StateRoot != nil {
+		p.StateRoot = eth.Bytes32(*m.StateRoot)
+	}
+	if m.ReceiptsRoot != nil {
+		p.ReceiptsRoot = eth.Bytes32(*m.ReceiptsRoot)
+	}
+	if m.GasUsed != nil {
+		p.GasUsed = eth.Uint64Quantity(*m.GasUsed)
+	}
+	if m.GasLimit != nil {
+		p.GasLimit = eth.Uint64Quantity(*m.GasLimit)
+	}
+	if m.Timestamp != nil {
+		p.Timestamp = eth.Uint64Quantity(*m.Timestamp)
+	}
+	if m.ExtraData != nil {
+		p.ExtraData = eth.BytesMax32(*m.ExtraData)
+	}
+	if m.DropTxs {
+		kept := p.Transactions[:0]
+		for _, tx := range p.Transactions {
+			if len(tx) > 0 && tx[0] == types.DepositTxType {
+				kept = append(kept, tx)
+			}
+		}
+		p.Transactions = kept
+	}
+	for _, tx := range txs {
+		p.Transactions = append(p.Transactions, eth.Data(tx))
+	}
+	if !m.KeepBlockHash {
+		p.BlockHash, _ = envelope.CheckBlockHash()
+	}
+}
+
+// waitForBlock waits for a child of parent to be the canonical block at its height.
+// If hash is set, the child must have that hash.
+func (r *Runner) waitForBlock(ctx context.Context, parent eth.L2BlockRef, hash *common.Hash, timeout time.Duration) (eth.L2BlockRef, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		ref, err := r.l2.L2BlockRefByNumber(ctx, parent.Number+1)
+		if err == nil && ref.ParentHash == parent.Hash && (hash == nil || ref.Hash == *hash) {
+			return ref, nil
+		}
+		select {
+		case <-ctx.Done():
+			if hash != nil {
+				return eth.L2BlockRef{}, fmt.Errorf("%w: no block %s on top of %s after %s", ErrNotIncluded, *hash, parent, timeout)
+			}
+			return eth.L2BlockRef{}, fmt.Errorf("%w: no block on top of %s after %s", ErrNotIncluded, parent, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// check compares the result of a block against the expectations of its step.
+func (r *Runner) check(ctx context.Context, step *Step, res *StepResult) error {
+	if res.Err != nil {
+		if !step.ExpectFail() {
+			return fmt.Errorf("expected block to be included: %w", res.Err)
+		}
+		if step.Expect.Error != "" && !strings.Contains(res.Err.Error(), step.Expect.Error) {
+			return fmt.Errorf("expected error containing %q, got: %w", step.Expect.Error, res.Err)
+		}
+		return nil
+	}
+	if step.ExpectFail() {
+		return fmt.Errorf("expected block to not be included, but %s was included", res.Block)
+	}
+	_, txs, err := r.l2.InfoAndTxsByHash(ctx, res.Block.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch txs of block %s: %w", res.Block, err)
+	}
+	var deposits, userTxs uint64
+	included := make(map[common.Hash]struct{}, len(txs))
+	for _, tx := range txs {
+		if tx.IsDepositTx() {
+			deposits++
+		} else {
+			userTxs++
+		}
+		included[tx.Hash()] = struct{}{}
+	}
+	if len(txs) > 0 && txs[0].IsDepositTx() {
+		deposits-- // the L1 attributes tx
+	}
+	for i, raw := range step.Txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return fmt.Errorf("failed to decode tx %d: %w", i, err)
+		}
+		if _, ok := included[tx.Hash()]; !ok {
+			return fmt.Errorf("tx %d (%s) is not included in block %s", i, tx.Hash(), res.Block)
+		}
+	}
+	if exp := step.Expect.TxCount; exp != nil && *exp != userTxs {
+		return fmt.Errorf("expected %d txs, block %s has %d", *exp, res.Block, userTxs)
+	}
+	if exp := step.Expect.Deposits; exp != nil && *exp != deposits {
+		return fmt.Errorf("expected %d deposits, block %s has %d", *exp, res.Block, deposits)
+	}
+	if exp := step.Expect.L1Origin; exp != nil && *exp != res.Block.L1Origin.Number {
+		return fmt.Errorf("expected L1 origin %d, block %s has %s", *exp, res.Block, res.Block.L1Origin)
+	}
+	return nil
+}
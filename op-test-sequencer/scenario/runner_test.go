@@ -0,0 +1,402 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-test-sequencer/sequencer/seqtypes"
+)
+
+// fakeChain is an L1 and L2 chain, which the fake sequencer and builder build on.
+type fakeChain struct {
+	l1 map[uint64]eth.L1BlockRef
+
+	blocks    map[common.Hash]eth.L2BlockRef
+	txs       map[common.Hash]types.Transactions
+	canonical map[uint64]common.Hash
+	head      eth.L2BlockRef
+
+	// sealed holds the blocks built by the builder, by hash. Only unmodified sealed blocks are accepted.
+	sealed map[common.Hash]eth.L2BlockRef
+	nonce  uint64
+}
+
+func newFakeChain() *fakeChain {
+	c := &fakeChain{
+		l1:        make(map[uint64]eth.L1BlockRef),
+		blocks:    make(map[common.Hash]eth.L2BlockRef),
+		txs:       make(map[common.Hash]types.Transactions),
+		canonical: make(map[uint64]common.Hash),
+		sealed:    make(map[common.Hash]eth.L2BlockRef),
+	}
+	for i := range uint64(10) {
+		c.l1[i] = eth.L1BlockRef{Hash: common.Hash{0x01, byte(i)}, Number: i, Time: 1000 + 12*i}
+	}
+	genesis := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 100, Time: 1000, L1Origin: c.l1[0].ID()}
+	c.insert(genesis, nil)
+	return c
+}
+
+func (c *fakeChain) insert(ref eth.L2BlockRef, txs types.Transactions) {
+	c.blocks[ref.Hash] = ref
+	c.txs[ref.Hash] = txs
+	for num := ref.Number; ; num++ {
+		if _, ok := c.canonical[num]; !ok {
+			break
+		}
+		delete(c.canonical, num)
+	}
+	c.canonical[ref.Number] = ref.Hash
+	c.head = ref
+}
+
+// child builds a block on top of the parent, with an L1 info deposit and a deposit for every L1 origin change.
+func (c *fakeChain) child(parentHash common.Hash, l1Origin *common.Hash, timestamp *hexutil.Uint64, txs []hexutil.Bytes) (eth.L2BlockRef, types.Transactions, error) {
+	parent, ok := c.blocks[parentHash]
+	if !ok {
+		return eth.L2BlockRef{}, nil, ethereum.NotFound
+	}
+	origin := parent.L1Origin
+	if l1Origin != nil {
+		found := false
+		for _, ref := range c.l1 {
+			if ref.Hash == *l1Origin {
+				origin, found = ref.ID(), true
+			}
+		}
+		if !found {
+			return eth.L2BlockRef{}, nil, errors.New("unknown L1 origin")
+		}
+	}
+	c.nonce++
+	blockTxs := types.Transactions{types.NewTx(&types.DepositTx{SourceHash: common.Hash{0x03, byte(c.nonce)}})}
+	if origin != parent.L1Origin {
+		blockTxs = append(blockTxs, types.NewTx(&types.DepositTx{SourceHash: common.Hash{0x04, byte(c.nonce)}}))
+	}
+	for _, raw := range txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return eth.L2BlockRef{}, nil, err
+		}
+		blockTxs = append(blockTxs, &tx)
+	}
+	ref := eth.L2BlockRef{
+		Hash:       crypto.Keccak256Hash(parentHash[:], []byte{byte(c.nonce)}),
+		Number:     parent.Number + 1,
+		ParentHash: parent.Hash,
+		Time:       parent.Time + 2,
+		L1Origin:   origin,
+	}
+	if timestamp != nil {
+		ref.Time = uint64(*timestamp)
+	}
+	return ref, blockTxs, nil
+}
+
+func (c *fakeChain) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	ref, ok := c.l1[num]
+	if !ok {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (c *fakeChain) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return c.head, nil
+}
+
+func (c *fakeChain) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	hash, ok := c.canonical[num]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return c.blocks[hash], nil
+}
+
+func (c *fakeChain) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	ref, ok := c.blocks[hash]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (c *fakeChain) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	txs, ok := c.txs[hash]
+	if !ok {
+		return nil, nil, ethereum.NotFound
+	}
+	return nil, txs, nil
+}
+
+type fakeSequencer struct {
+	chain *fakeChain
+
+	opts     *seqtypes.BuildOpts
+	txs      []hexutil.Bytes
+	prebuilt *eth.ExecutionPayloadEnvelope
+	nextErr  error
+	resets   int
+}
+
+func (s *fakeSequencer) New(ctx context.Context, opts seqtypes.BuildOpts) error {
+	if s.opts != nil || s.prebuilt != nil {
+		return seqtypes.ErrConflictingJob
+	}
+	s.opts = &opts
+	return nil
+}
+
+func (s *fakeSequencer) IncludeTx(ctx context.Context, tx hexutil.Bytes) error {
+	if s.opts == nil {
+		return seqtypes.ErrUnknownJob
+	}
+	s.txs = append(s.txs, tx)
+	return nil
+}
+
+func (s *fakeSequencer) PrebuiltEnvelope(ctx context.Context, block *eth.ExecutionPayloadEnvelope) error {
+	if s.opts != nil || s.prebuilt != nil {
+		return seqtypes.ErrConflictingJob
+	}
+	s.prebuilt = block
+	return nil
+}
+
+func (s *fakeSequencer) Next(ctx context.Context) error {
+	if s.nextErr != nil {
+		return s.nextErr
+	}
+	if s.prebuilt != nil {
+		// Like a node, silently drop blocks that do not match what the builder built
+		hash := s.prebuilt.ExecutionPayload.BlockHash
+		if actual, ok := s.prebuilt.CheckBlockHash(); ok && actual == hash {
+			if ref, ok := s.chain.sealed[hash]; ok {
+				s.chain.insert(ref, s.chain.txs[hash])
+			}
+		}
+		s.prebuilt = nil
+		return nil
+	}
+	if s.opts == nil {
+		return seqtypes.ErrUnknownJob
+	}
+	ref, txs, err := s.chain.child(s.opts.Parent, s.opts.L1Origin, s.opts.Timestamp, s.txs)
+	if err != nil {
+		return err
+	}
+	s.chain.insert(ref, txs)
+	s.opts, s.txs = nil, nil
+	return nil
+}
+
+func (s *fakeSequencer) Reset(ctx context.Context) error {
+	s.opts, s.txs, s.prebuilt = nil, nil, nil
+	s.resets++
+	return nil
+}
+
+type fakeBuilder struct {
+	chain *fakeChain
+	jobs  map[seqtypes.BuildJobID]*seqtypes.BuildOpts
+}
+
+func (b *fakeBuilder) New(ctx context.Context, builderID seqtypes.BuilderID, opts *seqtypes.BuildOpts) (seqtypes.BuildJobID, error) {
+	id := seqtypes.RandomJobID()
+	b.jobs[id] = opts
+	return id, nil
+}
+
+func (b *fakeBuilder) Open(ctx context.Context, jobID seqtypes.BuildJobID) error {
+	if _, ok := b.jobs[jobID]; !ok {
+		return seqtypes.ErrUnknownJob
+	}
+	return nil
+}
+
+func (b *fakeBuilder) SealEnvelope(ctx context.Context, jobID seqtypes.BuildJobID) (*eth.ExecutionPayloadEnvelope, error) {
+	opts, ok := b.jobs[jobID]
+	if !ok {
+		return nil, seqtypes.ErrUnknownJob
+	}
+	ref, txs, err := b.chain.child(opts.Parent, opts.L1Origin, opts.Timestamp, nil)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		ParentHash:   ref.ParentHash,
+		BlockNumber:  eth.Uint64Quantity(ref.Number),
+		Timestamp:    eth.Uint64Quantity(ref.Time),
+		GasLimit:     30_000_000,
+		StateRoot:    eth.Bytes32{0x05},
+		ReceiptsRoot: eth.Bytes32(types.EmptyReceiptsHash),
+	}}
+	for _, tx := range txs {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		envelope.ExecutionPayload.Transactions = append(envelope.ExecutionPayload.Transactions, raw)
+	}
+	ref.Hash, _ = envelope.CheckBlockHash()
+	envelope.ExecutionPayload.BlockHash = ref.Hash
+	b.chain.sealed[ref.Hash] = ref
+	b.chain.txs[ref.Hash] = txs
+	return envelope, nil
+}
+
+func (b *fakeBuilder) CloseJob(jobID seqtypes.BuildJobID) error {
+	delete(b.jobs, jobID)
+	return nil
+}
+
+func newTestRunner(t *testing.T) (*Runner, *fakeChain, *fakeSequencer) {
+	chain := newFakeChain()
+	seq := &fakeSequencer{chain: chain}
+	builder := &fakeBuilder{chain: chain, jobs: make(map[seqtypes.BuildJobID]*seqtypes.BuildOpts)}
+	r := NewRunner(testlog.Logger(t, log.LevelInfo), seq, builder, chain, chain)
+	r.pollInterval = 5 * time.Millisecond
+	return r, chain, seq
+}
+
+func signedTx(t *testing.T, nonce uint64) hexutil.Bytes {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(5000)
+	tx := types.MustSignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &common.Address{0x01},
+	})
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return raw
+}
+
+func TestRunner_Example(t *testing.T) {
+	r, chain, seq := newTestRunner(t)
+	sc, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+	sc.Timeout, sc.SettleTime = time.Second, 20*time.Millisecond
+	genesis := chain.head
+
+	results, err := r.Run(context.Background(), sc)
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	empty0, empty1, nextOrigin, reorg := results[0].Block, results[1].Block, results[2].Block, results[5].Block
+	require.Equal(t, genesis.Hash, empty0.ParentHash)
+	require.Equal(t, empty0.Hash, empty1.ParentHash)
+	require.Equal(t, empty1.Hash, nextOrigin.ParentHash)
+	require.Equal(t, uint64(1), nextOrigin.L1Origin.Number)
+	require.Equal(t, empty1.Time+2, nextOrigin.Time)
+
+	for _, res := range results[3:5] {
+		require.ErrorIs(t, res.Err, ErrNotIncluded)
+	}
+	require.Equal(t, 2, seq.resets)
+
+	// The last step reorgs out the block with the next L1 origin
+	require.Equal(t, empty1.Hash, reorg.ParentHash)
+	require.Equal(t, reorg, chain.head)
+	require.Equal(t, "reorg", results[5].Step)
+}
+
+func TestRunner_Expectations(t *testing.T) {
+	ctx := context.Background()
+	one, two := uint64(1), uint64(2)
+
+	t.Run("block", func(t *testing.T) {
+		r, chain, _ := newTestRunner(t)
+		ts := chain.head.Time + 10
+		sc := &Scenario{Steps: []*Step{{
+			L1Origin:  "2",
+			Timestamp: &ts,
+			Txs:       []hexutil.Bytes{signedTx(t, 0)},
+			Expect:    Expectation{TxCount: &one, Deposits: &one, L1Origin: &two},
+		}}}
+		results, err := r.Run(ctx, sc)
+		require.NoError(t, err)
+		require.Equal(t, ts, results[0].Block.Time)
+
+		sc.Steps[0].Expect.TxCount = &two
+		_, err = r.Run(ctx, sc)
+		require.ErrorContains(t, err, "expected 2 txs")
+	})
+
+	t.Run("missing tx", func(t *testing.T) {
+		r, chain, _ := newTestRunner(t)
+		step := &Step{Txs: []hexutil.Bytes{signedTx(t, 0)}}
+		// A block without the tx of the step
+		ref, txs, err := chain.child(chain.head.Hash, nil, nil, nil)
+		require.NoError(t, err)
+		chain.insert(ref, txs)
+		require.ErrorContains(t, r.check(ctx, step, &StepResult{Block: ref}), "is not included")
+	})
+
+	t.Run("unexpected failure", func(t *testing.T) {
+		r, _, seq := newTestRunner(t)
+		seq.nextErr = errors.New("engine said no")
+		results, err := r.Run(ctx, &Scenario{Steps: []*Step{{}}})
+		require.ErrorContains(t, err, "engine said no")
+		require.Len(t, results, 1)
+		require.Equal(t, 1, seq.resets)
+	})
+
+	t.Run("expected failure", func(t *testing.T) {
+		r, _, seq := newTestRunner(t)
+		seq.nextErr = errors.New("engine said no")
+		yes := true
+		sc := &Scenario{Steps: []*Step{{Expect: Expectation{Fail: &yes, Error: "said no"}}}}
+		_, err := r.Run(ctx, sc)
+		require.NoError(t, err)
+
+		sc.Steps[0].Expect.Error = "said yes"
+		_, err = r.Run(ctx, sc)
+		require.ErrorContains(t, err, `expected error containing "said yes"`)
+	})
+
+	t.Run("unexpected inclusion", func(t *testing.T) {
+		r, _, _ := newTestRunner(t)
+		no := false
+		sc := &Scenario{Builder: "test", SettleTime: 20 * time.Millisecond, Steps: []*Step{{
+			Mutate: &Mutation{KeepBlockHash: true},
+			Expect: Expectation{Fail: &no},
+		}}}
+		_, err := r.Run(ctx, sc)
+		require.NoError(t, err, "unmodified block is included")
+
+		sc.Steps[0].Expect.Fail = nil
+		_, err = r.Run(ctx, sc)
+		require.ErrorContains(t, err, "expected block to not be included")
+	})
+
+	t.Run("unknown parent step", func(t *testing.T) {
+		r, _, seq := newTestRunner(t)
+		sc := &Scenario{SettleTime: 20 * time.Millisecond, Steps: []*Step{
+			{Name: "rejected", Expect: Expectation{Fail: new(bool)}},
+			{Parent: "rejected"},
+		}}
+		*sc.Steps[0].Expect.Fail = true
+		seq.nextErr = errors.New("engine said no")
+		_, err := r.Run(ctx, sc)
+		require.ErrorContains(t, err, `step "rejected" has no included block`)
+	})
+}
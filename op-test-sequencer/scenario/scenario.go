@@ -0,0 +1,203 @@
+package scenario
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-test-sequencer/sequencer/seqtypes"
+)
+
+const (
+	// ParentHead builds on top of the unsafe head at the time the step runs.
+	ParentHead = "head"
+
+	// L1OriginSame keeps the L1 origin of the parent block.
+	L1OriginSame = "same"
+	// L1OriginNext advances the L1 origin by one block, which includes the deposits of that L1 block.
+	L1OriginNext = "next"
+
+	DefaultTimeout    = 30 * time.Second
+	DefaultSettleTime = 4 * time.Second
+)
+
+// Scenario describes a sequence of blocks to build with the test sequencer.
+// Scenarios are loaded from YAML, or from JSON, which is a subset of YAML.
+type Scenario struct {
+	Name string `yaml:"name"`
+	// Builder is the ID of the builder that builds the blocks of mutate steps.
+	// Only required if the scenario has steps with a mutation.
+	Builder seqtypes.BuilderID `yaml:"builder,omitempty"`
+	// Timeout is how long to wait for a block to become the unsafe head. Defaults to DefaultTimeout.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// SettleTime is how long to wait for a block, that is expected to be rejected, to not be included.
+	// Defaults to DefaultSettleTime.
+	SettleTime time.Duration `yaml:"settleTime,omitempty"`
+
+	Steps []*Step `yaml:"steps"`
+}
+
+// Step describes a block, or a number of blocks if Repeat is set, to build.
+type Step struct {
+	Name string `yaml:"name"`
+	// Repeat builds the step this many times, every block on top of the previous one.
+	// Zero is treated as one.
+	Repeat uint64 `yaml:"repeat,omitempty"`
+
+	// Parent is "head", the name of an earlier step, or a block hash. Defaults to "head".
+	Parent string `yaml:"parent,omitempty"`
+	// L1Origin is "same", "next", an L1 block number, or an L1 block hash. Defaults to "same".
+	L1Origin string `yaml:"l1Origin,omitempty"`
+	// Timestamp overrides the block timestamp. Mutually exclusive with TimestampDelta.
+	Timestamp *uint64 `yaml:"timestamp,omitempty"`
+	// TimestampDelta overrides the block timestamp, relative to the parent block timestamp.
+	TimestampDelta *uint64 `yaml:"timestampDelta,omitempty"`
+
+	// Txs are raw signed transactions to include in the block, after the deposits.
+	// On a mutate step the txs are appended to the sealed block instead.
+	Txs []hexutil.Bytes `yaml:"txs,omitempty"`
+
+	// Mutate changes the block after it was built by the builder, to attempt an invalid payload.
+	Mutate *Mutation `yaml:"mutate,omitempty"`
+
+	Expect Expectation `yaml:"expect,omitempty"`
+}
+
+// Mutation describes changes to a built block. Every set field overrides the built value.
+type Mutation struct {
+	StateRoot    *common.Hash   `yaml:"stateRoot,omitempty"`
+	ReceiptsRoot *common.Hash   `yaml:"receiptsRoot,omitempty"`
+	GasUsed      *uint64        `yaml:"gasUsed,omitempty"`
+	GasLimit     *uint64        `yaml:"gasLimit,omitempty"`
+	Timestamp    *uint64        `yaml:"timestamp,omitempty"`
+	ExtraData    *hexutil.Bytes `yaml:"extraData,omitempty"`
+	// DropTxs removes all non-deposit transactions from the block.
+	DropTxs bool `yaml:"dropTxs,omitempty"`
+	// KeepBlockHash keeps the original block hash, instead of recomputing it for the mutated block.
+	KeepBlockHash bool `yaml:"keepBlockHash,omitempty"`
+}
+
+// Expectation describes the expected outcome of a step.
+type Expectation struct {
+	// Fail expects the block to not be included. Defaults to true for mutate steps, and to false otherwise.
+	Fail *bool `yaml:"fail,omitempty"`
+	// Error is a substring the error of a failed step must contain.
+	Error string `yaml:"error,omitempty"`
+	// TxCount is the expected number of non-deposit transactions in the block.
+	TxCount *uint64 `yaml:"txCount,omitempty"`
+	// Deposits is the expected number of deposit transactions in the block, excluding the L1 attributes transaction.
+	Deposits *uint64 `yaml:"deposits,omitempty"`
+	// L1Origin is the expected L1 origin number of the block.
+	L1Origin *uint64 `yaml:"l1Origin,omitempty"`
+}
+
+// Load reads a scenario from the given YAML or JSON file path, and checks it.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a scenario from YAML or JSON, and checks it.
+func Parse(data []byte) (*Scenario, error) {
+	var out Scenario
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true) // a misspelled field would silently change what the scenario tests
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if err := out.Check(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %q: %w", out.Name, err)
+	}
+	return &out, nil
+}
+
+// Check validates the scenario.
+func (s *Scenario) Check() error {
+	if len(s.Steps) == 0 {
+		return errors.New("scenario has no steps")
+	}
+	names := make(map[string]struct{}, len(s.Steps))
+	for i, step := range s.Steps {
+		if step == nil {
+			return fmt.Errorf("step %d is empty", i)
+		}
+		if err := step.check(names); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i, step.Name, err)
+		}
+		if step.Mutate != nil && s.Builder == "" {
+			return fmt.Errorf("step %d (%s): mutate steps require a builder", i, step.Name)
+		}
+		if step.Name != "" {
+			names[step.Name] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (s *Step) check(earlier map[string]struct{}) error {
+	if s.Name == ParentHead {
+		return fmt.Errorf("step name %q is reserved", ParentHead)
+	}
+	if _, ok := earlier[s.Name]; ok {
+		return fmt.Errorf("duplicate step name %q", s.Name)
+	}
+	switch s.Parent {
+	case "", ParentHead:
+	default:
+		if _, ok := earlier[s.Parent]; !ok && !isHash(s.Parent) {
+			return fmt.Errorf("parent %q is neither %q, an earlier step, nor a block hash", s.Parent, ParentHead)
+		}
+	}
+	switch s.L1Origin {
+	case "", L1OriginSame, L1OriginNext:
+	default:
+		if _, err := strconv.ParseUint(s.L1Origin, 0, 64); err != nil && !isHash(s.L1Origin) {
+			return fmt.Errorf("l1Origin %q is neither %q, %q, a block number, nor a block hash", s.L1Origin, L1OriginSame, L1OriginNext)
+		}
+	}
+	if s.Mutate != nil && s.Mutate.ExtraData != nil && len(*s.Mutate.ExtraData) > 32 {
+		return errors.New("mutated extraData must not be larger than 32 bytes")
+	}
+	if s.Timestamp != nil && s.TimestampDelta != nil {
+		return errors.New("timestamp and timestampDelta are mutually exclusive")
+	}
+	if s.Expect.Error != "" && !s.ExpectFail() {
+		return errors.New("an expected error requires the step to be expected to fail")
+	}
+	if s.ExpectFail() && (s.Expect.TxCount != nil || s.Expect.Deposits != nil || s.Expect.L1Origin != nil) {
+		return errors.New("block expectations cannot be met by a step that is expected to fail")
+	}
+	return nil
+}
+
+// ExpectFail returns whether the block of the step is expected to not be included.
+func (s *Step) ExpectFail() bool {
+	if s.Expect.Fail != nil {
+		return *s.Expect.Fail
+	}
+	return s.Mutate != nil
+}
+
+// Count returns the number of blocks the step builds.
+func (s *Step) Count() uint64 {
+	return max(s.Repeat, 1)
+}
+
+func isHash(v string) bool {
+	if len(v) != 2+2*common.HashLength {
+		return false
+	}
+	_, err := hexutil.Decode(v)
+	return err == nil
+}
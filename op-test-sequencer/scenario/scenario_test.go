@@ -0,0 +1,91 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestLoad(t *testing.T) {
+	sc, err := Load("testdata/example.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "l1-origin-and-invalid-payloads", sc.Name)
+	require.Equal(t, "test-builder", sc.Builder.String())
+	require.Equal(t, 20*time.Second, sc.Timeout)
+	require.Equal(t, 3*time.Second, sc.SettleTime)
+	require.Len(t, sc.Steps, 5)
+
+	require.Equal(t, uint64(2), sc.Steps[0].Count())
+	require.False(t, sc.Steps[0].ExpectFail())
+	require.Equal(t, uint64(0), *sc.Steps[0].Expect.TxCount)
+
+	require.Equal(t, L1OriginNext, sc.Steps[1].L1Origin)
+	require.Equal(t, uint64(2), *sc.Steps[1].TimestampDelta)
+
+	require.True(t, sc.Steps[2].ExpectFail())
+	require.Equal(t, common.Hash{31: 1}, *sc.Steps[2].Mutate.StateRoot)
+	require.True(t, sc.Steps[3].Mutate.KeepBlockHash)
+
+	require.Equal(t, "empty", sc.Steps[4].Parent)
+}
+
+func TestParseJSON(t *testing.T) {
+	sc, err := Parse([]byte(`{"name": "json", "steps": [{"txs": ["0x01"], "expect": {"fail": true, "error": "invalid"}}]}`))
+	require.NoError(t, err)
+	require.Equal(t, "json", sc.Name)
+	require.Equal(t, []byte{1}, []byte(sc.Steps[0].Txs[0]))
+	require.True(t, sc.Steps[0].ExpectFail())
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := map[string]struct {
+		data string
+		err  string
+	}{
+		"unknown field": {
+			data: `{"steps": [{"parnet": "head"}]}`,
+			err:  "field parnet not found",
+		},
+		"no steps": {
+			data: `{"name": "empty"}`,
+			err:  "no steps",
+		},
+		"mutate without builder": {
+			data: `{"steps": [{"mutate": {"gasUsed": 1}}]}`,
+			err:  "require a builder",
+		},
+		"unknown parent": {
+			data: `{"steps": [{"parent": "later"}, {"name": "later"}]}`,
+			err:  "neither",
+		},
+		"invalid L1 origin": {
+			data: `{"steps": [{"l1Origin": "previous"}]}`,
+			err:  "neither",
+		},
+		"duplicate name": {
+			data: `{"steps": [{"name": "a"}, {"name": "a"}]}`,
+			err:  "duplicate step name",
+		},
+		"both timestamps": {
+			data: `{"steps": [{"timestamp": 10, "timestampDelta": 2}]}`,
+			err:  "mutually exclusive",
+		},
+		"error on valid step": {
+			data: `{"steps": [{"expect": {"error": "invalid"}}]}`,
+			err:  "expected to fail",
+		},
+		"block expectation on failing step": {
+			data: `{"builder": "b", "steps": [{"mutate": {"gasUsed": 1}, "expect": {"txCount": 0}}]}`,
+			err:  "cannot be met",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.data))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
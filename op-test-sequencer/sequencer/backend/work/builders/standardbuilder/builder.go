@@ -93,6 +93,10 @@ func (b *Builder) NewJob(ctx context.Context, opts seqtypes.BuildOpts) (work.Bui
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare payload attributes: %w", err)
 	}
+	if opts.Timestamp != nil {
+		b.log.Warn("Builder NewJob overriding block timestamp", "timestamp", uint64(*opts.Timestamp), "default", uint64(attrs.Timestamp))
+		attrs.Timestamp = *opts.Timestamp
+	}
 	b.log.Debug("Builder NewJob prepared payload attrs", "attrs", attrs)
 
 	id := seqtypes.RandomJobID()
@@ -120,3 +120,35 @@ func TestStandardBuilder(t *testing.T) {
 	job2.Close()
 	require.Nil(t, reg.GetJob(job2.ID()), "job 2 should be cleaned up")
 }
+
+func TestStandardBuilderTimestampOverride(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	rng := rand.New(rand.NewSource(123))
+	l2Parent := testutils.RandomL2BlockRef(rng)
+	ctx := context.Background()
+
+	build := func(t *testing.T, timestamp *eth.Uint64Quantity) *eth.PayloadAttributes {
+		api := &testAPI{}
+		l1 := &testutils.MockL1Source{}
+		l2 := &testutils.MockL2Client{}
+		x := NewBuilder(seqtypes.BuilderID("foo"), logger, &metrics.NoopMetrics{}, l1, l2, &fakeAttributesBuilder{}, api, work.NewJobRegistry())
+		l2.ExpectL2BlockRefByHash(l2Parent.Hash, l2Parent, nil)
+		job, err := x.NewJob(ctx, seqtypes.BuildOpts{Parent: l2Parent.Hash, Timestamp: timestamp})
+		require.NoError(t, err)
+		defer job.Close()
+		require.NoError(t, job.Open(ctx))
+		l2.AssertExpectations(t)
+		return api.attrs
+	}
+
+	t.Run("Override", func(t *testing.T) {
+		timestamp := eth.Uint64Quantity(l2Parent.Time + 10)
+		attrs := build(t, &timestamp)
+		require.Equal(t, timestamp, attrs.Timestamp)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		attrs := build(t, nil)
+		require.Equal(t, eth.Uint64Quantity(l2Parent.Time+2), attrs.Timestamp, "prepared timestamp is kept")
+	})
+}
@@ -145,6 +145,10 @@ type Sequencer interface {
 	// An error is returned if any step fails. It is safe to re-attempt with Next if so.
 	Next(ctx context.Context) error
 
+	// Reset discards the current sequencing slot, including any block that was built, signed or committed in it,
+	// so the next slot can be started after a failed step.
+	Reset(ctx context.Context) error
+
 	// Start starts automatic sequencing, on top of the given chain head.
 	// An error is returned if the head of the chain does not match the provided head,
 	// as safety measure to prevent reorgs during sequencer rotations.
@@ -230,6 +230,12 @@ func (s *Sequencer) Next(ctx context.Context) error {
 	return nil
 }
 
+func (s *Sequencer) Reset(ctx context.Context) error {
+	s.log.Info("Resetting sequencing slot")
+	s.lockingReset()
+	return nil
+}
+
 func (s *Sequencer) lockingReset() {
 	s.stateMu.Lock()
 	defer s.stateMu.Unlock()
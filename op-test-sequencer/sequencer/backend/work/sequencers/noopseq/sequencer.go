@@ -69,6 +69,10 @@ func (n *Sequencer) Next(ctx context.Context) error {
 	return nil
 }
 
+func (n *Sequencer) Reset(ctx context.Context) error {
+	return nil
+}
+
 func (n *Sequencer) Start(ctx context.Context, head common.Hash) error {
 	return seqtypes.ErrSequencerInactive
 }
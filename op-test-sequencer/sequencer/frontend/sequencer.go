@@ -59,6 +59,10 @@ func (bf *SequencerFrontend) Next(ctx context.Context) error {
 	return toJsonError(bf.Sequencer.Next(ctx))
 }
 
+func (bf *SequencerFrontend) Reset(ctx context.Context) error {
+	return toJsonError(bf.Sequencer.Reset(ctx))
+}
+
 func (bf *SequencerFrontend) Start(ctx context.Context, head common.Hash) error {
 	return toJsonError(bf.Sequencer.Start(ctx, head))
 }
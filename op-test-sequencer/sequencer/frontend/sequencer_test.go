@@ -88,6 +88,11 @@ func (m *mockSequencer) Next(ctx context.Context) error {
 	return m.err
 }
 
+func (m *mockSequencer) Reset(ctx context.Context) error {
+	m.action = "reset"
+	return m.err
+}
+
 func (m *mockSequencer) Start(ctx context.Context, head common.Hash) error {
 	m.action = "start"
 	return m.err
@@ -151,6 +156,8 @@ func TestSequencer(t *testing.T) {
 		require.Equal(t, "next", seq.action)
 		require.NoError(t, front.PrebuiltEnvelope(ctx, nil))
 		require.Equal(t, "prebuilt", seq.action)
+		require.NoError(t, front.Reset(ctx))
+		require.Equal(t, "reset", seq.action)
 		seq.action = ""
 	})
 	t.Run("start stop", func(t *testing.T) {
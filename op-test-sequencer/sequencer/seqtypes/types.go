@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -151,4 +152,9 @@ type BuildOpts struct {
 	// Optional, by default the L1 origin of the parent block
 	// is progressed when first allowed (respecting time invariants).
 	L1Origin *common.Hash `json:"l1Origin,omitempty"`
+
+	// Timestamp overrides the timestamp of the block.
+	// Optional, by default the block time is added to the parent timestamp.
+	// Setting this may produce an invalid block, which is useful to test block validation.
+	Timestamp *hexutil.Uint64 `json:"timestamp,omitempty"`
 }
@@ -38,6 +38,7 @@ func main() {
 				versionCmd(standard.ContractsV400Tag),
 				versionCmd(standard.ContractsV410Tag),
 				versionCmd(standard.ContractsV500Tag),
+				mantleCmd(),
 			},
 		},
 	}
@@ -61,3 +62,12 @@ func versionCmd(version string) *cli.Command {
 		},
 	}
 }
+
+func mantleCmd() *cli.Command {
+	return &cli.Command{
+		Name:   "mantle",
+		Usage:  "Run validation of a Mantle deployment against an expected manifest",
+		Flags:  append(service.MantleValidateFlags, oplog.CLIFlags(EnvVarPrefix)...),
+		Action: service.MantleValidateCmd,
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-validator/pkg/validations"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	OutputFormatMarkdown = "markdown"
+	OutputFormatJSON     = "json"
+)
+
+var (
+	MantleL2RPCURLFlag = &cli.StringFlag{
+		Name:  "l2-rpc-url",
+		Usage: "L2 RPC URL (required if the manifest checks the L2 GasPriceOracle)",
+	}
+	ManifestFlag = &cli.PathFlag{
+		Name:     "manifest",
+		Usage:    "Path to the JSON manifest of the expected deployment",
+		Required: true,
+	}
+	OutputFormatFlag = &cli.StringFlag{
+		Name:  "output-format",
+		Usage: "Output format, one of: markdown, json",
+		Value: OutputFormatMarkdown,
+	}
+)
+
+// MantleValidateFlags contains all the flags needed for Mantle validation
+var MantleValidateFlags = []cli.Flag{
+	L1RPCURLFlag,
+	MantleL2RPCURLFlag,
+	ManifestFlag,
+	OutputFormatFlag,
+	FailOnErrorFlag,
+}
+
+// MantleConfig represents the configuration for validating a Mantle deployment
+type MantleConfig struct {
+	L1RPCURL     string
+	L2RPCURL     string
+	Manifest     *validations.MantleManifest
+	OutputFormat string
+}
+
+// NewMantleConfig creates a new MantleConfig from CLI context
+func NewMantleConfig(ctx *cli.Context) (*MantleConfig, error) {
+	outputFormat := ctx.String(OutputFormatFlag.Name)
+	if outputFormat != OutputFormatMarkdown && outputFormat != OutputFormatJSON {
+		return nil, fmt.Errorf("invalid output format: %s", outputFormat)
+	}
+	manifest, err := validations.LoadMantleManifest(ctx.Path(ManifestFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	l2RPCURL := ctx.String(MantleL2RPCURLFlag.Name)
+	if manifest.GasPriceOracle != nil && l2RPCURL == "" {
+		return nil, fmt.Errorf("--%s is required to check the GasPriceOracle", MantleL2RPCURLFlag.Name)
+	}
+	return &MantleConfig{
+		L1RPCURL:     ctx.String(L1RPCURLFlag.Name),
+		L2RPCURL:     l2RPCURL,
+		Manifest:     manifest,
+		OutputFormat: outputFormat,
+	}, nil
+}
+
+func MantleValidateCmd(cliCtx *cli.Context) error {
+	logCfg := oplog.ReadCLIConfig(cliCtx)
+	lgr := oplog.NewLogger(oplog.AppOut(cliCtx), logCfg)
+	cfg, err := NewMantleConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	findings, err := ValidateMantle(cliCtx.Context, lgr, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to validate: %w", err)
+	}
+
+	out := validations.Output{
+		Findings: findings,
+	}
+	for _, f := range findings {
+		out.Errors = append(out.Errors, f.Code)
+	}
+
+	if cfg.OutputFormat == OutputFormatJSON {
+		data, err := out.AsJSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		fmt.Println(data)
+	} else {
+		fmt.Println(out.AsMarkdown())
+	}
+
+	if cliCtx.Bool(FailOnErrorFlag.Name) && len(findings) > 0 {
+		return cli.Exit("Validation errors found", 1)
+	}
+
+	return nil
+}
+
+func ValidateMantle(ctx context.Context, lgr log.Logger, cfg *MantleConfig) ([]validations.Finding, error) {
+	l1Client, err := rpc.Dial(cfg.L1RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Client.Close()
+
+	var l2Client *rpc.Client
+	if cfg.L2RPCURL != "" {
+		l2Client, err = rpc.Dial(cfg.L2RPCURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial L2 RPC: %w", err)
+		}
+		defer l2Client.Close()
+	}
+
+	lgr.Info("Validating Mantle deployment", "proxies", len(cfg.Manifest.Proxies), "disputeGames", len(cfg.Manifest.DisputeGames))
+	return validations.NewMantleValidator(l1Client, l2Client).Validate(ctx, cfg.Manifest)
+}
@@ -1,5 +1,10 @@
 package validations
 
+import (
+	"strconv"
+	"strings"
+)
+
 var descriptions = map[string]string{
 	// SuperchainConfig validations
 	"SPRCFG-10": "SuperchainConfig is paused",
@@ -179,8 +184,65 @@ var descriptions = map[string]string{
 	"CKDG-PIMGO-20":   "Custom dispute game preimage oracle challenge period not set to 86400",
 	"CKDG-PIMGO-30":   "Custom dispute game preimage oracle min proposal size not set to 126000",
 	"CKDG-GARGS-10":   "Custom dispute game game args mismatch",
+
+	// Mantle validations
+	"MNTPRX-10":  "Mantle proxy implementation mismatch",
+	"MNTPRX-20":  "Mantle proxy admin mismatch",
+	"MNTPRX-30":  "Mantle proxy implementation version mismatch",
+	"MNTROLE-10": "Mantle ProxyAdmin owner mismatch",
+	"MNTROLE-20": "Mantle SystemConfig owner mismatch",
+	"MNTROLE-30": "Mantle batcher address mismatch",
+	"MNTROLE-40": "Mantle unsafe block signer mismatch",
+	"MNTROLE-50": "Mantle OptimismPortal guardian mismatch",
+	"MNTROLE-60": "Mantle L2OutputOracle proposer mismatch",
+	"MNTROLE-70": "Mantle L2OutputOracle challenger mismatch",
+	"MNTSYS-10":  "Mantle SystemConfig gas limit mismatch",
+	"MNTSYS-20":  "Mantle SystemConfig base fee mismatch",
+	"MNTSYS-30":  "Mantle SystemConfig min base fee mismatch",
+	"MNTSYS-40":  "Mantle SystemConfig base fee scalar mismatch",
+	"MNTSYS-50":  "Mantle SystemConfig blob base fee scalar mismatch",
+	"MNTSYS-60":  "Mantle SystemConfig EIP-1559 denominator mismatch",
+	"MNTSYS-70":  "Mantle SystemConfig EIP-1559 elasticity mismatch",
+	"MNTSYS-80":  "Mantle SystemConfig operator fee scalar mismatch",
+	"MNTSYS-90":  "Mantle SystemConfig operator fee constant mismatch",
+	"MNTSYS-100": "Mantle SystemConfig DA footprint gas scalar mismatch",
+	"MNTPORT-10": "Mantle OptimismPortal L2OutputOracle address mismatch",
+	"MNTPORT-20": "Mantle OptimismPortal SystemConfig address mismatch",
+	"MNTPORT-30": "Mantle OptimismPortal L1 MNT token address mismatch",
+	"MNTL2OO-10": "Mantle L2OutputOracle submission interval mismatch",
+	"MNTL2OO-20": "Mantle L2OutputOracle L2 block time mismatch",
+	"MNTL2OO-30": "Mantle L2OutputOracle finalization period mismatch",
+	"MNTDG-10":   "Mantle dispute game implementation not found",
+	"MNTDG-20":   "Mantle dispute game implementation address mismatch",
+	"MNTDG-30":   "Mantle dispute game absolute prestate mismatch",
+	"MNTGPO-10":  "Mantle L2 GasPriceOracle token ratio mismatch",
+	"MNTGPO-20":  "Mantle L2 GasPriceOracle operator mismatch",
 }
 
 func ErrorDescription(code string) string {
 	return descriptions[code]
 }
+
+// CompareCodes orders error codes by prefix, then by their numeric suffix,
+// so that "MNTSYS-20" comes before "MNTSYS-100".
+func CompareCodes(a, b string) int {
+	aPrefix, aNum, aOk := splitCode(a)
+	bPrefix, bNum, bOk := splitCode(b)
+	if !aOk || !bOk || aPrefix != bPrefix {
+		return strings.Compare(a, b)
+	}
+	return aNum - bNum
+}
+
+// splitCode splits an error code into its prefix and the number after the last dash.
+func splitCode(code string) (string, int, bool) {
+	i := strings.LastIndexByte(code, '-')
+	if i < 0 {
+		return "", 0, false
+	}
+	num, err := strconv.Atoi(code[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return code[:i], num, true
+}
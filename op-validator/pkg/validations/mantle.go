@@ -0,0 +1,527 @@
+package validations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+)
+
+// Names of the proxies in a MantleManifest that the Mantle validations depend on.
+const (
+	MantleSystemConfigProxy       = "SystemConfig"
+	MantleOptimismPortalProxy     = "OptimismPortal"
+	MantleL2OutputOracleProxy     = "L2OutputOracle"
+	MantleDisputeGameFactoryProxy = "DisputeGameFactory"
+)
+
+var (
+	// EIP-1967 proxy storage slots
+	implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	adminSlot          = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+	versionFunc = w3.MustNewFunc("version()", "string")
+	ownerFunc   = w3.MustNewFunc("owner()", "address")
+
+	// SystemConfig
+	batcherHashFunc          = w3.MustNewFunc("batcherHash()", "bytes32")
+	unsafeBlockSignerFunc    = w3.MustNewFunc("unsafeBlockSigner()", "address")
+	gasLimitFunc             = w3.MustNewFunc("gasLimit()", "uint64")
+	baseFeeFunc              = w3.MustNewFunc("baseFee()", "uint256")
+	minBaseFeeFunc           = w3.MustNewFunc("minBaseFee()", "uint64")
+	basefeeScalarFunc        = w3.MustNewFunc("basefeeScalar()", "uint32")
+	blobbasefeeScalarFunc    = w3.MustNewFunc("blobbasefeeScalar()", "uint32")
+	eip1559DenominatorFunc   = w3.MustNewFunc("eip1559Denominator()", "uint32")
+	eip1559ElasticityFunc    = w3.MustNewFunc("eip1559Elasticity()", "uint32")
+	operatorFeeScalarFunc    = w3.MustNewFunc("operatorFeeScalar()", "uint32")
+	operatorFeeConstantFunc  = w3.MustNewFunc("operatorFeeConstant()", "uint64")
+	daFootprintGasScalarFunc = w3.MustNewFunc("daFootprintGasScalar()", "uint16")
+
+	// OptimismPortal
+	guardianFunc     = w3.MustNewFunc("GUARDIAN()", "address")
+	l2OracleFunc     = w3.MustNewFunc("L2_ORACLE()", "address")
+	systemConfigFunc = w3.MustNewFunc("SYSTEM_CONFIG()", "address")
+	l1MNTAddressFunc = w3.MustNewFunc("L1_MNT_ADDRESS()", "address")
+
+	// L2OutputOracle
+	proposerFunc                  = w3.MustNewFunc("PROPOSER()", "address")
+	challengerFunc                = w3.MustNewFunc("CHALLENGER()", "address")
+	submissionIntervalFunc        = w3.MustNewFunc("SUBMISSION_INTERVAL()", "uint256")
+	l2BlockTimeFunc               = w3.MustNewFunc("L2_BLOCK_TIME()", "uint256")
+	finalizationPeriodSecondsFunc = w3.MustNewFunc("FINALIZATION_PERIOD_SECONDS()", "uint256")
+
+	// DisputeGameFactory and dispute games
+	gameImplsFunc        = w3.MustNewFunc("gameImpls(uint32)", "address")
+	absolutePrestateFunc = w3.MustNewFunc("absolutePrestate()", "bytes32")
+
+	// L2 GasPriceOracle
+	tokenRatioFunc = w3.MustNewFunc("tokenRatio()", "uint256")
+	operatorFunc   = w3.MustNewFunc("operator()", "address")
+)
+
+// MantleManifest describes the expected state of a Mantle deployment.
+// Every section is optional, and only the values present in the manifest are checked.
+type MantleManifest struct {
+	ProxyAdmin     common.Address          `json:"proxyAdmin"`
+	Proxies        map[string]MantleProxy  `json:"proxies"`
+	Roles          MantleRoles             `json:"roles"`
+	SystemConfig   *MantleSystemConfig     `json:"systemConfig,omitempty"`
+	OptimismPortal *MantleOptimismPortal   `json:"optimismPortal,omitempty"`
+	L2OutputOracle *MantleL2OutputOracle   `json:"l2OutputOracle,omitempty"`
+	DisputeGames   []MantleDisputeGame     `json:"disputeGames,omitempty"`
+	GasPriceOracle *MantleGasPriceOracleL2 `json:"gasPriceOracle,omitempty"`
+}
+
+type MantleProxy struct {
+	Address        common.Address `json:"address"`
+	Implementation common.Address `json:"implementation"`
+	Version        string         `json:"version,omitempty"`
+}
+
+type MantleRoles struct {
+	ProxyAdminOwner   *common.Address `json:"proxyAdminOwner,omitempty"`
+	SystemConfigOwner *common.Address `json:"systemConfigOwner,omitempty"`
+	Batcher           *common.Address `json:"batcher,omitempty"`
+	UnsafeBlockSigner *common.Address `json:"unsafeBlockSigner,omitempty"`
+	Guardian          *common.Address `json:"guardian,omitempty"`
+	Proposer          *common.Address `json:"proposer,omitempty"`
+	Challenger        *common.Address `json:"challenger,omitempty"`
+}
+
+type MantleSystemConfig struct {
+	GasLimit             *uint64  `json:"gasLimit,omitempty"`
+	BaseFee              *big.Int `json:"baseFee,omitempty"`
+	MinBaseFee           *uint64  `json:"minBaseFee,omitempty"`
+	BasefeeScalar        *uint32  `json:"basefeeScalar,omitempty"`
+	BlobbasefeeScalar    *uint32  `json:"blobbasefeeScalar,omitempty"`
+	EIP1559Denominator   *uint32  `json:"eip1559Denominator,omitempty"`
+	EIP1559Elasticity    *uint32  `json:"eip1559Elasticity,omitempty"`
+	OperatorFeeScalar    *uint32  `json:"operatorFeeScalar,omitempty"`
+	OperatorFeeConstant  *uint64  `json:"operatorFeeConstant,omitempty"`
+	DAFootprintGasScalar *uint16  `json:"daFootprintGasScalar,omitempty"`
+}
+
+type MantleOptimismPortal struct {
+	L1MNTAddress *common.Address `json:"l1MNTAddress,omitempty"`
+}
+
+type MantleL2OutputOracle struct {
+	SubmissionInterval        *uint64 `json:"submissionInterval,omitempty"`
+	L2BlockTime               *uint64 `json:"l2BlockTime,omitempty"`
+	FinalizationPeriodSeconds *uint64 `json:"finalizationPeriodSeconds,omitempty"`
+}
+
+type MantleDisputeGame struct {
+	GameType         uint32          `json:"gameType"`
+	Implementation   *common.Address `json:"implementation,omitempty"`
+	AbsolutePrestate *common.Hash    `json:"absolutePrestate,omitempty"`
+}
+
+// MantleGasPriceOracleL2 is the expected configuration of the L2 GasPriceOracle predeploy.
+// It requires an L2 RPC to check.
+type MantleGasPriceOracleL2 struct {
+	TokenRatio *big.Int        `json:"tokenRatio,omitempty"`
+	Operator   *common.Address `json:"operator,omitempty"`
+}
+
+// Check verifies the manifest contains the proxies the requested checks depend on.
+func (m *MantleManifest) Check() error {
+	require := func(name string, reason string) error {
+		if _, ok := m.Proxies[name]; !ok {
+			return fmt.Errorf("proxy %s is required to check %s", name, reason)
+		}
+		return nil
+	}
+	r := m.Roles
+	if m.SystemConfig != nil || r.SystemConfigOwner != nil || r.Batcher != nil || r.UnsafeBlockSigner != nil {
+		if err := require(MantleSystemConfigProxy, "the SystemConfig"); err != nil {
+			return err
+		}
+	}
+	if m.OptimismPortal != nil || r.Guardian != nil {
+		if err := require(MantleOptimismPortalProxy, "the OptimismPortal"); err != nil {
+			return err
+		}
+	}
+	if m.L2OutputOracle != nil || r.Proposer != nil || r.Challenger != nil {
+		if err := require(MantleL2OutputOracleProxy, "the L2OutputOracle"); err != nil {
+			return err
+		}
+	}
+	if len(m.DisputeGames) != 0 {
+		if err := require(MantleDisputeGameFactoryProxy, "dispute games"); err != nil {
+			return err
+		}
+	}
+	if len(m.Proxies) != 0 && m.ProxyAdmin == (common.Address{}) {
+		return errors.New("proxy admin is required to check proxies")
+	}
+	return nil
+}
+
+// LoadMantleManifest reads a MantleManifest from a JSON file.
+func LoadMantleManifest(path string) (*MantleManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest MantleManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if err := manifest.Check(); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// chainReader reads contract state. It is implemented with w3 for RPC clients.
+type chainReader interface {
+	CallFunc(ctx context.Context, to common.Address, fn *w3.Func, args []any, returns ...any) error
+	StorageAt(ctx context.Context, addr common.Address, slot common.Hash) (common.Hash, error)
+}
+
+type w3Reader struct {
+	client *w3.Client
+}
+
+func (r *w3Reader) CallFunc(ctx context.Context, to common.Address, fn *w3.Func, args []any, returns ...any) error {
+	return r.client.CallCtx(ctx, eth.CallFunc(to, fn, args...).Returns(returns...))
+}
+
+func (r *w3Reader) StorageAt(ctx context.Context, addr common.Address, slot common.Hash) (common.Hash, error) {
+	var value common.Hash
+	err := r.client.CallCtx(ctx, eth.StorageAt(addr, slot, nil).Returns(&value))
+	return value, err
+}
+
+// MantleValidator validates a Mantle deployment against a MantleManifest.
+// Unlike the StandardValidator based validations, all checks are performed in Go.
+type MantleValidator struct {
+	l1 chainReader
+	// l2 is optional, and only required to check the L2 GasPriceOracle.
+	l2 chainReader
+}
+
+// NewMantleValidator creates a MantleValidator. l2Client may be nil if the manifest has no L2 checks.
+func NewMantleValidator(l1Client *rpc.Client, l2Client *rpc.Client) *MantleValidator {
+	v := &MantleValidator{l1: &w3Reader{client: w3.NewClient(l1Client)}}
+	if l2Client != nil {
+		v.l2 = &w3Reader{client: w3.NewClient(l2Client)}
+	}
+	return v
+}
+
+type mantleCheck struct {
+	ctx      context.Context
+	findings []Finding
+}
+
+func (c *mantleCheck) add(code string, target string, expected any, actual any) {
+	c.findings = append(c.findings, Finding{
+		Code:        code,
+		Description: ErrorDescription(code),
+		Target:      target,
+		Expected:    fmt.Sprint(expected),
+		Actual:      fmt.Sprint(actual),
+	})
+}
+
+// expect adds a finding with the given code if the expected value is set and differs from the actual value.
+func expect[T comparable](c *mantleCheck, code string, target string, expected *T, actual T) {
+	if expected != nil && *expected != actual {
+		c.add(code, target, *expected, actual)
+	}
+}
+
+func expectBig(c *mantleCheck, code string, target string, expected *big.Int, actual *big.Int) {
+	if expected != nil && expected.Cmp(actual) != 0 {
+		c.add(code, target, expected, actual)
+	}
+}
+
+// Validate checks the deployment against the manifest, and returns the findings sorted by code.
+// An error is returned if the deployment state could not be read.
+func (v *MantleValidator) Validate(ctx context.Context, manifest *MantleManifest) ([]Finding, error) {
+	c := &mantleCheck{ctx: ctx, findings: []Finding{}}
+	steps := []struct {
+		name string
+		fn   func(*mantleCheck, *MantleManifest) error
+	}{
+		{"proxies", v.validateProxies},
+		{"roles", v.validateRoles},
+		{"system config", v.validateSystemConfig},
+		{"optimism portal", v.validateOptimismPortal},
+		{"l2 output oracle", v.validateL2OutputOracle},
+		{"dispute games", v.validateDisputeGames},
+		{"gas price oracle", v.validateGasPriceOracle},
+	}
+	for _, step := range steps {
+		if err := step.fn(c, manifest); err != nil {
+			return nil, fmt.Errorf("failed to validate %s: %w", step.name, err)
+		}
+	}
+	slices.SortStableFunc(c.findings, func(a, b Finding) int {
+		return CompareCodes(a.Code, b.Code)
+	})
+	return c.findings, nil
+}
+
+func (v *MantleValidator) validateProxies(c *mantleCheck, m *MantleManifest) error {
+	names := make([]string, 0, len(m.Proxies))
+	for name := range m.Proxies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		proxy := m.Proxies[name]
+		impl, err := v.l1.StorageAt(c.ctx, proxy.Address, implementationSlot)
+		if err != nil {
+			return fmt.Errorf("%s implementation: %w", name, err)
+		}
+		if actual := common.BytesToAddress(impl[:]); actual != proxy.Implementation {
+			c.add("MNTPRX-10", name, proxy.Implementation, actual)
+		}
+		admin, err := v.l1.StorageAt(c.ctx, proxy.Address, adminSlot)
+		if err != nil {
+			return fmt.Errorf("%s admin: %w", name, err)
+		}
+		if actual := common.BytesToAddress(admin[:]); actual != m.ProxyAdmin {
+			c.add("MNTPRX-20", name, m.ProxyAdmin, actual)
+		}
+		if proxy.Version != "" {
+			var version string
+			if err := v.l1.CallFunc(c.ctx, proxy.Address, versionFunc, nil, &version); err != nil {
+				return fmt.Errorf("%s version: %w", name, err)
+			}
+			expect(c, "MNTPRX-30", name, &proxy.Version, version)
+		}
+	}
+	return nil
+}
+
+func (v *MantleValidator) readAddress(c *mantleCheck, to common.Address, fn *w3.Func) (common.Address, error) {
+	var out common.Address
+	err := v.l1.CallFunc(c.ctx, to, fn, nil, &out)
+	return out, err
+}
+
+func (v *MantleValidator) validateRoles(c *mantleCheck, m *MantleManifest) error {
+	r := m.Roles
+	checks := []struct {
+		code     string
+		target   string
+		expected *common.Address
+		proxy    common.Address
+		fn       *w3.Func
+	}{
+		{"MNTROLE-10", "ProxyAdmin", r.ProxyAdminOwner, m.ProxyAdmin, ownerFunc},
+		{"MNTROLE-20", MantleSystemConfigProxy, r.SystemConfigOwner, m.Proxies[MantleSystemConfigProxy].Address, ownerFunc},
+		{"MNTROLE-40", MantleSystemConfigProxy, r.UnsafeBlockSigner, m.Proxies[MantleSystemConfigProxy].Address, unsafeBlockSignerFunc},
+		{"MNTROLE-50", MantleOptimismPortalProxy, r.Guardian, m.Proxies[MantleOptimismPortalProxy].Address, guardianFunc},
+		{"MNTROLE-60", MantleL2OutputOracleProxy, r.Proposer, m.Proxies[MantleL2OutputOracleProxy].Address, proposerFunc},
+		{"MNTROLE-70", MantleL2OutputOracleProxy, r.Challenger, m.Proxies[MantleL2OutputOracleProxy].Address, challengerFunc},
+	}
+	for _, check := range checks {
+		if check.expected == nil {
+			continue
+		}
+		actual, err := v.readAddress(c, check.proxy, check.fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", check.code, err)
+		}
+		expect(c, check.code, check.target, check.expected, actual)
+	}
+	if r.Batcher != nil {
+		var batcherHash common.Hash
+		if err := v.l1.CallFunc(c.ctx, m.Proxies[MantleSystemConfigProxy].Address, batcherHashFunc, nil, &batcherHash); err != nil {
+			return fmt.Errorf("batcher hash: %w", err)
+		}
+		expect(c, "MNTROLE-30", MantleSystemConfigProxy, r.Batcher, common.BytesToAddress(batcherHash[:]))
+	}
+	return nil
+}
+
+func (v *MantleValidator) validateSystemConfig(c *mantleCheck, m *MantleManifest) error {
+	expected := m.SystemConfig
+	if expected == nil {
+		return nil
+	}
+	addr := m.Proxies[MantleSystemConfigProxy].Address
+	var (
+		gasLimit, minBaseFee, operatorFeeConstant                                               uint64
+		basefeeScalar, blobbasefeeScalar, eip1559Denominator, eip1559Elasticity, operatorScalar uint32
+		daFootprintGasScalar                                                                    uint16
+		baseFee                                                                                 = new(big.Int)
+	)
+	reads := []struct {
+		set bool
+		fn  *w3.Func
+		out any
+	}{
+		{expected.GasLimit != nil, gasLimitFunc, &gasLimit},
+		{expected.BaseFee != nil, baseFeeFunc, baseFee},
+		{expected.MinBaseFee != nil, minBaseFeeFunc, &minBaseFee},
+		{expected.BasefeeScalar != nil, basefeeScalarFunc, &basefeeScalar},
+		{expected.BlobbasefeeScalar != nil, blobbasefeeScalarFunc, &blobbasefeeScalar},
+		{expected.EIP1559Denominator != nil, eip1559DenominatorFunc, &eip1559Denominator},
+		{expected.EIP1559Elasticity != nil, eip1559ElasticityFunc, &eip1559Elasticity},
+		{expected.OperatorFeeScalar != nil, operatorFeeScalarFunc, &operatorScalar},
+		{expected.OperatorFeeConstant != nil, operatorFeeConstantFunc, &operatorFeeConstant},
+		{expected.DAFootprintGasScalar != nil, daFootprintGasScalarFunc, &daFootprintGasScalar},
+	}
+	for _, read := range reads {
+		if !read.set {
+			continue
+		}
+		if err := v.l1.CallFunc(c.ctx, addr, read.fn, nil, read.out); err != nil {
+			return fmt.Errorf("%s: %w", read.fn.Signature, err)
+		}
+	}
+	expect(c, "MNTSYS-10", MantleSystemConfigProxy, expected.GasLimit, gasLimit)
+	expectBig(c, "MNTSYS-20", MantleSystemConfigProxy, expected.BaseFee, baseFee)
+	expect(c, "MNTSYS-30", MantleSystemConfigProxy, expected.MinBaseFee, minBaseFee)
+	expect(c, "MNTSYS-40", MantleSystemConfigProxy, expected.BasefeeScalar, basefeeScalar)
+	expect(c, "MNTSYS-50", MantleSystemConfigProxy, expected.BlobbasefeeScalar, blobbasefeeScalar)
+	expect(c, "MNTSYS-60", MantleSystemConfigProxy, expected.EIP1559Denominator, eip1559Denominator)
+	expect(c, "MNTSYS-70", MantleSystemConfigProxy, expected.EIP1559Elasticity, eip1559Elasticity)
+	expect(c, "MNTSYS-80", MantleSystemConfigProxy, expected.OperatorFeeScalar, operatorScalar)
+	expect(c, "MNTSYS-90", MantleSystemConfigProxy, expected.OperatorFeeConstant, operatorFeeConstant)
+	expect(c, "MNTSYS-100", MantleSystemConfigProxy, expected.DAFootprintGasScalar, daFootprintGasScalar)
+	return nil
+}
+
+func (v *MantleValidator) validateOptimismPortal(c *mantleCheck, m *MantleManifest) error {
+	portal, ok := m.Proxies[MantleOptimismPortalProxy]
+	if !ok {
+		return nil
+	}
+	checks := []struct {
+		code     string
+		expected *common.Address
+		fn       *w3.Func
+	}{
+		{"MNTPORT-10", proxyAddress(m, MantleL2OutputOracleProxy), l2OracleFunc},
+		{"MNTPORT-20", proxyAddress(m, MantleSystemConfigProxy), systemConfigFunc},
+	}
+	if m.OptimismPortal != nil {
+		checks = append(checks, struct {
+			code     string
+			expected *common.Address
+			fn       *w3.Func
+		}{"MNTPORT-30", m.OptimismPortal.L1MNTAddress, l1MNTAddressFunc})
+	}
+	for _, check := range checks {
+		if check.expected == nil {
+			continue
+		}
+		actual, err := v.readAddress(c, portal.Address, check.fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", check.code, err)
+		}
+		expect(c, check.code, MantleOptimismPortalProxy, check.expected, actual)
+	}
+	return nil
+}
+
+func proxyAddress(m *MantleManifest, name string) *common.Address {
+	proxy, ok := m.Proxies[name]
+	if !ok {
+		return nil
+	}
+	return &proxy.Address
+}
+
+func (v *MantleValidator) validateL2OutputOracle(c *mantleCheck, m *MantleManifest) error {
+	expected := m.L2OutputOracle
+	if expected == nil {
+		return nil
+	}
+	addr := m.Proxies[MantleL2OutputOracleProxy].Address
+	checks := []struct {
+		code     string
+		expected *uint64
+		fn       *w3.Func
+	}{
+		{"MNTL2OO-10", expected.SubmissionInterval, submissionIntervalFunc},
+		{"MNTL2OO-20", expected.L2BlockTime, l2BlockTimeFunc},
+		{"MNTL2OO-30", expected.FinalizationPeriodSeconds, finalizationPeriodSecondsFunc},
+	}
+	for _, check := range checks {
+		if check.expected == nil {
+			continue
+		}
+		actual := new(big.Int)
+		if err := v.l1.CallFunc(c.ctx, addr, check.fn, nil, actual); err != nil {
+			return fmt.Errorf("%s: %w", check.code, err)
+		}
+		expectBig(c, check.code, MantleL2OutputOracleProxy, new(big.Int).SetUint64(*check.expected), actual)
+	}
+	return nil
+}
+
+func (v *MantleValidator) validateDisputeGames(c *mantleCheck, m *MantleManifest) error {
+	factory := m.Proxies[MantleDisputeGameFactoryProxy].Address
+	for _, game := range m.DisputeGames {
+		target := fmt.Sprintf("%s game type %d", MantleDisputeGameFactoryProxy, game.GameType)
+		impl, err := v.readGameImpl(c, factory, game.GameType)
+		if err != nil {
+			return fmt.Errorf("game type %d implementation: %w", game.GameType, err)
+		}
+		if impl == (common.Address{}) {
+			c.add("MNTDG-10", target, game.Implementation, impl)
+			continue
+		}
+		expect(c, "MNTDG-20", target, game.Implementation, impl)
+		if game.AbsolutePrestate != nil {
+			var prestate common.Hash
+			if err := v.l1.CallFunc(c.ctx, impl, absolutePrestateFunc, nil, &prestate); err != nil {
+				return fmt.Errorf("game type %d absolute prestate: %w", game.GameType, err)
+			}
+			expect(c, "MNTDG-30", target, game.AbsolutePrestate, prestate)
+		}
+	}
+	return nil
+}
+
+func (v *MantleValidator) readGameImpl(c *mantleCheck, factory common.Address, gameType uint32) (common.Address, error) {
+	var impl common.Address
+	err := v.l1.CallFunc(c.ctx, factory, gameImplsFunc, []any{gameType}, &impl)
+	return impl, err
+}
+
+func (v *MantleValidator) validateGasPriceOracle(c *mantleCheck, m *MantleManifest) error {
+	expected := m.GasPriceOracle
+	if expected == nil {
+		return nil
+	}
+	if v.l2 == nil {
+		return errors.New("an L2 RPC is required to check the GasPriceOracle")
+	}
+	if expected.TokenRatio != nil {
+		tokenRatio := new(big.Int)
+		if err := v.l2.CallFunc(c.ctx, predeploys.GasPriceOracleAddr, tokenRatioFunc, nil, tokenRatio); err != nil {
+			return fmt.Errorf("token ratio: %w", err)
+		}
+		expectBig(c, "MNTGPO-10", "GasPriceOracle", expected.TokenRatio, tokenRatio)
+	}
+	if expected.Operator != nil {
+		var operator common.Address
+		if err := v.l2.CallFunc(c.ctx, predeploys.GasPriceOracleAddr, operatorFunc, nil, &operator); err != nil {
+			return fmt.Errorf("operator: %w", err)
+		}
+		expect(c, "MNTGPO-20", "GasPriceOracle", expected.Operator, operator)
+	}
+	return nil
+}
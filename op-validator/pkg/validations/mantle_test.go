@@ -0,0 +1,264 @@
+package validations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-core/predeploys"
+)
+
+type fakeChain struct {
+	calls   map[string]any
+	storage map[common.Address]map[common.Hash]common.Hash
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		calls:   make(map[string]any),
+		storage: make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+func callKey(to common.Address, fn *w3.Func, args []any) string {
+	return fmt.Sprintf("%s %s %v", to, fn.Signature, args)
+}
+
+func (f *fakeChain) set(to common.Address, fn *w3.Func, value any, args ...any) {
+	f.calls[callKey(to, fn, args)] = value
+}
+
+func (f *fakeChain) setProxy(proxy common.Address, impl common.Address, admin common.Address) {
+	f.storage[proxy] = map[common.Hash]common.Hash{
+		implementationSlot: common.BytesToHash(impl[:]),
+		adminSlot:          common.BytesToHash(admin[:]),
+	}
+}
+
+func (f *fakeChain) CallFunc(_ context.Context, to common.Address, fn *w3.Func, args []any, returns ...any) error {
+	value, ok := f.calls[callKey(to, fn, args)]
+	if !ok {
+		return fmt.Errorf("execution reverted: %s", fn.Signature)
+	}
+	if v, ok := value.(*big.Int); ok {
+		returns[0].(*big.Int).Set(v)
+		return nil
+	}
+	reflect.ValueOf(returns[0]).Elem().Set(reflect.ValueOf(value))
+	return nil
+}
+
+func (f *fakeChain) StorageAt(_ context.Context, addr common.Address, slot common.Hash) (common.Hash, error) {
+	return f.storage[addr][slot], nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMantleValidator(t *testing.T) {
+	var (
+		proxyAdmin    = common.Address{0x01}
+		systemConfig  = common.Address{0x02}
+		portal        = common.Address{0x03}
+		l2OO          = common.Address{0x04}
+		factory       = common.Address{0x05}
+		game          = common.Address{0x06}
+		owner         = common.Address{0x10}
+		batcher       = common.Address{0x11}
+		proposer      = common.Address{0x12}
+		mntToken      = common.Address{0x13}
+		prestate      = common.Hash{0xaa}
+		implAddresses = map[string]common.Address{
+			MantleSystemConfigProxy:       {0x20},
+			MantleOptimismPortalProxy:     {0x21},
+			MantleL2OutputOracleProxy:     {0x22},
+			MantleDisputeGameFactoryProxy: {0x23},
+		}
+	)
+
+	manifest := func() *MantleManifest {
+		return &MantleManifest{
+			ProxyAdmin: proxyAdmin,
+			Proxies: map[string]MantleProxy{
+				MantleSystemConfigProxy:       {Address: systemConfig, Implementation: implAddresses[MantleSystemConfigProxy], Version: "1.2.0"},
+				MantleOptimismPortalProxy:     {Address: portal, Implementation: implAddresses[MantleOptimismPortalProxy]},
+				MantleL2OutputOracleProxy:     {Address: l2OO, Implementation: implAddresses[MantleL2OutputOracleProxy]},
+				MantleDisputeGameFactoryProxy: {Address: factory, Implementation: implAddresses[MantleDisputeGameFactoryProxy]},
+			},
+			Roles: MantleRoles{
+				ProxyAdminOwner:   &owner,
+				SystemConfigOwner: &owner,
+				Batcher:           &batcher,
+				Proposer:          &proposer,
+			},
+			SystemConfig: &MantleSystemConfig{
+				GasLimit:      ptr(uint64(200_000_000)),
+				BaseFee:       big.NewInt(20_000_000),
+				BasefeeScalar: ptr(uint32(1368)),
+			},
+			OptimismPortal: &MantleOptimismPortal{L1MNTAddress: &mntToken},
+			L2OutputOracle: &MantleL2OutputOracle{SubmissionInterval: ptr(uint64(1800))},
+			DisputeGames: []MantleDisputeGame{
+				{GameType: 1, Implementation: &game, AbsolutePrestate: &prestate},
+			},
+			GasPriceOracle: &MantleGasPriceOracleL2{TokenRatio: big.NewInt(4000)},
+		}
+	}
+
+	chains := func() (*fakeChain, *fakeChain) {
+		l1, l2 := newFakeChain(), newFakeChain()
+		for name, proxy := range manifest().Proxies {
+			l1.setProxy(proxy.Address, implAddresses[name], proxyAdmin)
+		}
+		l1.set(systemConfig, versionFunc, "1.2.0")
+		l1.set(proxyAdmin, ownerFunc, owner)
+		l1.set(systemConfig, ownerFunc, owner)
+		l1.set(systemConfig, batcherHashFunc, common.BytesToHash(batcher[:]))
+		l1.set(systemConfig, gasLimitFunc, uint64(200_000_000))
+		l1.set(systemConfig, baseFeeFunc, big.NewInt(20_000_000))
+		l1.set(systemConfig, basefeeScalarFunc, uint32(1368))
+		l1.set(portal, l2OracleFunc, l2OO)
+		l1.set(portal, systemConfigFunc, systemConfig)
+		l1.set(portal, l1MNTAddressFunc, mntToken)
+		l1.set(l2OO, proposerFunc, proposer)
+		l1.set(l2OO, submissionIntervalFunc, big.NewInt(1800))
+		l1.set(factory, gameImplsFunc, game, uint32(1))
+		l1.set(game, absolutePrestateFunc, prestate)
+		l2.set(predeploys.GasPriceOracleAddr, tokenRatioFunc, big.NewInt(4000))
+		return l1, l2
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		l1, l2 := chains()
+		findings, err := (&MantleValidator{l1: l1, l2: l2}).Validate(context.Background(), manifest())
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("Mismatches", func(t *testing.T) {
+		l1, l2 := chains()
+		l1.setProxy(portal, common.Address{0xff}, proxyAdmin)
+		l1.set(systemConfig, versionFunc, "1.1.0")
+		l1.set(systemConfig, batcherHashFunc, common.Hash{0xff})
+		l1.set(systemConfig, baseFeeFunc, big.NewInt(1))
+		l1.set(l2OO, submissionIntervalFunc, big.NewInt(3600))
+		l1.set(game, absolutePrestateFunc, common.Hash{0xbb})
+		l2.set(predeploys.GasPriceOracleAddr, tokenRatioFunc, big.NewInt(1))
+		m := manifest()
+		m.SystemConfig.DAFootprintGasScalar = ptr(uint16(400))
+		l1.set(systemConfig, daFootprintGasScalarFunc, uint16(300))
+
+		findings, err := (&MantleValidator{l1: l1, l2: l2}).Validate(context.Background(), m)
+		require.NoError(t, err)
+		var codes []string
+		for _, f := range findings {
+			codes = append(codes, f.Code)
+			require.NotEmpty(t, f.Description, "missing description for %s", f.Code)
+		}
+		require.Equal(t, []string{"MNTDG-30", "MNTGPO-10", "MNTL2OO-10", "MNTPRX-10", "MNTPRX-30", "MNTROLE-30", "MNTSYS-20", "MNTSYS-100"}, codes)
+		require.Equal(t, Finding{
+			Code:        "MNTSYS-20",
+			Description: ErrorDescription("MNTSYS-20"),
+			Target:      MantleSystemConfigProxy,
+			Expected:    "20000000",
+			Actual:      "1",
+		}, findings[len(findings)-2])
+	})
+
+	t.Run("MissingGame", func(t *testing.T) {
+		l1, l2 := chains()
+		l1.set(factory, gameImplsFunc, common.Address{}, uint32(1))
+		findings, err := (&MantleValidator{l1: l1, l2: l2}).Validate(context.Background(), manifest())
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		require.Equal(t, "MNTDG-10", findings[0].Code)
+	})
+
+	t.Run("L2RequiredForGasPriceOracle", func(t *testing.T) {
+		l1, _ := chains()
+		_, err := (&MantleValidator{l1: l1}).Validate(context.Background(), manifest())
+		require.ErrorContains(t, err, "L2 RPC is required")
+	})
+
+	t.Run("CallError", func(t *testing.T) {
+		l1, l2 := chains()
+		delete(l1.calls, callKey(l2OO, proposerFunc, nil))
+		_, err := (&MantleValidator{l1: l1, l2: l2}).Validate(context.Background(), manifest())
+		require.ErrorContains(t, err, "PROPOSER()")
+	})
+}
+
+func TestCompareCodes(t *testing.T) {
+	codes := []string{"MNTSYS-100", "MNTPRX-20", "MNTSYS-20", "CKDG-PIMGO-30", "MNTSYS-9", "CKDG-GARGS-10", "OTHER"}
+	slices.SortFunc(codes, CompareCodes)
+	require.Equal(t, []string{"CKDG-GARGS-10", "CKDG-PIMGO-30", "MNTPRX-20", "MNTSYS-9", "MNTSYS-20", "MNTSYS-100", "OTHER"}, codes)
+}
+
+func TestLoadMantleManifest(t *testing.T) {
+	write := func(t *testing.T, manifest any) string {
+		path := filepath.Join(t.TempDir(), "manifest.json")
+		data, err := json.Marshal(manifest)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return path
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		path := write(t, map[string]any{
+			"proxyAdmin": common.Address{0x01},
+			"proxies": map[string]any{
+				MantleSystemConfigProxy: map[string]any{"address": common.Address{0x02}, "implementation": common.Address{0x03}},
+			},
+			"systemConfig": map[string]any{"gasLimit": 30_000_000, "baseFee": 1000},
+		})
+		manifest, err := LoadMantleManifest(path)
+		require.NoError(t, err)
+		require.Equal(t, uint64(30_000_000), *manifest.SystemConfig.GasLimit)
+		require.Equal(t, big.NewInt(1000), manifest.SystemConfig.BaseFee)
+		require.Nil(t, manifest.SystemConfig.MinBaseFee)
+	})
+
+	t.Run("MissingProxy", func(t *testing.T) {
+		path := write(t, map[string]any{
+			"roles": map[string]any{"guardian": common.Address{0x01}},
+		})
+		_, err := LoadMantleManifest(path)
+		require.ErrorContains(t, err, "proxy OptimismPortal is required")
+	})
+
+	t.Run("MissingProxyAdmin", func(t *testing.T) {
+		path := write(t, map[string]any{
+			"proxies": map[string]any{
+				MantleSystemConfigProxy: map[string]any{"address": common.Address{0x02}, "implementation": common.Address{0x03}},
+			},
+		})
+		_, err := LoadMantleManifest(path)
+		require.ErrorContains(t, err, "proxy admin is required")
+	})
+}
+
+func TestOutput_Findings(t *testing.T) {
+	out := Output{
+		Errors:   []string{"MNTSYS-20"},
+		Findings: []Finding{{Code: "MNTSYS-20", Description: ErrorDescription("MNTSYS-20"), Target: MantleSystemConfigProxy, Expected: "2", Actual: "1"}},
+	}
+	require.Contains(t, out.AsMarkdown(), "MNTSYS-20")
+	require.Contains(t, out.AsMarkdown(), "EXPECTED")
+
+	data, err := out.AsJSON()
+	require.NoError(t, err)
+	var decoded Output
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	require.Equal(t, out, decoded)
+}
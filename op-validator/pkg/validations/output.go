@@ -2,15 +2,31 @@ package validations
 
 import (
 	"bytes"
+	"encoding/json"
 
 	"github.com/olekukonko/tablewriter"
 )
 
 type Output struct {
-	Errors []string
+	Errors []string `json:"errors"`
+	// Findings are errors with the expected and actual values, reported by validations performed in Go.
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// Finding is a single validation error, and the values that caused it.
+type Finding struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Target      string `json:"target"`
+	Expected    string `json:"expected"`
+	Actual      string `json:"actual"`
 }
 
 func (o *Output) AsMarkdown() string {
+	if o.Findings != nil {
+		return o.findingsAsMarkdown()
+	}
+
 	buf := new(bytes.Buffer)
 	table := tablewriter.NewWriter(buf)
 	table.SetHeader([]string{"Error", "Description"})
@@ -35,3 +51,37 @@ func (o *Output) AsMarkdown() string {
 	table.Render()
 	return buf.String()
 }
+
+func (o *Output) findingsAsMarkdown() string {
+	buf := new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetHeader([]string{"Error", "Description", "Target", "Expected", "Actual"})
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	if len(o.Findings) == 0 {
+		table.Append([]string{"No errors.", "No errors.", "", "", ""})
+		table.Render()
+		return buf.String()
+	}
+
+	for _, f := range o.Findings {
+		table.Append([]string{f.Code, f.Description, f.Target, f.Expected, f.Actual})
+	}
+
+	table.Render()
+	return buf.String()
+}
+
+func (o *Output) AsJSON() (string, error) {
+	out := *o
+	if out.Errors == nil {
+		out.Errors = []string{}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
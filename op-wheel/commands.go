@@ -260,6 +260,11 @@ func rollupFromGethConfig(cfg *params.ChainConfig) *rollup.Config {
 		IsthmusTime:  cfg.IsthmusTime,
 		JovianTime:   cfg.JovianTime,
 		InteropTime:  cfg.InteropTime,
+
+		MantleEverestTime: cfg.MantleEverestTime,
+		MantleSkadiTime:   cfg.MantleSkadiTime,
+		MantleLimbTime:    cfg.MantleLimbTime,
+		MantleArsiaTime:   cfg.MantleArsiaTime,
 	}
 }
 
@@ -586,6 +591,143 @@ var (
 		}),
 	}
 
+	EngineReplayCmd = &cli.Command{
+		Name: "replay",
+		Description: "Re-import a range of blocks from source into the engine with NewPayload and forkchoice updates, " +
+			"and report the first block that the engine did not import like the source. " +
+			"The parent of the first block must already be part of the engine chain.",
+		Flags: withEngineFlags(
+			&cli.StringFlag{
+				Name:     "source",
+				Usage:    "Unauthenticated regular eth JSON RPC to pull block data from, can be HTTP/WS/IPC.",
+				Required: true,
+				EnvVars:  prefixEnvVars("SOURCE"),
+			},
+			&cli.Uint64Flag{
+				Name:     "from",
+				Usage:    "First block number to replay",
+				Required: true,
+				EnvVars:  prefixEnvVars("REPLAY_FROM"),
+			},
+			&cli.Uint64Flag{
+				Name:     "to",
+				Usage:    "Last block number to replay, inclusive",
+				Required: true,
+				EnvVars:  prefixEnvVars("REPLAY_TO"),
+			},
+		),
+		Action: EngineAction(func(ctx *cli.Context, dest *sources.EngineAPIClient, lgr log.Logger) error {
+			rpcClient, err := rpc.DialOptions(context.Background(), ctx.String("source"))
+			if err != nil {
+				return fmt.Errorf("failed to dial engine source endpoint: %w", err)
+			}
+			source := client.NewBaseRPCClient(rpcClient)
+			open, err := initOpenEngineRPC(ctx, lgr)
+			if err != nil {
+				return fmt.Errorf("failed to dial open RPC endpoint: %w", err)
+			}
+			divergence, err := engine.Replay(ctx.Context, lgr, source, dest, open, ctx.Uint64("from"), ctx.Uint64("to"))
+			if err != nil {
+				return err
+			}
+			if divergence != nil {
+				enc := json.NewEncoder(ctx.App.Writer)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(divergence); err != nil {
+					return fmt.Errorf("failed to encode divergence: %w", err)
+				}
+				return fmt.Errorf("engine diverged at %s", divergence)
+			}
+			lgr.Info("Replayed all blocks", "from", ctx.Uint64("from"), "to", ctx.Uint64("to"))
+			return nil
+		}),
+	}
+
+	EngineFuzzCmd = &cli.Command{
+		Name: "fuzz",
+		Description: "Take the block by number from source, and send mutated copies of it to the engine with NewPayload, " +
+			"to check that the engine rejects them. The unmutated block is sent first, and must be accepted as valid. The forkchoice is not updated. " +
+			"Fails if the engine accepts any mutated payload as valid.",
+		Flags: withEngineFlags(
+			&cli.StringFlag{
+				Name:     "source",
+				Usage:    "Unauthenticated regular eth JSON RPC to pull block data from, can be HTTP/WS/IPC.",
+				Required: true,
+				EnvVars:  prefixEnvVars("SOURCE"),
+			},
+			&cli.Uint64Flag{
+				Name:     "number",
+				Usage:    "Block number to mutate, its parent must be part of the engine chain",
+				Required: true,
+				EnvVars:  prefixEnvVars("NUMBER"),
+			},
+			&cli.StringSliceFlag{
+				Name:    "mutations",
+				Usage:   "Payload mutations to apply, defaults to all. Available: " + strings.Join(engine.PayloadMutationNames(), ", "),
+				EnvVars: prefixEnvVars("FUZZ_MUTATIONS"),
+			},
+			&cli.IntFlag{
+				Name:    "iterations",
+				Usage:   "Number of times each mutation is applied, with different random values",
+				Value:   1,
+				EnvVars: prefixEnvVars("FUZZ_ITERATIONS"),
+			},
+			&cli.Int64Flag{
+				Name:    "seed",
+				Usage:   "Seed of the random mutation values, defaults to the current time",
+				EnvVars: prefixEnvVars("FUZZ_SEED"),
+			},
+			&cli.BoolFlag{
+				Name:    "reseal",
+				Usage:   "Recompute the block hash of mutated payloads, so the engine validates the mutated fields rather than only the block hash",
+				Value:   true,
+				EnvVars: prefixEnvVars("FUZZ_RESEAL"),
+			},
+		),
+		Action: EngineAction(func(ctx *cli.Context, dest *sources.EngineAPIClient, lgr log.Logger) error {
+			mutations := engine.PayloadMutations
+			if names := ctx.StringSlice("mutations"); len(names) > 0 {
+				mutations = nil
+				for _, name := range names {
+					m, err := engine.PayloadMutationByName(name)
+					if err != nil {
+						return err
+					}
+					mutations = append(mutations, m)
+				}
+			}
+			seed := ctx.Int64("seed")
+			if !ctx.IsSet("seed") {
+				seed = time.Now().UnixNano()
+			}
+			lgr.Info("Fuzzing payloads", "number", ctx.Uint64("number"), "mutations", len(mutations), "seed", seed)
+
+			rpcClient, err := rpc.DialOptions(context.Background(), ctx.String("source"))
+			if err != nil {
+				return fmt.Errorf("failed to dial engine source endpoint: %w", err)
+			}
+			source := client.NewBaseRPCClient(rpcClient)
+			results, err := engine.Fuzz(ctx.Context, lgr, source, dest, ctx.Uint64("number"), &engine.FuzzSettings{
+				Mutations:  mutations,
+				Iterations: ctx.Int("iterations"),
+				Seed:       seed,
+				Reseal:     ctx.Bool("reseal"),
+			})
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(ctx.App.Writer)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(results); err != nil {
+				return fmt.Errorf("failed to encode fuzz results: %w", err)
+			}
+			if accepted := engine.AcceptedMutations(results); len(accepted) > 0 {
+				return fmt.Errorf("engine accepted mutated payloads (seed %d): %s", seed, strings.Join(accepted, ", "))
+			}
+			return nil
+		}),
+	}
+
 	EngineSetForkchoiceCmd = &cli.Command{
 		Name:        "set-forkchoice",
 		Description: "Set forkchoice, specify unsafe, safe and finalized blocks by number",
@@ -715,7 +857,7 @@ var CheatCmd = &cli.Command{
 
 var EngineCmd = &cli.Command{
 	Name:        "engine",
-	Usage:       "Engine API commands to build/reorg/rewind/finalize/copy blocks.",
+	Usage:       "Engine API commands to build/reorg/rewind/finalize/copy/replay blocks.",
 	Description: "Each sub-command dials the engine API endpoint (with provided JWT secret) and then runs the action",
 	Subcommands: []*cli.Command{
 		EngineBlockCmd,
@@ -723,6 +865,8 @@ var EngineCmd = &cli.Command{
 		EngineStatusCmd,
 		EngineCopyCmd,
 		EngineCopyPayloadCmd,
+		EngineReplayCmd,
+		EngineFuzzCmd,
 		EngineSetForkchoiceCmd,
 		EngineSetForkchoiceHashCmd,
 		EngineRewindCmd,
@@ -49,6 +49,9 @@ func getBlock(ctx context.Context, client client.RPC, method string, tag string)
 	if err := json.Unmarshal(raw, &head); err != nil {
 		return nil, err
 	}
+	if head == nil {
+		return nil, fmt.Errorf("block %s not found", tag)
+	}
 
 	type rpcBlock struct {
 		Hash         common.Hash          `json:"hash"`
@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// PayloadMutation is a change to a valid payload that should make the engine reject it.
+type PayloadMutation struct {
+	Name        string
+	Description string
+	// Apply mutates the payload, and returns false if the mutation does not apply to it,
+	// e.g. because the field is not present before a fork.
+	Apply func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool
+}
+
+func randomHash(rng *rand.Rand) common.Hash {
+	var h common.Hash
+	rng.Read(h[:])
+	return h
+}
+
+// PayloadMutations are all the mutations supported by Fuzz.
+var PayloadMutations = []PayloadMutation{
+	{
+		Name:        "withdrawals-root",
+		Description: "replace the withdrawals root, which commits to the L2ToL1MessagePasser storage root after Isthmus and Mantle Skadi",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ExecutionPayload.WithdrawalsRoot == nil {
+				return false
+			}
+			h := randomHash(rng)
+			env.ExecutionPayload.WithdrawalsRoot = &h
+			return true
+		},
+	},
+	{
+		Name:        "withdrawals-root-missing",
+		Description: "remove the withdrawals root after Isthmus and Mantle Skadi",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ExecutionPayload.WithdrawalsRoot == nil {
+				return false
+			}
+			env.ExecutionPayload.WithdrawalsRoot = nil
+			return true
+		},
+	},
+	{
+		Name:        "withdrawals",
+		Description: "add a withdrawal, which must be empty after Canyon and Mantle Skadi",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ExecutionPayload.Withdrawals == nil {
+				return false
+			}
+			env.ExecutionPayload.Withdrawals = &types.Withdrawals{{
+				Index:   rng.Uint64(),
+				Address: common.BytesToAddress(randomHash(rng).Bytes()),
+				Amount:  1,
+			}}
+			return true
+		},
+	},
+	{
+		Name:        "blob-gas-used",
+		Description: "change the blob gas used, which is zero on L2 or carries the DA footprint after Jovian",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ExecutionPayload.BlobGasUsed == nil {
+				return false
+			}
+			v := *env.ExecutionPayload.BlobGasUsed + eth.Uint64Quantity(rng.Intn(1<<17)+1)
+			env.ExecutionPayload.BlobGasUsed = &v
+			return true
+		},
+	},
+	{
+		Name:        "excess-blob-gas",
+		Description: "change the excess blob gas, which must stay zero on L2",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ExecutionPayload.ExcessBlobGas == nil {
+				return false
+			}
+			v := *env.ExecutionPayload.ExcessBlobGas + eth.Uint64Quantity(rng.Intn(1<<17)+1)
+			env.ExecutionPayload.ExcessBlobGas = &v
+			return true
+		},
+	},
+	{
+		Name:        "parent-beacon-root",
+		Description: "replace the parent beacon block root, which is the L1 origin beacon root after Ecotone and Mantle Skadi",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ParentBeaconBlockRoot == nil {
+				return false
+			}
+			h := randomHash(rng)
+			env.ParentBeaconBlockRoot = &h
+			return true
+		},
+	},
+	{
+		Name:        "extra-data",
+		Description: "replace the extra data, which encodes the EIP-1559 parameters after Holocene",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			extra := make([]byte, len(env.ExecutionPayload.ExtraData))
+			if len(extra) == 0 {
+				extra = make([]byte, 9)
+			}
+			rng.Read(extra)
+			env.ExecutionPayload.ExtraData = extra
+			return true
+		},
+	},
+	{
+		Name:        "state-root",
+		Description: "replace the state root",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			env.ExecutionPayload.StateRoot = eth.Bytes32(randomHash(rng))
+			return true
+		},
+	},
+	{
+		Name:        "receipts-root",
+		Description: "replace the receipts root",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			env.ExecutionPayload.ReceiptsRoot = eth.Bytes32(randomHash(rng))
+			return true
+		},
+	},
+	{
+		Name:        "gas-used",
+		Description: "change the gas used",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			env.ExecutionPayload.GasUsed += eth.Uint64Quantity(rng.Intn(21000) + 1)
+			return true
+		},
+	},
+	{
+		Name:        "base-fee",
+		Description: "change the base fee",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			fee := (*uint256.Int)(&env.ExecutionPayload.BaseFeePerGas)
+			fee.AddUint64(fee, uint64(rng.Intn(1000)+1))
+			return true
+		},
+	},
+	{
+		Name:        "timestamp",
+		Description: "move the timestamp back by one second",
+		Apply: func(env *eth.ExecutionPayloadEnvelope, rng *rand.Rand) bool {
+			if env.ExecutionPayload.Timestamp == 0 {
+				return false
+			}
+			env.ExecutionPayload.Timestamp--
+			return true
+		},
+	},
+}
+
+// PayloadMutationByName returns the mutation with the given name.
+func PayloadMutationByName(name string) (PayloadMutation, error) {
+	for _, m := range PayloadMutations {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return PayloadMutation{}, fmt.Errorf("unknown payload mutation %q, expected one of: %s", name, strings.Join(PayloadMutationNames(), ", "))
+}
+
+// PayloadMutationNames returns the names of all the mutations supported by Fuzz.
+func PayloadMutationNames() []string {
+	names := make([]string, len(PayloadMutations))
+	for i, m := range PayloadMutations {
+		names[i] = m.Name
+	}
+	return names
+}
+
+type FuzzSettings struct {
+	Mutations []PayloadMutation
+	// Iterations is the number of times each mutation is applied, with different random values.
+	Iterations int
+	Seed       int64
+	// Reseal recomputes the block hash after the mutation, so the engine has to validate the mutated field itself
+	// rather than only rejecting the block hash.
+	Reseal bool
+}
+
+// FuzzResult is the response of the engine to a single mutated payload.
+type FuzzResult struct {
+	Mutation  string                   `json:"mutation"`
+	Iteration int                      `json:"iteration"`
+	BlockHash common.Hash              `json:"blockHash"`
+	Status    eth.ExecutePayloadStatus `json:"status,omitempty"`
+	// Error is the validation error of the engine, or the RPC error if it rejected the payload outright.
+	Error string `json:"error,omitempty"`
+}
+
+// Accepted returns whether the engine accepted the mutated payload as valid, which indicates a validation bug.
+func (r *FuzzResult) Accepted() bool {
+	return r.Status == eth.ExecutionValid
+}
+
+// payloadExecutor executes payloads, implemented by [sources.EngineAPIClient].
+type payloadExecutor interface {
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+}
+
+// checkCleanPayload verifies that the unmutated payload has a correct block hash and is accepted by dest as valid.
+// Otherwise the rejection of the mutated payloads says nothing about the validation of the mutated fields,
+// e.g. because the parent block is not known by dest, or the payload conversion does not match the chain.
+func checkCleanPayload(ctx context.Context, dest payloadExecutor, env *eth.ExecutionPayloadEnvelope) error {
+	if actual, ok := env.CheckBlockHash(); !ok {
+		return fmt.Errorf("clean payload has block hash %s, but the header hashes to %s", env.ExecutionPayload.BlockHash, actual)
+	}
+	res, err := dest.NewPayload(ctx, env.ExecutionPayload, env.ParentBeaconBlockRoot)
+	if err != nil {
+		return fmt.Errorf("failed to submit clean payload: %w", err)
+	}
+	if res.Status != eth.ExecutionValid {
+		validationErr := ""
+		if res.ValidationError != nil {
+			validationErr = *res.ValidationError
+		}
+		return fmt.Errorf("engine did not accept clean payload as valid, status %s: %q", res.Status, validationErr)
+	}
+	return nil
+}
+
+// Fuzz takes the block by number from source, and sends mutated copies of it to dest with NewPayload.
+// The clean block is sent first, and fuzzing is aborted unless dest accepts it as valid.
+// The forkchoice of dest is not updated, so the mutated blocks never become canonical.
+// The parent of the block must be part of the dest chain for the payloads to be fully validated.
+func Fuzz(ctx context.Context, lgr log.Logger, source client.RPC, dest *sources.EngineAPIClient, number uint64, settings *FuzzSettings) ([]FuzzResult, error) {
+	block, err := getBlock(ctx, source, methodEthGetBlockByNumber, hexutil.Uint64(number).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source block %d: %w", number, err)
+	}
+	config := payloadConfig(ctx, lgr, source, dest.EngineVersionProvider(), block.Time())
+	clean, err := eth.BlockAsPayloadEnv(block, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block %d to payload: %w", number, err)
+	}
+	if err := checkCleanPayload(ctx, dest, clean); err != nil {
+		return nil, fmt.Errorf("block %d: %w", number, err)
+	}
+	rng := rand.New(rand.NewSource(settings.Seed))
+
+	var results []FuzzResult
+	for _, mutation := range settings.Mutations {
+		for i := 0; i < settings.Iterations; i++ {
+			env, err := eth.BlockAsPayloadEnv(block, config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert block %d to payload: %w", number, err)
+			}
+			if !mutation.Apply(env, rng) {
+				lgr.Info("Mutation does not apply to block", "mutation", mutation.Name, "number", number)
+				break
+			}
+			if settings.Reseal {
+				hash, unchanged := env.CheckBlockHash()
+				if unchanged {
+					// The mutated field is not part of the header, the engine has to validate it against the body.
+					lgr.Debug("Mutation does not change the block hash", "mutation", mutation.Name, "iteration", i)
+				}
+				env.ExecutionPayload.BlockHash = hash
+			}
+			result := FuzzResult{
+				Mutation:  mutation.Name,
+				Iteration: i,
+				BlockHash: env.ExecutionPayload.BlockHash,
+			}
+			res, err := dest.NewPayload(ctx, env.ExecutionPayload, env.ParentBeaconBlockRoot)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Status = res.Status
+				if res.ValidationError != nil {
+					result.Error = *res.ValidationError
+				}
+			}
+			if result.Accepted() {
+				lgr.Error("Engine accepted mutated payload", "mutation", mutation.Name, "iteration", i, "hash", result.BlockHash)
+			} else {
+				lgr.Info("Engine rejected mutated payload", "mutation", mutation.Name, "iteration", i, "status", result.Status, "err", result.Error)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// AcceptedMutations returns the names of the mutations that the engine accepted at least once.
+func AcceptedMutations(results []FuzzResult) []string {
+	var accepted []string
+	for _, r := range results {
+		if r.Accepted() && !slices.Contains(accepted, r.Mutation) {
+			accepted = append(accepted, r.Mutation)
+		}
+	}
+	return accepted
+}
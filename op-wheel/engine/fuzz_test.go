@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// testPayloadEnv returns a sealed payload with all the fork-specific fields set, or none if preFork.
+func testPayloadEnv(t *testing.T, preFork bool) *eth.ExecutionPayloadEnvelope {
+	env := &eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{
+			ParentHash:   common.Hash{0x01},
+			FeeRecipient: common.Address{0x02},
+			StateRoot:    eth.Bytes32{0x03},
+			ReceiptsRoot: eth.Bytes32(types.EmptyReceiptsHash),
+			BlockNumber:  100,
+			GasLimit:     30_000_000,
+			Timestamp:    1000,
+			ExtraData:    []byte{0x00},
+		},
+	}
+	(*uint256.Int)(&env.ExecutionPayload.BaseFeePerGas).SetUint64(1_000_000)
+	if !preFork {
+		zero := eth.Uint64Quantity(0)
+		withdrawalsRoot := common.Hash{0x04}
+		beaconRoot := common.Hash{0x05}
+		env.ExecutionPayload.ExtraData = []byte{0x00, 0, 0, 0, 250, 0, 0, 0, 6}
+		env.ExecutionPayload.Withdrawals = &types.Withdrawals{}
+		env.ExecutionPayload.WithdrawalsRoot = &withdrawalsRoot
+		env.ExecutionPayload.BlobGasUsed = &zero
+		env.ExecutionPayload.ExcessBlobGas = &zero
+		env.ParentBeaconBlockRoot = &beaconRoot
+	}
+	env.ExecutionPayload.BlockHash, _ = env.CheckBlockHash()
+	_, ok := env.CheckBlockHash()
+	require.True(t, ok)
+	return env
+}
+
+func copyPayloadEnv(env *eth.ExecutionPayloadEnvelope) *eth.ExecutionPayloadEnvelope {
+	payload := *env.ExecutionPayload
+	payload.ExtraData = append([]byte(nil), payload.ExtraData...)
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload, ParentBeaconBlockRoot: env.ParentBeaconBlockRoot}
+}
+
+func TestPayloadMutations(t *testing.T) {
+	// Mutations of fields that are not part of the block header are only detected by validating the body.
+	bodyOnly := map[string]bool{"withdrawals": true}
+	forkSpecific := map[string]bool{
+		"withdrawals-root":         true,
+		"withdrawals-root-missing": true,
+		"withdrawals":              true,
+		"blob-gas-used":            true,
+		"excess-blob-gas":          true,
+		"parent-beacon-root":       true,
+	}
+	for _, m := range PayloadMutations {
+		t.Run(m.Name, func(t *testing.T) {
+			require.NotEmpty(t, m.Description)
+			clean := testPayloadEnv(t, false)
+			env := copyPayloadEnv(clean)
+			require.True(t, m.Apply(env, rand.New(rand.NewSource(1))))
+			require.False(t, reflect.DeepEqual(clean, env), "mutation must change the payload")
+			_, unchanged := env.CheckBlockHash()
+			require.Equal(t, bodyOnly[m.Name], unchanged, "mutation must change the block hash of header fields")
+
+			again := copyPayloadEnv(clean)
+			require.True(t, m.Apply(again, rand.New(rand.NewSource(1))))
+			require.Equal(t, env, again, "mutation must be deterministic for a seed")
+
+			preFork := testPayloadEnv(t, true)
+			env = copyPayloadEnv(preFork)
+			require.Equal(t, !forkSpecific[m.Name], m.Apply(env, rand.New(rand.NewSource(1))))
+			if forkSpecific[m.Name] {
+				require.Equal(t, preFork, env, "mutation that does not apply must not change the payload")
+			}
+		})
+	}
+
+	t.Run("TimestampZero", func(t *testing.T) {
+		m, err := PayloadMutationByName("timestamp")
+		require.NoError(t, err)
+		env := testPayloadEnv(t, false)
+		env.ExecutionPayload.Timestamp = 0
+		require.False(t, m.Apply(env, rand.New(rand.NewSource(1))))
+	})
+}
+
+func TestPayloadMutationByName(t *testing.T) {
+	for _, name := range PayloadMutationNames() {
+		m, err := PayloadMutationByName(name)
+		require.NoError(t, err)
+		require.Equal(t, name, m.Name)
+	}
+	_, err := PayloadMutationByName("unknown")
+	require.ErrorContains(t, err, "state-root")
+}
+
+func TestAcceptedMutations(t *testing.T) {
+	require.Empty(t, AcceptedMutations(nil))
+	results := []FuzzResult{
+		{Mutation: "state-root", Status: eth.ExecutionInvalid},
+		{Mutation: "gas-used", Status: eth.ExecutionValid},
+		{Mutation: "extra-data", Error: "boom"},
+		{Mutation: "gas-used", Iteration: 1, Status: eth.ExecutionValid},
+		{Mutation: "state-root", Iteration: 1, Status: eth.ExecutionInvalidBlockHash},
+		{Mutation: "timestamp", Status: eth.ExecutionValid},
+		{Mutation: "base-fee", Status: eth.ExecutionSyncing},
+	}
+	require.Equal(t, []string{"gas-used", "timestamp"}, AcceptedMutations(results))
+}
+
+type stubPayloadExecutor struct {
+	status *eth.PayloadStatusV1
+	err    error
+	calls  int
+}
+
+func (s *stubPayloadExecutor) NewPayload(context.Context, *eth.ExecutionPayload, *common.Hash) (*eth.PayloadStatusV1, error) {
+	s.calls++
+	return s.status, s.err
+}
+
+func TestCheckCleanPayload(t *testing.T) {
+	ctx := context.Background()
+	validationErr := "unknown parent"
+
+	t.Run("Valid", func(t *testing.T) {
+		dest := &stubPayloadExecutor{status: &eth.PayloadStatusV1{Status: eth.ExecutionValid}}
+		require.NoError(t, checkCleanPayload(ctx, dest, testPayloadEnv(t, false)))
+		require.Equal(t, 1, dest.calls)
+	})
+
+	t.Run("BlockHashMismatch", func(t *testing.T) {
+		dest := &stubPayloadExecutor{status: &eth.PayloadStatusV1{Status: eth.ExecutionValid}}
+		env := testPayloadEnv(t, false)
+		env.ExecutionPayload.BlockHash = common.Hash{0xff}
+		require.ErrorContains(t, checkCleanPayload(ctx, dest, env), "hashes to")
+		require.Zero(t, dest.calls, "must not submit a payload with a wrong block hash")
+	})
+
+	t.Run("NotValid", func(t *testing.T) {
+		for _, status := range []eth.ExecutePayloadStatus{eth.ExecutionSyncing, eth.ExecutionAccepted, eth.ExecutionInvalid} {
+			dest := &stubPayloadExecutor{status: &eth.PayloadStatusV1{Status: status, ValidationError: &validationErr}}
+			err := checkCleanPayload(ctx, dest, testPayloadEnv(t, false))
+			require.ErrorContains(t, err, string(status))
+			require.ErrorContains(t, err, validationErr)
+		}
+	})
+
+	t.Run("RPCError", func(t *testing.T) {
+		rpcErr := errors.New("boom")
+		dest := &stubPayloadExecutor{err: rpcErr}
+		require.ErrorIs(t, checkCleanPayload(ctx, dest, testPayloadEnv(t, false)), rpcErr)
+	})
+}
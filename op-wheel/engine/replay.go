@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// Divergence describes the first block that the destination engine did not import like the source engine.
+type Divergence struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	// Status is the payload status returned by the destination engine, if it returned one.
+	Status eth.ExecutePayloadStatus `json:"status,omitempty"`
+	// LatestValidHash is the latest valid ancestor reported by the destination engine, if any.
+	LatestValidHash *common.Hash `json:"latestValidHash,omitempty"`
+	// Actual is the hash of the destination block at Number, if it differs from Hash.
+	Actual *common.Hash `json:"actual,omitempty"`
+	Reason string       `json:"reason"`
+}
+
+func (d *Divergence) String() string {
+	return fmt.Sprintf("block %d (%s): %s", d.Number, d.Hash, d.Reason)
+}
+
+// payloadConfig returns the chain config used to convert blocks of the source engine to payloads.
+// It falls back to the Canyon hack of blockAsPayloadEnv if the source does not serve its chain config,
+// which is not sufficient for Mantle blocks after Skadi, as those carry a withdrawals root.
+func payloadConfig(ctx context.Context, lgr log.Logger, source client.RPC, evp sources.EngineVersionProvider, timestamp uint64) *params.ChainConfig {
+	cfg, err := GetChainConfig(ctx, source)
+	if err == nil && cfg != nil {
+		return cfg
+	}
+	lgr.Warn("Failed to get chain config of source, inferring payload fields from the Engine API version", "err", err)
+	var config params.ChainConfig
+	if v := evp.NewPayloadVersion(timestamp); v != eth.NewPayloadV2 {
+		config.CanyonTime = new(uint64)
+	}
+	return &config
+}
+
+// Replay re-imports the blocks [from, to] of source into dest, one by one, with NewPayload and forkchoice updates.
+// The parent of from must already be part of the dest chain.
+// It returns the first block that dest did not import, or imported with a different hash, or nil if all blocks matched.
+// The dest safe and finalized blocks are left unchanged.
+func Replay(ctx context.Context, lgr log.Logger, source client.RPC, dest *sources.EngineAPIClient, destOpen client.RPC, from, to uint64) (*Divergence, error) {
+	if from == 0 {
+		return nil, fmt.Errorf("cannot replay the genesis block")
+	}
+	if to < from {
+		return nil, fmt.Errorf("cannot replay to (%d) < from (%d)", to, from)
+	}
+	sourceParent, err := getHeader(ctx, source, methodEthGetBlockByNumber, hexutil.Uint64(from-1).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source block %d: %w", from-1, err)
+	}
+	if sourceParent == nil {
+		return nil, fmt.Errorf("source block %d not found", from-1)
+	}
+	destParent, err := getHeader(ctx, destOpen, methodEthGetBlockByNumber, hexutil.Uint64(from-1).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dest block %d: %w", from-1, err)
+	}
+	if destParent == nil || destParent.Hash() != sourceParent.Hash() {
+		return &Divergence{
+			Number: from - 1,
+			Hash:   sourceParent.Hash(),
+			Actual: headerHash(destParent),
+			Reason: "dest does not have the parent of the first replayed block",
+		}, nil
+	}
+	safe, finalized, err := safeFinalized(ctx, destOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dest safe and finalized blocks: %w", err)
+	}
+	config := payloadConfig(ctx, lgr, source, dest.EngineVersionProvider(), sourceParent.Time)
+
+	for n := from; n <= to; n++ {
+		block, err := getBlock(ctx, source, methodEthGetBlockByNumber, hexutil.Uint64(n).String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source block %d: %w", n, err)
+		}
+		if div, err := replayBlock(ctx, dest, destOpen, block, config, safe.Hash(), finalized.Hash()); err != nil {
+			return nil, err
+		} else if div != nil {
+			return div, nil
+		}
+		lgr.Info("Replayed block", "number", n, "hash", block.Hash(), "txs", len(block.Transactions()), "gas", block.GasUsed())
+	}
+	return nil, nil
+}
+
+func replayBlock(ctx context.Context, dest *sources.EngineAPIClient, destOpen client.RPC, block *types.Block, config *params.ChainConfig, safe, finalized common.Hash) (*Divergence, error) {
+	n, hash := block.NumberU64(), block.Hash()
+	payloadEnv, err := eth.BlockAsPayloadEnv(block, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block %d to payload: %w", n, err)
+	}
+	if actual, ok := payloadEnv.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("payload of block %d does not reproduce its hash %s, got %s: is the source chain config complete?", n, hash, actual)
+	}
+
+	res, err := dest.NewPayload(ctx, payloadEnv.ExecutionPayload, payloadEnv.ParentBeaconBlockRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert block %d: %w", n, err)
+	}
+	if res.Status != eth.ExecutionValid {
+		return &Divergence{
+			Number:          n,
+			Hash:            hash,
+			Status:          res.Status,
+			LatestValidHash: res.LatestValidHash,
+			Reason:          fmt.Sprintf("new payload was not valid: %s", validationError(res.ValidationError)),
+		}, nil
+	}
+
+	fcRes, err := dest.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{
+		HeadBlockHash:      hash,
+		SafeBlockHash:      safe,
+		FinalizedBlockHash: finalized,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update forkchoice to block %d: %w", n, err)
+	}
+	if fcRes.PayloadStatus.Status != eth.ExecutionValid {
+		return &Divergence{
+			Number:          n,
+			Hash:            hash,
+			Status:          fcRes.PayloadStatus.Status,
+			LatestValidHash: fcRes.PayloadStatus.LatestValidHash,
+			Reason:          fmt.Sprintf("forkchoice update was not valid: %s", validationError(fcRes.PayloadStatus.ValidationError)),
+		}, nil
+	}
+
+	destHeader, err := getHeader(ctx, destOpen, methodEthGetBlockByNumber, hexutil.Uint64(n).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dest block %d: %w", n, err)
+	}
+	if destHeader == nil || destHeader.Hash() != hash {
+		return &Divergence{
+			Number: n,
+			Hash:   hash,
+			Actual: headerHash(destHeader),
+			Reason: "dest canonical block does not match the replayed block",
+		}, nil
+	}
+	return nil, nil
+}
+
+func headerHash(h *types.Header) *common.Hash {
+	if h == nil {
+		return nil
+	}
+	hash := h.Hash()
+	return &hash
+}
+
+func validationError(msg *string) string {
+	if msg == nil {
+		return "no validation error"
+	}
+	return *msg
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -30,18 +29,6 @@ type SuperRootProof struct {
 
 var UnknownNonceVersion = errors.New("Unknown nonce version")
 
-// Mantle-specific V1 relay message ABI with dual values (mntValue, ethValue)
-var mantleRelayMessage1ABI = `[{"inputs":[{"internalType":"uint256","name":"_nonce","type":"uint256"},{"internalType":"address","name":"_sender","type":"address"},{"internalType":"address","name":"_target","type":"address"},{"internalType":"uint256","name":"_mntValue","type":"uint256"},{"internalType":"uint256","name":"_ethValue","type":"uint256"},{"internalType":"uint256","name":"_minGasLimit","type":"uint256"},{"internalType":"bytes","name":"_message","type":"bytes"}],"name":"relayMessage","outputs":[],"stateMutability":"payable","type":"function"}]`
-var mantleRelayMessage1 abi.ABI
-
-func init() {
-	var err error
-	mantleRelayMessage1, err = abi.JSON(strings.NewReader(mantleRelayMessage1ABI))
-	if err != nil {
-		panic(err)
-	}
-}
-
 // checkOk checks if ok is false, and panics if so.
 // Shorthand to ease go's god awful error handling
 func checkOk(ok bool) {
@@ -62,21 +49,10 @@ func checkErr(err error, failReason string) {
 // Mantle uses dual values (mntValue, ethValue) in V1 encoding.
 func encodeCrossDomainMessage(nonce *big.Int, sender common.Address, target common.Address, mntValue *big.Int, ethValue *big.Int, gasLimit *big.Int, data []byte) ([]byte, error) {
 	_, version := crossdomain.DecodeVersionedNonce(nonce)
-
-	var encoded []byte
-	var err error
-	if version.Cmp(big.NewInt(0)) == 0 {
-		// Encode cross domain message V0
-		encoded, err = crossdomain.EncodeCrossDomainMessageV0(target, sender, data, nonce)
-	} else if version.Cmp(big.NewInt(1)) == 0 {
-		// Encode cross domain message V1 with Mantle dual values
-		// relayMessage(uint256,address,address,uint256,uint256,uint256,bytes)
-		encoded, err = mantleRelayMessage1.Pack("relayMessage", nonce, sender, target, mntValue, ethValue, gasLimit, data)
-	} else {
+	if version.Cmp(big.NewInt(1)) > 0 {
 		return nil, UnknownNonceVersion
 	}
-
-	return encoded, err
+	return crossdomain.NewMantleCrossDomainMessage(nonce, sender, target, mntValue, ethValue, gasLimit, data).Encode()
 }
 
 // parseSuperRootProof parses an abi encoded super root proof into a SuperRootProof struct.
@@ -154,28 +130,9 @@ func encodeSuperRootProof(superRootProof *SuperRootProof) ([]byte, error) {
 // hashWithdrawal hashes a withdrawal transaction with Mantle dual values.
 // Matches Solidity: keccak256(abi.encode(nonce, sender, target, mntValue, ethValue, gasLimit, data))
 func hashWithdrawal(nonce *big.Int, sender common.Address, target common.Address, mntValue *big.Int, ethValue *big.Int, gasLimit *big.Int, data []byte) (common.Hash, error) {
-	wdArgs := abi.Arguments{
-		{Name: "nonce", Type: uint256ABI},
-		{Name: "sender", Type: addressABI},
-		{Name: "target", Type: addressABI},
-		{Name: "mntValue", Type: uint256ABI},
-		{Name: "ethValue", Type: uint256ABI},
-		{Name: "gasLimit", Type: uint256ABI},
-		{Name: "data", Type: bytesABI},
-	}
-	enc, err := wdArgs.Pack(nonce, sender, target, mntValue, ethValue, gasLimit, data)
-	if err != nil {
-		return common.Hash{}, err
-	}
-	return crypto.Keccak256Hash(enc), nil
+	return crossdomain.NewMantleWithdrawal(nonce, &sender, &target, mntValue, ethValue, gasLimit, data).Hash()
 }
 
-var (
-	uint256ABI, _ = abi.NewType("uint256", "", nil)
-	addressABI, _ = abi.NewType("address", "", nil)
-	bytesABI, _   = abi.NewType("bytes", "", nil)
-)
-
 // hashOutputRootProof hashes an output root proof.
 // Matches Solidity: keccak256(abi.encode(version, stateRoot, messagePasserStorageRoot, latestBlockhash))
 func hashOutputRootProof(version common.Hash, stateRoot common.Hash, messagePasserStorageRoot common.Hash, latestBlockHash common.Hash) (common.Hash, error) {